@@ -9,6 +9,9 @@ import (
 	"github.com/mitchellh/cli"
 
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/flightcontrol/download"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/flightcontrol/httpclient"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/flightcontrol/tar"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/flightcontrol/waitfor"
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/flightcontrol/zip"
 )
 
@@ -26,9 +29,18 @@ func main() {
 			"download": func() (cli.Command, error) {
 				return download.NewCommand(ui)
 			},
+			"http": func() (cli.Command, error) {
+				return httpclient.NewCommand(ui)
+			},
 			"unzip": func() (cli.Command, error) {
 				return zip.NewUnzipCommand(ui)
 			},
+			"untar": func() (cli.Command, error) {
+				return tar.NewUntarCommand(ui)
+			},
+			"wait-for": func() (cli.Command, error) {
+				return waitfor.NewCommand(ui)
+			},
 		},
 	}
 
@@ -17,3 +17,35 @@ data "enos_artifactory_item" "vault" {
     "productVersion"  = "1.7.0"
   }
 }
+
+data "enos_artifactory_item" "latest" {
+  token = "1234abcd"
+
+  host = "https://artifactory.example.org/artifactory"
+  repo = "myappartifacts/*"
+  name = "*.zip"
+
+  properties = {
+    "EDITION" = "ent"
+    "GOARCH"  = "amd64"
+    "GOOS"    = "linux"
+  }
+
+  # Only consider builds from the release branch that are at least 1.15.0
+  property_criteria = [
+    {
+      property = "branch"
+      operator = "$in"
+      values   = ["main", "release/1.15.x"]
+    },
+    {
+      property = "productVersion"
+      operator = "$gte"
+      values   = ["1.15.0"]
+    },
+  ]
+
+  # Return only the item with the highest productVersion
+  selection        = "highest_version"
+  version_property = "productVersion"
+}
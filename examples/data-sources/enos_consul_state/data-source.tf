@@ -0,0 +1,19 @@
+data "enos_consul_state" "consul" {
+  depends_on = [
+    enos_consul_start.consul
+  ]
+
+  transport = {
+    ssh = {
+      host = aws_instance.consul_instance[0].public_ip
+    }
+  }
+}
+
+output "consul_leader" {
+  value = data.enos_consul_state.consul.leader
+}
+
+output "consul_voters" {
+  value = [for peer in data.enos_consul_state.consul.raft_peers : peer.node if peer.voter]
+}
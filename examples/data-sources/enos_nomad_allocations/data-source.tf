@@ -0,0 +1,17 @@
+# Find the running allocations of a job and use the transports to run commands in the tasks.
+data "enos_nomad_allocations" "vault" {
+  host           = enos_nomad_job.vault.host
+  secret_id      = var.nomad_token
+  namespace      = enos_nomad_job.vault.job_namespace
+  job_id         = enos_nomad_job.vault.job_id
+  task_group     = "vault"
+  expected_count = 3
+}
+
+resource "enos_remote_exec" "vault_status" {
+  inline = ["vault status"]
+
+  transport = {
+    nomad = data.enos_nomad_allocations.vault.transports[0]
+  }
+}
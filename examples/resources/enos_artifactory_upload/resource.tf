@@ -0,0 +1,24 @@
+# Publish a debug bundle that the scenario generated to Artifactory
+resource "enos_artifactory_upload" "debug" {
+  token  = var.artifactory_token
+  host   = "https://artifactory.hashicorp.engineering/artifactory"
+  repo   = "enos-scenario-artifacts-local"
+  path   = "vault/${var.vault_revision}/debug"
+  source = "${path.root}/support/debug/vault-debug.tar.gz"
+
+  properties = {
+    "scenario" = "smoke"
+    "commit"   = var.vault_revision
+  }
+}
+
+# Publish a binary under a different name using an API key
+resource "enos_artifactory_upload" "binary" {
+  username = "some-user@your-org.com"
+  token    = var.artifactory_token
+  host     = "https://artifactory.hashicorp.engineering/artifactory"
+  repo     = "enos-scenario-artifacts-local"
+  path     = "vault/${var.vault_revision}/bin"
+  name     = "vault_linux_amd64"
+  source   = "${path.root}/dist/vault"
+}
@@ -0,0 +1,12 @@
+resource "enos_boundary_migrate" "migrate" {
+  depends_on = [enos_bundle_install.boundary_upgrade]
+
+  bin_path    = "/opt/boundary/bin"
+  config_path = "/etc/boundary"
+
+  transport = {
+    ssh = {
+      host = aws_instance.controller[0].public_ip
+    }
+  }
+}
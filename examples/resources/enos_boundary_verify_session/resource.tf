@@ -0,0 +1,16 @@
+resource "enos_boundary_verify_session" "verify" {
+  depends_on = [enos_boundary_start.controller_start, enos_boundary_worker.worker]
+
+  bin_path        = "/opt/boundary/bin"
+  controller_addr = "http://${aws_instance.controller[0].private_ip}:9200"
+  auth_method_id  = enos_boundary_init.init.auth_method_id
+  auth_login_name = enos_boundary_init.init.auth_login_name
+  auth_password   = enos_boundary_init.init.auth_password
+  target_id       = enos_boundary_init.init.target_id
+
+  transport = {
+    ssh = {
+      host = aws_instance.controller[0].public_ip
+    }
+  }
+}
@@ -0,0 +1,25 @@
+resource "enos_boundary_worker" "worker" {
+  depends_on = [enos_boundary_start.controller_start]
+
+  bin_path        = "/opt/boundary/bin"
+  controller_addr = "http://${aws_instance.controller[0].private_ip}:9200"
+  auth_method_id  = enos_boundary_init.init.auth_method_id
+  auth_login_name = enos_boundary_init.init.auth_login_name
+  auth_password   = enos_boundary_init.init.auth_password
+  registration    = "worker-led"
+
+  config = {
+    name              = "worker-0"
+    public_addr       = aws_instance.worker[0].public_ip
+    initial_upstreams = ["${aws_instance.controller[0].private_ip}:9201"]
+    tags = {
+      type = ["enos", "worker"]
+    }
+  }
+
+  transport = {
+    ssh = {
+      host = aws_instance.worker[0].public_ip
+    }
+  }
+}
@@ -2,7 +2,7 @@ resource "enos_bundle_install" "vault" {
   # the destination is the directory when the binary will be placed. Only required for bundles
   destination = "/opt/vault/bin"
 
-  # install from a local bundle, deb, or rpm
+  # install from a local zip or tar archive, deb, rpm, or binary
   path = "/path/to/bundle.zip"
 
   transport = {
@@ -18,7 +18,8 @@ resource "enos_bundle_install" "vault" {
   # the destination is the directory when the binary will be placed. Only required for bundles
   destination = "/opt/vault/bin"
 
-  # install from releases.hashicorp.com
+  # install from releases.hashicorp.com. The version can also be "latest", "latest-prerelease", or a
+  # version constraint like "~> 1.15.0". The resolved version is exported as the version attribute.
   release = {
     product = "vault"
     version = "1.7.0"
@@ -37,6 +38,33 @@ resource "enos_bundle_install" "vault" {
   }
 }
 
+resource "enos_bundle_install" "vault" {
+  # the destination is the directory when the binary will be placed. Only required for bundles
+  destination = "/opt/vault/bin"
+
+  # install from a mirror of releases.hashicorp.com. The mirror can also be configured on the
+  # provider with releases_base_url, releases_headers, and releases_ca_bundle.
+  release = {
+    product   = "vault"
+    version   = "1.7.0"
+    edition   = "ent"
+    base_url  = "https://releases.example.com"
+    ca_bundle = file("/path/to/ca.pem")
+  }
+
+  release_headers = {
+    Authorization = "Bearer ${var.releases_token}"
+  }
+
+  transport = {
+    ssh = {
+      host             = "192.168.0.1"
+      user             = "ubuntu"
+      private_key_path = "/path/to/private/key.pem"
+    }
+  }
+}
+
 resource "enos_bundle_install" "vault" {
   # the destination is the directory when the binary will be placed. Only required for bundles
   destination = "/opt/vault/bin"
@@ -62,3 +90,36 @@ resource "enos_bundle_install" "vault" {
     }
   }
 }
+
+resource "enos_bundle_install" "vault" {
+  # install from the HashiCorp apt or yum repository, depending on the target's package manager
+  repository = {
+    package = "vault-enterprise"
+    version = "1.15.2+ent"
+  }
+
+  transport = {
+    ssh = {
+      host             = "192.168.0.1"
+      user             = "ubuntu"
+      private_key_path = "/path/to/private/key.pem"
+    }
+  }
+}
+
+resource "enos_bundle_install" "tool" {
+  # binaries are installed into the destination directory and made executable
+  destination = "/usr/local/bin"
+
+  # install a binary from a local path. Artifacts without an extension are installed as binaries.
+  path        = "/path/to/tool_1.2.3_linux_amd64"
+  binary_name = "tool"
+
+  transport = {
+    ssh = {
+      host             = "192.168.0.1"
+      user             = "ubuntu"
+      private_key_path = "/path/to/private/key.pem"
+    }
+  }
+}
@@ -0,0 +1,49 @@
+resource "enos_consul_acl" "consul" {
+  depends_on = [
+    enos_consul_start.consul
+  ]
+
+  bin_path = "/opt/consul/bin/consul"
+
+  policies = {
+    vault = <<-EOT
+      key_prefix "vault/" {
+        policy = "write"
+      }
+      service "vault" {
+        policy = "write"
+      }
+      session_prefix "" {
+        policy = "write"
+      }
+    EOT
+  }
+
+  tokens = [
+    {
+      name     = "vault"
+      policies = ["vault"]
+    }
+  ]
+
+  transport = {
+    ssh = {
+      host = aws_instance.consul_instance[0].public_ip
+    }
+  }
+}
+
+resource "enos_vault_start" "vault" {
+  config = {
+    storage = {
+      type = "consul"
+      attributes = {
+        address = "127.0.0.1:8500"
+        path    = "vault"
+        token   = enos_consul_acl.consul.token_secret_ids["vault"]
+      }
+    }
+    // ...
+  }
+  // ...
+}
@@ -0,0 +1,37 @@
+resource "enos_consul_kv" "write" {
+  depends_on = [
+    enos_consul_start.consul
+  ]
+
+  bin_path = "/opt/consul/bin/consul"
+  token    = enos_consul_acl.consul.management_token
+
+  data = {
+    "enos/foo" = "bar"
+    "enos/baz" = "qux"
+  }
+
+  transport = {
+    ssh = {
+      host = aws_instance.consul_instance[0].public_ip
+    }
+  }
+}
+
+resource "enos_consul_kv" "verify" {
+  depends_on = [
+    enos_consul_snapshot.restore
+  ]
+
+  bin_path = "/opt/consul/bin/consul"
+  mode     = "verify"
+  token    = enos_consul_acl.consul.management_token
+  data     = enos_consul_kv.write.data
+  hosts    = aws_instance.new_consul_instance[*].public_ip
+
+  transport = {
+    ssh = {
+      host = aws_instance.new_consul_instance[0].public_ip
+    }
+  }
+}
@@ -0,0 +1,37 @@
+# Save a snapshot on the old cluster and download it locally
+resource "enos_consul_snapshot" "save" {
+  depends_on = [
+    enos_consul_kv.write
+  ]
+
+  bin_path   = "/opt/consul/bin/consul"
+  path       = "/tmp/consul.snap"
+  local_path = "${path.root}/consul.snap"
+  token      = enos_consul_acl.consul.management_token
+
+  transport = {
+    ssh = {
+      host = aws_instance.consul_instance[0].public_ip
+    }
+  }
+}
+
+# Upload and restore the snapshot on the new cluster
+resource "enos_consul_snapshot" "restore" {
+  depends_on = [
+    enos_consul_snapshot.save,
+    enos_consul_start.new_consul,
+  ]
+
+  bin_path   = "/opt/consul/bin/consul"
+  mode       = "restore"
+  path       = "/tmp/consul.snap"
+  local_path = "${path.root}/consul.snap"
+  token      = enos_consul_acl.consul.management_token
+
+  transport = {
+    ssh = {
+      host = aws_instance.new_consul_instance[0].public_ip
+    }
+  }
+}
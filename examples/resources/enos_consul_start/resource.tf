@@ -7,6 +7,7 @@ resource "enos_consul_start" "consul" {
   bin_path   = "/opt/consul/bin/consul"
   data_dir   = "/var/lib/consul"
   config_dir = "/etc/consul.d"
+  gossip_key = var.consul_gossip_key
   config = {
     # Handle instance types that have multiple bind addresses
     # Template syntax : https://pkg.go.dev/github.com/hashicorp/go-sockaddr/template#pkg-overview
@@ -19,6 +20,28 @@ resource "enos_consul_start" "consul" {
     bootstrap_expect = 3
     log_level        = "info"
     log_file         = "/var/log/consul.d"
+    tls = {
+      defaults = {
+        ca_file         = "/etc/consul.d/tls/ca.pem"
+        cert_file       = "/etc/consul.d/tls/server.pem"
+        key_file        = "/etc/consul.d/tls/server-key.pem"
+        verify_incoming = true
+        verify_outgoing = true
+      }
+    }
+    auto_encrypt = {
+      allow_tls = true
+    }
+    acl = {
+      enabled        = true
+      default_policy = "deny"
+    }
+    ports = {
+      https = 8501
+    }
+    ui_config = {
+      enabled = true
+    }
   }
   # Only required for Consul Enterprise
   license   = file("/path/to/consul-enterprise.lic")
@@ -0,0 +1,18 @@
+resource "enos_consul_verify_cluster" "consul" {
+  depends_on = [
+    enos_consul_start.consul
+  ]
+
+  hosts          = aws_instance.consul_instance[*].public_ip
+  leader         = true
+  voters         = 3
+  checks_passing = true
+  timeout        = "5m"
+
+  transport = {
+    ssh = {
+      user             = "ubuntu"
+      private_key_path = "/path/to/private/key.pem"
+    }
+  }
+}
@@ -0,0 +1,17 @@
+resource "enos_consul_verify_version" "consul" {
+  depends_on = [
+    enos_consul_start.consul
+  ]
+
+  bin_path   = "/opt/consul/bin/consul"
+  version    = var.consul_version
+  edition    = var.consul_edition
+  revision   = var.consul_revision
+  build_date = var.consul_build_date
+
+  transport = {
+    ssh = {
+      host = aws_instance.consul_instance.public_ip
+    }
+  }
+}
@@ -0,0 +1,11 @@
+# Register a job from an HCL or JSON jobspec and wait for it to become healthy.
+resource "enos_nomad_job" "vault" {
+  depends_on = [enos_nomad_start.nomad]
+
+  host         = "http://${aws_instance.nomad_instance[0].public_ip}:4646"
+  secret_id    = var.nomad_token
+  namespace    = "default"
+  jobspec      = file("${path.module}/vault.nomad.hcl")
+  wait_timeout = "10m"
+  purge        = true
+}
@@ -0,0 +1,42 @@
+resource "enos_nomad_start" "nomad" {
+  depends_on = [
+    aws_instance.nomad_instance,
+    enos_bundle_install.nomad,
+  ]
+
+  bin_path   = "/opt/nomad/bin/nomad"
+  data_dir   = "/opt/nomad/data"
+  config_dir = "/etc/nomad.d"
+  config = {
+    # Handle instance types that have multiple bind addresses
+    bind_addr  = "{{ GetPrivateInterfaces | include \"type\" \"IP\" | sort \"default\" |  limit 1 | attr \"address\"}}"
+    datacenter = "dc1"
+    region     = "global"
+    retry_join = ["provider=aws tag_key=Type tag_value=nomad-server"]
+    log_level  = "info"
+    server = {
+      enabled          = true
+      bootstrap_expect = 3
+    }
+    client = {
+      enabled = true
+    }
+    plugin = {
+      docker = {
+        config = {
+          allow_privileged = true
+        }
+      }
+    }
+  }
+  # Only required for Nomad Enterprise
+  license   = file("/path/to/nomad-enterprise.hclic")
+  unit_name = "nomad"
+  username  = "nomad"
+
+  transport = {
+    ssh = {
+      host = aws_instance.nomad_instance[0].public_ip
+    }
+  }
+}
@@ -0,0 +1,65 @@
+resource "enos_systemd_service" "app" {
+  name = "my-app"
+
+  unit = {
+    Unit = {
+      Description = "My App"
+      Requires    = "network-online.target"
+      After       = "network-online.target"
+    }
+    Service = {
+      User      = "my-app"
+      ExecStart = "/usr/local/bin/my-app -config /etc/my-app.d"
+      Restart   = "on-failure"
+    }
+    Install = {
+      WantedBy = "multi-user.target"
+    }
+  }
+
+  transport = {
+    ssh = {
+      host = aws_instance.target.public_ip
+    }
+  }
+}
+
+resource "enos_systemd_service" "vault_limits" {
+  depends_on = [enos_bundle_install.vault]
+
+  name = "vault"
+
+  drop_ins = {
+    "10-limits" = {
+      Service = {
+        LimitNOFILE = 65536
+      }
+    }
+  }
+
+  transport = {
+    ssh = {
+      host = aws_instance.target.public_ip
+    }
+  }
+}
+
+resource "enos_systemd_service" "backup" {
+  name = "backup.timer"
+
+  unit = {
+    Timer = {
+      OnCalendar = "daily"
+      Persistent = true
+    }
+    Install = {
+      WantedBy = "timers.target"
+    }
+  }
+
+  transport = {
+    ssh = {
+      host = aws_instance.target.public_ip
+    }
+  }
+}
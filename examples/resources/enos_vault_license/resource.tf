@@ -0,0 +1,17 @@
+resource "enos_vault_license" "vault" {
+  depends_on = [
+    enos_vault_unseal.vault
+  ]
+
+  bin_path          = "/opt/vault/bin/vault"
+  vault_addr        = enos_vault_start.vault.config.api_addr
+  token             = enos_vault_init.vault.root_token
+  license           = file("./vault.hclic")
+  expiration_window = "720h"
+
+  transport = {
+    ssh = {
+      host = aws_instance.vault_instance.public_ip
+    }
+  }
+}
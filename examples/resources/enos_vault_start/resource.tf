@@ -143,3 +143,45 @@ resource "enos_vault_start" "vault" {
     }
   }
 }
+
+# Configure and restart a vault pod that is managed by a stateful set
+resource "enos_vault_start" "vault" {
+  bin_path = "/bin/vault"
+  config = {
+    api_addr     = "http://127.0.0.1:8200"
+    cluster_addr = "http://127.0.0.1:8201"
+    listener = {
+      type = "tcp"
+      attributes = {
+        address     = "0.0.0.0:8200"
+        tls_disable = "true"
+      }
+    }
+    storage = {
+      type = "raft"
+      attributes = {
+        path = "/vault/data"
+      }
+    }
+    seal = {
+      type       = "shamir"
+      attributes = null
+    }
+    ui = true
+  }
+  license = var.vault_license
+
+  kubernetes = {
+    config_map_name = "vault-config"
+    secret_name     = "vault-env"
+  }
+
+  transport = {
+    kubernetes = {
+      kubeconfig_base64 = var.kubeconfig_base64
+      context_name      = var.context_name
+      pod               = "vault-0"
+      namespace         = "vault"
+    }
+  }
+}
@@ -0,0 +1,19 @@
+resource "enos_vault_verify_version" "vault" {
+  depends_on = [
+    enos_vault_unseal.vault
+  ]
+
+  bin_path   = "/opt/vault/bin/vault"
+  vault_addr = enos_vault_start.vault.config.api_addr
+  token      = enos_vault_init.vault.root_token
+  version    = var.vault_version
+  edition    = var.vault_edition
+  revision   = var.vault_revision
+  build_date = var.vault_build_date
+
+  transport = {
+    ssh = {
+      host = aws_instance.vault_instance.public_ip
+    }
+  }
+}
@@ -0,0 +1,43 @@
+# Write some data before upgrading the cluster
+resource "enos_vault_verify_write_read" "write" {
+  depends_on = [
+    enos_vault_unseal.vault
+  ]
+
+  bin_path   = "/opt/vault/bin/vault"
+  vault_addr = enos_vault_start.vault.config.api_addr
+  token      = enos_vault_init.vault.root_token
+  mount      = "enos"
+  path       = "upgrade"
+  data = {
+    foo = "bar"
+  }
+
+  transport = {
+    ssh = {
+      host = aws_instance.vault_instance[0].public_ip
+    }
+  }
+}
+
+# Verify that every node still has the data after the upgrade
+resource "enos_vault_verify_write_read" "verify" {
+  depends_on = [
+    enos_vault_start.upgrade
+  ]
+
+  mode       = "verify"
+  bin_path   = "/opt/vault/bin/vault"
+  vault_addr = enos_vault_start.vault.config.api_addr
+  token      = enos_vault_init.vault.root_token
+  mount      = enos_vault_verify_write_read.write.mount
+  path       = enos_vault_verify_write_read.write.path
+  data       = enos_vault_verify_write_read.write.data
+  nodes      = [for host in aws_instance.vault_instance : "http://${host.private_ip}:8200"]
+
+  transport = {
+    ssh = {
+      host = aws_instance.vault_instance[0].public_ip
+    }
+  }
+}
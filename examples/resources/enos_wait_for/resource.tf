@@ -0,0 +1,20 @@
+resource "enos_wait_for" "vault" {
+  depends_on = [
+    enos_vault_start.vault
+  ]
+
+  tcp               = ["127.0.0.1:8200"]
+  http              = ["https://127.0.0.1:8200/v1/sys/health"]
+  http_status_codes = [200, 429, 501, 503]
+  files             = ["/etc/vault.d/vault.hcl"]
+  processes         = ["vault"]
+  ca_cert           = var.vault_ca_cert
+  interval          = "2s"
+  timeout           = "2m"
+
+  transport = {
+    ssh = {
+      host = aws_instance.vault_instance.public_ip
+    }
+  }
+}
@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package artifactcache is a local artifact cache. Artifacts are downloaded and verified once on
+// the host that is running the provider so that they can be distributed to many targets without
+// each target having to download them.
+package artifactcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/cacert"
+)
+
+// ErrSHA256Mismatch means the downloaded artifact did not match the expected SHA256 sum.
+var ErrSHA256Mismatch = errors.New("artifact SHA256 sum does not match")
+
+// locks are per-artifact locks that prevent concurrent resources from downloading the same
+// artifact into the cache at the same time.
+var locks sync.Map
+
+// Cache is a local artifact cache directory. Artifacts are stored as <dir>/<sha256>/<name>.
+type Cache struct {
+	Dir string
+}
+
+// Artifact is an artifact to get from the cache.
+type Artifact struct {
+	URL          string
+	SHA256       string
+	Headers      map[string]string // Headers to add to the download request
+	CACert       string            // PEM encoded CA certificate bundle used to verify the server
+	AuthUser     string
+	AuthPassword string
+	AuthToken    string
+}
+
+// ArtifactOpt is a functional option for NewArtifact.
+type ArtifactOpt func(*Artifact) *Artifact
+
+// New takes the cache directory and returns a new Cache.
+func New(dir string) *Cache {
+	return &Cache{Dir: dir}
+}
+
+// NewArtifact takes functional options and returns a new Artifact.
+func NewArtifact(opts ...ArtifactOpt) *Artifact {
+	a := &Artifact{}
+
+	for _, opt := range opts {
+		a = opt(a)
+	}
+
+	return a
+}
+
+// WithArtifactURL sets the artifact URL.
+func WithArtifactURL(url string) ArtifactOpt {
+	return func(a *Artifact) *Artifact {
+		a.URL = url
+		return a
+	}
+}
+
+// WithArtifactSHA256 sets the expected artifact SHA256 sum.
+func WithArtifactSHA256(sha string) ArtifactOpt {
+	return func(a *Artifact) *Artifact {
+		a.SHA256 = sha
+		return a
+	}
+}
+
+// WithArtifactHeaders sets headers that will be added to the download request.
+func WithArtifactHeaders(headers map[string]string) ArtifactOpt {
+	return func(a *Artifact) *Artifact {
+		a.Headers = headers
+		return a
+	}
+}
+
+// WithArtifactCACert sets the PEM encoded CA certificate bundle used to verify the server.
+func WithArtifactCACert(cert string) ArtifactOpt {
+	return func(a *Artifact) *Artifact {
+		a.CACert = cert
+		return a
+	}
+}
+
+// WithArtifactAuthUser sets the basic auth user.
+func WithArtifactAuthUser(user string) ArtifactOpt {
+	return func(a *Artifact) *Artifact {
+		a.AuthUser = user
+		return a
+	}
+}
+
+// WithArtifactAuthPassword sets the basic auth password.
+func WithArtifactAuthPassword(password string) ArtifactOpt {
+	return func(a *Artifact) *Artifact {
+		a.AuthPassword = password
+		return a
+	}
+}
+
+// WithArtifactAuthToken sets the bearer auth token.
+func WithArtifactAuthToken(token string) ArtifactOpt {
+	return func(a *Artifact) *Artifact {
+		a.AuthToken = token
+		return a
+	}
+}
+
+// Path returns the path of the artifact in the cache.
+func (c *Cache) Path(a *Artifact) (string, error) {
+	if c.Dir == "" {
+		return "", errors.New("no artifact cache directory has been configured")
+	}
+
+	if a.SHA256 == "" {
+		return "", errors.New("artifacts must have a SHA256 sum to be cached")
+	}
+
+	// The SHA256 sum is part of the cache path so we have to make sure that it's valid
+	if sum, err := hex.DecodeString(a.SHA256); err != nil || len(sum) != sha256.Size {
+		return "", fmt.Errorf("artifact SHA256 sum is not a valid hex encoded SHA256 sum: %s", a.SHA256)
+	}
+
+	u, err := url.Parse(a.URL)
+	if err != nil {
+		return "", fmt.Errorf("parsing artifact URL: %w", err)
+	}
+
+	name := path.Base(u.Path)
+	if name == "" || name == "." || name == "/" {
+		return "", fmt.Errorf("unable to determine artifact name from URL: %s", a.URL)
+	}
+
+	return filepath.Join(c.Dir, strings.ToLower(a.SHA256), name), nil
+}
+
+// Get returns the path to the artifact in the cache. If the artifact is not in the cache it will
+// be downloaded and verified first.
+func (c *Cache) Get(ctx context.Context, a *Artifact) (string, error) {
+	dst, err := c.Path(a)
+	if err != nil {
+		return "", err
+	}
+
+	lock, _ := locks.LoadOrStore(dst, &sync.Mutex{})
+	mu, ok := lock.(*sync.Mutex)
+	if !ok {
+		return "", errors.New("unexpected artifact cache lock type")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+
+	// If we've already got a verified copy of the artifact we're done
+	sum, err := fileSHA256(dst)
+	if err == nil && strings.EqualFold(sum, a.SHA256) {
+		return dst, nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(dst), 0o755)
+	if err != nil {
+		return "", fmt.Errorf("creating artifact cache directory: %w", err)
+	}
+
+	err = a.download(ctx, dst)
+	if err != nil {
+		return "", fmt.Errorf("downloading artifact %s to cache: %w", a.URL, err)
+	}
+
+	return dst, nil
+}
+
+// download downloads the artifact into a temporary file next to the destination, verifies it,
+// and moves it into place.
+func (a *Artifact) download(ctx context.Context, dst string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	for key, val := range a.Headers {
+		req.Header.Set(key, val)
+	}
+
+	if a.AuthUser != "" && a.AuthPassword != "" {
+		req.SetBasicAuth(a.AuthUser, a.AuthPassword)
+	}
+
+	if a.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+a.AuthToken)
+	}
+
+	client, err := a.httpClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".download.*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, hash), resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if sum := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(sum, a.SHA256) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrSHA256Mismatch, a.SHA256, sum)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmp.Name(), 0o644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), dst)
+}
+
+// httpClient returns an HTTP client that trusts the artifact CA certificate if one is set.
+func (a *Artifact) httpClient() (*http.Client, error) {
+	client, err := cacert.HTTPClient(a.CACert)
+	if err != nil {
+		return nil, fmt.Errorf("artifact CA certificate: %w", err)
+	}
+
+	return client, nil
+}
+
+// fileSHA256 returns the hex encoded SHA256 sum of the file.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, f)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package artifactcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheGet(t *testing.T) {
+	t.Parallel()
+
+	body := []byte("vault bundle")
+	sum := sha256.Sum256(body)
+	sha := hex.EncodeToString(sum[:])
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+
+	cache := New(t.TempDir())
+	artifact := NewArtifact(
+		WithArtifactURL(srv.URL+"/vault/1.15.2/vault_1.15.2_linux_amd64.zip"),
+		WithArtifactSHA256(sha),
+		WithArtifactAuthToken("token"),
+	)
+
+	// Many concurrent gets should only download the artifact once
+	wg := sync.WaitGroup{}
+	for range 5 {
+		wg.Go(func() {
+			p, err := cache.Get(context.Background(), artifact)
+			assert := require.New(t)
+			assert.NoError(err)
+			assert.Equal(filepath.Join(cache.Dir, sha, "vault_1.15.2_linux_amd64.zip"), p)
+		})
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), requests.Load())
+
+	p, err := cache.Path(artifact)
+	require.NoError(t, err)
+	got, err := os.ReadFile(p)
+	require.NoError(t, err)
+	require.Equal(t, body, got)
+
+	// A corrupted artifact in the cache should be replaced
+	require.NoError(t, os.WriteFile(p, []byte("corrupted"), 0o644))
+	_, err = cache.Get(context.Background(), artifact)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), requests.Load())
+	got, err = os.ReadFile(p)
+	require.NoError(t, err)
+	require.Equal(t, body, got)
+}
+
+func TestCacheGetSHA256Mismatch(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("not the artifact you're looking for"))
+	}))
+	t.Cleanup(srv.Close)
+
+	cache := New(t.TempDir())
+	artifact := NewArtifact(
+		WithArtifactURL(srv.URL+"/artifact.zip"),
+		WithArtifactSHA256("0000000000000000000000000000000000000000000000000000000000000000"),
+	)
+
+	_, err := cache.Get(context.Background(), artifact)
+	require.ErrorIs(t, err, ErrSHA256Mismatch)
+
+	// Nothing should be left behind in the cache
+	p, err := cache.Path(artifact)
+	require.NoError(t, err)
+	entries, err := os.ReadDir(filepath.Dir(p))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestCachePath(t *testing.T) {
+	t.Parallel()
+
+	cache := New("/cache")
+
+	_, err := cache.Path(NewArtifact(WithArtifactURL("https://example.com/artifact.zip")))
+	require.Error(t, err)
+
+	sum := strings.Repeat("AB", 32)
+
+	_, err = cache.Path(NewArtifact(WithArtifactURL("https://example.com/"), WithArtifactSHA256(sum)))
+	require.Error(t, err)
+
+	_, err = New("").Path(NewArtifact(WithArtifactURL("https://example.com/artifact.zip"), WithArtifactSHA256(sum)))
+	require.Error(t, err)
+
+	for _, invalid := range []string{"abc", "../../../etc", sum[:62] + "zz", sum + "ab"} {
+		_, err = cache.Path(NewArtifact(WithArtifactURL("https://example.com/artifact.zip"), WithArtifactSHA256(invalid)))
+		require.Error(t, err, invalid)
+	}
+
+	p, err := cache.Path(NewArtifact(
+		WithArtifactURL("https://example.com/some/artifact.zip?token=abc"),
+		WithArtifactSHA256(sum),
+	))
+	require.NoError(t, err)
+	require.Equal(t, "/cache/"+strings.Repeat("ab", 32)+"/artifact.zip", p)
+}
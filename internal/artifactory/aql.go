@@ -5,14 +5,19 @@ package artifactory
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"maps"
+	"slices"
+	"strings"
 	"text/template"
+
+	"github.com/hashicorp/go-version"
 )
 
-// AQLQueryTemplate is our default query template. The syntax for AQL, combined
-// a go template might make this hard to read, but most of what we're doing
-// is dealing with trailing commas. The rendered query will look something like
-// the following:
+// AQLQueryTemplate is our default query template. The search criteria are rendered by the
+// request's Criteria() method so that the template only needs to deal with trailing commas. The
+// rendered query will look something like the following:
 //
 //	items.find({
 //	  "repo": { "$match": "hashicorp-packagespec-buildcache-local*" },
@@ -23,60 +28,91 @@ import (
 //	  "@GOOS": { "$match": "linux" },
 //	  "@artifactType": { "$match": "package" },
 //	  "@productRevision": { "$match": "f45845666b4e552bfc8ca775834a3ef6fc097fe0" },
-//	  "@productVersion": { "$match": "1.7.0" }
-//	}) .include("*", "property.*") .sort({"$desc": ["modified"]}) .limit(1)
+//	  "@productVersion": { "$match": "1.7.0" },
+//	  "$and": [{ "@productVersion": { "$gt": "1.6.0" } }]
+//	}) .include("*", "property.*") .sort({"$desc": ["modified"]}) .offset(10) .limit(1)
 var AQLQueryTemplate = template.Must(template.New("aql_query").Parse(`items.find({
-  {{ if .Repo -}}
-  "repo": { "$match": "{{ .Repo }}" }{{ if or .Path .Name .Properties -}},{{ end }}
-  {{ end -}}
-  {{ if .Path -}}
-  "path": { "$match": "{{ .Path }}" }{{ if or .Name .Properties -}},{{ end }}
-  {{ end -}}
-  {{ if .Name -}}
-  "name": { "$match": "{{ .Name }}" }{{ if .Properties -}},{{ end }}
+  {{ range $i, $criterion := .Criteria -}}
+  {{ if $i -}},
   {{ end -}}
-  {{ if .Properties -}}
-  {{ $first := true -}}
-  {{ range $k, $v := .Properties -}}
-  {{ if $first -}}
-	{{ $first = false -}}
-  {{ else -}},{{ end -}}
-  "@{{ $k }}": { "$match": "{{ $v }}" }
-  {{ end -}}
-  {{ end -}}
-}) .include("*", "property.*") .sort({"$desc": ["modified"]}) {{ if .Limit -}}.limit({{ .Limit -}}){{ end -}}
+  {{ $criterion }}
+  {{- end }}
+}) .include("*", "property.*") .sort({{ .SortClause }}) {{ if .Offset -}}.offset({{ .Offset -}}) {{ end -}}{{ if .Limit -}}.limit({{ .Limit -}}){{ end -}}
 `))
 
+// Sort orders.
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// Property comparison operators.
+const (
+	OperatorEq     = "$eq"
+	OperatorNe     = "$ne"
+	OperatorGt     = "$gt"
+	OperatorGte    = "$gte"
+	OperatorLt     = "$lt"
+	OperatorLte    = "$lte"
+	OperatorMatch  = "$match"
+	OperatorNmatch = "$nmatch"
+	// OperatorIn is not an AQL operator. It matches any of the values by rendering an "$or" of
+	// "$eq" comparisons.
+	OperatorIn = "$in"
+)
+
+// SupportedOperators are the property comparison operators that we support.
+var SupportedOperators = []string{
+	OperatorEq, OperatorNe, OperatorGt, OperatorGte, OperatorLt, OperatorLte,
+	OperatorMatch, OperatorNmatch, OperatorIn,
+}
+
+// PropertyCriterion is a comparison of an item property.
+type PropertyCriterion struct {
+	Property string
+	Operator string
+	Values   []string
+}
+
 type SearchAQLOpt func(*SearchAQLRequest) *SearchAQLRequest
 
 type SearchAQLResponse struct {
-	Results []struct {
-		Repo       string      `json:"repo"`
-		Path       string      `json:"path"`
-		Name       string      `json:"name"`
-		Type       string      `json:"type"`
-		Size       json.Number `json:"size"`
-		SHA256     string      `json:"sha256"`
-		Properties []struct {
-			Key   string `json:"key"`
-			Value string `json:"value"`
-		} `json:"properties"`
-	} `json:"results"`
+	Results []SearchAQLResult `json:"results"`
+}
+
+// SearchAQLResult is an item that was found by an AQL search.
+type SearchAQLResult struct {
+	Repo       string      `json:"repo"`
+	Path       string      `json:"path"`
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	Size       json.Number `json:"size"`
+	SHA256     string      `json:"sha256"`
+	Properties []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"properties"`
 }
 
 type SearchAQLRequest struct {
-	Repo          string
-	Path          string
-	Name          string
-	Properties    map[string]string
-	QueryTemplate *template.Template
-	Limit         string
+	Repo             string
+	Path             string
+	Name             string
+	Properties       map[string]string
+	PropertyCriteria []*PropertyCriterion
+	SortFields       []string
+	SortOrder        string
+	QueryTemplate    *template.Template
+	Offset           string
+	Limit            string
 }
 
 func NewSearchAQLRequest(opts ...SearchAQLOpt) *SearchAQLRequest {
 	req := &SearchAQLRequest{
 		QueryTemplate: AQLQueryTemplate,
 		Properties:    map[string]string{},
+		SortFields:    []string{"modified"},
+		SortOrder:     SortOrderDesc,
 	}
 
 	for _, opt := range opts {
@@ -114,6 +150,35 @@ func WithLimit(limit string) SearchAQLOpt {
 	}
 }
 
+// WithOffset sets the number of results to skip.
+func WithOffset(offset string) SearchAQLOpt {
+	return func(req *SearchAQLRequest) *SearchAQLRequest {
+		req.Offset = offset
+		return req
+	}
+}
+
+// WithSort sets the fields to sort the results by and the sort order.
+func WithSort(order string, fields ...string) SearchAQLOpt {
+	return func(req *SearchAQLRequest) *SearchAQLRequest {
+		req.SortOrder = order
+		req.SortFields = fields
+		return req
+	}
+}
+
+// WithPropertyCriterion adds a property comparison to the search.
+func WithPropertyCriterion(property, operator string, values ...string) SearchAQLOpt {
+	return func(req *SearchAQLRequest) *SearchAQLRequest {
+		req.PropertyCriteria = append(req.PropertyCriteria, &PropertyCriterion{
+			Property: property,
+			Operator: operator,
+			Values:   values,
+		})
+		return req
+	}
+}
+
 func WithQueryTemplate(temp *template.Template) SearchAQLOpt {
 	return func(req *SearchAQLRequest) *SearchAQLRequest {
 		req.QueryTemplate = temp
@@ -135,3 +200,169 @@ func WithProperties(props map[string]string) SearchAQLOpt {
 		return req
 	}
 }
+
+// Criteria returns the rendered search criteria of the request.
+func (r *SearchAQLRequest) Criteria() ([]string, error) {
+	criteria := []string{}
+
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"repo", r.Repo},
+		{"path", r.Path},
+		{"name", r.Name},
+	} {
+		if field.value == "" {
+			continue
+		}
+
+		c, err := renderComparison(field.name, OperatorMatch, field.value)
+		if err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, c)
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(r.Properties)) {
+		c, err := renderComparison("@"+k, OperatorMatch, r.Properties[k])
+		if err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, c)
+	}
+
+	if len(r.PropertyCriteria) > 0 {
+		and := []string{}
+		for _, pc := range r.PropertyCriteria {
+			c, err := pc.render()
+			if err != nil {
+				return nil, err
+			}
+			and = append(and, "{ "+c+" }")
+		}
+		criteria = append(criteria, `"$and": [`+strings.Join(and, ", ")+"]")
+	}
+
+	return criteria, nil
+}
+
+// SortClause returns the rendered sort clause of the request.
+func (r *SearchAQLRequest) SortClause() (string, error) {
+	order := r.SortOrder
+	if order == "" {
+		order = SortOrderDesc
+	}
+
+	if order != SortOrderAsc && order != SortOrderDesc {
+		return "", fmt.Errorf("unsupported sort order %q, must be one of %q or %q", order, SortOrderAsc, SortOrderDesc)
+	}
+
+	fields := r.SortFields
+	if len(fields) == 0 {
+		fields = []string{"modified"}
+	}
+
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`{"$%s": %s}`, order, string(fieldsJSON)), nil
+}
+
+// render renders the property criterion.
+func (p *PropertyCriterion) render() (string, error) {
+	if p.Property == "" {
+		return "", errors.New("property criteria must include a property")
+	}
+
+	if !slices.Contains(SupportedOperators, p.Operator) {
+		return "", fmt.Errorf("unsupported operator %q for property %s, must be one of: %s",
+			p.Operator, p.Property, strings.Join(SupportedOperators, ", "),
+		)
+	}
+
+	if len(p.Values) == 0 {
+		return "", fmt.Errorf("property criteria for %s must include at least one value", p.Property)
+	}
+
+	if p.Operator != OperatorIn {
+		if len(p.Values) > 1 {
+			return "", fmt.Errorf("the %s operator for property %s only supports a single value", p.Operator, p.Property)
+		}
+
+		return renderComparison("@"+p.Property, p.Operator, p.Values[0])
+	}
+
+	or := []string{}
+	for _, v := range p.Values {
+		c, err := renderComparison("@"+p.Property, OperatorEq, v)
+		if err != nil {
+			return "", err
+		}
+		or = append(or, "{ "+c+" }")
+	}
+
+	return `"$or": [` + strings.Join(or, ", ") + "]", nil
+}
+
+// renderComparison renders a field comparison, e.g. "name": { "$match": "*.zip" }.
+func renderComparison(field, operator, value string) (string, error) {
+	fieldJSON, err := json.Marshal(field)
+	if err != nil {
+		return "", err
+	}
+
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`%s: { "%s": %s }`, fieldJSON, operator, valueJSON), nil
+}
+
+// Property returns the first value of the property with the given key.
+func (r SearchAQLResult) Property(key string) (string, bool) {
+	for _, prop := range r.Properties {
+		if prop.Key == key {
+			return prop.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// SortByVersionProperty sorts the results from the highest to lowest semantic version of the
+// given property. Results that do not have the property or whose value is not a valid version are
+// removed. Results with equal versions retain their original order.
+func (r *SearchAQLResponse) SortByVersionProperty(property string) {
+	type versioned struct {
+		result  SearchAQLResult
+		version *version.Version
+	}
+
+	results := []versioned{}
+	for _, result := range r.Results {
+		val, ok := result.Property(property)
+		if !ok {
+			continue
+		}
+
+		ver, err := version.NewVersion(val)
+		if err != nil {
+			continue
+		}
+
+		results = append(results, versioned{result: result, version: ver})
+	}
+
+	slices.SortStableFunc(results, func(a, b versioned) int {
+		return b.version.Compare(a.version)
+	})
+
+	r.Results = []SearchAQLResult{}
+	for _, result := range results {
+		r.Results = append(r.Results, result.result)
+	}
+}
@@ -5,6 +5,7 @@ package artifactory
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
@@ -275,3 +276,102 @@ items.find(
 		})
 	}
 }
+
+func TestAQLQueryTemplate(t *testing.T) {
+	t.Parallel()
+
+	for desc, test := range map[string]struct {
+		opts     []SearchAQLOpt
+		expected string
+		fails    bool
+	}{
+		"defaults": {
+			opts: []SearchAQLOpt{
+				WithRepo("hashicorp-crt-prod-local*"),
+				WithName("*.zip"),
+				WithProperty("product-name", "vault"),
+			},
+			expected: `items.find({
+  "repo": { "$match": "hashicorp-crt-prod-local*" },
+  "name": { "$match": "*.zip" },
+  "@product-name": { "$match": "vault" }
+}) .include("*", "property.*") .sort({"$desc": ["modified"]}) `,
+		},
+		"sort and pagination": {
+			opts: []SearchAQLOpt{
+				WithPath("vault/*"),
+				WithSort(SortOrderAsc, "name", "created"),
+				WithOffset("10"),
+				WithLimit("5"),
+			},
+			expected: `items.find({
+  "path": { "$match": "vault/*" }
+}) .include("*", "property.*") .sort({"$asc": ["name","created"]}) .offset(10) .limit(5)`,
+		},
+		"property criteria": {
+			opts: []SearchAQLOpt{
+				WithProperty("product-name", "vault"),
+				WithPropertyCriterion("productVersion", OperatorGte, "1.15.0"),
+				WithPropertyCriterion("EDITION", OperatorIn, "ent", "ent.hsm"),
+				WithPropertyCriterion("commit", OperatorNe, `a"b`),
+			},
+			expected: `items.find({
+  "@product-name": { "$match": "vault" },
+  "$and": [{ "@productVersion": { "$gte": "1.15.0" } }, { "$or": [{ "@EDITION": { "$eq": "ent" } }, { "@EDITION": { "$eq": "ent.hsm" } }] }, { "@commit": { "$ne": "a\"b" } }]
+}) .include("*", "property.*") .sort({"$desc": ["modified"]}) `,
+		},
+		"unsupported operator": {
+			opts:  []SearchAQLOpt{WithPropertyCriterion("productVersion", "$like", "1.15.0")},
+			fails: true,
+		},
+		"multiple values without in": {
+			opts:  []SearchAQLOpt{WithPropertyCriterion("productVersion", OperatorEq, "1.15.0", "1.16.0")},
+			fails: true,
+		},
+		"no values": {
+			opts:  []SearchAQLOpt{WithPropertyCriterion("productVersion", OperatorIn)},
+			fails: true,
+		},
+		"unsupported sort order": {
+			opts:  []SearchAQLOpt{WithSort("up", "name")},
+			fails: true,
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			req := NewSearchAQLRequest(test.opts...)
+			buf := &bytes.Buffer{}
+			err := req.QueryTemplate.Execute(buf, req)
+			if test.fails {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, buf.String())
+		})
+	}
+}
+
+func TestSearchAQLResponseSortByVersionProperty(t *testing.T) {
+	t.Parallel()
+
+	res := &SearchAQLResponse{}
+	require.NoError(t, json.Unmarshal([]byte(`{"results": [
+  { "name": "1.15.2", "properties": [{ "key": "productVersion", "value": "1.15.2" }] },
+  { "name": "none", "properties": [] },
+  { "name": "1.16.0-rc1", "properties": [{ "key": "productVersion", "value": "1.16.0-rc1" }] },
+  { "name": "invalid", "properties": [{ "key": "productVersion", "value": "main" }] },
+  { "name": "1.16.0", "properties": [{ "key": "productVersion", "value": "1.16.0" }] },
+  { "name": "1.15.10", "properties": [{ "key": "productVersion", "value": "1.15.10" }] },
+  { "name": "1.16.0+ent", "properties": [{ "key": "productVersion", "value": "1.16.0+ent" }] }
+]}`), res))
+
+	res.SortByVersionProperty("productVersion")
+
+	names := []string{}
+	for _, result := range res.Results {
+		names = append(names, result.Name)
+	}
+	require.Equal(t, []string{"1.16.0", "1.16.0+ent", "1.16.0-rc1", "1.15.10", "1.15.2"}, names)
+}
@@ -89,13 +89,7 @@ func (c *Client) SearchAQL(ctx context.Context, req *SearchAQLRequest) (*SearchA
 		return res, fmt.Errorf("generating artifactory search request: %w", err)
 	}
 	areq.Header.Add("Content-Type", "text/plain")
-	if c.Username == "" {
-		// Identity token
-		areq.Header.Add("Authorization", "Bearer "+c.Token)
-	} else {
-		// API key
-		areq.SetBasicAuth(c.Username, c.Token)
-	}
+	c.setAuth(areq)
 
 	ares, err := c.http.Do(areq)
 	if err != nil {
@@ -123,3 +117,14 @@ func (c *Client) SearchAQL(ctx context.Context, req *SearchAQLRequest) (*SearchA
 
 	return res, nil
 }
+
+// setAuth sets the request authentication.
+func (c *Client) setAuth(req *http.Request) {
+	if c.Username == "" {
+		// Identity token
+		req.Header.Add("Authorization", "Bearer "+c.Token)
+	} else {
+		// API key
+		req.SetBasicAuth(c.Username, c.Token)
+	}
+}
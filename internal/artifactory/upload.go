@@ -0,0 +1,291 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package artifactory
+
+import (
+	"context"
+	"crypto/md5"  //#nosec:G501
+	"crypto/sha1" //#nosec:G505
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// UploadRequest is a request to upload a local file to Artifactory.
+type UploadRequest struct {
+	Repo       string
+	Path       string // The path of the item in the repository, including the item name
+	SourcePath string // The path to the local file to upload
+	Properties map[string]string
+}
+
+// UploadOpt is a functional option for NewUploadRequest.
+type UploadOpt func(*UploadRequest) *UploadRequest
+
+// UploadResponse is the response from an upload.
+type UploadResponse struct {
+	Repo        string      `json:"repo"`
+	Path        string      `json:"path"`
+	DownloadURI string      `json:"downloadUri"`
+	URI         string      `json:"uri"`
+	Size        json.Number `json:"size"`
+	Checksums   Checksums   `json:"checksums"`
+
+	// DeployedByChecksum is whether or not the item was deployed by checksum, i.e. Artifactory
+	// already had the content and we did not need to upload it.
+	DeployedByChecksum bool `json:"-"`
+}
+
+// Checksums are the checksums of an item.
+type Checksums struct {
+	SHA1   string `json:"sha1"`
+	MD5    string `json:"md5"`
+	SHA256 string `json:"sha256"`
+}
+
+// NewUploadRequest takes functional options and returns a new UploadRequest.
+func NewUploadRequest(opts ...UploadOpt) *UploadRequest {
+	req := &UploadRequest{
+		Properties: map[string]string{},
+	}
+
+	for _, opt := range opts {
+		req = opt(req)
+	}
+
+	return req
+}
+
+// WithUploadRepo sets the repository to upload to.
+func WithUploadRepo(repo string) UploadOpt {
+	return func(req *UploadRequest) *UploadRequest {
+		req.Repo = repo
+		return req
+	}
+}
+
+// WithUploadPath sets the path of the item in the repository, including the item name.
+func WithUploadPath(path string) UploadOpt {
+	return func(req *UploadRequest) *UploadRequest {
+		req.Path = path
+		return req
+	}
+}
+
+// WithUploadSourcePath sets the path to the local file to upload.
+func WithUploadSourcePath(path string) UploadOpt {
+	return func(req *UploadRequest) *UploadRequest {
+		req.SourcePath = path
+		return req
+	}
+}
+
+// WithUploadProperties sets the properties of the uploaded item.
+func WithUploadProperties(props map[string]string) UploadOpt {
+	return func(req *UploadRequest) *UploadRequest {
+		maps.Copy(req.Properties, props)
+
+		return req
+	}
+}
+
+// ItemURL returns the fully qualified URL of an item in a repository.
+func (c *Client) ItemURL(repo, path string) string {
+	parts := []string{strings.TrimSuffix(c.Host, "/"), url.PathEscape(repo)}
+	for part := range strings.SplitSeq(strings.Trim(path, "/"), "/") {
+		parts = append(parts, url.PathEscape(part))
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// Upload uploads the local file to Artifactory. We'll attempt to deploy the item by checksum
+// first so that content Artifactory already has is not uploaded again. If Artifactory does not
+// have the content we'll upload it.
+func (c *Client) Upload(ctx context.Context, req *UploadRequest) (*UploadResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if req.Repo == "" {
+		return nil, errors.New("uploading artifactory item: no repository was provided")
+	}
+
+	if req.Path == "" {
+		return nil, errors.New("uploading artifactory item: no item path was provided")
+	}
+
+	sums, err := fileChecksums(req.SourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("uploading artifactory item: determining checksums: %w", err)
+	}
+
+	itemURL := c.ItemURL(req.Repo, req.Path) + matrixParams(req.Properties)
+
+	tflog.Info(ctx, "deploying artifactory item by checksum", map[string]any{
+		"url":    itemURL,
+		"sha256": sums.SHA256,
+	})
+
+	res, err := c.put(ctx, itemURL, sums, nil, true)
+	if err == nil {
+		res.DeployedByChecksum = true
+
+		return res, nil
+	}
+
+	if !errors.Is(err, errNotFound) {
+		return nil, fmt.Errorf("deploying artifactory item by checksum: %w", err)
+	}
+
+	// Artifactory doesn't have the content so we'll need to upload it.
+	f, err := os.Open(req.SourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("uploading artifactory item: %w", err)
+	}
+	defer f.Close()
+
+	tflog.Info(ctx, "uploading artifactory item", map[string]any{
+		"url":    itemURL,
+		"sha256": sums.SHA256,
+	})
+
+	res, err = c.put(ctx, itemURL, sums, f, false)
+	if err != nil {
+		return nil, fmt.Errorf("uploading artifactory item: %w", err)
+	}
+
+	return res, nil
+}
+
+// DeleteItem deletes an item from Artifactory. Items that do not exist are considered deleted.
+func (c *Client) DeleteItem(ctx context.Context, repo, path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	itemURL := c.ItemURL(repo, path)
+
+	tflog.Info(ctx, "deleting artifactory item", map[string]any{
+		"url": itemURL,
+	})
+
+	areq, err := http.NewRequestWithContext(ctx, http.MethodDelete, itemURL, nil)
+	if err != nil {
+		return fmt.Errorf("generating artifactory delete request: %w", err)
+	}
+	c.setAuth(areq)
+
+	ares, err := c.http.Do(areq)
+	if err != nil {
+		return fmt.Errorf("executing artifactory delete request: %w", err)
+	}
+	defer ares.Body.Close()
+
+	switch ares.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		body, _ := io.ReadAll(ares.Body)
+
+		return fmt.Errorf("executing artifactory delete request: %s - %s", ares.Status, string(body))
+	}
+}
+
+var errNotFound = errors.New("not found")
+
+// put performs a PUT request to deploy an item.
+func (c *Client) put(ctx context.Context, itemURL string, sums *Checksums, body io.Reader, byChecksum bool) (*UploadResponse, error) {
+	areq, err := http.NewRequestWithContext(ctx, http.MethodPut, itemURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("generating artifactory upload request: %w", err)
+	}
+	c.setAuth(areq)
+	areq.Header.Set("X-Checksum-Sha256", sums.SHA256)
+	areq.Header.Set("X-Checksum-Sha1", sums.SHA1)
+	areq.Header.Set("X-Checksum", sums.MD5)
+	if byChecksum {
+		areq.Header.Set("X-Checksum-Deploy", "true")
+	}
+
+	ares, err := c.http.Do(areq)
+	if err != nil {
+		return nil, err
+	}
+	defer ares.Body.Close()
+
+	resBody, err := io.ReadAll(ares.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading artifactory upload response body: %w", err)
+	}
+
+	switch ares.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s - %s", errNotFound, ares.Status, string(resBody))
+	default:
+		return nil, fmt.Errorf("%s - %s", ares.Status, string(resBody))
+	}
+
+	res := &UploadResponse{}
+	err = json.Unmarshal(resBody, res)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling artifactory upload response: %w", err)
+	}
+
+	if res.Checksums.SHA256 != "" && !strings.EqualFold(res.Checksums.SHA256, sums.SHA256) {
+		return nil, fmt.Errorf("uploaded item SHA256 %s does not match expected SHA256 %s",
+			res.Checksums.SHA256, sums.SHA256,
+		)
+	}
+	res.Checksums = *sums
+
+	return res, nil
+}
+
+// matrixParams returns the properties as Artifactory matrix parameters.
+func matrixParams(props map[string]string) string {
+	params := strings.Builder{}
+	for _, k := range slices.Sorted(maps.Keys(props)) {
+		params.WriteString(";" + escapeMatrixParam(k) + "=" + escapeMatrixParam(props[k]))
+	}
+
+	return params.String()
+}
+
+func escapeMatrixParam(param string) string {
+	return strings.NewReplacer("=", "%3D", ",", "%2C", ";", "%3B").Replace(url.PathEscape(param))
+}
+
+// fileChecksums returns the checksums of the file.
+func fileChecksums(path string) (*Checksums, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sha256Hash := sha256.New()
+	sha1Hash := sha1.New() //#nosec:G401
+	md5Hash := md5.New()   //#nosec:G401
+	_, err = io.Copy(io.MultiWriter(sha256Hash, sha1Hash, md5Hash), f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Checksums{
+		SHA256: hex.EncodeToString(sha256Hash.Sum(nil)),
+		SHA1:   hex.EncodeToString(sha1Hash.Sum(nil)),
+		MD5:    hex.EncodeToString(md5Hash.Sum(nil)),
+	}, nil
+}
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package artifactory
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testArtifactoryServer is a minimal stand-in for the Artifactory deploy and delete APIs.
+type testArtifactoryServer struct {
+	mu         sync.Mutex
+	token      string
+	items      map[string]string // item path -> sha256
+	content    map[string]bool   // sha256 of content that has been uploaded
+	properties map[string]string // item path -> matrix params
+	uploads    int
+}
+
+func newTestArtifactoryServer(t *testing.T, token string) (*testArtifactoryServer, *httptest.Server) {
+	t.Helper()
+
+	s := &testArtifactoryServer{
+		token:      token,
+		items:      map[string]string{},
+		content:    map[string]bool{},
+		properties: map[string]string{},
+	}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	return s, srv
+}
+
+func (s *testArtifactoryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	itemPath, params, _ := strings.Cut(r.URL.EscapedPath(), ";")
+
+	switch r.Method {
+	case http.MethodPut:
+		sha := r.Header.Get("X-Checksum-Sha256")
+		if r.Header.Get("X-Checksum-Deploy") == "true" {
+			if !s.content[sha] {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+		} else {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			sum := sha256.Sum256(body)
+			if hex.EncodeToString(sum[:]) != sha {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			s.content[sha] = true
+			s.uploads++
+		}
+
+		s.items[itemPath] = sha
+		s.properties[itemPath] = params
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"downloadUri": "http://" + r.Host + itemPath,
+			"checksums":   map[string]string{"sha256": sha},
+			"size":        "7",
+		})
+	case http.MethodDelete:
+		if _, ok := s.items[itemPath]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.items, itemPath)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestUploadAndDeleteItem(t *testing.T) {
+	t.Parallel()
+
+	s, srv := newTestArtifactoryServer(t, "token")
+
+	src := filepath.Join(t.TempDir(), "debug.tar.gz")
+	require.NoError(t, os.WriteFile(src, []byte("content"), 0o644))
+	sum := sha256.Sum256([]byte("content"))
+	sha := hex.EncodeToString(sum[:])
+
+	client := NewClient(WithHost(srv.URL+"/artifactory"), WithToken("token"))
+	upload := func(path string) *UploadResponse {
+		res, err := client.Upload(context.Background(), NewUploadRequest(
+			WithUploadRepo("enos-local"),
+			WithUploadPath(path),
+			WithUploadSourcePath(src),
+			WithUploadProperties(map[string]string{"scenario": "smoke", "commit": "a,b=c"}),
+		))
+		require.NoError(t, err)
+
+		return res
+	}
+
+	// The first upload does not exist so we'll have to upload the content
+	res := upload("debug/one/debug.tar.gz")
+	require.False(t, res.DeployedByChecksum)
+	require.Equal(t, sha, res.Checksums.SHA256)
+	require.Equal(t, srv.URL+"/artifactory/enos-local/debug/one/debug.tar.gz", res.DownloadURI)
+	require.Equal(t, "commit=a%2Cb%3Dc;scenario=smoke", s.properties["/artifactory/enos-local/debug/one/debug.tar.gz"])
+
+	// The second upload has the same content so it should be deployed by checksum
+	res = upload("debug/two/debug.tar.gz")
+	require.True(t, res.DeployedByChecksum)
+	require.Equal(t, 1, s.uploads)
+	require.Len(t, s.items, 2)
+
+	// Deleting should remove the item and deleting it again is not an error
+	for range 2 {
+		require.NoError(t, client.DeleteItem(context.Background(), "enos-local", "debug/one/debug.tar.gz"))
+		require.Len(t, s.items, 1)
+	}
+}
+
+func TestUploadUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	_, srv := newTestArtifactoryServer(t, "token")
+
+	src := filepath.Join(t.TempDir(), "debug.tar.gz")
+	require.NoError(t, os.WriteFile(src, []byte("content"), 0o644))
+
+	client := NewClient(WithHost(srv.URL), WithToken("wrong"))
+	_, err := client.Upload(context.Background(), NewUploadRequest(
+		WithUploadRepo("enos-local"),
+		WithUploadPath("debug.tar.gz"),
+		WithUploadSourcePath(src),
+	))
+	require.Error(t, err)
+	require.Error(t, client.DeleteItem(context.Background(), "enos-local", "debug.tar.gz"))
+}
+
+func TestItemURL(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithHost("https://artifactory.example.com/artifactory/"))
+	require.Equal(t,
+		"https://artifactory.example.com/artifactory/enos-local/some/path/vault%201.15.zip",
+		client.ItemURL("enos-local", "/some/path/vault 1.15.zip"),
+	)
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cacert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+)
+
+// HTTPClient returns an HTTP client that trusts the PEM encoded CA certificate bundle. If no CA
+// certificate is set the default client is used.
+func HTTPClient(pem string) (*http.Client, error) {
+	if pem == "" {
+		return http.DefaultClient, nil
+	}
+
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM([]byte(pem)); !ok {
+		return nil, errors.New("no certificates found in CA certificate")
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("unable to clone the default HTTP transport")
+	}
+	transport = transport.Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
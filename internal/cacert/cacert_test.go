@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cacert
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		client, err := HTTPClient("")
+		require.NoError(t, err)
+		require.Equal(t, http.DefaultClient, client)
+	})
+
+	t.Run("trusted", func(t *testing.T) {
+		t.Parallel()
+
+		client, err := HTTPClient(string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: srv.Certificate().Raw,
+		})))
+		require.NoError(t, err)
+
+		res, err := client.Get(srv.URL)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := HTTPClient("not a certificate")
+		require.Error(t, err)
+	})
+}
@@ -42,8 +42,14 @@ type CommandArgs struct {
 	authUser                  string
 	authPassword              string
 	authToken                 string
+	headers                   headerFlags
 	replace                   bool
 	exitWithRequestStatusCode bool
+	caCert                    string
+	clientCert                string
+	clientKey                 string
+	tlsServerName             string
+	tlsSkipVerify             bool
 }
 
 // Synopsis is the cli.Command synopsis.
@@ -68,8 +74,14 @@ Options:
   --sha256                  Verifies that the downloaded file matches the given SHA 256 sum
   --auth-user               The username to use for basic auth
   --auth-password           The password to use for basic auth
+  --header                  A header to add to the request in 'Key: Value' format. Can be given multiple times
   --replace                 Replace the destination file if it exists
   --exit-with-status-code   On failure, exit with the HTTP status code returned
+  --ca-cert                 The CA certificate used to verify the server certificate
+  --client-cert             The client certificate to use for TLS authentication
+  --client-key              The client key to use for TLS authentication
+  --tls-server-name         The server name used to verify the server certificate
+  --tls-skip-verify         Do not verify the server certificate
 
 `
 
@@ -114,8 +126,14 @@ func (a *CommandArgs) Parse(args []string) error {
 	a.flags.StringVar(&a.authUser, "auth-user", "", "if given, sets the basic auth username when making the HTTP request - only username/ password OR token should be used")
 	a.flags.StringVar(&a.authPassword, "auth-password", "", "if given, sets the basic auth password when making the HTTP request - only username/ password OR token should be used")
 	a.flags.StringVar(&a.authToken, "auth-token", "", "if given, sets the auth token when making the HTTP request - only username/ password OR token should be used")
+	a.flags.Var(&a.headers, "header", "if given, adds the header to the HTTP request in 'Key: Value' format - can be given multiple times")
 	a.flags.BoolVar(&a.replace, "replace", false, "overwite the destination if it already exists")
 	a.flags.BoolVar(&a.exitWithRequestStatusCode, "exit-with-status-code", false, "On failure, exit with the HTTP status code returned")
+	a.flags.StringVar(&a.caCert, "ca-cert", "", "if given, the CA certificate used to verify the server certificate")
+	a.flags.StringVar(&a.clientCert, "client-cert", "", "if given, the client certificate to use for TLS authentication")
+	a.flags.StringVar(&a.clientKey, "client-key", "", "if given, the client key to use for TLS authentication")
+	a.flags.StringVar(&a.tlsServerName, "tls-server-name", "", "if given, the server name used to verify the server certificate")
+	a.flags.BoolVar(&a.tlsSkipVerify, "tls-skip-verify", false, "do not verify the server certificate")
 
 	err := a.flags.Parse(args)
 	if err != nil {
@@ -126,6 +144,16 @@ func (a *CommandArgs) Parse(args []string) error {
 		return errors.New("you must provide either a destination or stdout")
 	}
 
+	for _, header := range a.headers {
+		if key, _, ok := strings.Cut(header, ":"); !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("invalid header %q, headers must be in 'Key: Value' format", header)
+		}
+	}
+
+	if (a.clientCert == "") != (a.clientKey == "") {
+		return errors.New("you must provide both a client certificate and client key")
+	}
+
 	return nil
 }
 
@@ -175,6 +203,27 @@ func (c *Command) Download() error {
 		opts = append(opts, WithRequestAuthToken(c.args.authToken))
 	}
 
+	for _, header := range c.args.headers {
+		key, val, _ := strings.Cut(header, ":")
+		opts = append(opts, WithRequestHeader(strings.TrimSpace(key), strings.TrimSpace(val)))
+	}
+
+	if c.args.caCert != "" {
+		opts = append(opts, WithRequestCACert(c.args.caCert))
+	}
+
+	if c.args.clientCert != "" {
+		opts = append(opts, WithRequestClientCert(c.args.clientCert, c.args.clientKey))
+	}
+
+	if c.args.tlsServerName != "" {
+		opts = append(opts, WithRequestTLSServerName(c.args.tlsServerName))
+	}
+
+	if c.args.tlsSkipVerify {
+		opts = append(opts, WithRequestTLSSkipVerify(true))
+	}
+
 	req, err := NewRequest(opts...)
 	if err != nil {
 		return err
@@ -182,3 +231,18 @@ func (c *Command) Download() error {
 
 	return Download(ctx, req)
 }
+
+// headerFlags is a flag.Value that allows the --header flag to be given multiple times.
+type headerFlags []string
+
+// String returns the headers as a string.
+func (h *headerFlags) String() string {
+	return strings.Join(*h, ", ")
+}
+
+// Set appends a header.
+func (h *headerFlags) Set(val string) error {
+	*h = append(*h, val)
+
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
@@ -24,7 +26,18 @@ type Request struct {
 	AuthUser     string
 	AuthPassword string
 	AuthToken    string
+	Headers      http.Header
 	WriteStdout  bool
+	*TLSConfig
+}
+
+// TLSConfig is the client TLS configuration of a download request.
+type TLSConfig struct {
+	CACertPath     string
+	ClientCertPath string
+	ClientKeyPath  string
+	ServerName     string
+	SkipVerify     bool
 }
 
 // RequestOpt are functional options for a new Request.
@@ -100,6 +113,18 @@ func WithRequestAuthToken(token string) RequestOpt {
 	}
 }
 
+// WithRequestHeader adds a header to the request.
+func WithRequestHeader(key, value string) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		if req.Headers == nil {
+			req.Headers = http.Header{}
+		}
+		req.Headers.Add(key, value)
+
+		return req, nil
+	}
+}
+
 // WithRequestWriteStdout sets whether or not we should write the body to STDOUT.
 func WithRequestWriteStdout(enabled bool) RequestOpt {
 	return func(req *Request) (*Request, error) {
@@ -109,10 +134,49 @@ func WithRequestWriteStdout(enabled bool) RequestOpt {
 	}
 }
 
+// WithRequestCACert sets the path to the CA certificate used to verify the server.
+func WithRequestCACert(path string) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.CACertPath = path
+
+		return req, nil
+	}
+}
+
+// WithRequestClientCert sets the paths to the client certificate and key.
+func WithRequestClientCert(certPath, keyPath string) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.ClientCertPath = certPath
+		req.ClientKeyPath = keyPath
+
+		return req, nil
+	}
+}
+
+// WithRequestTLSServerName sets the server name used to verify the server certificate.
+func WithRequestTLSServerName(name string) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.ServerName = name
+
+		return req, nil
+	}
+}
+
+// WithRequestTLSSkipVerify disables verification of the server certificate.
+func WithRequestTLSSkipVerify(skip bool) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.SkipVerify = skip
+
+		return req, nil
+	}
+}
+
 // NewRequest takes N RequestOpt args and returns a new request.
 func NewRequest(opts ...RequestOpt) (*Request, error) {
 	r := &Request{
 		HTTPMethod: http.MethodGet,
+		Headers:    http.Header{},
+		TLSConfig:  &TLSConfig{},
 	}
 
 	for _, opt := range opts {
@@ -140,6 +204,12 @@ func Download(ctx context.Context, req *Request) error {
 		return err
 	}
 
+	for key, vals := range req.Headers {
+		for _, val := range vals {
+			dreq.Header.Add(key, val)
+		}
+	}
+
 	if req.AuthUser != "" && req.AuthPassword != "" {
 		dreq.SetBasicAuth(req.AuthUser, req.AuthPassword)
 	}
@@ -148,7 +218,12 @@ func Download(ctx context.Context, req *Request) error {
 		dreq.Header.Add("Authorization", "Bearer "+req.AuthToken)
 	}
 
-	res, err := http.DefaultClient.Do(dreq)
+	client, err := req.httpClient()
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(dreq)
 	if err != nil {
 		return err
 	}
@@ -197,3 +272,52 @@ func Download(ctx context.Context, req *Request) error {
 
 	return nil
 }
+
+// httpClient returns the HTTP client to use for the request.
+func (r *Request) httpClient() (*http.Client, error) {
+	return r.TLSConfig.HTTPClient()
+}
+
+// HTTPClient returns an HTTP client that uses the TLS configuration. If no TLS options have been
+// configured the default client is used.
+func (r *TLSConfig) HTTPClient() (*http.Client, error) {
+	if r == nil || *r == (TLSConfig{}) {
+		return http.DefaultClient, nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         r.ServerName,
+		InsecureSkipVerify: r.SkipVerify, //nolint:gosec// it's explicitly opt-in
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if r.CACertPath != "" {
+		caCert, err := os.ReadFile(r.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificate: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if ok := pool.AppendCertsFromPEM(caCert); !ok {
+			return nil, fmt.Errorf("no certificates found in CA certificate %s", r.CACertPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if r.ClientCertPath != "" || r.ClientKeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(r.ClientCertPath, r.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("unable to clone the default HTTP transport")
+	}
+	transport = transport.Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Transport: transport}, nil
+}
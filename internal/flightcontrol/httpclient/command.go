@@ -0,0 +1,318 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/flightcontrol/download"
+)
+
+// Output formats.
+const (
+	FormatBody = "body"
+	FormatJSON = "json"
+)
+
+// Command is a cli.Command.
+type Command struct {
+	ui   cli.Ui
+	args *CommandArgs
+}
+
+// NewCommand takes a user interface and returns a new cli.Command.
+func NewCommand(ui cli.Ui) (*Command, error) {
+	return &Command{
+		ui:   ui,
+		args: &CommandArgs{},
+	}, nil
+}
+
+// CommandArgs are the http commands arguments.
+type CommandArgs struct {
+	flags                     *flag.FlagSet
+	url                       string
+	method                    string
+	headers                   stringFlags
+	body                      string
+	bodyFile                  string
+	jsonBody                  string
+	authUser                  string
+	authPassword              string
+	authToken                 string
+	expectedStatuses          stringFlags
+	timeout                   time.Duration
+	retries                   int
+	retryInterval             time.Duration
+	jsonPath                  string
+	format                    string
+	exitWithRequestStatusCode bool
+	caCert                    string
+	clientCert                string
+	clientKey                 string
+	tlsServerName             string
+	tlsSkipVerify             bool
+}
+
+// Synopsis is the cli.Command synopsis.
+func (c *Command) Synopsis() string {
+	return "Make an HTTP request"
+}
+
+// Help is the cli.Command help.
+func (c *Command) Help() string {
+	help := `
+Usage: enos-flight-control http --url https://127.0.0.1:8200/v1/sys/health --expected-status 200 --expected-status 429 --json-path .initialized --retries 5
+
+  Makes an HTTP request and writes the response body to stdout
+
+Options:
+
+  --url                     The URL of the request
+  --method                  The HTTP method of the request, eg: POST. Defaults to GET
+  --header                  A header to add to the request in 'Key: Value' format. Can be given multiple times
+  --body                    The request body
+  --body-file               The path to a file that contains the request body
+  --json                    A JSON request body. The Content-Type header is set to application/json
+  --auth-user               The username to use for basic auth
+  --auth-password           The password to use for basic auth
+  --auth-token              The bearer token to use for auth
+  --expected-status         An expected response status code. Can be given multiple times. Defaults to any 2xx status
+  --timeout                 The maximum allowable time of each request attempt, eg: 30s
+  --retries                 The number of times to retry a failed request
+  --retry-interval          The time to wait between retries, eg: 1s
+  --json-path               Write the value at the JSON path of the response body instead of the body, eg: .data.items[0].name
+  --format                  The output format. Either 'body' or 'json'. Defaults to 'body'
+  --exit-with-status-code   On failure, exit with the HTTP status code returned
+  --ca-cert                 The CA certificate used to verify the server certificate
+  --client-cert             The client certificate to use for TLS authentication
+  --client-key              The client key to use for TLS authentication
+  --tls-server-name         The server name used to verify the server certificate
+  --tls-skip-verify         Do not verify the server certificate
+
+`
+
+	return strings.TrimSpace(help)
+}
+
+// Run is the main cli.Command execution function.
+func (c *Command) Run(args []string) int {
+	err := c.args.Parse(args)
+	if err != nil {
+		c.ui.Error(err.Error())
+		return 1
+	}
+
+	res, err := c.Request()
+	if err == nil {
+		err = c.write(res)
+		if err == nil {
+			return 0
+		}
+	}
+
+	exitCode := 1
+	if c.args.exitWithRequestStatusCode {
+		var errStatus *ErrUnexpectedStatus
+		if errors.As(err, &errStatus) {
+			exitCode = errStatus.StatusCode
+		}
+	}
+
+	c.ui.Error(err.Error())
+
+	return exitCode
+}
+
+// Parse parses the raw args and maps them to the CommandArgs.
+func (a *CommandArgs) Parse(args []string) error {
+	a.flags = flag.NewFlagSet("http", flag.ContinueOnError)
+	a.flags.StringVar(&a.url, "url", "", "the URL of the request")
+	a.flags.StringVar(&a.method, "method", "GET", "the HTTP method of the request")
+	a.flags.Var(&a.headers, "header", "if given, adds the header to the HTTP request in 'Key: Value' format - can be given multiple times")
+	a.flags.StringVar(&a.body, "body", "", "the request body")
+	a.flags.StringVar(&a.bodyFile, "body-file", "", "the path to a file that contains the request body")
+	a.flags.StringVar(&a.jsonBody, "json", "", "a JSON request body")
+	a.flags.StringVar(&a.authUser, "auth-user", "", "if given, sets the basic auth username when making the HTTP request - only username/ password OR token should be used")
+	a.flags.StringVar(&a.authPassword, "auth-password", "", "if given, sets the basic auth password when making the HTTP request - only username/ password OR token should be used")
+	a.flags.StringVar(&a.authToken, "auth-token", "", "if given, sets the auth token when making the HTTP request - only username/ password OR token should be used")
+	a.flags.Var(&a.expectedStatuses, "expected-status", "an expected response status code - can be given multiple times")
+	a.flags.DurationVar(&a.timeout, "timeout", 30*time.Second, "the maximum allowable time of each request attempt")
+	a.flags.IntVar(&a.retries, "retries", 0, "the number of times to retry a failed request")
+	a.flags.DurationVar(&a.retryInterval, "retry-interval", time.Second, "the time to wait between retries")
+	a.flags.StringVar(&a.jsonPath, "json-path", "", "if given, writes the value at the JSON path of the response body")
+	a.flags.StringVar(&a.format, "format", FormatBody, "the output format")
+	a.flags.BoolVar(&a.exitWithRequestStatusCode, "exit-with-status-code", false, "On failure, exit with the HTTP status code returned")
+	a.flags.StringVar(&a.caCert, "ca-cert", "", "if given, the CA certificate used to verify the server certificate")
+	a.flags.StringVar(&a.clientCert, "client-cert", "", "if given, the client certificate to use for TLS authentication")
+	a.flags.StringVar(&a.clientKey, "client-key", "", "if given, the client key to use for TLS authentication")
+	a.flags.StringVar(&a.tlsServerName, "tls-server-name", "", "if given, the server name used to verify the server certificate")
+	a.flags.BoolVar(&a.tlsSkipVerify, "tls-skip-verify", false, "do not verify the server certificate")
+
+	err := a.flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if a.url == "" {
+		return errors.New("you must provide a URL")
+	}
+
+	bodies := 0
+	for _, body := range []string{a.body, a.bodyFile, a.jsonBody} {
+		if body != "" {
+			bodies++
+		}
+	}
+	if bodies > 1 {
+		return errors.New("only one of --body, --body-file or --json can be provided")
+	}
+
+	for _, header := range a.headers {
+		if key, _, ok := strings.Cut(header, ":"); !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("invalid header %q, headers must be in 'Key: Value' format", header)
+		}
+	}
+
+	for _, status := range a.expectedStatuses {
+		code, err := strconv.Atoi(status)
+		if err != nil || code < 100 || code > 599 {
+			return fmt.Errorf("invalid expected status %q, it must be an HTTP status code", status)
+		}
+	}
+
+	if a.retries < 0 {
+		return errors.New("retries cannot be negative")
+	}
+
+	if a.format != FormatBody && a.format != FormatJSON {
+		return fmt.Errorf("unsupported format %q, must be one of %q or %q", a.format, FormatBody, FormatJSON)
+	}
+
+	if (a.clientCert == "") != (a.clientKey == "") {
+		return errors.New("you must provide both a client certificate and client key")
+	}
+
+	return nil
+}
+
+// Request makes the HTTP request.
+func (c *Command) Request() (*Response, error) {
+	opts := []RequestOpt{
+		WithRequestURL(c.args.url),
+		WithRequestMethod(c.args.method),
+		WithRequestTimeout(c.args.timeout),
+		WithRequestRetries(c.args.retries),
+		WithRequestRetryInterval(c.args.retryInterval),
+		WithRequestJSONPath(c.args.jsonPath),
+		WithRequestTLSConfig(&download.TLSConfig{
+			CACertPath:     c.args.caCert,
+			ClientCertPath: c.args.clientCert,
+			ClientKeyPath:  c.args.clientKey,
+			ServerName:     c.args.tlsServerName,
+			SkipVerify:     c.args.tlsSkipVerify,
+		}),
+	}
+
+	for _, header := range c.args.headers {
+		key, val, _ := strings.Cut(header, ":")
+		opts = append(opts, WithRequestHeader(strings.TrimSpace(key), strings.TrimSpace(val)))
+	}
+
+	switch {
+	case c.args.body != "":
+		opts = append(opts, WithRequestBody([]byte(c.args.body)))
+	case c.args.bodyFile != "":
+		body, err := os.ReadFile(c.args.bodyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading request body file: %w", err)
+		}
+		opts = append(opts, WithRequestBody(body))
+	case c.args.jsonBody != "":
+		opts = append(opts, WithRequestJSONBody([]byte(c.args.jsonBody)))
+	}
+
+	if c.args.authUser != "" {
+		opts = append(opts, WithRequestAuthUser(c.args.authUser))
+	}
+
+	if c.args.authPassword != "" {
+		opts = append(opts, WithRequestAuthPassword(c.args.authPassword))
+	}
+
+	if c.args.authToken != "" {
+		opts = append(opts, WithRequestAuthToken(c.args.authToken))
+	}
+
+	if len(c.args.expectedStatuses) > 0 {
+		codes := []int{}
+		for _, status := range c.args.expectedStatuses {
+			code, _ := strconv.Atoi(status)
+			codes = append(codes, code)
+		}
+		opts = append(opts, WithRequestExpectedStatuses(codes...))
+	}
+
+	req, err := NewRequest(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return Do(context.Background(), req)
+}
+
+// write writes the response in the configured format.
+func (c *Command) write(res *Response) error {
+	if c.args.format == FormatJSON {
+		out, err := json.Marshal(res)
+		if err != nil {
+			return err
+		}
+		c.ui.Output(string(out))
+
+		return nil
+	}
+
+	if c.args.jsonPath == "" {
+		c.ui.Output(res.Body)
+
+		return nil
+	}
+
+	// Write strings without quotes so that they're easy to use in scripts.
+	var str string
+	if err := json.Unmarshal(res.Value, &str); err == nil {
+		c.ui.Output(str)
+
+		return nil
+	}
+	c.ui.Output(string(res.Value))
+
+	return nil
+}
+
+// stringFlags is a flag.Value that allows a flag to be given multiple times.
+type stringFlags []string
+
+// String returns the values as a string.
+func (s *stringFlags) String() string {
+	return strings.Join(*s, ", ")
+}
+
+// Set appends a value.
+func (s *stringFlags) Set(val string) error {
+	*s = append(*s, val)
+
+	return nil
+}
@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package httpclient
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	requests := &atomic.Int32{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := requests.Add(1)
+
+		switch r.URL.Path {
+		case "/v1/sys/health":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"initialized": true, "sealed": false, "version": "1.15.2"}`))
+		case "/flaky":
+			// Fail the first two attempts
+			if attempt < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`ok`))
+		case "/echo":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"method":       r.Method,
+				"content_type": r.Header.Get("Content-Type"),
+				"header":       r.Header.Get("X-Enos"),
+				"body":         string(body),
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, requests
+}
+
+func TestHTTPCommand(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestServer(t)
+
+	for desc, test := range map[string]struct {
+		args     []string
+		exitCode int
+		stdout   string
+	}{
+		"unexpected status": {
+			args:     []string{"--url", srv.URL + "/v1/sys/health"},
+			exitCode: 1,
+		},
+		"unexpected status exit code": {
+			args:     []string{"--url", srv.URL + "/v1/sys/health", "--exit-with-status-code"},
+			exitCode: http.StatusTooManyRequests,
+		},
+		"expected status and json path": {
+			args: []string{
+				"--url", srv.URL + "/v1/sys/health",
+				"--expected-status", "200", "--expected-status", "429",
+				"--json-path", ".version",
+			},
+			stdout: "1.15.2\n",
+		},
+		"json path non-string value": {
+			args:   []string{"--url", srv.URL + "/v1/sys/health", "--expected-status", "429", "--json-path", "initialized"},
+			stdout: "true\n",
+		},
+		"missing json path": {
+			args:     []string{"--url", srv.URL + "/v1/sys/health", "--expected-status", "429", "--json-path", ".data.keys"},
+			exitCode: 1,
+		},
+		"method headers and json body": {
+			args: []string{
+				"--url", srv.URL + "/echo",
+				"--method", "post",
+				"--header", "X-Enos: flight-control",
+				"--auth-token", "token",
+				"--json", `{"hello":"world"}`,
+				"--json-path", ".",
+			},
+			stdout: `{"body":"{\"hello\":\"world\"}","content_type":"application/json","header":"flight-control","method":"POST"}` + "\n",
+		},
+		"invalid json body": {
+			args:     []string{"--url", srv.URL + "/echo", "--json", `{"hello"`},
+			exitCode: 1,
+		},
+		"no url": {
+			args:     []string{},
+			exitCode: 1,
+		},
+		"multiple bodies": {
+			args:     []string{"--url", srv.URL, "--body", "a", "--json", "{}"},
+			exitCode: 1,
+		},
+		"invalid expected status": {
+			args:     []string{"--url", srv.URL, "--expected-status", "ok"},
+			exitCode: 1,
+		},
+		"invalid format": {
+			args:     []string{"--url", srv.URL, "--format", "yaml"},
+			exitCode: 1,
+		},
+		"client cert without key": {
+			args:     []string{"--url", srv.URL, "--client-cert", "/tmp/cert.pem"},
+			exitCode: 1,
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			ui := cli.NewMockUi()
+			cmd, err := NewCommand(ui)
+			require.NoError(t, err)
+			require.Equal(t, test.exitCode, cmd.Run(test.args), ui.ErrorWriter.String())
+			if test.stdout != "" {
+				require.Equal(t, test.stdout, ui.OutputWriter.String())
+			}
+		})
+	}
+}
+
+func TestHTTPCommandRetries(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newTestServer(t)
+
+	ui := cli.NewMockUi()
+	cmd, err := NewCommand(ui)
+	require.NoError(t, err)
+	require.Equal(t, 0, cmd.Run([]string{
+		"--url", srv.URL + "/flaky",
+		"--retries", "2",
+		"--retry-interval", "1ms",
+		"--format", "json",
+	}), ui.ErrorWriter.String())
+	require.Equal(t, int32(3), requests.Load())
+
+	res := &Response{}
+	require.NoError(t, json.Unmarshal([]byte(ui.OutputWriter.String()), res))
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "ok", res.Body)
+	require.Empty(t, res.Value)
+}
+
+func TestExtractJSONPath(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`{"data": {"items": [{"name": "vault"}, {"name": "consul", "port": 8500}]}}`)
+
+	for path, expected := range map[string]string{
+		"":                     `{"data":{"items":[{"name":"vault"},{"name":"consul","port":8500}]}}`,
+		".":                    `{"data":{"items":[{"name":"vault"},{"name":"consul","port":8500}]}}`,
+		".data.items[0].name":  `"vault"`,
+		"data.items.1.name":    `"consul"`,
+		".data.items[1].port":  `8500`,
+		".data.items[1]":       `{"name":"consul","port":8500}`,
+		".data.items[2].name":  "",
+		".data.items.name":     "",
+		".data.items[0].name.": `"vault"`,
+		".data.missing":        "",
+		".data.items[0].x.y":   "",
+	} {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+
+			val, err := ExtractJSONPath(doc, path)
+			if expected == "" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			out, err := json.Marshal(val)
+			require.NoError(t, err)
+			require.Equal(t, expected, string(out))
+		})
+	}
+
+	_, err := ExtractJSONPath([]byte("not json"), ".")
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "decoding"))
+}
@@ -0,0 +1,364 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/flightcontrol/download"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/retry"
+)
+
+// Request is an HTTP request.
+type Request struct {
+	URL              *url.URL
+	Method           string
+	Headers          http.Header
+	Body             []byte
+	AuthUser         string
+	AuthPassword     string
+	AuthToken        string
+	ExpectedStatuses []int // If unset any 2xx status is expected
+	Timeout          time.Duration
+	Retries          int
+	RetryInterval    time.Duration
+	JSONPath         string
+	*download.TLSConfig
+}
+
+// RequestOpt are functional options for a new Request.
+type RequestOpt func(*Request) (*Request, error)
+
+// Response is the response of an HTTP request.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers"`
+	Body       string      `json:"body"`
+	// Value is the JSON encoded value at the JSON path of the response body, if a JSON path was
+	// given.
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ErrUnexpectedStatus is returned when the response status code was not expected.
+type ErrUnexpectedStatus struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+// Error returns the error as a string.
+func (e *ErrUnexpectedStatus) Error() string {
+	return fmt.Sprintf("unexpected response status: %s - %s", e.Status, e.Body)
+}
+
+// NewRequest takes N RequestOpt args and returns a new request.
+func NewRequest(opts ...RequestOpt) (*Request, error) {
+	r := &Request{
+		Method:        http.MethodGet,
+		Headers:       http.Header{},
+		Timeout:       30 * time.Second,
+		RetryInterval: time.Second,
+		TLSConfig:     &download.TLSConfig{},
+	}
+
+	for _, opt := range opts {
+		var err error
+		r, err = opt(r)
+		if err != nil {
+			return r, err
+		}
+	}
+
+	return r, nil
+}
+
+// WithRequestURL sets the request URL.
+func WithRequestURL(u string) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		var err error
+		req.URL, err = url.Parse(u)
+
+		return req, err
+	}
+}
+
+// WithRequestMethod sets the HTTP method.
+func WithRequestMethod(method string) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.Method = strings.ToUpper(method)
+
+		return req, nil
+	}
+}
+
+// WithRequestHeader adds a header to the request.
+func WithRequestHeader(key, value string) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.Headers.Add(key, value)
+
+		return req, nil
+	}
+}
+
+// WithRequestBody sets the request body.
+func WithRequestBody(body []byte) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.Body = body
+
+		return req, nil
+	}
+}
+
+// WithRequestJSONBody sets a JSON request body and the Content-Type header.
+func WithRequestJSONBody(body []byte) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		if !json.Valid(body) {
+			return req, errors.New("the request body is not valid JSON")
+		}
+
+		req.Body = body
+		req.Headers.Set("Content-Type", "application/json")
+
+		return req, nil
+	}
+}
+
+// WithRequestAuthUser sets the basic auth user.
+func WithRequestAuthUser(user string) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.AuthUser = user
+
+		return req, nil
+	}
+}
+
+// WithRequestAuthPassword sets the basic auth password.
+func WithRequestAuthPassword(password string) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.AuthPassword = password
+
+		return req, nil
+	}
+}
+
+// WithRequestAuthToken sets the auth token.
+func WithRequestAuthToken(token string) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.AuthToken = token
+
+		return req, nil
+	}
+}
+
+// WithRequestExpectedStatuses sets the expected response status codes.
+func WithRequestExpectedStatuses(codes ...int) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.ExpectedStatuses = codes
+
+		return req, nil
+	}
+}
+
+// WithRequestTimeout sets the maximum allowable time of each request attempt.
+func WithRequestTimeout(timeout time.Duration) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.Timeout = timeout
+
+		return req, nil
+	}
+}
+
+// WithRequestRetries sets the number of times to retry failed requests.
+func WithRequestRetries(retries int) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.Retries = retries
+
+		return req, nil
+	}
+}
+
+// WithRequestRetryInterval sets the interval between retries.
+func WithRequestRetryInterval(interval time.Duration) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.RetryInterval = interval
+
+		return req, nil
+	}
+}
+
+// WithRequestJSONPath sets the JSON path of the value to extract from the response body.
+func WithRequestJSONPath(path string) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.JSONPath = path
+
+		return req, nil
+	}
+}
+
+// WithRequestTLSConfig sets the client TLS configuration.
+func WithRequestTLSConfig(cfg *download.TLSConfig) RequestOpt {
+	return func(req *Request) (*Request, error) {
+		req.TLSConfig = cfg
+
+		return req, nil
+	}
+}
+
+// Do performs the request, retrying failed attempts. A request fails if we're unable to make it,
+// the response status is not expected, or the JSON path cannot be extracted from the response.
+func Do(ctx context.Context, req *Request) (*Response, error) {
+	if req.URL == nil {
+		return nil, errors.New("no request URL was provided")
+	}
+
+	client, err := req.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := retry.NewRetrier(
+		// The retrier counts the initial attempt as a retry
+		retry.WithMaxRetries(req.Retries+1),
+		retry.WithIntervalFunc(retry.IntervalDuration(req.RetryInterval)),
+		retry.WithRetrierFunc(func(ctx context.Context) (any, error) {
+			return req.do(ctx, client)
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := retry.Retry(ctx, r)
+	resp, _ := res.(*Response)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// do performs a single attempt of the request.
+func (r *Request) do(ctx context.Context, client *http.Client) (*Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	hreq, err := http.NewRequestWithContext(ctx, r.Method, r.URL.String(), bytes.NewReader(r.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	for key, vals := range r.Headers {
+		for _, val := range vals {
+			hreq.Header.Add(key, val)
+		}
+	}
+
+	if r.AuthUser != "" && r.AuthPassword != "" {
+		hreq.SetBasicAuth(r.AuthUser, r.AuthPassword)
+	}
+
+	if r.AuthToken != "" {
+		hreq.Header.Set("Authorization", "Bearer "+r.AuthToken)
+	}
+
+	hres, err := client.Do(hreq)
+	if err != nil {
+		return nil, err
+	}
+	defer hres.Body.Close()
+
+	body, err := io.ReadAll(hres.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	res := &Response{
+		StatusCode: hres.StatusCode,
+		Headers:    hres.Header,
+		Body:       string(body),
+	}
+
+	if !r.expectedStatus(hres.StatusCode) {
+		return res, &ErrUnexpectedStatus{
+			StatusCode: hres.StatusCode,
+			Status:     hres.Status,
+			Body:       string(body),
+		}
+	}
+
+	if r.JSONPath != "" {
+		val, err := ExtractJSONPath(body, r.JSONPath)
+		if err != nil {
+			return res, err
+		}
+
+		res.Value, err = json.Marshal(val)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// expectedStatus determines whether or not the status code is expected.
+func (r *Request) expectedStatus(code int) bool {
+	if len(r.ExpectedStatuses) == 0 {
+		return code >= 200 && code < 300
+	}
+
+	return slices.Contains(r.ExpectedStatuses, code)
+}
+
+// ExtractJSONPath decodes the JSON document and returns the value at the path. The path is a
+// dot separated list of object keys and array indices, e.g. ".data.items[0].name" or
+// "data.items.0.name". An empty path or "." returns the entire document.
+func ExtractJSONPath(doc []byte, path string) (any, error) {
+	var val any
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	if err := dec.Decode(&val); err != nil {
+		return nil, fmt.Errorf("decoding JSON response body: %w", err)
+	}
+
+	steps := strings.Split(strings.NewReplacer("[", ".", "]", "").Replace(strings.TrimPrefix(path, ".")), ".")
+	for _, step := range steps {
+		if step == "" {
+			continue
+		}
+
+		switch t := val.(type) {
+		case map[string]any:
+			next, ok := t[step]
+			if !ok {
+				return nil, fmt.Errorf("JSON path %s: no key %q in object", path, step)
+			}
+			val = next
+		case []any:
+			i, err := strconv.Atoi(step)
+			if err != nil {
+				return nil, fmt.Errorf("JSON path %s: %q is not a valid array index", path, step)
+			}
+			if i < 0 || i >= len(t) {
+				return nil, fmt.Errorf("JSON path %s: index %d is out of range", path, i)
+			}
+			val = t[i]
+		default:
+			return nil, fmt.Errorf("JSON path %s: cannot get %q of a non-object value", path, step)
+		}
+	}
+
+	return val, nil
+}
@@ -0,0 +1,351 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tar
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// UntarCommand is the untar command.
+type UntarCommand struct {
+	ui   cli.Ui
+	args *UntarCommandArgs
+}
+
+// NewUntarCommand takes a user interface and returns a new cli.Command.
+func NewUntarCommand(ui cli.Ui) (*UntarCommand, error) {
+	return &UntarCommand{
+		ui:   ui,
+		args: &UntarCommandArgs{},
+	}, nil
+}
+
+// UntarCommandArgs are the untar command's arguments.
+type UntarCommandArgs struct {
+	flags           *flag.FlagSet
+	source          string
+	destination     string
+	createDest      bool
+	destinationMode int
+	mode            int
+	stripComponents int
+	replace         bool
+}
+
+// Synopsis is the cli.Command synopsis.
+func (c *UntarCommand) Synopsis() string {
+	return "Expand a tar archive"
+}
+
+// Help is the cli.Command help.
+func (c *UntarCommand) Help() string {
+	help := `
+Usage: enos-flight-control untar --source /some/file.tar.gz --destination /some/directory --create-destination true
+
+  Expands a tar archive. Gzip compressed archives are detected automatically.
+
+Options:
+
+  --source               The source tar archive
+  --destination          The destination directory
+  --create-destination   Create destination directory
+  --destination-mode     The destination directory mode if creating it
+  --mode                 The desired file permissions of the expanded archive files. Use 0 to keep the archive permissions
+  --strip-components     Strip the given number of leading path components from the expanded archive files
+  --replace              Replace any existing files with matching path
+
+`
+
+	return strings.TrimSpace(help)
+}
+
+// Run is the cli.Command main execution function.
+func (c *UntarCommand) Run(args []string) int {
+	err := c.args.Parse(args)
+	if err != nil {
+		c.ui.Error(err.Error())
+
+		return 1
+	}
+
+	err = c.Untar()
+	if err != nil {
+		c.ui.Error(err.Error())
+
+		return 1
+	}
+
+	return 0
+}
+
+// Parse parses the arguments and maps them to the UntarCommandArgs.
+func (a *UntarCommandArgs) Parse(args []string) error {
+	a.flags = flag.NewFlagSet("untar", flag.ContinueOnError)
+	a.flags.StringVar(&a.source, "source", "", "the source tar archive")
+	a.flags.IntVar(&a.mode, "mode", 0, "the file permissions of the expanded archive files, 0 keeps the archive permissions")
+	a.flags.StringVar(&a.destination, "destination", "", "where to expand the archive")
+	a.flags.BoolVar(&a.createDest, "create-destination", true, "create the destination directory if necessary")
+	a.flags.IntVar(&a.destinationMode, "destination-mode", 0o755, "the file permissions of the destination directory")
+	a.flags.IntVar(&a.stripComponents, "strip-components", 0, "strip the number of leading path components from the expanded archive files")
+	a.flags.BoolVar(&a.replace, "replace", false, "replace any existing files with matching paths")
+
+	err := a.flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if a.source == "" {
+		return errors.New("you must provide a source archive")
+	}
+
+	if a.destination == "" {
+		return errors.New("you must provide a destination directory")
+	}
+
+	if a.stripComponents < 0 {
+		return errors.New("strip-components must not be negative")
+	}
+
+	return nil
+}
+
+// Untar performs the untar request.
+func (c *UntarCommand) Untar() error {
+	src, err := os.Open(c.args.source)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	archive, err := newTarReader(src)
+	if err != nil {
+		return err
+	}
+
+	// Make sure we've got a destination directory
+	s, err := os.Stat(c.args.destination)
+	if err != nil {
+		if !c.args.createDest || !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		err = os.MkdirAll(c.args.destination, fs.FileMode(c.args.destinationMode)) //#nosec:G115
+		if err != nil {
+			return err
+		}
+	} else if !s.IsDir() {
+		return errors.New("destination path exists but is not a directory")
+	}
+
+	// Expand the files into the destination directory
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		dstPath, ok, err := c.destinationPath(header.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		err = c.expand(archive, header, dstPath)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// destinationPath returns the path in the destination directory of the archive entry. If the
+// entry does not have a path after stripping the leading components it should be skipped.
+func (c *UntarCommand) destinationPath(name string) (string, bool, error) {
+	parts := strings.Split(filepath.ToSlash(filepath.Clean(name)), "/")
+	if len(parts) <= c.args.stripComponents {
+		return "", false, nil
+	}
+
+	rel := filepath.Join(parts[c.args.stripComponents:]...)
+	if rel == "." || rel == "" {
+		return "", false, nil
+	}
+
+	dstPath := filepath.Join(c.args.destination, rel)
+	if !c.inDestination(dstPath) {
+		return "", false, fmt.Errorf("archive entry %s is outside of the destination directory", name)
+	}
+
+	return dstPath, true, nil
+}
+
+// inDestination returns whether or not the path is inside of the destination directory.
+func (c *UntarCommand) inDestination(path string) bool {
+	return strings.HasPrefix(filepath.Clean(path), filepath.Clean(c.args.destination)+string(os.PathSeparator))
+}
+
+// verifyParents verifies that none of the parent directories of the destination path inside of
+// the destination directory are symlinks. Writing through a symlink that an earlier archive entry
+// created could otherwise write outside of the destination directory.
+func (c *UntarCommand) verifyParents(dstPath string) error {
+	dst := filepath.Clean(c.args.destination)
+	rel, err := filepath.Rel(dst, filepath.Dir(dstPath))
+	if err != nil {
+		return err
+	}
+	if rel == "." {
+		return nil
+	}
+
+	path := dst
+	for _, part := range strings.Split(rel, string(os.PathSeparator)) {
+		path = filepath.Join(path, part)
+		info, err := os.Lstat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if info.Mode()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("archive entry %s is inside of the symlink %s", dstPath, path)
+		}
+	}
+
+	return nil
+}
+
+// verifySymlink verifies that the symlink target is a relative path inside of the destination
+// directory. As the parent directories of the symlink are not symlinks we only have to lexically
+// check the target if it does not traverse up after a path component. A ".." after a path
+// component resolves against that component, which could be a symlink that has been extracted
+// already or that will be extracted later, so we reject such targets.
+func (c *UntarCommand) verifySymlink(dstPath string, target string) error {
+	if filepath.IsAbs(target) {
+		return fmt.Errorf("archive symlink %s has an absolute target %s", dstPath, target)
+	}
+
+	inPath := false
+	for _, part := range strings.Split(filepath.ToSlash(target), "/") {
+		switch part {
+		case "", ".":
+		case "..":
+			if inPath {
+				return fmt.Errorf("archive symlink %s target %s has a .. after a path component", dstPath, target)
+			}
+		default:
+			inPath = true
+		}
+	}
+
+	if !c.inDestination(filepath.Join(filepath.Dir(dstPath), target)) {
+		return fmt.Errorf("archive symlink %s target %s is outside of the destination directory", dstPath, target)
+	}
+
+	return nil
+}
+
+func (c *UntarCommand) expand(archive *tar.Reader, header *tar.Header, dstPath string) error {
+	fileMode := fs.FileMode(header.Mode).Perm() //#nosec:G115
+	if c.args.mode != 0 {
+		fileMode = fs.FileMode(c.args.mode) //#nosec:G115
+	}
+
+	switch header.Typeflag {
+	case tar.TypeDir, tar.TypeReg, tar.TypeSymlink:
+	default:
+		// Skip anything that isn't a directory, file, or symlink
+		return nil
+	}
+
+	err := c.verifyParents(dstPath)
+	if err != nil {
+		return err
+	}
+
+	if header.Typeflag == tar.TypeDir {
+		return os.MkdirAll(dstPath, fs.FileMode(c.args.destinationMode)) //#nosec:G115
+	}
+
+	if header.Typeflag == tar.TypeSymlink {
+		err = c.verifySymlink(dstPath, header.Linkname)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.MkdirAll(filepath.Dir(dstPath), fs.FileMode(c.args.destinationMode)) //#nosec:G115
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Lstat(dstPath)
+	if err == nil {
+		// The destination file already exists
+		if !c.args.replace {
+			return fmt.Errorf("%s already exists. Set --replace=true to replace existing files", dstPath)
+		}
+
+		err = os.RemoveAll(dstPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if header.Typeflag == tar.TypeSymlink {
+		return os.Symlink(header.Linkname, dstPath)
+	}
+
+	dst, err := os.OpenFile(dstPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, fileMode)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, archive) //#nosec:G110
+	if err != nil {
+		return err
+	}
+
+	// Make sure the mode is set even if it was modified by the umask
+	return dst.Chmod(fileMode)
+}
+
+// newTarReader returns a new tar reader for the source. If the source is gzip compressed it
+// will be decompressed.
+func newTarReader(src io.Reader) (*tar.Reader, error) {
+	buf := bufio.NewReader(src)
+	magic, err := buf.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		return tar.NewReader(gz), nil
+	}
+
+	return tar.NewReader(buf), nil
+}
@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tar
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+type testTarEntry struct {
+	name     string
+	typeflag byte
+	mode     int64
+	body     string
+	linkname string
+}
+
+func writeTestArchive(t *testing.T, path string, compress bool, entries ...testTarEntry) {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	var w io.Writer = buf
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(buf)
+		w = gz
+	}
+
+	tw := tar.NewWriter(w)
+	for _, e := range entries {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Mode:     e.mode,
+			Size:     int64(len(e.body)),
+			Linkname: e.linkname,
+		}))
+		if e.body != "" {
+			_, err := tw.Write([]byte(e.body))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	if gz != nil {
+		require.NoError(t, gz.Close())
+	}
+
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+}
+
+func TestUntar(t *testing.T) {
+	t.Parallel()
+
+	entries := []testTarEntry{
+		{name: "vault_1.15.2/", typeflag: tar.TypeDir, mode: 0o755},
+		{name: "vault_1.15.2/vault", typeflag: tar.TypeReg, mode: 0o755, body: "binary"},
+		{name: "vault_1.15.2/LICENSE.txt", typeflag: tar.TypeReg, mode: 0o644, body: "license"},
+		{name: "vault_1.15.2/bin/vault", typeflag: tar.TypeSymlink, linkname: "../vault"},
+	}
+
+	for desc, test := range map[string]struct {
+		compress bool
+		args     []string
+		expected map[string]os.FileMode
+	}{
+		"tar": {
+			args: []string{},
+			expected: map[string]os.FileMode{
+				"vault_1.15.2/vault":       0o755,
+				"vault_1.15.2/LICENSE.txt": 0o644,
+			},
+		},
+		"tar.gz": {
+			compress: true,
+			args:     []string{},
+			expected: map[string]os.FileMode{
+				"vault_1.15.2/vault":       0o755,
+				"vault_1.15.2/LICENSE.txt": 0o644,
+			},
+		},
+		"strip components and mode": {
+			compress: true,
+			args:     []string{"--strip-components", "1", "--mode", "0700"},
+			expected: map[string]os.FileMode{
+				"vault":       0o700,
+				"LICENSE.txt": 0o700,
+			},
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			src := filepath.Join(dir, "archive")
+			dst := filepath.Join(dir, "dst")
+			writeTestArchive(t, src, test.compress, entries...)
+
+			cmd, err := NewUntarCommand(cli.NewMockUi())
+			require.NoError(t, err)
+			args := append([]string{"--source", src, "--destination", dst}, test.args...)
+			require.Equal(t, 0, cmd.Run(args))
+
+			for name, mode := range test.expected {
+				info, err := os.Stat(filepath.Join(dst, name))
+				require.NoError(t, err)
+				require.Equal(t, mode, info.Mode().Perm(), name)
+			}
+
+			// Running it again should fail unless we replace the existing files
+			require.Equal(t, 1, cmd.Run(args))
+			require.Equal(t, 0, cmd.Run(append(args, "--replace")))
+		})
+	}
+}
+
+func TestUntarRejectsPathTraversal(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "archive.tar")
+	dst := filepath.Join(dir, "dst")
+	writeTestArchive(t, src, false, testTarEntry{
+		name: "../escape", typeflag: tar.TypeReg, mode: 0o644, body: "nope",
+	})
+
+	cmd, err := NewUntarCommand(cli.NewMockUi())
+	require.NoError(t, err)
+	require.Equal(t, 1, cmd.Run([]string{"--source", src, "--destination", dst}))
+	_, err = os.Stat(filepath.Join(dir, "escape"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestUntarRejectsSymlinkEscape(t *testing.T) {
+	t.Parallel()
+
+	for desc, entries := range map[string][]testTarEntry{
+		"absolute target": {
+			{name: "link", typeflag: tar.TypeSymlink, linkname: "/tmp"},
+		},
+		"relative target outside of destination": {
+			{name: "link", typeflag: tar.TypeSymlink, linkname: "../"},
+		},
+		"symlink resolving to destination": {
+			{name: "dir/", typeflag: tar.TypeDir, mode: 0o755},
+			{name: "link", typeflag: tar.TypeSymlink, linkname: "dir/.."},
+		},
+		"symlink chain resolving outside of destination": {
+			{name: "a", typeflag: tar.TypeSymlink, linkname: "."},
+			{name: "a/b", typeflag: tar.TypeSymlink, linkname: "../../evil"},
+		},
+		"symlink target through a later symlink": {
+			{name: "dir/", typeflag: tar.TypeDir, mode: 0o755},
+			{name: "sib/", typeflag: tar.TypeDir, mode: 0o755},
+			{name: "link", typeflag: tar.TypeSymlink, linkname: "dir/up/../../evil"},
+			{name: "dir/up", typeflag: tar.TypeSymlink, linkname: "../sib"},
+		},
+		"file through symlink": {
+			{name: "dir/", typeflag: tar.TypeDir, mode: 0o755},
+			{name: "link", typeflag: tar.TypeSymlink, linkname: "dir"},
+			{name: "link/evil", typeflag: tar.TypeReg, mode: 0o644, body: "nope"},
+		},
+		"directory through symlink": {
+			{name: "dir/", typeflag: tar.TypeDir, mode: 0o755},
+			{name: "link", typeflag: tar.TypeSymlink, linkname: "dir"},
+			{name: "link/evil/", typeflag: tar.TypeDir, mode: 0o755},
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			src := filepath.Join(dir, "archive.tar")
+			dst := filepath.Join(dir, "dst")
+			writeTestArchive(t, src, false, entries...)
+
+			cmd, err := NewUntarCommand(cli.NewMockUi())
+			require.NoError(t, err)
+			require.Equal(t, 1, cmd.Run([]string{"--source", src, "--destination", dst}))
+			_, err = os.Stat(filepath.Join(dir, "evil"))
+			require.ErrorIs(t, err, os.ErrNotExist)
+			_, err = os.Stat(filepath.Join(dst, "dir", "evil"))
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
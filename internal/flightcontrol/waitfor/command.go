@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package waitfor
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/flightcontrol/download"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/flightcontrol/httpclient"
+)
+
+// Command is a cli.Command.
+type Command struct {
+	ui   cli.Ui
+	args *CommandArgs
+}
+
+// NewCommand takes a user interface and returns a new cli.Command.
+func NewCommand(ui cli.Ui) (*Command, error) {
+	return &Command{
+		ui:   ui,
+		args: &CommandArgs{},
+	}, nil
+}
+
+// CommandArgs are the wait-for commands arguments.
+type CommandArgs struct {
+	flags         *flag.FlagSet
+	tcp           stringFlags
+	http          stringFlags
+	httpStatuses  stringFlags
+	files         stringFlags
+	processes     stringFlags
+	interval      time.Duration
+	timeout       time.Duration
+	probeTimeout  time.Duration
+	caCert        string
+	clientCert    string
+	clientKey     string
+	tlsServerName string
+	tlsSkipVerify bool
+}
+
+// Synopsis is the cli.Command synopsis.
+func (c *Command) Synopsis() string {
+	return "Wait for ports, HTTP endpoints, files or processes"
+}
+
+// Help is the cli.Command help.
+func (c *Command) Help() string {
+	help := `
+Usage: enos-flight-control wait-for --tcp 127.0.0.1:8200 --http https://127.0.0.1:8200/v1/sys/health --http-status 200 --http-status 429 --timeout 5m
+
+  Waits until all of the given probes succeed
+
+Options:
+
+  --tcp                     An address that must accept TCP connections, eg: 127.0.0.1:8200. Can be given multiple times
+  --http                    A URL that must return an expected HTTP status. Can be given multiple times
+  --http-status             An expected HTTP status code. Can be given multiple times. Defaults to any 2xx status
+  --file                    A path to a file that must exist. Can be given multiple times
+  --process                 The name of a process that must be running. Can be given multiple times
+  --interval                The time to wait between probe attempts, eg: 1s
+  --timeout                 The maximum allowable time to wait for all probes to succeed, eg: 5m
+  --probe-timeout           The maximum allowable time of each probe attempt, eg: 5s
+  --ca-cert                 The CA certificate used to verify HTTP server certificates
+  --client-cert             The client certificate to use for HTTP TLS authentication
+  --client-key              The client key to use for HTTP TLS authentication
+  --tls-server-name         The server name used to verify HTTP server certificates
+  --tls-skip-verify         Do not verify HTTP server certificates
+
+`
+
+	return strings.TrimSpace(help)
+}
+
+// Run is the main cli.Command execution function.
+func (c *Command) Run(args []string) int {
+	err := c.args.Parse(args)
+	if err != nil {
+		c.ui.Error(err.Error())
+		return 1
+	}
+
+	probes, err := c.Probes()
+	if err != nil {
+		c.ui.Error(err.Error())
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.args.timeout)
+	defer cancel()
+
+	err = Wait(ctx, c.args.interval, c.args.probeTimeout, probes...)
+	if err != nil {
+		c.ui.Error(err.Error())
+		return 1
+	}
+
+	for _, probe := range probes {
+		c.ui.Output(probe.String() + ": ok")
+	}
+
+	return 0
+}
+
+// Parse parses the raw args and maps them to the CommandArgs.
+func (a *CommandArgs) Parse(args []string) error {
+	a.flags = flag.NewFlagSet("wait-for", flag.ContinueOnError)
+	a.flags.Var(&a.tcp, "tcp", "an address that must accept TCP connections - can be given multiple times")
+	a.flags.Var(&a.http, "http", "a URL that must return an expected HTTP status - can be given multiple times")
+	a.flags.Var(&a.httpStatuses, "http-status", "an expected HTTP status code - can be given multiple times")
+	a.flags.Var(&a.files, "file", "a path to a file that must exist - can be given multiple times")
+	a.flags.Var(&a.processes, "process", "the name of a process that must be running - can be given multiple times")
+	a.flags.DurationVar(&a.interval, "interval", time.Second, "the time to wait between probe attempts")
+	a.flags.DurationVar(&a.timeout, "timeout", 5*time.Minute, "the maximum allowable time to wait for all probes to succeed")
+	a.flags.DurationVar(&a.probeTimeout, "probe-timeout", 5*time.Second, "the maximum allowable time of each probe attempt")
+	a.flags.StringVar(&a.caCert, "ca-cert", "", "if given, the CA certificate used to verify HTTP server certificates")
+	a.flags.StringVar(&a.clientCert, "client-cert", "", "if given, the client certificate to use for HTTP TLS authentication")
+	a.flags.StringVar(&a.clientKey, "client-key", "", "if given, the client key to use for HTTP TLS authentication")
+	a.flags.StringVar(&a.tlsServerName, "tls-server-name", "", "if given, the server name used to verify HTTP server certificates")
+	a.flags.BoolVar(&a.tlsSkipVerify, "tls-skip-verify", false, "do not verify HTTP server certificates")
+
+	err := a.flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if len(a.tcp)+len(a.http)+len(a.files)+len(a.processes) == 0 {
+		return errors.New("you must provide at least one --tcp, --http, --file or --process probe")
+	}
+
+	for _, addr := range a.tcp {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return fmt.Errorf("invalid TCP address %q: %w", addr, err)
+		}
+	}
+
+	for _, u := range a.http {
+		parsed, err := url.Parse(u)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("invalid HTTP URL %q", u)
+		}
+	}
+
+	for _, status := range a.httpStatuses {
+		code, err := strconv.Atoi(status)
+		if err != nil || code < 100 || code > 599 {
+			return fmt.Errorf("invalid HTTP status %q, it must be an HTTP status code", status)
+		}
+	}
+
+	if a.interval <= 0 || a.timeout <= 0 || a.probeTimeout <= 0 {
+		return errors.New("the interval, timeout and probe timeout must be greater than zero")
+	}
+
+	if (a.clientCert == "") != (a.clientKey == "") {
+		return errors.New("you must provide both a client certificate and client key")
+	}
+
+	return nil
+}
+
+// Probes returns the configured probes.
+func (c *Command) Probes() ([]Probe, error) {
+	probes := []Probe{}
+
+	for _, addr := range c.args.tcp {
+		probes = append(probes, &TCPProbe{Address: addr})
+	}
+
+	codes := []int{}
+	for _, status := range c.args.httpStatuses {
+		code, _ := strconv.Atoi(status)
+		codes = append(codes, code)
+	}
+
+	for _, u := range c.args.http {
+		req, err := httpclient.NewRequest(
+			httpclient.WithRequestURL(u),
+			httpclient.WithRequestExpectedStatuses(codes...),
+			httpclient.WithRequestTimeout(c.args.probeTimeout),
+			httpclient.WithRequestTLSConfig(&download.TLSConfig{
+				CACertPath:     c.args.caCert,
+				ClientCertPath: c.args.clientCert,
+				ClientKeyPath:  c.args.clientKey,
+				ServerName:     c.args.tlsServerName,
+				SkipVerify:     c.args.tlsSkipVerify,
+			}),
+		)
+		if err != nil {
+			return nil, err
+		}
+		probes = append(probes, &HTTPProbe{Request: req})
+	}
+
+	for _, path := range c.args.files {
+		probes = append(probes, &FileProbe{Path: path})
+	}
+
+	for _, name := range c.args.processes {
+		probes = append(probes, &ProcessProbe{Name: name})
+	}
+
+	return probes, nil
+}
+
+// stringFlags is a flag.Value that allows a flag to be given multiple times.
+type stringFlags []string
+
+// String returns the values as a string.
+func (s *stringFlags) String() string {
+	return strings.Join(*s, ", ")
+}
+
+// Set appends a value.
+func (s *stringFlags) Set(val string) error {
+	*s = append(*s, val)
+
+	return nil
+}
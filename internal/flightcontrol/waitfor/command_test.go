@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package waitfor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForCommand(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(srv.Close)
+
+	file := filepath.Join(t.TempDir(), "ready")
+	require.NoError(t, os.WriteFile(file, []byte("ok"), 0o644))
+
+	for desc, test := range map[string]struct {
+		args     []string
+		exitCode int
+	}{
+		"all probes succeed": {
+			args: []string{
+				"--tcp", ln.Addr().String(),
+				"--http", srv.URL, "--http-status", "429",
+				"--file", file,
+			},
+		},
+		"unexpected http status": {
+			args:     []string{"--http", srv.URL, "--timeout", "50ms", "--interval", "10ms"},
+			exitCode: 1,
+		},
+		"missing file": {
+			args:     []string{"--file", file + ".missing", "--timeout", "50ms", "--interval", "10ms"},
+			exitCode: 1,
+		},
+		"no probes": {
+			args:     []string{},
+			exitCode: 1,
+		},
+		"invalid tcp address": {
+			args:     []string{"--tcp", "localhost"},
+			exitCode: 1,
+		},
+		"invalid http url": {
+			args:     []string{"--http", "localhost:8200"},
+			exitCode: 1,
+		},
+		"invalid http status": {
+			args:     []string{"--http", srv.URL, "--http-status", "ok"},
+			exitCode: 1,
+		},
+		"invalid interval": {
+			args:     []string{"--file", file, "--interval", "0s"},
+			exitCode: 1,
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			ui := cli.NewMockUi()
+			cmd, err := NewCommand(ui)
+			require.NoError(t, err)
+			require.Equal(t, test.exitCode, cmd.Run(test.args), ui.ErrorWriter.String())
+		})
+	}
+}
+
+func TestWaitRetriesUntilSuccess(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "ready")
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_ = os.WriteFile(file, []byte("ok"), 0o644)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, Wait(ctx, 10*time.Millisecond, time.Second, &FileProbe{Path: file}))
+}
+
+// countingProbe succeeds after a number of attempts.
+type countingProbe struct {
+	attempts atomic.Int32
+	succeed  int32
+}
+
+func (p *countingProbe) String() string {
+	return "counting"
+}
+
+func (p *countingProbe) Probe(ctx context.Context) error {
+	if p.attempts.Add(1) < p.succeed {
+		return os.ErrNotExist
+	}
+
+	return nil
+}
+
+func TestWaitDoesNotRerunSucceededProbes(t *testing.T) {
+	t.Parallel()
+
+	fast := &countingProbe{succeed: 1}
+	slow := &countingProbe{succeed: 3}
+
+	require.NoError(t, Wait(context.Background(), time.Millisecond, time.Second, fast, slow))
+	require.Equal(t, int32(1), fast.attempts.Load())
+	require.Equal(t, int32(3), slow.attempts.Load())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := Wait(ctx, time.Millisecond, time.Second, &countingProbe{succeed: 1000})
+	require.ErrorIs(t, err, ErrProbesFailed)
+}
+
+func TestProcessProbe(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for pid, proc := range map[string]struct {
+		comm    string
+		cmdline string
+	}{
+		"1":    {"systemd\n", "/sbin/init\x00splash\x00"},
+		"42":   {"vault-long-name\n", "/usr/bin/vault-long-name-binary\x00server\x00"},
+		"self": {"ignored\n", "ignored\x00"},
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, pid), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, pid, "comm"), []byte(proc.comm), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(root, pid, "cmdline"), []byte(proc.cmdline), 0o644))
+	}
+
+	for name, found := range map[string]bool{
+		"systemd":                true,
+		"init":                   true,
+		"vault-long-name-binary": true,
+		"vault-long-name":        true,
+		"vault":                  false,
+		"ignored":                false,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := (&ProcessProbe{Name: name, ProcRoot: root}).Probe(context.Background())
+			if found {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
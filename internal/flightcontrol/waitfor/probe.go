@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package waitfor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/flightcontrol/httpclient"
+)
+
+// Probe is a condition that we can wait for.
+type Probe interface {
+	// String returns a human readable description of the probe.
+	String() string
+	// Probe returns nil if the condition has been met.
+	Probe(ctx context.Context) error
+}
+
+// TCPProbe waits for an address to accept TCP connections.
+type TCPProbe struct {
+	Address string
+}
+
+var _ Probe = (*TCPProbe)(nil)
+
+// String returns a human readable description of the probe.
+func (p *TCPProbe) String() string {
+	return "tcp " + p.Address
+}
+
+// Probe returns nil if a connection can be established.
+func (p *TCPProbe) Probe(ctx context.Context) error {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", p.Address)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// HTTPProbe waits for an HTTP request to return an expected status code.
+type HTTPProbe struct {
+	Request *httpclient.Request
+}
+
+var _ Probe = (*HTTPProbe)(nil)
+
+// String returns a human readable description of the probe.
+func (p *HTTPProbe) String() string {
+	return "http " + p.Request.URL.String()
+}
+
+// Probe returns nil if the request returns an expected status code.
+func (p *HTTPProbe) Probe(ctx context.Context) error {
+	_, err := httpclient.Do(ctx, p.Request)
+
+	return err
+}
+
+// FileProbe waits for a file to exist.
+type FileProbe struct {
+	Path string
+}
+
+var _ Probe = (*FileProbe)(nil)
+
+// String returns a human readable description of the probe.
+func (p *FileProbe) String() string {
+	return "file " + p.Path
+}
+
+// Probe returns nil if the file exists.
+func (p *FileProbe) Probe(ctx context.Context) error {
+	_, err := os.Stat(p.Path)
+
+	return err
+}
+
+// ProcessProbe waits for a process with the name to exist. A process matches if either its
+// command name or the base name of its executable matches.
+type ProcessProbe struct {
+	Name     string
+	ProcRoot string // The procfs mount point, defaults to /proc
+}
+
+var _ Probe = (*ProcessProbe)(nil)
+
+// String returns a human readable description of the probe.
+func (p *ProcessProbe) String() string {
+	return "process " + p.Name
+}
+
+// Probe returns nil if a matching process exists.
+func (p *ProcessProbe) Probe(ctx context.Context) error {
+	root := p.ProcRoot
+	if root == "" {
+		root = "/proc"
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return fmt.Errorf("listing processes: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.Trim(entry.Name(), "0123456789") != "" {
+			continue
+		}
+
+		// The process might exit while we're looking at it so we ignore read errors
+		comm, err := os.ReadFile(filepath.Join(root, entry.Name(), "comm"))
+		if err == nil && strings.TrimSpace(string(comm)) == p.Name {
+			return nil
+		}
+
+		cmdline, err := os.ReadFile(filepath.Join(root, entry.Name(), "cmdline"))
+		if err == nil && len(cmdline) > 0 {
+			argv0, _, _ := strings.Cut(string(cmdline), "\x00")
+			if filepath.Base(argv0) == p.Name {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("no process named %s was found", p.Name)
+}
+
+// ErrProbesFailed is returned when the probes have not succeeded before the deadline.
+var ErrProbesFailed = errors.New("timed out waiting for probes to succeed")
+
+// Wait runs the probes every interval until they have all succeeded or the context is done. Each
+// probe attempt is limited to the probe timeout. Probes that have succeeded are not run again.
+func Wait(ctx context.Context, interval time.Duration, probeTimeout time.Duration, probes ...Probe) error {
+	pending := probes
+
+	for {
+		failed := []Probe{}
+		var errs error
+
+		for _, probe := range pending {
+			pctx, cancel := context.WithTimeout(ctx, probeTimeout)
+			err := probe.Probe(pctx)
+			cancel()
+			if err != nil {
+				failed = append(failed, probe)
+				errs = errors.Join(errs, fmt.Errorf("%s: %w", probe.String(), err))
+			}
+		}
+
+		if len(failed) == 0 {
+			return nil
+		}
+		pending = failed
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(ErrProbesFailed, errs)
+		case <-time.After(interval):
+		}
+	}
+}
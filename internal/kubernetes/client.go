@@ -16,7 +16,9 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	cv1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -60,6 +62,15 @@ type Client interface {
 	GetLogs(ctx context.Context, req GetPodLogsRequest) (*GetPodLogsResponse, error)
 	// ListPods returns all pods matching the given request
 	ListPods(ctx context.Context, req *ListPodsRequest) (*ListPodsResponse, error)
+	// ApplyConfigMap creates or updates the config map described by the request
+	ApplyConfigMap(ctx context.Context, req ApplyConfigMapRequest) error
+	// ApplySecret creates or updates the secret described by the request
+	ApplySecret(ctx context.Context, req ApplySecretRequest) error
+	// RestartWorkload restarts the workload described by the request
+	RestartWorkload(ctx context.Context, req RestartWorkloadRequest) error
+	// GetWorkloadRolloutStatus gets the rollout status of the stateful set or deployment described
+	// by the request
+	GetWorkloadRolloutStatus(ctx context.Context, req GetWorkloadRolloutStatusRequest) (*WorkloadRolloutStatus, error)
 }
 
 type ClientCfg struct {
@@ -143,6 +154,84 @@ type ListPodsRequest struct {
 	RetryOpts []retry.RetrierOpt
 }
 
+// ApplyConfigMapRequest is a request to create or update a config map.
+type ApplyConfigMapRequest struct {
+	// Namespace the namespace of the config map
+	Namespace string
+	// Name the name of the config map
+	Name string
+	// Data the config map data. Any existing data will be replaced
+	Data map[string]string
+}
+
+// ApplySecretRequest is a request to create or update an opaque secret.
+type ApplySecretRequest struct {
+	// Namespace the namespace of the secret
+	Namespace string
+	// Name the name of the secret
+	Name string
+	// Data the secret data. Any existing data will be replaced
+	Data map[string]string
+}
+
+// WorkloadKind is the kind of workload to restart.
+type WorkloadKind string
+
+// The supported WorkloadKind's.
+const (
+	WorkloadKindPod         WorkloadKind = "pod"
+	WorkloadKindStatefulSet WorkloadKind = "statefulset"
+	WorkloadKindDeployment  WorkloadKind = "deployment"
+)
+
+// RestartWorkloadRequest is a request to restart a workload. Pods are restarted by deleting them
+// and relying on their controller to recreate them. Stateful sets and deployments are restarted
+// by updating the restart annotation of the pod template, the same way "kubectl rollout restart"
+// does.
+type RestartWorkloadRequest struct {
+	// Namespace the namespace of the workload
+	Namespace string
+	// Kind the kind of workload
+	Kind WorkloadKind
+	// Name the name of the workload
+	Name string
+}
+
+// GetWorkloadRolloutStatusRequest is a request to get the rollout status of a stateful set or
+// deployment.
+type GetWorkloadRolloutStatusRequest struct {
+	// Namespace the namespace of the workload
+	Namespace string
+	// Kind the kind of workload, either statefulset or deployment
+	Kind WorkloadKind
+	// Name the name of the workload
+	Name string
+}
+
+// WorkloadRolloutStatus is the rollout status of a stateful set or deployment.
+type WorkloadRolloutStatus struct {
+	// UpdateStrategy the update strategy type of the workload, e.g. RollingUpdate
+	UpdateStrategy string
+	// Generation the generation of the workload spec
+	Generation int64
+	// ObservedGeneration the generation that the controller has observed
+	ObservedGeneration int64
+	// DesiredReplicas the number of replicas in the workload spec
+	DesiredReplicas int32
+	// Replicas the number of pods that have been created for the workload
+	Replicas int32
+	// UpdatedReplicas the number of pods that have been created from the latest pod template
+	UpdatedReplicas int32
+}
+
+// Complete returns whether or not all of the pods of the workload have been replaced with pods
+// created from the latest pod template. It does not consider whether or not the pods are ready.
+func (s *WorkloadRolloutStatus) Complete() bool {
+	return s.ObservedGeneration >= s.Generation &&
+		s.UpdatedReplicas == s.DesiredReplicas &&
+		s.Replicas == s.DesiredReplicas
+}
+
 // ListPodsResponse is ListPods response.
 type ListPodsResponse struct {
 	Pods *Pods
@@ -475,6 +564,173 @@ func (c *client) ListPods(ctx context.Context, req *ListPodsRequest) (*ListPodsR
 	return res, nil
 }
 
+// ApplyConfigMap creates the config map if it does not exist, otherwise it replaces the data of the
+// existing config map.
+func (c *client) ApplyConfigMap(ctx context.Context, req ApplyConfigMapRequest) error {
+	namespace := req.Namespace
+	if strings.TrimSpace(namespace) == "" {
+		namespace = defaultNamespace
+	}
+
+	configMaps := c.clientset.CoreV1().ConfigMaps(namespace)
+	cm, err := configMaps.Get(ctx, req.Name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get config map: %s in namespace: %s, due to: %w", req.Name, namespace, err)
+		}
+
+		_, err = configMaps.Create(ctx, &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: namespace},
+			Data:       req.Data,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create config map: %s in namespace: %s, due to: %w", req.Name, namespace, err)
+		}
+
+		return nil
+	}
+
+	cm.Data = req.Data
+	_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update config map: %s in namespace: %s, due to: %w", req.Name, namespace, err)
+	}
+
+	return nil
+}
+
+// ApplySecret creates the opaque secret if it does not exist, otherwise it replaces the data of the
+// existing secret.
+func (c *client) ApplySecret(ctx context.Context, req ApplySecretRequest) error {
+	namespace := req.Namespace
+	if strings.TrimSpace(namespace) == "" {
+		namespace = defaultNamespace
+	}
+
+	secrets := c.clientset.CoreV1().Secrets(namespace)
+	secret, err := secrets.Get(ctx, req.Name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get secret: %s in namespace: %s, due to: %w", req.Name, namespace, err)
+		}
+
+		_, err = secrets.Create(ctx, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: namespace},
+			Type:       v1.SecretTypeOpaque,
+			StringData: req.Data,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create secret: %s in namespace: %s, due to: %w", req.Name, namespace, err)
+		}
+
+		return nil
+	}
+
+	secret.Data = nil
+	secret.StringData = req.Data
+	_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update secret: %s in namespace: %s, due to: %w", req.Name, namespace, err)
+	}
+
+	return nil
+}
+
+// RestartWorkload restarts the pod, stateful set, or deployment.
+func (c *client) RestartWorkload(ctx context.Context, req RestartWorkloadRequest) error {
+	namespace := req.Namespace
+	if strings.TrimSpace(namespace) == "" {
+		namespace = defaultNamespace
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return errors.New("cannot restart workload without the workload name")
+	}
+
+	var err error
+	switch req.Kind {
+	case WorkloadKindPod:
+		err = c.clientset.CoreV1().Pods(namespace).Delete(ctx, req.Name, metav1.DeleteOptions{})
+	case WorkloadKindStatefulSet, WorkloadKindDeployment:
+		patch := fmt.Sprintf(
+			`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":"%s"}}}}}`,
+			time.Now().Format(time.RFC3339),
+		)
+
+		if req.Kind == WorkloadKindStatefulSet {
+			_, err = c.clientset.AppsV1().StatefulSets(namespace).Patch(
+				ctx, req.Name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{},
+			)
+		} else {
+			_, err = c.clientset.AppsV1().Deployments(namespace).Patch(
+				ctx, req.Name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{},
+			)
+		}
+	default:
+		return fmt.Errorf("unsupported workload kind: %s", req.Kind)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to restart %s: %s in namespace: %s, due to: %w", req.Kind, req.Name, namespace, err)
+	}
+
+	return nil
+}
+
+// GetWorkloadRolloutStatus gets the rollout status of the stateful set or deployment.
+func (c *client) GetWorkloadRolloutStatus(ctx context.Context, req GetWorkloadRolloutStatusRequest) (*WorkloadRolloutStatus, error) {
+	namespace := req.Namespace
+	if strings.TrimSpace(namespace) == "" {
+		namespace = defaultNamespace
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("cannot get workload rollout status without the workload name")
+	}
+
+	switch req.Kind {
+	case WorkloadKindStatefulSet:
+		set, err := c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, req.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %s in namespace: %s, due to: %w", req.Kind, req.Name, namespace, err)
+		}
+
+		return &WorkloadRolloutStatus{
+			UpdateStrategy:     string(set.Spec.UpdateStrategy.Type),
+			Generation:         set.Generation,
+			ObservedGeneration: set.Status.ObservedGeneration,
+			DesiredReplicas:    desiredReplicas(set.Spec.Replicas),
+			Replicas:           set.Status.Replicas,
+			UpdatedReplicas:    set.Status.UpdatedReplicas,
+		}, nil
+	case WorkloadKindDeployment:
+		deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, req.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %s in namespace: %s, due to: %w", req.Kind, req.Name, namespace, err)
+		}
+
+		return &WorkloadRolloutStatus{
+			UpdateStrategy:     string(deployment.Spec.Strategy.Type),
+			Generation:         deployment.Generation,
+			ObservedGeneration: deployment.Status.ObservedGeneration,
+			DesiredReplicas:    desiredReplicas(deployment.Spec.Replicas),
+			Replicas:           deployment.Status.Replicas,
+			UpdatedReplicas:    deployment.Status.UpdatedReplicas,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported workload kind for rollout status: %s", req.Kind)
+	}
+}
+
+// desiredReplicas returns the number of desired replicas, which defaults to one if unset.
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+
+	return *replicas
+}
+
 // CoreV1 returns the clients CoreV1 client.
 func (c *client) CoreV1() cv1.CoreV1Interface {
 	return c.clientset.CoreV1()
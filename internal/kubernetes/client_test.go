@@ -47,3 +47,43 @@ func TestGetPodLogsResponse_GetLogFileName(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkloadRolloutStatusComplete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status WorkloadRolloutStatus
+		want   bool
+	}{
+		{
+			name:   "complete",
+			status: WorkloadRolloutStatus{Generation: 2, ObservedGeneration: 2, DesiredReplicas: 3, Replicas: 3, UpdatedReplicas: 3},
+			want:   true,
+		},
+		{
+			name:   "not observed",
+			status: WorkloadRolloutStatus{Generation: 2, ObservedGeneration: 1, DesiredReplicas: 3, Replicas: 3, UpdatedReplicas: 3},
+			want:   false,
+		},
+		{
+			name:   "updating",
+			status: WorkloadRolloutStatus{Generation: 2, ObservedGeneration: 2, DesiredReplicas: 3, Replicas: 3, UpdatedReplicas: 1},
+			want:   false,
+		},
+		{
+			name:   "old replicas remain",
+			status: WorkloadRolloutStatus{Generation: 2, ObservedGeneration: 2, DesiredReplicas: 3, Replicas: 4, UpdatedReplicas: 3},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.status.Complete(); got != tt.want {
+				t.Errorf("Complete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -11,11 +11,15 @@ import (
 
 // MockClient a mock Kubernetes Client.
 type MockClient struct {
-	NewExecRequestFunc func(opts ExecRequestOpts) it.ExecRequest
-	QueryPodInfosFunc  func(ctx context.Context, req QueryPodInfosRequest) ([]PodInfo, error)
-	GetPodInfoFunc     func(ctx context.Context, req GetPodInfoRequest) (*PodInfo, error)
-	GetLogsFunc        func(ctx context.Context, req GetPodLogsRequest) (*GetPodLogsResponse, error)
-	ListPodsFunc       func(ctx context.Context, req *ListPodsRequest) (*ListPodsResponse, error)
+	NewExecRequestFunc           func(opts ExecRequestOpts) it.ExecRequest
+	QueryPodInfosFunc            func(ctx context.Context, req QueryPodInfosRequest) ([]PodInfo, error)
+	GetPodInfoFunc               func(ctx context.Context, req GetPodInfoRequest) (*PodInfo, error)
+	GetLogsFunc                  func(ctx context.Context, req GetPodLogsRequest) (*GetPodLogsResponse, error)
+	ListPodsFunc                 func(ctx context.Context, req *ListPodsRequest) (*ListPodsResponse, error)
+	ApplyConfigMapFunc           func(ctx context.Context, req ApplyConfigMapRequest) error
+	ApplySecretFunc              func(ctx context.Context, req ApplySecretRequest) error
+	RestartWorkloadFunc          func(ctx context.Context, req RestartWorkloadRequest) error
+	GetWorkloadRolloutStatusFunc func(ctx context.Context, req GetWorkloadRolloutStatusRequest) (*WorkloadRolloutStatus, error)
 }
 
 func (m *MockClient) NewExecRequest(opts ExecRequestOpts) it.ExecRequest {
@@ -38,6 +42,22 @@ func (m *MockClient) ListPods(ctx context.Context, req *ListPodsRequest) (*ListP
 	return m.ListPodsFunc(ctx, req)
 }
 
+func (m *MockClient) ApplyConfigMap(ctx context.Context, req ApplyConfigMapRequest) error {
+	return m.ApplyConfigMapFunc(ctx, req)
+}
+
+func (m *MockClient) ApplySecret(ctx context.Context, req ApplySecretRequest) error {
+	return m.ApplySecretFunc(ctx, req)
+}
+
+func (m *MockClient) RestartWorkload(ctx context.Context, req RestartWorkloadRequest) error {
+	return m.RestartWorkloadFunc(ctx, req)
+}
+
+func (m *MockClient) GetWorkloadRolloutStatus(ctx context.Context, req GetWorkloadRolloutStatusRequest) (*WorkloadRolloutStatus, error) {
+	return m.GetWorkloadRolloutStatusFunc(ctx, req)
+}
+
 // NewMockGetLogsFunc creates a GetLogsFunc that returns the provided logs when called.
 func NewMockGetLogsFunc(logs []byte) func(ctx context.Context, req GetPodLogsRequest) (*GetPodLogsResponse, error) {
 	return func(ctx context.Context, req GetPodLogsRequest) (*GetPodLogsResponse, error) {
@@ -6,6 +6,7 @@ package nomad
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -69,19 +70,61 @@ type Client interface {
 	Exec(ctx context.Context, opts ExecRequestOpts, streams *it.ExecStreams) *it.ExecResponse
 	// GetLogs gets the logs for the allocation and task as specified in the request
 	GetLogs(ctx context.Context, req GetTaskLogsRequest) (*GetTaskLogsResponse, error)
+	// SetVariable creates or updates the Nomad variable as specified in the request
+	SetVariable(ctx context.Context, req SetVariableRequest) error
+	// RestartTask restarts the task in the allocation as specified in the request
+	RestartTask(ctx context.Context, req RestartTaskRequest) error
+	// RegisterJob creates or updates the job as specified in the request
+	RegisterJob(ctx context.Context, req RegisterJobRequest) (*RegisterJobResponse, error)
+	// WaitForJob waits for the latest version of the job to be healthy
+	WaitForJob(ctx context.Context, req WaitForJobRequest) error
+	// DeregisterJob stops the job as specified in the request
+	DeregisterJob(ctx context.Context, req DeregisterJobRequest) error
+	// ListAllocations lists the running allocations of the job as specified in the request
+	ListAllocations(ctx context.Context, req ListAllocationsRequest) ([]AllocationInfo, error)
 	// Close closes the Client.
 	Close()
 }
 
+// SetVariableRequest a request to create or update a Nomad variable.
+type SetVariableRequest struct {
+	// Namespace the namespace of the variable. If not provided the default namespace is used
+	Namespace string
+	// Path the path of the variable
+	Path string
+	// Items the items of the variable. Any existing items will be replaced
+	Items map[string]string
+}
+
+// RestartTaskRequest a request to restart a task in an allocation.
+type RestartTaskRequest struct {
+	// AllocationID the allocation ID or allocation ID prefix
+	AllocationID string
+	// Task the name of the task to restart
+	Task string
+}
+
 // client a wrapper for the Nomad API Client.
 type client struct {
 	apiClient *api.Client
 }
 
-// ClientCfg the configuration required for the Client.
+// ClientCfg the configuration required for the Client. Any values that are not set fall back to the
+// standard NOMAD_* environment variables, i.e. NOMAD_ADDR, NOMAD_TOKEN, NOMAD_NAMESPACE,
+// NOMAD_REGION, NOMAD_CACERT, NOMAD_CLIENT_CERT, NOMAD_CLIENT_KEY and NOMAD_TLS_SERVER_NAME.
 type ClientCfg struct {
-	Host     string
-	SecretID string
+	Host      string
+	SecretID  string
+	Namespace string
+	Region    string
+	// CACert the path to a PEM encoded CA certificate used to verify the Nomad server
+	CACert string
+	// ClientCert the path to a PEM encoded client certificate for mTLS
+	ClientCert string
+	// ClientKey the path to a PEM encoded private key for the client certificate
+	ClientKey string
+	// TLSServerName the server name to use as the SNI host when connecting via TLS
+	TLSServerName string
 }
 
 // ExecRequestOpts exec options for a Nomad exec request.
@@ -261,18 +304,57 @@ ReadFrames:
 	}, nil
 }
 
+// SetVariable creates or updates the Nomad variable. Nomad variables are replaced as a whole,
+// so the items in the request will replace any existing items in the variable.
+func (c *client) SetVariable(ctx context.Context, req SetVariableRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	_, _, err := c.apiClient.Variables().Create(&api.Variable{
+		Namespace: req.Namespace,
+		Path:      req.Path,
+		Items:     req.Items,
+	}, (&api.WriteOptions{Namespace: req.Namespace}).WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to set variable: %s, due to: %w", req.Path, err)
+	}
+
+	return nil
+}
+
+// RestartTask restarts the task in place, which means the allocation ID does not change.
+func (c *client) RestartTask(ctx context.Context, req RestartTaskRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	alloc, err := c.GetAllocation(req.AllocationID)
+	if err != nil {
+		return fmt.Errorf("failed to restart task: %s, due to: %w", req.Task, err)
+	}
+
+	err = c.apiClient.Allocations().Restart(alloc, req.Task, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to restart task: %s in allocation: %s, due to: %w", req.Task, alloc.ID, err)
+	}
+
+	return nil
+}
+
 func (c *client) Close() {
 	c.apiClient.Close()
 }
 
 // createClient creates the Nomad API client.
 func createClient(opts ClientCfg) (*api.Client, error) {
-	config := &api.Config{
-		Address: opts.Host,
-	}
-
-	if len(opts.SecretID) > 0 {
-		config.SecretID = opts.SecretID
+	config, err := newAPIConfig(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the nomad client due to: %w", err)
 	}
 
 	client, err := api.NewClient(config)
@@ -282,3 +364,47 @@ func createClient(opts ClientCfg) (*api.Client, error) {
 
 	return client, nil
 }
+
+// newAPIConfig creates the Nomad API client configuration. The default config is populated from
+// the NOMAD_* environment variables, anything that has been explicitly configured overrides them.
+func newAPIConfig(opts ClientCfg) (*api.Config, error) {
+	config := api.DefaultConfig()
+
+	if len(opts.Host) > 0 {
+		config.Address = opts.Host
+	}
+
+	if len(opts.SecretID) > 0 {
+		config.SecretID = opts.SecretID
+	}
+
+	if len(opts.Namespace) > 0 {
+		config.Namespace = opts.Namespace
+	}
+
+	if len(opts.Region) > 0 {
+		config.Region = opts.Region
+	}
+
+	if len(opts.CACert) > 0 {
+		config.TLSConfig.CACert = opts.CACert
+	}
+
+	if len(opts.ClientCert) > 0 {
+		config.TLSConfig.ClientCert = opts.ClientCert
+	}
+
+	if len(opts.ClientKey) > 0 {
+		config.TLSConfig.ClientKey = opts.ClientKey
+	}
+
+	if len(opts.TLSServerName) > 0 {
+		config.TLSConfig.TLSServerName = opts.TLSServerName
+	}
+
+	if (config.TLSConfig.ClientCert == "") != (config.TLSConfig.ClientKey == "") {
+		return nil, errors.New("you must supply both a client certificate and client key")
+	}
+
+	return config, nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetTaskLogsResponse_GetLogFileName(t *testing.T) {
@@ -22,3 +23,52 @@ func TestGetTaskLogsResponse_GetLogFileName(t *testing.T) {
 
 	assert.Equal(t, "taco-truck_make.taco[0]_chicken.log", logFile)
 }
+
+//nolint:paralleltest// t.Setenv is not compatible with parallel tests
+func TestNewAPIConfig(t *testing.T) {
+	t.Setenv("NOMAD_ADDR", "https://10.0.0.1:4646")
+	t.Setenv("NOMAD_TOKEN", "env-token")
+	t.Setenv("NOMAD_NAMESPACE", "env-namespace")
+	t.Setenv("NOMAD_REGION", "env-region")
+	t.Setenv("NOMAD_CACERT", "/etc/nomad.d/env-ca.pem")
+	t.Setenv("NOMAD_CLIENT_CERT", "")
+	t.Setenv("NOMAD_CLIENT_KEY", "")
+	t.Setenv("NOMAD_TLS_SERVER_NAME", "")
+
+	t.Run("environment", func(t *testing.T) {
+		cfg, err := newAPIConfig(ClientCfg{})
+		require.NoError(t, err)
+		assert.Equal(t, "https://10.0.0.1:4646", cfg.Address)
+		assert.Equal(t, "env-token", cfg.SecretID)
+		assert.Equal(t, "env-namespace", cfg.Namespace)
+		assert.Equal(t, "env-region", cfg.Region)
+		assert.Equal(t, "/etc/nomad.d/env-ca.pem", cfg.TLSConfig.CACert)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		cfg, err := newAPIConfig(ClientCfg{
+			Host:          "https://10.0.0.2:4646",
+			SecretID:      "token",
+			Namespace:     "enos",
+			Region:        "east",
+			CACert:        "/etc/nomad.d/ca.pem",
+			ClientCert:    "/etc/nomad.d/client.pem",
+			ClientKey:     "/etc/nomad.d/client-key.pem",
+			TLSServerName: "client.east.nomad",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://10.0.0.2:4646", cfg.Address)
+		assert.Equal(t, "token", cfg.SecretID)
+		assert.Equal(t, "enos", cfg.Namespace)
+		assert.Equal(t, "east", cfg.Region)
+		assert.Equal(t, "/etc/nomad.d/ca.pem", cfg.TLSConfig.CACert)
+		assert.Equal(t, "/etc/nomad.d/client.pem", cfg.TLSConfig.ClientCert)
+		assert.Equal(t, "/etc/nomad.d/client-key.pem", cfg.TLSConfig.ClientKey)
+		assert.Equal(t, "client.east.nomad", cfg.TLSConfig.TLSServerName)
+	})
+
+	t.Run("client-cert-without-key", func(t *testing.T) {
+		_, err := newAPIConfig(ClientCfg{ClientCert: "/etc/nomad.d/client.pem"})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,417 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/retry"
+)
+
+// errJobNotHealthy is returned when a job is not healthy yet but might become healthy.
+var errJobNotHealthy = errors.New("job is not healthy")
+
+// RegisterJobRequest a request to register (create or update) a Nomad job.
+type RegisterJobRequest struct {
+	// Jobspec the HCL or JSON jobspec
+	Jobspec string
+	// Namespace overrides the namespace of the job. If not provided the namespace in the jobspec
+	// is used
+	Namespace string
+}
+
+// RegisterJobResponse the response of registering a Nomad job.
+type RegisterJobResponse struct {
+	JobID     string
+	Namespace string
+	Type      string
+	EvalID    string
+}
+
+// WaitForJobRequest a request to wait for a Nomad job to become healthy.
+type WaitForJobRequest struct {
+	Namespace string
+	JobID     string
+	// Type the type of the job, e.g. service, batch, system
+	Type string
+	// Timeout the amount of time to wait for the job to be healthy
+	Timeout time.Duration
+}
+
+// DeregisterJobRequest a request to stop and optionally purge a Nomad job.
+type DeregisterJobRequest struct {
+	Namespace string
+	JobID     string
+	Purge     bool
+}
+
+// ListAllocationsRequest a request to list the allocations of a Nomad job.
+type ListAllocationsRequest struct {
+	Namespace string
+	JobID     string
+	// TaskGroup only list allocations for the task group, if set
+	TaskGroup string
+	// ExpectedCount the number of running allocations that are expected
+	ExpectedCount int
+	// WaitTimeout the amount of time to wait for the expected allocations to be running
+	WaitTimeout time.Duration
+}
+
+// AllocationInfo information about an allocation.
+type AllocationInfo struct {
+	ID           string
+	Name         string
+	Namespace    string
+	JobID        string
+	TaskGroup    string
+	NodeID       string
+	NodeName     string
+	ClientStatus string
+	Tasks        []string
+}
+
+// RegisterJob parses the jobspec and registers the job.
+func (c *client) RegisterJob(ctx context.Context, req RegisterJobRequest) (*RegisterJobResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	job, err := c.parseJobspec(req.Jobspec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse jobspec, due to: %w", err)
+	}
+
+	if req.Namespace != "" {
+		job.Namespace = &req.Namespace
+	}
+
+	namespace := ""
+	if job.Namespace != nil {
+		namespace = *job.Namespace
+	}
+
+	res, _, err := c.apiClient.Jobs().Register(job, (&api.WriteOptions{Namespace: namespace}).WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to register job: %s, due to: %w", derefString(job.ID), err)
+	}
+
+	return &RegisterJobResponse{
+		JobID:     derefString(job.ID),
+		Namespace: namespace,
+		Type:      derefString(job.Type),
+		EvalID:    res.EvalID,
+	}, nil
+}
+
+// WaitForJob waits for the latest version of the job to become healthy. Service jobs with an update
+// strategy are healthy when the deployment of the latest version is successful. Jobs that do not
+// create a deployment for the latest version are healthy when all of the allocations of the latest
+// version are running, or complete for batch jobs.
+func (c *client) WaitForJob(ctx context.Context, req WaitForJobRequest) error {
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	q := &api.QueryOptions{Namespace: req.Namespace}
+
+	checkJob := func(ctx context.Context) (any, error) {
+		job, _, err := c.apiClient.Jobs().Info(req.JobID, q.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to get job: %s, due to: %w", errJobNotHealthy, req.JobID, err)
+		}
+
+		version := derefUint64(job.Version)
+		if req.Type == api.JobTypeService && jobCreatesDeployments(job) {
+			deployment, _, err := c.apiClient.Jobs().LatestDeployment(req.JobID, q.WithContext(ctx))
+			if err != nil {
+				return nil, fmt.Errorf(
+					"%w: failed to get the latest deployment for job: %s, due to: %w", errJobNotHealthy, req.JobID, err,
+				)
+			}
+
+			// Nomad creates the deployment in the same plan as the allocations of the job version, so if
+			// there's no deployment for the version we fall back to checking the allocations.
+			if deployment != nil && deployment.JobVersion == version {
+				return nil, checkDeployment(deployment, version)
+			}
+		}
+
+		allocs, _, err := c.apiClient.Jobs().Allocations(req.JobID, false, q.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to list allocations for job: %s, due to: %w", errJobNotHealthy, req.JobID, err)
+		}
+
+		return nil, checkAllocations(allocs, req.Type, version)
+	}
+
+	r, err := retry.NewRetrier(
+		retry.WithIntervalFunc(retry.IntervalDuration(2*time.Second)),
+		retry.WithRetrierFunc(checkJob),
+		retry.WithOnlyRetryErrors(errJobNotHealthy),
+	)
+	if err == nil {
+		_, err = retry.Retry(ctx, r)
+	}
+
+	if err != nil {
+		return fmt.Errorf("waiting for job: %s to be healthy: %w", req.JobID, err)
+	}
+
+	return nil
+}
+
+// DeregisterJob stops the job, and purges it if requested.
+func (c *client) DeregisterJob(ctx context.Context, req DeregisterJobRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	_, _, err := c.apiClient.Jobs().Deregister(req.JobID, req.Purge, (&api.WriteOptions{Namespace: req.Namespace}).WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to deregister job: %s, due to: %w", req.JobID, err)
+	}
+
+	return nil
+}
+
+// ListAllocations lists the running allocations of a job, waiting for the expected number of
+// allocations to be running if an expected count is provided.
+func (c *client) ListAllocations(ctx context.Context, req ListAllocationsRequest) ([]AllocationInfo, error) {
+	if req.WaitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.WaitTimeout)
+		defer cancel()
+	}
+
+	listAllocs := func(ctx context.Context) (any, error) {
+		allocs, _, err := c.apiClient.Jobs().Allocations(
+			req.JobID, false, (&api.QueryOptions{Namespace: req.Namespace}).WithContext(ctx),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list allocations for job: %s, due to: %w", req.JobID, err)
+		}
+
+		infos := runningAllocationInfos(allocs, req.TaskGroup)
+		if req.ExpectedCount > 0 && len(infos) != req.ExpectedCount {
+			return nil, fmt.Errorf(
+				"expected %d running allocations for job: %s, got: %d", req.ExpectedCount, req.JobID, len(infos),
+			)
+		}
+
+		return infos, nil
+	}
+
+	opts := []retry.RetrierOpt{
+		retry.WithIntervalFunc(retry.IntervalDuration(2 * time.Second)),
+		retry.WithRetrierFunc(listAllocs),
+	}
+	if req.ExpectedCount < 1 {
+		opts = append(opts, retry.WithMaxRetries(0))
+	}
+
+	r, err := retry.NewRetrier(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := retry.Retry(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]AllocationInfo), nil
+}
+
+// parseJobspec parses a JSON jobspec locally, or an HCL jobspec using the Nomad API.
+func (c *client) parseJobspec(jobspec string) (*api.Job, error) {
+	if strings.HasPrefix(strings.TrimSpace(jobspec), "{") {
+		return parseJSONJobspec(jobspec)
+	}
+
+	return c.apiClient.Jobs().ParseHCLOpts(&api.JobsParseRequest{
+		JobHCL:       jobspec,
+		Canonicalize: true,
+	})
+}
+
+// parseJSONJobspec parses a JSON jobspec. Both the API format, where the job is nested in a "Job"
+// attribute, and the bare job format are supported.
+func parseJSONJobspec(jobspec string) (*api.Job, error) {
+	wrapped := &struct {
+		Job *api.Job `json:"Job"`
+	}{}
+
+	err := json.Unmarshal([]byte(jobspec), wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	job := wrapped.Job
+	if job == nil {
+		job = &api.Job{}
+		if err = json.Unmarshal([]byte(jobspec), job); err != nil {
+			return nil, err
+		}
+	}
+
+	if job.ID == nil || *job.ID == "" {
+		return nil, errors.New("the jobspec does not have an ID")
+	}
+
+	job.Canonicalize()
+
+	return job, nil
+}
+
+// jobCreatesDeployments returns whether or not Nomad creates deployments for the job. Deployments
+// are only created for service jobs that have an update strategy.
+func jobCreatesDeployments(job *api.Job) bool {
+	if job == nil || derefString(job.Type) != api.JobTypeService {
+		return false
+	}
+
+	if updateStrategyEnabled(job.Update) {
+		return true
+	}
+
+	for _, group := range job.TaskGroups {
+		if group != nil && updateStrategyEnabled(group.Update) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updateStrategyEnabled returns whether or not the update strategy will create deployments.
+func updateStrategyEnabled(update *api.UpdateStrategy) bool {
+	return update != nil && update.MaxParallel != nil && *update.MaxParallel > 0
+}
+
+// checkDeployment checks that the deployment of the job version has succeeded.
+func checkDeployment(deployment *api.Deployment, version uint64) error {
+	if deployment == nil {
+		return fmt.Errorf("%w: no deployment found for job version: %d", errJobNotHealthy, version)
+	}
+
+	if deployment.JobVersion != version {
+		return fmt.Errorf(
+			"%w: waiting for deployment of job version: %d, latest deployment is for version: %d",
+			errJobNotHealthy, version, deployment.JobVersion,
+		)
+	}
+
+	switch deployment.Status {
+	case api.DeploymentStatusSuccessful:
+		return nil
+	case api.DeploymentStatusFailed, api.DeploymentStatusCancelled:
+		// The deployment will never become healthy so we return an error that is not retried.
+		return fmt.Errorf(
+			"deployment: %s has status: %s, %s", deployment.ID, deployment.Status, deployment.StatusDescription,
+		)
+	default:
+		return fmt.Errorf("%w: deployment: %s has status: %s", errJobNotHealthy, deployment.ID, deployment.Status)
+	}
+}
+
+// checkAllocations checks that all allocations of the job version are running, or have completed
+// for batch jobs.
+func checkAllocations(allocs []*api.AllocationListStub, jobType string, version uint64) error {
+	expected := []string{api.AllocClientStatusRunning}
+	if jobType == api.JobTypeBatch || jobType == api.JobTypeSysbatch {
+		expected = append(expected, api.AllocClientStatusComplete)
+	}
+
+	var err error
+	count := 0
+	for _, alloc := range allocs {
+		if alloc == nil || alloc.JobVersion != version || alloc.DesiredStatus != api.AllocDesiredStatusRun {
+			continue
+		}
+
+		count++
+		if !slices.Contains(expected, alloc.ClientStatus) {
+			err = errors.Join(err, fmt.Errorf("allocation: %s has status: %s", alloc.ID, alloc.ClientStatus))
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf("%w: no allocations found for job version: %d", errJobNotHealthy, version)
+	}
+
+	if err != nil {
+		return errors.Join(errJobNotHealthy, err)
+	}
+
+	return nil
+}
+
+// runningAllocationInfos returns the allocation info for all running allocations, optionally
+// filtered by task group.
+func runningAllocationInfos(allocs []*api.AllocationListStub, taskGroup string) []AllocationInfo {
+	infos := []AllocationInfo{}
+	for _, alloc := range allocs {
+		if alloc == nil || alloc.ClientStatus != api.AllocClientStatusRunning {
+			continue
+		}
+
+		if taskGroup != "" && alloc.TaskGroup != taskGroup {
+			continue
+		}
+
+		tasks := []string{}
+		for task := range alloc.TaskStates {
+			tasks = append(tasks, task)
+		}
+		slices.Sort(tasks)
+
+		infos = append(infos, AllocationInfo{
+			ID:           alloc.ID,
+			Name:         alloc.Name,
+			Namespace:    alloc.Namespace,
+			JobID:        alloc.JobID,
+			TaskGroup:    alloc.TaskGroup,
+			NodeID:       alloc.NodeID,
+			NodeName:     alloc.NodeName,
+			ClientStatus: alloc.ClientStatus,
+			Tasks:        tasks,
+		})
+	}
+
+	slices.SortFunc(infos, func(a, b AllocationInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return infos
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func derefUint64(i *uint64) uint64 {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
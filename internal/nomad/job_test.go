@@ -0,0 +1,297 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONJobspec(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		jobspec    string
+		shouldFail bool
+	}{
+		"wrapped": {
+			`{"Job": {"ID": "vault", "Type": "service", "Namespace": "enos"}}`,
+			false,
+		},
+		"bare": {
+			`{"ID": "vault", "Type": "service", "Namespace": "enos"}`,
+			false,
+		},
+		"missing-id": {
+			`{"Job": {"Type": "service"}}`,
+			true,
+		},
+		"invalid": {
+			`{"Job": `,
+			true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			job, err := parseJSONJobspec(test.jobspec)
+			if test.shouldFail {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "vault", *job.ID)
+			require.Equal(t, "vault", *job.Name)
+			require.Equal(t, "enos", *job.Namespace)
+		})
+	}
+}
+
+func TestJobCreatesDeployments(t *testing.T) {
+	t.Parallel()
+
+	update := func(maxParallel int) *api.UpdateStrategy {
+		return &api.UpdateStrategy{MaxParallel: &maxParallel}
+	}
+	job := func(jobType string, jobUpdate *api.UpdateStrategy, groupUpdate *api.UpdateStrategy) *api.Job {
+		return &api.Job{
+			Type:       &jobType,
+			Update:     jobUpdate,
+			TaskGroups: []*api.TaskGroup{{Update: groupUpdate}},
+		}
+	}
+
+	for name, test := range map[string]struct {
+		job      *api.Job
+		expected bool
+	}{
+		"job-update":         {job(api.JobTypeService, update(1), nil), true},
+		"group-update":       {job(api.JobTypeService, nil, update(2)), true},
+		"no-update":          {job(api.JobTypeService, nil, nil), false},
+		"disabled-update":    {job(api.JobTypeService, update(0), update(0)), false},
+		"system-with-update": {job(api.JobTypeSystem, update(1), update(1)), false},
+		"nil":                {nil, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.expected, jobCreatesDeployments(test.job))
+		})
+	}
+}
+
+// TestWaitForJobServiceWithoutDeployment tests that we don't wait for a deployment of service jobs
+// that don't create one.
+func TestWaitForJobServiceWithoutDeployment(t *testing.T) {
+	t.Parallel()
+
+	maxParallel := 1
+	for name, update := range map[string]*api.UpdateStrategy{
+		"no-update-strategy": nil,
+		"no-deployment":      {MaxParallel: &maxParallel},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body any
+				switch r.URL.Path {
+				case "/v1/job/web":
+					jobType := api.JobTypeService
+					version := uint64(1)
+					body = &api.Job{
+						Type:       &jobType,
+						Version:    &version,
+						TaskGroups: []*api.TaskGroup{{Update: update}},
+					}
+				case "/v1/job/web/deployment":
+					body = nil
+				case "/v1/job/web/allocations":
+					body = []*api.AllocationListStub{{
+						ID:            "a",
+						JobVersion:    1,
+						DesiredStatus: api.AllocDesiredStatusRun,
+						ClientStatus:  api.AllocClientStatusRunning,
+					}}
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				require.NoError(t, json.NewEncoder(w).Encode(body))
+			}))
+			t.Cleanup(srv.Close)
+
+			c, err := NewClient(ClientCfg{Host: srv.URL})
+			require.NoError(t, err)
+
+			err = c.WaitForJob(context.Background(), WaitForJobRequest{
+				JobID:   "web",
+				Type:    api.JobTypeService,
+				Timeout: 5 * time.Second,
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckDeployment(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		deployment *api.Deployment
+		retryable  bool
+		shouldFail bool
+	}{
+		"successful": {
+			&api.Deployment{JobVersion: 1, Status: api.DeploymentStatusSuccessful},
+			false,
+			false,
+		},
+		"missing": {
+			nil,
+			true,
+			true,
+		},
+		"previous-version": {
+			&api.Deployment{JobVersion: 0, Status: api.DeploymentStatusSuccessful},
+			true,
+			true,
+		},
+		"running": {
+			&api.Deployment{JobVersion: 1, Status: api.DeploymentStatusRunning},
+			true,
+			true,
+		},
+		"failed": {
+			&api.Deployment{JobVersion: 1, Status: api.DeploymentStatusFailed},
+			false,
+			true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkDeployment(test.deployment, 1)
+			if !test.shouldFail {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			require.Equal(t, test.retryable, errors.Is(err, errJobNotHealthy))
+		})
+	}
+}
+
+func TestCheckAllocations(t *testing.T) {
+	t.Parallel()
+
+	alloc := func(version uint64, desired, status string) *api.AllocationListStub {
+		return &api.AllocationListStub{
+			ID:            "a",
+			JobVersion:    version,
+			DesiredStatus: desired,
+			ClientStatus:  status,
+		}
+	}
+
+	for name, test := range map[string]struct {
+		jobType    string
+		allocs     []*api.AllocationListStub
+		shouldFail bool
+	}{
+		"running": {
+			api.JobTypeSystem,
+			[]*api.AllocationListStub{
+				alloc(1, api.AllocDesiredStatusRun, api.AllocClientStatusRunning),
+				alloc(0, api.AllocDesiredStatusStop, api.AllocClientStatusComplete),
+			},
+			false,
+		},
+		"pending": {
+			api.JobTypeSystem,
+			[]*api.AllocationListStub{
+				alloc(1, api.AllocDesiredStatusRun, api.AllocClientStatusPending),
+			},
+			true,
+		},
+		"batch-complete": {
+			api.JobTypeBatch,
+			[]*api.AllocationListStub{
+				alloc(1, api.AllocDesiredStatusRun, api.AllocClientStatusComplete),
+			},
+			false,
+		},
+		"only-previous-version": {
+			api.JobTypeSystem,
+			[]*api.AllocationListStub{
+				alloc(0, api.AllocDesiredStatusRun, api.AllocClientStatusRunning),
+			},
+			true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkAllocations(test.allocs, test.jobType, 1)
+			if test.shouldFail {
+				require.ErrorIs(t, err, errJobNotHealthy)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRunningAllocationInfos(t *testing.T) {
+	t.Parallel()
+
+	allocs := []*api.AllocationListStub{
+		{
+			ID:           "b",
+			Name:         "vault.vault[1]",
+			TaskGroup:    "vault",
+			ClientStatus: api.AllocClientStatusRunning,
+			TaskStates:   map[string]*api.TaskState{"vault": {}, "agent": {}},
+		},
+		{
+			ID:           "a",
+			Name:         "vault.vault[0]",
+			TaskGroup:    "vault",
+			ClientStatus: api.AllocClientStatusRunning,
+			TaskStates:   map[string]*api.TaskState{"vault": {}},
+		},
+		{
+			ID:           "c",
+			Name:         "vault.vault[2]",
+			TaskGroup:    "vault",
+			ClientStatus: api.AllocClientStatusFailed,
+		},
+		{
+			ID:           "d",
+			Name:         "vault.proxy[0]",
+			TaskGroup:    "proxy",
+			ClientStatus: api.AllocClientStatusRunning,
+		},
+	}
+
+	infos := runningAllocationInfos(allocs, "vault")
+	require.Len(t, infos, 2)
+	require.Equal(t, "a", infos[0].ID)
+	require.Equal(t, "b", infos[1].ID)
+	require.Equal(t, []string{"agent", "vault"}, infos[1].Tasks)
+
+	require.Len(t, runningAllocationInfos(allocs, ""), 3)
+}
@@ -7,6 +7,9 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"slices"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/asaskevich/govalidator"
@@ -29,22 +32,37 @@ type artifactoryItem struct {
 var _ datarouter.DataSource = (*artifactoryItem)(nil)
 
 type artifactoryItemStateV1 struct {
-	ID            *tfString
-	Username      *tfString
-	Token         *tfString
-	Host          *tfString
-	Repo          *tfString
-	Path          *tfString
-	Name          *tfString
-	Properties    *tfStringMap
-	QueryTemplate *tfString
-	Results       *tfObjectSlice
+	ID               *tfString
+	Username         *tfString
+	Token            *tfString
+	Host             *tfString
+	Repo             *tfString
+	Path             *tfString
+	Name             *tfString
+	Properties       *tfStringMap
+	PropertyCriteria *tfObjectSlice
+	Sort             *tfStringSlice
+	SortOrder        *tfString
+	Offset           *tfNum
+	Limit            *tfNum
+	Selection        *tfString
+	VersionProperty  *tfString
+	QueryTemplate    *tfString
+	Results          *tfObjectSlice
 
 	failureHandlers
 }
 
 var _ state.State = (*artifactoryItemStateV1)(nil)
 
+// Artifactory item result selection modes.
+const (
+	artifactoryItemSelectionAll            = "all"
+	artifactoryItemSelectionHighestVersion = "highest_version"
+)
+
+const artifactoryItemDefaultVersionProperty = "productVersion"
+
 func newArtifactoryItem() *artifactoryItem {
 	return &artifactoryItem{
 		providerConfig: newProviderConfig(),
@@ -61,18 +79,32 @@ func newArtifactoryItemStateV1() *artifactoryItemStateV1 {
 		"size":   tftypes.String,
 	}
 
+	propertyCriteria := newTfObjectSlice()
+	propertyCriteria.AttrTypes = map[string]tftypes.Type{
+		"property": tftypes.String,
+		"operator": tftypes.String,
+		"values":   tftypes.List{ElementType: tftypes.String},
+	}
+
 	return &artifactoryItemStateV1{
-		ID:              newTfString(),
-		Username:        newTfString(),
-		Token:           newTfString(),
-		Host:            newTfString(),
-		Repo:            newTfString(),
-		Path:            newTfString(),
-		Name:            newTfString(),
-		Properties:      newTfStringMap(),
-		QueryTemplate:   newTfString(),
-		Results:         results,
-		failureHandlers: failureHandlers{},
+		ID:               newTfString(),
+		Username:         newTfString(),
+		Token:            newTfString(),
+		Host:             newTfString(),
+		Repo:             newTfString(),
+		Path:             newTfString(),
+		Name:             newTfString(),
+		Properties:       newTfStringMap(),
+		PropertyCriteria: propertyCriteria,
+		Sort:             newTfStringSlice(),
+		SortOrder:        newTfString(),
+		Offset:           newTfNum(),
+		Limit:            newTfNum(),
+		Selection:        newTfString(),
+		VersionProperty:  newTfString(),
+		QueryTemplate:    newTfString(),
+		Results:          results,
+		failureHandlers:  failureHandlers{},
 	}
 }
 
@@ -170,8 +202,22 @@ are expected and the datasource will automatically generate a query where and se
 is included in the ^items.find()^ query with a ^$match^ operator. The more specific your search criteria,
 via the ^path^, ^name^, and ^properties^, the fewer results you'll receive.
 
-For the ^query_template^, the ^repo^, ^path^, ^name^, and ^properties^ attributes are not automatically
-included in a query for you. Instead, you provide a Go text template which includes the entire query.
+More advanced comparisons can be made with ^property_criteria^. Each criterion compares a ^property^
+using an ^operator^ and must all be true for an item to match. Supported operators are ^$eq^, ^$ne^,
+^$gt^, ^$gte^, ^$lt^, ^$lte^, ^$match^, ^$nmatch^ and ^$in^. All operators except ^$in^ take a single
+value. ^$in^ matches any of the given ^values^.
+
+Results are sorted by the ^sort^ fields, ^modified^ by default, in the ^sort_order^, ^desc^ by default.
+The ^offset^ and ^limit^ attributes can be used to paginate through large result sets.
+
+If you want the latest build of a branch you can set ^selection^ to ^highest_version^. The results
+will be sorted by the semantic version of the ^version_property^, ^productVersion^ by default, and only
+the item with the highest version will be returned. Items without a valid version are ignored. As the
+selection is made after the query has been executed the ^offset^ and ^limit^ are applied to the query
+results before the selection, so you'll usually want to avoid setting a ^limit^ when selecting versions.
+
+For the ^query_template^, the ^repo^, ^path^, ^name^, ^properties^, ^property_criteria^, ^sort^,
+^sort_order^ and ^offset^ attributes are not automatically included in a query for you. Instead, you provide a Go text template which includes the entire query.
 This is an advanced option for hand crafted artisinal queries. As it is a Go template, you can provide
 pure text string or include [Go text template](https://pkg.go.dev/text/template#Template) directives.
 For the latter, you can expect the evaluation context to include an object with the following attributes:
@@ -179,6 +225,8 @@ For the latter, you can expect the evaluation context to include an object with
   - Path          string
   - Name          string
   - Properties    map[string]string
+  - Offset        string
+  - Limit         string
 
 For example:
 ^^^hcl
@@ -193,7 +241,7 @@ EOQ
 ^^^
 
 *NOTE*: The underlying implementation uses AQL to search for artifacts and uses the ^$match^ operator
-for every criteria. This means that you can use wildcards ^*^ for any field. See the [AQL developer guide](https://www.jfrog.com/confluence/display/JFROG/Artifactory+Query+Language) for more information.
+for the ^repo^, ^path^, ^name^ and ^properties^ criteria. This means that you can use wildcards ^*^ for any field. See the [AQL developer guide](https://www.jfrog.com/confluence/display/JFROG/Artifactory+Query+Language) for more information.
 `),
 			Attributes: []*tfprotov6.SchemaAttribute{
 				{
@@ -245,6 +293,54 @@ for every criteria. This means that you can use wildcards ^*^ for any field. See
 					Optional:    true,
 					Description: "A map of properties to match on",
 				},
+				{
+					Name:            "property_criteria",
+					Type:            s.PropertyCriteria.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description: docCaretToBacktick(`
+A list of property comparisons that must all match
+- ^property_criteria.property^ (String) The name of the property
+- ^property_criteria.operator^ (String) The comparison operator. One of ^$eq^, ^$ne^, ^$gt^, ^$gte^, ^$lt^, ^$lte^, ^$match^, ^$nmatch^ or ^$in^
+- ^property_criteria.values^ (List of String) The values to compare against. Only ^$in^ supports more than one value
+`),
+				},
+				{
+					Name:        "sort",
+					Type:        s.Sort.TFType(),
+					Optional:    true,
+					Description: "The fields to sort the results by. Defaults to 'modified'",
+				},
+				{
+					Name:        "sort_order",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The order to sort the results in. Either 'asc' or 'desc'. Defaults to 'desc'",
+				},
+				{
+					Name:        "offset",
+					Type:        tftypes.Number,
+					Optional:    true,
+					Description: "The number of results to skip",
+				},
+				{
+					Name:        "limit",
+					Type:        tftypes.Number,
+					Optional:    true,
+					Description: "The maximum number of results to return from the query",
+				},
+				{
+					Name:        "selection",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "How to select from the query results. Either 'all' or 'highest_version'. Defaults to 'all'",
+				},
+				{
+					Name:        "version_property",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The property to use as the version when the selection is 'highest_version'. Defaults to 'productVersion'",
+				},
 				{
 					Name:        "query_template",
 					Type:        tftypes.String,
@@ -284,6 +380,74 @@ func (s *artifactoryItemStateV1) Validate(ctx context.Context) error {
 		}
 	}
 
+	if criteria, ok := s.PropertyCriteria.GetObjects(); ok {
+		for _, criterion := range criteria {
+			property, _ := criterion["property"].(string)
+			if property == "" {
+				return ValidationError("property criteria must include a property", "property_criteria")
+			}
+
+			operator, _ := criterion["operator"].(string)
+			if !slices.Contains(artifactory.SupportedOperators, operator) {
+				return ValidationError(fmt.Sprintf(
+					"unsupported operator %q for property %s, must be one of: %s",
+					operator, property, strings.Join(artifactory.SupportedOperators, ", "),
+				), "property_criteria")
+			}
+
+			values, _ := criterion["values"].([]string)
+			if len(values) == 0 {
+				return ValidationError(
+					fmt.Sprintf("property criteria for %s must include at least one value", property),
+					"property_criteria",
+				)
+			}
+
+			if operator != artifactory.OperatorIn && len(values) > 1 {
+				return ValidationError(
+					fmt.Sprintf("the %s operator for property %s only supports a single value", operator, property),
+					"property_criteria",
+				)
+			}
+		}
+	}
+
+	if order, ok := s.SortOrder.Get(); ok {
+		if order != artifactory.SortOrderAsc && order != artifactory.SortOrderDesc {
+			return ValidationError(fmt.Sprintf(
+				"unsupported sort_order %q, must be one of %q or %q",
+				order, artifactory.SortOrderAsc, artifactory.SortOrderDesc,
+			), "sort_order")
+		}
+	}
+
+	if fields, ok := s.Sort.GetStrings(); ok {
+		if slices.Contains(fields, "") {
+			return ValidationError("sort fields cannot be empty", "sort")
+		}
+	}
+
+	if offset, ok := s.Offset.Get(); ok && offset < 0 {
+		return ValidationError("offset cannot be negative", "offset")
+	}
+
+	if limit, ok := s.Limit.Get(); ok && limit < 1 {
+		return ValidationError("limit must be greater than zero", "limit")
+	}
+
+	if selection, ok := s.Selection.Get(); ok {
+		if selection != artifactoryItemSelectionAll && selection != artifactoryItemSelectionHighestVersion {
+			return ValidationError(fmt.Sprintf(
+				"unsupported selection %q, must be one of %q or %q",
+				selection, artifactoryItemSelectionAll, artifactoryItemSelectionHighestVersion,
+			), "selection")
+		}
+	}
+
+	if prop, ok := s.VersionProperty.Get(); ok && prop == "" {
+		return ValidationError("version_property cannot be empty", "version_property")
+	}
+
 	if !s.QueryTemplate.Unknown {
 		templ, ok := s.QueryTemplate.Get()
 		if ok && templ != "" {
@@ -303,16 +467,23 @@ func (s *artifactoryItemStateV1) Validate(ctx context.Context) error {
 // FromTerraform5Value is a callback to unmarshal from the		tftypes.Vault with As().
 func (s *artifactoryItemStateV1) FromTerraform5Value(val tftypes.Value) error {
 	_, err := mapAttributesTo(val, map[string]any{
-		"id":             s.ID,
-		"username":       s.Username,
-		"token":          s.Token,
-		"host":           s.Host,
-		"repo":           s.Repo,
-		"path":           s.Path,
-		"name":           s.Name,
-		"properties":     s.Properties,
-		"query_template": s.QueryTemplate,
-		"results":        s.Results,
+		"id":                s.ID,
+		"username":          s.Username,
+		"token":             s.Token,
+		"host":              s.Host,
+		"repo":              s.Repo,
+		"path":              s.Path,
+		"name":              s.Name,
+		"properties":        s.Properties,
+		"property_criteria": s.PropertyCriteria,
+		"sort":              s.Sort,
+		"sort_order":        s.SortOrder,
+		"offset":            s.Offset,
+		"limit":             s.Limit,
+		"selection":         s.Selection,
+		"version_property":  s.VersionProperty,
+		"query_template":    s.QueryTemplate,
+		"results":           s.Results,
 	})
 
 	return err
@@ -321,32 +492,46 @@ func (s *artifactoryItemStateV1) FromTerraform5Value(val tftypes.Value) error {
 // Terraform5Type is the file state tftypes.Type.
 func (s *artifactoryItemStateV1) Terraform5Type() tftypes.Type {
 	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
-		"id":             s.ID.TFType(),
-		"username":       s.Username.TFType(),
-		"token":          s.Token.TFType(),
-		"host":           s.Host.TFType(),
-		"repo":           s.Repo.TFType(),
-		"path":           s.Path.TFType(),
-		"name":           s.Name.TFType(),
-		"properties":     s.Properties.TFType(),
-		"query_template": s.QueryTemplate.TFType(),
-		"results":        s.Results.TFType(),
+		"id":                s.ID.TFType(),
+		"username":          s.Username.TFType(),
+		"token":             s.Token.TFType(),
+		"host":              s.Host.TFType(),
+		"repo":              s.Repo.TFType(),
+		"path":              s.Path.TFType(),
+		"name":              s.Name.TFType(),
+		"properties":        s.Properties.TFType(),
+		"property_criteria": s.PropertyCriteria.TFType(),
+		"sort":              s.Sort.TFType(),
+		"sort_order":        s.SortOrder.TFType(),
+		"offset":            s.Offset.TFType(),
+		"limit":             s.Limit.TFType(),
+		"selection":         s.Selection.TFType(),
+		"version_property":  s.VersionProperty.TFType(),
+		"query_template":    s.QueryTemplate.TFType(),
+		"results":           s.Results.TFType(),
 	}}
 }
 
 // Terraform5Value is the file state tftypes.Value.
 func (s *artifactoryItemStateV1) Terraform5Value() tftypes.Value {
 	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
-		"id":             s.ID.TFValue(),
-		"username":       s.Username.TFValue(),
-		"token":          s.Token.TFValue(),
-		"host":           s.Host.TFValue(),
-		"repo":           s.Repo.TFValue(),
-		"path":           s.Path.TFValue(),
-		"name":           s.Name.TFValue(),
-		"properties":     s.Properties.TFValue(),
-		"query_template": s.QueryTemplate.TFValue(),
-		"results":        s.Results.TFValue(),
+		"id":                s.ID.TFValue(),
+		"username":          s.Username.TFValue(),
+		"token":             s.Token.TFValue(),
+		"host":              s.Host.TFValue(),
+		"repo":              s.Repo.TFValue(),
+		"path":              s.Path.TFValue(),
+		"name":              s.Name.TFValue(),
+		"properties":        s.Properties.TFValue(),
+		"property_criteria": s.PropertyCriteria.TFValue(),
+		"sort":              s.Sort.TFValue(),
+		"sort_order":        s.SortOrder.TFValue(),
+		"offset":            s.Offset.TFValue(),
+		"limit":             s.Limit.TFValue(),
+		"selection":         s.Selection.TFValue(),
+		"version_property":  s.VersionProperty.TFValue(),
+		"query_template":    s.QueryTemplate.TFValue(),
+		"results":           s.Results.TFValue(),
 	})
 }
 
@@ -378,6 +563,29 @@ func (s *artifactoryItemStateV1) Search(ctx context.Context) error {
 	if props, ok := s.Properties.GetStrings(); ok {
 		reqArgs = append(reqArgs, artifactory.WithProperties(props))
 	}
+	if criteria, ok := s.PropertyCriteria.GetObjects(); ok {
+		for _, criterion := range criteria {
+			property, _ := criterion["property"].(string)
+			operator, _ := criterion["operator"].(string)
+			values, _ := criterion["values"].([]string)
+			reqArgs = append(reqArgs, artifactory.WithPropertyCriterion(property, operator, values...))
+		}
+	}
+	if fields, ok := s.Sort.GetStrings(); ok && len(fields) > 0 {
+		order, ok := s.SortOrder.Get()
+		if !ok {
+			order = artifactory.SortOrderDesc
+		}
+		reqArgs = append(reqArgs, artifactory.WithSort(order, fields...))
+	} else if order, ok := s.SortOrder.Get(); ok {
+		reqArgs = append(reqArgs, artifactory.WithSort(order, "modified"))
+	}
+	if offset, ok := s.Offset.Get(); ok {
+		reqArgs = append(reqArgs, artifactory.WithOffset(strconv.Itoa(offset)))
+	}
+	if limit, ok := s.Limit.Get(); ok {
+		reqArgs = append(reqArgs, artifactory.WithLimit(strconv.Itoa(limit)))
+	}
 	if query, ok := s.QueryTemplate.Get(); ok {
 		if ok && query != "" {
 			t, err := template.New("query").Parse(query)
@@ -385,7 +593,9 @@ func (s *artifactoryItemStateV1) Search(ctx context.Context) error {
 				return fmt.Errorf("search failed: invalid query template: %w", err)
 			}
 
-			reqArgs = append(reqArgs, artifactory.WithLimit("1"))
+			if _, ok := s.Limit.Get(); !ok {
+				reqArgs = append(reqArgs, artifactory.WithLimit("1"))
+			}
 			reqArgs = append(reqArgs, artifactory.WithQueryTemplate(t))
 		}
 	}
@@ -396,6 +606,19 @@ func (s *artifactoryItemStateV1) Search(ctx context.Context) error {
 		return fmt.Errorf("search failed, due to: %w", err)
 	}
 
+	if selection, ok := s.Selection.Get(); ok && selection == artifactoryItemSelectionHighestVersion {
+		prop, ok := s.VersionProperty.Get()
+		if !ok {
+			prop = artifactoryItemDefaultVersionProperty
+		}
+
+		res.SortByVersionProperty(prop)
+		if len(res.Results) == 0 {
+			return fmt.Errorf("search failed: no items with a valid %s property were found", prop)
+		}
+		res.Results = res.Results[:1]
+	}
+
 	results := []*tfObject{}
 	for _, result := range res.Results {
 		r := newTfObject()
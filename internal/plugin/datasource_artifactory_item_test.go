@@ -5,6 +5,9 @@ package plugin
 
 import (
 	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
 	"testing"
@@ -212,3 +215,135 @@ output "name" {
 		})
 	}
 }
+
+func TestArtifactoryItemSearchHighestVersion(t *testing.T) {
+	t.Parallel()
+
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		query = string(body)
+
+		_, _ = w.Write([]byte(`{"results": [
+  { "repo": "vault", "path": "main", "name": "vault_1.15.2.zip", "properties": [{ "key": "productVersion", "value": "1.15.2" }] },
+  { "repo": "vault", "path": "main", "name": "vault_1.16.0.zip", "properties": [{ "key": "productVersion", "value": "1.16.0" }] },
+  { "repo": "vault", "path": "main", "name": "vault_main.zip", "properties": [{ "key": "productVersion", "value": "main" }] }
+]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	item := newArtifactoryItemStateV1()
+	item.Token.Set("token")
+	item.Host.Set(srv.URL)
+	item.Repo.Set("vault")
+	item.PropertyCriteria.SetObjects([]map[string]any{
+		{"property": "branch", "operator": "$in", "values": []string{"main", "release/1.16.x"}},
+	})
+	item.Sort.SetStrings([]string{"created"})
+	item.SortOrder.Set("asc")
+	item.Offset.Set(10)
+	item.Selection.Set("highest_version")
+	require.NoError(t, item.Validate(t.Context()))
+	require.NoError(t, item.Search(t.Context()))
+
+	require.Contains(t, query, `"$or": [{ "@branch": { "$eq": "main" } }, { "@branch": { "$eq": "release/1.16.x" } }]`)
+	require.Contains(t, query, `.sort({"$asc": ["created"]}) .offset(10)`)
+	require.NotContains(t, query, ".limit(")
+
+	results, ok := item.Results.Get()
+	require.True(t, ok)
+	require.Len(t, results, 1)
+	require.Equal(t, srv.URL+"/vault/main/vault_1.16.0.zip", results[0].Value()["url"].(*tfString).Value())
+}
+
+func TestArtifactoryItemValidate(t *testing.T) {
+	t.Parallel()
+
+	for desc, test := range map[string]struct {
+		configure func(*artifactoryItemStateV1)
+		fails     bool
+	}{
+		"valid": {
+			configure: func(s *artifactoryItemStateV1) {
+				s.PropertyCriteria.SetObjects([]map[string]any{
+					{"property": "productVersion", "operator": "$gte", "values": []string{"1.15.0"}},
+					{"property": "EDITION", "operator": "$in", "values": []string{"ent", "ent.hsm"}},
+				})
+				s.Sort.SetStrings([]string{"name"})
+				s.SortOrder.Set("asc")
+				s.Offset.Set(0)
+				s.Limit.Set(10)
+				s.Selection.Set("highest_version")
+				s.VersionProperty.Set("version")
+			},
+		},
+		"unsupported operator": {
+			configure: func(s *artifactoryItemStateV1) {
+				s.PropertyCriteria.SetObjects([]map[string]any{
+					{"property": "productVersion", "operator": "$like", "values": []string{"1.15.0"}},
+				})
+			},
+			fails: true,
+		},
+		"multiple values": {
+			configure: func(s *artifactoryItemStateV1) {
+				s.PropertyCriteria.SetObjects([]map[string]any{
+					{"property": "productVersion", "operator": "$eq", "values": []string{"1.15.0", "1.16.0"}},
+				})
+			},
+			fails: true,
+		},
+		"no values": {
+			configure: func(s *artifactoryItemStateV1) {
+				s.PropertyCriteria.SetObjects([]map[string]any{
+					{"property": "productVersion", "operator": "$in", "values": []string{}},
+				})
+			},
+			fails: true,
+		},
+		"no property": {
+			configure: func(s *artifactoryItemStateV1) {
+				s.PropertyCriteria.SetObjects([]map[string]any{
+					{"property": "", "operator": "$eq", "values": []string{"1.15.0"}},
+				})
+			},
+			fails: true,
+		},
+		"invalid sort order": {
+			configure: func(s *artifactoryItemStateV1) { s.SortOrder.Set("up") },
+			fails:     true,
+		},
+		"negative offset": {
+			configure: func(s *artifactoryItemStateV1) { s.Offset.Set(-1) },
+			fails:     true,
+		},
+		"zero limit": {
+			configure: func(s *artifactoryItemStateV1) { s.Limit.Set(0) },
+			fails:     true,
+		},
+		"invalid selection": {
+			configure: func(s *artifactoryItemStateV1) { s.Selection.Set("latest") },
+			fails:     true,
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			item := newArtifactoryItemStateV1()
+			item.Token.Set("token")
+			item.Host.Set("https://artifactory.example.com/artifactory")
+			test.configure(item)
+
+			err := item.Validate(t.Context())
+			if test.fails {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,484 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/consul"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/datarouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+)
+
+type consulState struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ datarouter.DataSource = (*consulState)(nil)
+
+type consulStateStateV1 struct {
+	ID              *tfString
+	ConsulAddr      *tfString
+	SystemdUnitName *tfString
+	TLS             *tlsClientConfig
+	Transport       *embeddedTransportV1
+	Hostname        *tfString
+	Leader          *tfString
+	NodeChecks      *tfObjectSlice
+	PassingChecks   *tfObjectSlice
+	RaftPeers       *tfObjectSlice
+
+	failureHandlers
+}
+
+var _ state.State = (*consulStateStateV1)(nil)
+
+func newConsulState() *consulState {
+	return &consulState{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newConsulStateStateV1() *consulStateStateV1 {
+	nodeChecks := newTfObjectSlice()
+	nodeChecks.AttrTypes = consulHealthCheckAttrTypes()
+
+	passingChecks := newTfObjectSlice()
+	passingChecks.AttrTypes = consulHealthCheckAttrTypes()
+
+	raftPeers := newTfObjectSlice()
+	raftPeers.AttrTypes = map[string]tftypes.Type{
+		"address":          tftypes.String,
+		"id":               tftypes.String,
+		"leader":           tftypes.Bool,
+		"node":             tftypes.String,
+		"protocol_version": tftypes.String,
+		"voter":            tftypes.Bool,
+	}
+
+	return &consulStateStateV1{
+		ID:              newTfString(),
+		ConsulAddr:      newTfString(),
+		SystemdUnitName: newTfString(),
+		TLS:             newTLSClientConfig(),
+		Transport:       newEmbeddedTransport(),
+		Hostname:        newTfString(),
+		Leader:          newTfString(),
+		NodeChecks:      nodeChecks,
+		PassingChecks:   passingChecks,
+		RaftPeers:       raftPeers,
+		failureHandlers: failureHandlers{},
+	}
+}
+
+func consulHealthCheckAttrTypes() map[string]tftypes.Type {
+	return map[string]tftypes.Type{
+		"check_id": tftypes.String,
+		"name":     tftypes.String,
+		"node":     tftypes.String,
+		"notes":    tftypes.String,
+		"output":   tftypes.String,
+		"status":   tftypes.String,
+	}
+}
+
+func (d *consulState) Name() string {
+	return "enos_consul_state"
+}
+
+func (d *consulState) Schema() *tfprotov6.Schema {
+	return newConsulStateStateV1().Schema()
+}
+
+func (d *consulState) SetProviderConfig(meta tftypes.Value) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.providerConfig.FromTerraform5Value(meta)
+}
+
+func (d *consulState) GetProviderConfig() (*config, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.providerConfig.Copy()
+}
+
+// ValidateDataResourceConfig is the request Terraform sends when it wants to
+// validate the data source's configuration.
+func (d *consulState) ValidateDataResourceConfig(ctx context.Context, req tfprotov6.ValidateDataResourceConfigRequest, res *tfprotov6.ValidateDataResourceConfigResponse) {
+	select {
+	case <-ctx.Done():
+		res.Diagnostics = append(res.Diagnostics, ctxToDiagnostic(ctx))
+		return
+	default:
+	}
+
+	// unmarshal it to our known type to ensure whatever was passed in matches
+	// the correct schema.
+	newConfig := newConsulStateStateV1()
+	err := unmarshal(newConfig, req.Config)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+	}
+}
+
+// ReadDataSource is the request Terraform sends when it wants to get the latest
+// state for the data source.
+func (d *consulState) ReadDataSource(ctx context.Context, req tfprotov6.ReadDataSourceRequest, res *tfprotov6.ReadDataSourceResponse) {
+	select {
+	case <-ctx.Done():
+		res.Diagnostics = append(res.Diagnostics, ctxToDiagnostic(ctx))
+		return
+	default:
+	}
+
+	newState := newConsulStateStateV1()
+
+	err := unmarshal(newState, req.Config)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+		return
+	}
+
+	if err = newState.Validate(ctx); err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Validation Error", err))
+		return
+	}
+
+	providerConfig, err := d.GetProviderConfig()
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic(
+			"Read Error",
+			fmt.Errorf("failed to get provider config, due to: %w", err),
+		))
+
+		return
+	}
+
+	et, err := newState.Transport.Copy()
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic(
+			"Transport Error",
+			fmt.Errorf("failed to copy embedded transport, due to: %w", err),
+		))
+
+		return
+	}
+
+	if _, err = et.ApplyDefaults(providerConfig.Transport); err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic(
+			"Transport Error",
+			fmt.Errorf("failed to apply transport defaults, due to: %w", err),
+		))
+
+		return
+	}
+
+	if err = et.Validate(ctx); err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Validation Error", err))
+		return
+	}
+
+	client, err := et.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	nodeState, err := consul.GetState(timeoutCtx, client, newState.buildStateRequest())
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic(
+			"Consul State Error",
+			fmt.Errorf("failed to get the consul state, due to: %w", err),
+		))
+
+		return
+	}
+
+	newState.ID.Set("static")
+	newState.setState(nodeState)
+
+	res.State, err = state.Marshal(newState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *consulStateStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_consul_state^ datasource reads the state of a Consul agent and the cluster it is a
+member of. The state is read from the agent's HTTP API with ^enos-flight-control^, which is installed
+on the target if necessary.
+
+As this is a datasource it will be read during plan time unless it depends on information that is
+not available until apply. Make the datasource depend on the resources that start the Consul cluster.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        s.ID.TFType(),
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:            "consul_addr",
+					Type:            s.ConsulAddr.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The address of the Consul HTTP API. Defaults to `http://127.0.0.1:8500`",
+				},
+				{
+					Name:            "unit_name",
+					Type:            s.SystemdUnitName.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The name of the Consul systemd unit. Defaults to `consul`",
+				},
+				s.TLS.SchemaAttribute("Consul"),
+				s.Transport.SchemaAttributeTransport(supportsSSH),
+				{
+					Name:        "hostname",
+					Type:        s.Hostname.TFType(),
+					Computed:    true,
+					Description: "The hostname of the Consul agent",
+				},
+				{
+					Name:        "leader",
+					Type:        s.Leader.TFType(),
+					Computed:    true,
+					Description: "The node name of the raft leader. It is empty if the cluster does not have a leader",
+				},
+				{
+					Name:            "node_checks",
+					Type:            s.NodeChecks.TFType(),
+					Computed:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description: docCaretToBacktick(`
+The health checks of the Consul agent's node.
+- ^node_checks[].check_id^ (String) The ID of the check
+- ^node_checks[].name^ (String) The name of the check
+- ^node_checks[].node^ (String) The node name
+- ^node_checks[].notes^ (String) The check notes
+- ^node_checks[].output^ (String) The output of the last check
+- ^node_checks[].status^ (String) The check status, e.g. ^passing^ or ^critical^
+`),
+				},
+				{
+					Name:            "passing_checks",
+					Type:            s.PassingChecks.TFType(),
+					Computed:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description: docCaretToBacktick(`
+All passing health checks in the cluster. The attributes are the same as ^node_checks^.
+`),
+				},
+				{
+					Name:            "raft_peers",
+					Type:            s.RaftPeers.TFType(),
+					Computed:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description: docCaretToBacktick(`
+The raft peers of the Consul cluster.
+- ^raft_peers[].address^ (String) The raft address of the server
+- ^raft_peers[].id^ (String) The ID of the server
+- ^raft_peers[].leader^ (Bool) Whether or not the server is the leader
+- ^raft_peers[].node^ (String) The node name of the server
+- ^raft_peers[].protocol_version^ (String) The raft protocol version of the server
+- ^raft_peers[].voter^ (Bool) Whether or not the server is a voter
+`),
+				},
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *consulStateStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	return s.TLS.Validate()
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Consul with As().
+func (s *consulStateStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":             s.ID,
+		"consul_addr":    s.ConsulAddr,
+		"hostname":       s.Hostname,
+		"leader":         s.Leader,
+		"node_checks":    s.NodeChecks,
+		"passing_checks": s.PassingChecks,
+		"raft_peers":     s.RaftPeers,
+		"unit_name":      s.SystemdUnitName,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.TLS.FromTerraform5Value(vals["tls"])
+	if err != nil {
+		return err
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *consulStateStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":             s.ID.TFType(),
+		"consul_addr":    s.ConsulAddr.TFType(),
+		"hostname":       s.Hostname.TFType(),
+		"leader":         s.Leader.TFType(),
+		"node_checks":    s.NodeChecks.TFType(),
+		"passing_checks": s.PassingChecks.TFType(),
+		"raft_peers":     s.RaftPeers.TFType(),
+		"tls":            s.TLS.Terraform5Type(),
+		"transport":      s.Transport.Terraform5Type(),
+		"unit_name":      s.SystemdUnitName.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *consulStateStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":             s.ID.TFValue(),
+		"consul_addr":    s.ConsulAddr.TFValue(),
+		"hostname":       s.Hostname.TFValue(),
+		"leader":         s.Leader.TFValue(),
+		"node_checks":    s.NodeChecks.TFValue(),
+		"passing_checks": s.PassingChecks.TFValue(),
+		"raft_peers":     s.RaftPeers.TFValue(),
+		"tls":            s.TLS.Terraform5Value(),
+		"transport":      s.Transport.Terraform5Value(),
+		"unit_name":      s.SystemdUnitName.TFValue(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *consulStateStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+func (s *consulStateStateV1) buildStateRequest() *consul.StateRequest {
+	opts := []consul.StateRequestOpt{
+		consul.WithStateRequestFlightControlUseHomeDir(),
+		consul.WithStateRequestTLSConfig(s.TLS.TLSConfig()),
+	}
+
+	if addr, ok := s.ConsulAddr.Get(); ok {
+		opts = append(opts, consul.WithStateRequestConsulAddr(addr))
+	}
+
+	if unit, ok := s.SystemdUnitName.Get(); ok {
+		opts = append(opts, consul.WithStateRequestSystemdUnitName(unit))
+	}
+
+	return consul.NewStateRequest(opts...)
+}
+
+// setState sets the computed attributes from the consul state.
+func (s *consulStateStateV1) setState(nodeState *consul.State) {
+	s.Hostname.Set(nodeState.Hostname())
+
+	s.Leader.Set("")
+	if leader, ok := nodeState.RaftConfigurationResponse.Leader(); ok {
+		s.Leader.Set(leader.Node)
+	}
+
+	s.NodeChecks.Set(consulHealthChecksToTfObjects(nodeState.HealthNodeResponse.Nodes))
+	s.PassingChecks.Set(consulHealthChecksToTfObjects(nodeState.HealthStatePassingResponse.Nodes))
+
+	peers := []*tfObject{}
+	if nodeState.RaftConfigurationResponse != nil {
+		for _, server := range nodeState.Servers {
+			peer := newTfObject()
+			address := newTfString()
+			address.Set(server.Address)
+			id := newTfString()
+			id.Set(server.ID)
+			leader := newTfBool()
+			leader.Set(server.Leader)
+			node := newTfString()
+			node.Set(server.Node)
+			protocolVersion := newTfString()
+			protocolVersion.Set(server.ProtocolVersion.String())
+			voter := newTfBool()
+			voter.Set(server.Voter)
+
+			peer.Set(map[string]any{
+				"address":          address,
+				"id":               id,
+				"leader":           leader,
+				"node":             node,
+				"protocol_version": protocolVersion,
+				"voter":            voter,
+			})
+			peers = append(peers, peer)
+		}
+	}
+	s.RaftPeers.Set(peers)
+}
+
+func consulHealthChecksToTfObjects(checks []*consul.NodeHealth) []*tfObject {
+	objs := []*tfObject{}
+	for _, check := range checks {
+		if check == nil {
+			continue
+		}
+
+		obj := newTfObject()
+		checkID := newTfString()
+		checkID.Set(check.CheckID)
+		name := newTfString()
+		name.Set(check.Name)
+		node := newTfString()
+		node.Set(check.Node)
+		notes := newTfString()
+		notes.Set(check.Notes)
+		output := newTfString()
+		output.Set(check.Output)
+		status := newTfString()
+		status.Set(check.Status)
+
+		obj.Set(map[string]any{
+			"check_id": checkID,
+			"name":     name,
+			"node":     node,
+			"notes":    notes,
+			"output":   output,
+			"status":   status,
+		})
+		objs = append(objs, obj)
+	}
+
+	return objs
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/consul"
+)
+
+func TestConsulStateSetState(t *testing.T) {
+	t.Parallel()
+
+	nodeState := consul.NewState()
+	nodeState.AgentHostResponse = &consul.AgentHostResponse{Host: &consul.AgentHostResponseHost{Hostname: "consul-0"}}
+	nodeState.HealthNodeResponse = &consul.HealthNodeResponse{Nodes: []*consul.NodeHealth{
+		{Node: "consul-0", CheckID: "serfHealth", Name: "Serf Health Status", Status: "passing"},
+	}}
+	nodeState.HealthStatePassingResponse = &consul.HealthStatePassingResponse{Nodes: []*consul.NodeHealth{
+		{Node: "consul-0", CheckID: "serfHealth", Name: "Serf Health Status", Status: "passing"},
+		{Node: "consul-1", CheckID: "serfHealth", Name: "Serf Health Status", Status: "passing"},
+	}}
+	nodeState.RaftConfigurationResponse = &consul.RaftConfigurationResponse{Servers: []*consul.RaftServer{
+		{ID: "a", Node: "consul-0", Address: "10.0.0.1:8300", Voter: true, ProtocolVersion: "3"},
+		{ID: "b", Node: "consul-1", Address: "10.0.0.2:8300", Voter: true, Leader: true, ProtocolVersion: "3"},
+	}}
+
+	s := newConsulStateStateV1()
+	s.setState(nodeState)
+
+	require.Equal(t, "consul-0", s.Hostname.Value())
+	require.Equal(t, "consul-1", s.Leader.Value())
+
+	peers, ok := s.RaftPeers.Get()
+	require.True(t, ok)
+	require.Len(t, peers, 2)
+	peer, ok := peers[1].Get()
+	require.True(t, ok)
+	require.Equal(t, "consul-1", peer["node"].(*tfString).Value())
+	require.True(t, peer["leader"].(*tfBool).Value())
+
+	checks, ok := s.NodeChecks.Get()
+	require.True(t, ok)
+	require.Len(t, checks, 1)
+	checks, ok = s.PassingChecks.Get()
+	require.True(t, ok)
+	require.Len(t, checks, 2)
+
+	// Make sure we can marshal our computed state
+	val := s.Terraform5Value()
+	_, err := tfprotov6.NewDynamicValue(val.Type(), val)
+	require.NoError(t, err)
+}
@@ -0,0 +1,438 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/nomad"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/datarouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+)
+
+type allocationInfoGetter func(ctx context.Context, state nomadAllocationsStateV1) ([]nomad.AllocationInfo, error)
+
+var defaultAllocationInfoGetter allocationInfoGetter = func(ctx context.Context, state nomadAllocationsStateV1) ([]nomad.AllocationInfo, error) {
+	client, err := nomad.NewClient(nomad.ClientCfg{
+		Host:     state.Host.Value(),
+		SecretID: state.SecretID.Value(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a Nomad Client, due to: %w", err)
+	}
+	defer client.Close()
+
+	request := nomad.ListAllocationsRequest{
+		Namespace: state.Namespace.Value(),
+		JobID:     state.JobID.Value(),
+		TaskGroup: state.TaskGroup.Value(),
+	}
+
+	if count, ok := state.ExpectedCount.Get(); ok {
+		request.ExpectedCount = count
+	}
+
+	if timeout, ok := state.WaitTimeout.Get(); ok {
+		var timeoutDuration time.Duration
+		timeoutDuration, err = time.ParseDuration(timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse 'wait_timeout': %s", timeout)
+		}
+		request.WaitTimeout = timeoutDuration
+	} else {
+		request.WaitTimeout = defaultWaitTimeout
+	}
+
+	allocs, err := client.ListAllocations(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query allocations, due to: %w", err)
+	}
+
+	return allocs, nil
+}
+
+type nomadAllocations struct {
+	providerConfig       *config
+	allocationInfoGetter allocationInfoGetter
+}
+
+var _ datarouter.DataSource = (*nomadAllocations)(nil)
+
+type nomadAllocationsStateV1 struct {
+	ID            *tfString
+	Host          *tfString
+	SecretID      *tfString
+	Namespace     *tfString
+	JobID         *tfString
+	TaskGroup     *tfString
+	ExpectedCount *tfNum
+	WaitTimeout   *tfString
+	Allocations   *tfObjectSlice
+	Transports    *tfObjectSlice
+
+	failureHandlers
+}
+
+var _ state.State = (*nomadAllocationsStateV1)(nil)
+
+func newNomadAllocations() *nomadAllocations {
+	return &nomadAllocations{
+		providerConfig:       newProviderConfig(),
+		allocationInfoGetter: defaultAllocationInfoGetter,
+	}
+}
+
+func newNomadAllocationsStateV1() *nomadAllocationsStateV1 {
+	allocations := newTfObjectSlice()
+	allocations.AttrTypes = map[string]tftypes.Type{
+		"id":            tftypes.String,
+		"name":          tftypes.String,
+		"namespace":     tftypes.String,
+		"job_id":        tftypes.String,
+		"task_group":    tftypes.String,
+		"node_id":       tftypes.String,
+		"node_name":     tftypes.String,
+		"client_status": tftypes.String,
+		"tasks": tftypes.List{
+			ElementType: tftypes.String,
+		},
+	}
+
+	transports := newTfObjectSlice()
+	transports.AttrTypes = map[string]tftypes.Type{
+		"host":          tftypes.String,
+		"secret_id":     tftypes.String,
+		"namespace":     tftypes.String,
+		"allocation_id": tftypes.String,
+		"task_name":     tftypes.String,
+	}
+
+	return &nomadAllocationsStateV1{
+		ID:              newTfString(),
+		Host:            newTfString(),
+		SecretID:        newTfString(),
+		Namespace:       newTfString(),
+		JobID:           newTfString(),
+		TaskGroup:       newTfString(),
+		ExpectedCount:   newTfNum(),
+		WaitTimeout:     newTfString(),
+		Allocations:     allocations,
+		Transports:      transports,
+		failureHandlers: failureHandlers{},
+	}
+}
+
+func (d *nomadAllocations) Name() string {
+	return "enos_nomad_allocations"
+}
+
+func (d *nomadAllocations) Schema() *tfprotov6.Schema {
+	return newNomadAllocationsStateV1().Schema()
+}
+
+func (d *nomadAllocations) SetProviderConfig(meta tftypes.Value) error {
+	return d.providerConfig.FromTerraform5Value(meta)
+}
+
+// ValidateDataResourceConfig is the request Terraform sends when it wants to
+// validate the data source's configuration.
+func (d *nomadAllocations) ValidateDataResourceConfig(ctx context.Context, req tfprotov6.ValidateDataResourceConfigRequest, res *tfprotov6.ValidateDataResourceConfigResponse) {
+	select {
+	case <-ctx.Done():
+		res.Diagnostics = append(res.Diagnostics, ctxToDiagnostic(ctx))
+		return
+	default:
+	}
+
+	// unmarshal it to our known type to ensure whatever was passed in matches
+	// the correct schema.
+	state := newNomadAllocationsStateV1()
+	err := unmarshal(state, req.Config)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+	}
+}
+
+// ReadDataSource is the request Terraform sends when it wants to get the latest
+// state for the data source.
+func (d *nomadAllocations) ReadDataSource(ctx context.Context, req tfprotov6.ReadDataSourceRequest, res *tfprotov6.ReadDataSourceResponse) {
+	select {
+	case <-ctx.Done():
+		res.Diagnostics = append(res.Diagnostics, ctxToDiagnostic(ctx))
+		return
+	default:
+	}
+
+	allocState := newNomadAllocationsStateV1()
+
+	err := unmarshal(allocState, req.Config)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+		return
+	}
+
+	if err = allocState.Validate(ctx); err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Validation Error", err))
+		return
+	}
+
+	allocState.ID.Set("static")
+
+	allocs, err := d.allocationInfoGetter(ctx, *allocState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Allocation Query Error", err))
+		return
+	}
+
+	allocResults := make([]*tfObject, len(allocs))
+	var transportResults []*tfObject
+	for i := range allocs {
+		alloc := newTfObject()
+		attrs := map[string]any{}
+		for name, val := range map[string]string{
+			"id":            allocs[i].ID,
+			"name":          allocs[i].Name,
+			"namespace":     allocs[i].Namespace,
+			"job_id":        allocs[i].JobID,
+			"task_group":    allocs[i].TaskGroup,
+			"node_id":       allocs[i].NodeID,
+			"node_name":     allocs[i].NodeName,
+			"client_status": allocs[i].ClientStatus,
+		} {
+			str := newTfString()
+			str.Set(val)
+			attrs[name] = str
+		}
+
+		tasks := newTfStringSlice()
+		tasks.SetStrings(allocs[i].Tasks)
+		attrs["tasks"] = tasks
+
+		alloc.Set(attrs)
+		allocResults[i] = alloc
+
+		for _, t := range allocs[i].Tasks {
+			transport := newTfObject()
+			host := newTfString()
+			host.Set(allocState.Host.Val)
+			secretID := newTfString()
+			if id, ok := allocState.SecretID.Get(); ok {
+				secretID.Set(id)
+			}
+			namespace := newTfString()
+			if allocs[i].Namespace != "" {
+				namespace.Set(allocs[i].Namespace)
+			}
+			allocationID := newTfString()
+			allocationID.Set(allocs[i].ID)
+			taskName := newTfString()
+			taskName.Set(t)
+
+			transport.Set(map[string]any{
+				"host":          host,
+				"secret_id":     secretID,
+				"namespace":     namespace,
+				"allocation_id": allocationID,
+				"task_name":     taskName,
+			})
+
+			transportResults = append(transportResults, transport)
+		}
+	}
+	allocState.Allocations.Set(allocResults)
+	allocState.Transports.Set(transportResults)
+
+	res.State, err = state.Marshal(allocState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+	}
+}
+
+// Schema the nomadAllocationsStateV1 Schema.
+func (s *nomadAllocationsStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_nomad_allocations^ datasource can be used to query a Nomad cluster for the running
+allocations of a job. It returns the allocations and a nomad transport for each task in each
+allocation that can be used with any resource that supports the nomad transport.
+
+**Important Note:**
+
+As this is a datasource it will be run during plan time, unless the datasource
+depends on information not available till apply. Therefore, if this datasource is used in a module
+where the job is being created at the same time, you must make the datasource depend
+either directly or indirectly on the ^enos_nomad_job^ resource.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "host",
+					Description: "The address of the Nomad HTTP API, e.g. http://23.56.78.9:4646",
+					Type:        tftypes.String,
+					Required:    true,
+				},
+				{
+					Name:        "secret_id",
+					Description: "The Nomad ACL token secret ID to use when the cluster has ACLs enabled",
+					Type:        tftypes.String,
+					Optional:    true,
+					Sensitive:   true,
+				},
+				{
+					Name:        "namespace",
+					Description: "The namespace of the job. If not set the default namespace is used",
+					Type:        tftypes.String,
+					Optional:    true,
+				},
+				{
+					Name:        "job_id",
+					Description: "The ID of the job to query allocations for",
+					Type:        tftypes.String,
+					Required:    true,
+				},
+				{
+					Name:        "task_group",
+					Description: "Only return allocations for this task group",
+					Type:        tftypes.String,
+					Optional:    true,
+				},
+				{
+					Name:        "expected_count",
+					Description: "The number of running allocations that are expected to be found matching the query",
+					Type:        tftypes.Number,
+					Optional:    true,
+				},
+				{
+					Name:        "wait_timeout",
+					Description: "The amount of time to wait for the expected number of allocations to be running. If not provided a default of 1m will be used",
+					Type:        tftypes.String,
+					Optional:    true,
+				},
+				{
+					Name:            "allocations",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description: docCaretToBacktick(`
+A list of the running allocations that match the query
+- ^allocations[].id^ (String) The ID of the allocation
+- ^allocations[].name^ (String) The name of the allocation
+- ^allocations[].namespace^ (String) The namespace of the allocation
+- ^allocations[].job_id^ (String) The ID of the job
+- ^allocations[].task_group^ (String) The task group of the allocation
+- ^allocations[].node_id^ (String) The ID of the client node running the allocation
+- ^allocations[].node_name^ (String) The name of the client node running the allocation
+- ^allocations[].client_status^ (String) The client status of the allocation
+- ^allocations[].tasks^ (List of String) The tasks in the allocation
+`),
+					Type:     s.Allocations.TFType(),
+					Computed: true,
+				},
+				{
+					Name:            "transports",
+					DescriptionKind: nomadTransportDescriptionKind,
+					Description:     nomadTransportSchemaMarkdown,
+					Type:            s.Transports.TFType(),
+					Computed:        true,
+				},
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *nomadAllocationsStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if _, ok := s.Host.Get(); !ok {
+		// this should never happen, since 'host' is a required field and Terraform would barf
+		// before passing the request to the provider
+		return ValidationError("cannot query allocations without a 'host'", "host")
+	}
+
+	if _, ok := s.JobID.Get(); !ok {
+		return ValidationError("cannot query allocations without a 'job_id'", "job_id")
+	}
+
+	if timeout, ok := s.WaitTimeout.Get(); ok {
+		_, err := time.ParseDuration(timeout)
+		if err != nil {
+			return ValidationError(fmt.Sprintf("failed to parse duration [%s]", timeout), "wait_timeout")
+		}
+	}
+
+	if count, ok := s.ExpectedCount.Get(); ok {
+		if count <= 0 {
+			return ValidationError("expected count must be greater than 0", "expected_count")
+		}
+	}
+
+	return nil
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Value with As().
+func (s *nomadAllocationsStateV1) FromTerraform5Value(val tftypes.Value) error {
+	_, err := mapAttributesTo(val, map[string]any{
+		"id":             s.ID,
+		"host":           s.Host,
+		"secret_id":      s.SecretID,
+		"namespace":      s.Namespace,
+		"job_id":         s.JobID,
+		"task_group":     s.TaskGroup,
+		"expected_count": s.ExpectedCount,
+		"wait_timeout":   s.WaitTimeout,
+		"allocations":    s.Allocations,
+		"transports":     s.Transports,
+	})
+
+	return err
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *nomadAllocationsStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":             s.ID.TFType(),
+		"host":           s.Host.TFType(),
+		"secret_id":      s.SecretID.TFType(),
+		"namespace":      s.Namespace.TFType(),
+		"job_id":         s.JobID.TFType(),
+		"task_group":     s.TaskGroup.TFType(),
+		"expected_count": s.ExpectedCount.TFType(),
+		"wait_timeout":   s.WaitTimeout.TFType(),
+		"allocations":    s.Allocations.TFType(),
+		"transports":     s.Transports.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *nomadAllocationsStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":             s.ID.TFValue(),
+		"host":           s.Host.TFValue(),
+		"secret_id":      s.SecretID.TFValue(),
+		"namespace":      s.Namespace.TFValue(),
+		"job_id":         s.JobID.TFValue(),
+		"task_group":     s.TaskGroup.TFValue(),
+		"expected_count": s.ExpectedCount.TFValue(),
+		"wait_timeout":   s.WaitTimeout.TFValue(),
+		"allocations":    s.Allocations.TFValue(),
+		"transports":     s.Transports.TFValue(),
+	})
+}
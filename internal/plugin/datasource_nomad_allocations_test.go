@@ -0,0 +1,209 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/nomad"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server"
+	dr "github.com/hashicorp-forge/terraform-provider-enos/internal/server/datarouter"
+)
+
+func TestNomadAllocationsValidate(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		state      func() *nomadAllocationsStateV1
+		shouldFail bool
+	}{
+		"valid": {
+			func() *nomadAllocationsStateV1 {
+				s := newNomadAllocationsStateV1()
+				s.Host.Set("http://127.0.0.1:4646")
+				s.JobID.Set("vault")
+				s.ExpectedCount.Set(3)
+				s.WaitTimeout.Set("2m")
+
+				return s
+			},
+			false,
+		},
+		"missing-job-id": {
+			func() *nomadAllocationsStateV1 {
+				s := newNomadAllocationsStateV1()
+				s.Host.Set("http://127.0.0.1:4646")
+
+				return s
+			},
+			true,
+		},
+		"invalid-count": {
+			func() *nomadAllocationsStateV1 {
+				s := newNomadAllocationsStateV1()
+				s.Host.Set("http://127.0.0.1:4646")
+				s.JobID.Set("vault")
+				s.ExpectedCount.Set(0)
+
+				return s
+			},
+			true,
+		},
+		"invalid-timeout": {
+			func() *nomadAllocationsStateV1 {
+				s := newNomadAllocationsStateV1()
+				s.Host.Set("http://127.0.0.1:4646")
+				s.JobID.Set("vault")
+				s.WaitTimeout.Set("two minutes")
+
+				return s
+			},
+			true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if test.shouldFail {
+				require.Error(t, test.state().Validate(context.Background()))
+			} else {
+				require.NoError(t, test.state().Validate(context.Background()))
+			}
+		})
+	}
+}
+
+// TestAccDataSourceNomadAllocations tests the enos_nomad_allocations data source with a
+// stubbed allocation query.
+func TestAccDataSourceNomadAllocations(t *testing.T) {
+	t.Parallel()
+
+	_, okacc := os.LookupEnv("TF_ACC")
+
+	if !okacc {
+		t.Log(`skipping data "enos_nomad_allocations" test because TF_ACC isn't set`)
+		t.Skip()
+
+		return
+	}
+
+	cfg := template.Must(template.New("enos_data_nomad_allocations").Parse(`data "enos_nomad_allocations" "vault" {
+  host           = "{{ .Host.Value }}"
+  secret_id      = "{{ .SecretID.Value }}"
+  namespace      = "{{ .Namespace.Value }}"
+  job_id         = "{{ .JobID.Value }}"
+  task_group     = "{{ .TaskGroup.Value }}"
+  expected_count = {{ .ExpectedCount.Value }}
+}
+
+output "allocations" {
+  value = jsonencode([for alloc in data.enos_nomad_allocations.vault.allocations : "${alloc.id}_${alloc.name}_${jsonencode(alloc.tasks)}"])
+}
+
+output "transports_0_allocation_id" {
+  value = data.enos_nomad_allocations.vault.transports[0].allocation_id
+}
+
+output "transports_0_task_name" {
+  value = data.enos_nomad_allocations.vault.transports[0].task_name
+}
+
+output "transports_1_namespace" {
+  value = data.enos_nomad_allocations.vault.transports[1].namespace
+}
+
+output "transports_1_host" {
+  value = data.enos_nomad_allocations.vault.transports[1].host
+}
+
+output "transports_1_allocation_id" {
+  value = data.enos_nomad_allocations.vault.transports[1].allocation_id
+}
+
+output "transports_1_task_name" {
+  value = data.enos_nomad_allocations.vault.transports[1].task_name
+}
+`))
+
+	allocs := []nomad.AllocationInfo{
+		{
+			ID:           "f4a2b1c3-0e3a-4c1b-9f77-5b0e1b7a9d10",
+			Name:         "vault.vault[0]",
+			Namespace:    "enos",
+			JobID:        "vault",
+			TaskGroup:    "vault",
+			ClientStatus: "running",
+			Tasks:        []string{"vault"},
+		},
+		{
+			ID:           "9c0a6d2e-7e1f-4b7b-8e3c-2d6f1e0a4b22",
+			Name:         "vault.vault[1]",
+			Namespace:    "enos",
+			JobID:        "vault",
+			TaskGroup:    "vault",
+			ClientStatus: "running",
+			Tasks:        []string{"vault"},
+		},
+	}
+
+	state := newNomadAllocationsStateV1()
+	state.Host.Set("http://127.0.0.1:4646")
+	state.SecretID.Set("secret")
+	state.Namespace.Set("enos")
+	state.JobID.Set("vault")
+	state.TaskGroup.Set("vault")
+	state.ExpectedCount.Set(2)
+
+	buf := bytes.Buffer{}
+	require.NoError(t, cfg.Execute(&buf, state))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testNomadAllocationsProvider(allocs),
+		Steps: []resource.TestStep{
+			{
+				Config: buf.String(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.enos_nomad_allocations.vault", "id", regexp.MustCompile(`^static$`)),
+					resource.TestMatchResourceAttr("data.enos_nomad_allocations.vault", "job_id", regexp.MustCompile(`^vault$`)),
+					resource.TestMatchOutput("allocations", regexp.MustCompile(`.*f4a2b1c3.*vault\[0\].*vault.*9c0a6d2e.*vault\[1\].*vault.*`)),
+					resource.TestCheckOutput("transports_0_allocation_id", "f4a2b1c3-0e3a-4c1b-9f77-5b0e1b7a9d10"),
+					resource.TestCheckOutput("transports_0_task_name", "vault"),
+					resource.TestCheckOutput("transports_1_host", "http://127.0.0.1:4646"),
+					resource.TestCheckOutput("transports_1_namespace", "enos"),
+					resource.TestCheckOutput("transports_1_allocation_id", "9c0a6d2e-7e1f-4b7b-8e3c-2d6f1e0a4b22"),
+					resource.TestCheckOutput("transports_1_task_name", "vault"),
+				),
+			},
+		},
+	})
+}
+
+func testNomadAllocationsProvider(allocs []nomad.AllocationInfo) map[string]func() (tfprotov6.ProviderServer, error) {
+	ds := newNomadAllocations()
+	ds.allocationInfoGetter = func(ctx context.Context, state nomadAllocationsStateV1) ([]nomad.AllocationInfo, error) {
+		return allocs, nil
+	}
+	s := server.New(
+		server.RegisterProvider(newProvider()),
+		server.RegisterDataRouter(dr.New(
+			dr.RegisterDataSource(ds),
+		)),
+	)
+
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		//nolint:unparam// we always return nil here but we have to adhere to an interface that can return an error
+		"enos": func() (tfprotov6.ProviderServer, error) {
+			return s, nil
+		},
+	}
+}
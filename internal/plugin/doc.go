@@ -104,6 +104,53 @@ provider "enos" {
   debug_data_root_dir = "./enos/support/debug"
 }
 ^^^
+
+## Releases Mirror
+
+Resources that install release bundles, e.g. ^enos_bundle_install^, download them from
+^https://releases.hashicorp.com^ by default. If you need to install releases from a mirror, e.g. in
+an air-gapped network, you can configure the provider with a ^releases_base_url^. The mirror must
+have the same layout as ^releases.hashicorp.com^. If the mirror requires authentication you can
+configure ^releases_headers^, and if it is served with a certificate that is signed by a private CA
+you can configure the PEM encoded CA bundle with ^releases_ca_bundle^. The same configuration can be
+set on a resource, in which case it will take precedence over the provider configuration.
+
+The headers and CA bundle are used both when the provider verifies the release ^SHA256SUMS^ and when
+the release bundle is downloaded on the target.
+
+^^^hcl
+provider "enos" {
+  releases_base_url  = "https://releases.example.com"
+  releases_ca_bundle = file("/path/to/ca.pem")
+  releases_headers = {
+    Authorization = "Bearer ${var.releases_token}"
+  }
+}
+^^^
+
+## Artifact Cache
+
+By default each target host downloads the artifact that ^enos_bundle_install^ installs from either
+the releases endpoint or artifactory. When installing the same artifact on many hosts you can
+configure the provider with an ^artifact_cache_dir^. The provider will then download and verify each
+release or artifactory artifact once on the machine executing Terraform and copy it to every target
+host with the resource ^transport^. This speeds up applies and means that target hosts don't need
+network access to the releases endpoint or artifactory. Artifacts are cached by their SHA256 sum so
+the directory can be shared between Terraform runs.
+
+If the provider is unable to download an artifact into the cache the install will fail. Set
+^artifact_cache_fallback^ to ^true^ to fall back to downloading the artifact on the target host instead.
+
+The ^artifact_cache_dir^ can also be configured via the environment variable: ^ENOS_ARTIFACT_CACHE_DIR^.
+Configuring via an environment variable will override the value configured within any ^enos^ provider
+configuration block within the Terraform configuration that is being run.
+
+^^^hcl
+provider "enos" {
+  artifact_cache_dir      = "./enos/support/artifacts"
+  artifact_cache_fallback = true
+}
+^^^
 `), transportsDescription)
 
 var (
@@ -189,12 +236,44 @@ provider "enos" {
   }
 }
 ^^^
+
+Clusters that require mTLS, or jobs that run in a non-default namespace or region, can be
+configured as well:
+
+^^^hcl
+provider "enos" {
+  transport = {
+    nomad = {
+      host          = "https://127.0.0.1:4646"
+      secret_id     = "some secret"
+      namespace     = "vault"
+      region        = "us-east"
+      ca_cert       = "/etc/nomad.d/tls/ca.pem"
+      client_cert   = "/etc/nomad.d/tls/client.pem"
+      client_key    = "/etc/nomad.d/tls/client-key.pem"
+      allocation_id = "g72bc97a"
+      task_name     = "vault"
+    }
+  }
+}
+^^^
+
+Any attribute that is not set falls back to the standard Nomad environment variables, i.e.
+^NOMAD_ADDR^, ^NOMAD_TOKEN^, ^NOMAD_NAMESPACE^, ^NOMAD_REGION^, ^NOMAD_CACERT^,
+^NOMAD_CLIENT_CERT^, ^NOMAD_CLIENT_KEY^ and ^NOMAD_TLS_SERVER_NAME^. ^NOMAD_SKIP_VERIFY^ is
+also honored.
 `), nomadTransportSchemaMarkdown)
 
 	nomadTransportSchemaMarkdown = docCaretToBacktick(`
 - ^transport.nomad^ (Object) the nomad transport configuration
 - ^transport.nomad.host^ (String) nomad server host, i.e. http://23.56.78.9:4646
 - ^transport.nomad.secret_id^ (String) the nomad server secret for authenticated connections
+- ^transport.nomad.namespace^ (String) the namespace of the allocation
+- ^transport.nomad.region^ (String) the region of the allocation
+- ^transport.nomad.ca_cert^ (String) the path to a PEM encoded CA certificate used to verify the nomad server
+- ^transport.nomad.client_cert^ (String) the path to a PEM encoded client certificate for mTLS
+- ^transport.nomad.client_key^ (String) the path to a PEM encoded private key for the client certificate
+- ^transport.nomad.tls_server_name^ (String) the server name to use as the SNI host when connecting via TLS
 - ^transport.nomad.allocation_id^ (String) the allocation id for the allocation to access
 - ^transport.nomad.task_name^ (String) the name of the task within the allocation to access`)
 )
@@ -336,6 +336,28 @@ func (em *embeddedTransportV1) Copy() (*embeddedTransportV1, error) {
 	return newCopy, nil
 }
 
+// CopyForSSHHost returns a validated copy of the transport with the provider defaults applied
+// and the ssh host set to the given host. It is used by resources that need to run commands on
+// several hosts with otherwise identical transport configuration.
+func (em *embeddedTransportV1) CopyForSSHHost(ctx context.Context, host string, defaults *embeddedTransportV1) (*embeddedTransportV1, error) {
+	et, err := em.Copy()
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy embedded transport, due to: %w", err)
+	}
+
+	if _, err = et.ApplyDefaults(defaults); err != nil {
+		return nil, fmt.Errorf("failed to apply transport defaults, due to: %w", err)
+	}
+
+	ssh, ok := et.SSH()
+	if !ok {
+		return nil, errors.New("failed to configure the transport, only the ssh transport is supported")
+	}
+	ssh.Host.Set(host)
+
+	return et, et.Validate(ctx)
+}
+
 // CopyValues returns only the values that have received their configuration from the
 // terraform configuration for that resource. The configured attributes do not include the attributes
 // that may have been received as default values via ApplyDefaults. To get all the attribute values
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"text/template"
 
@@ -17,6 +18,9 @@ import (
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/nomad"
 )
 
+// nomadAddrEnvVarKey is the standard environment variable for the Nomad HTTP API address.
+const nomadAddrEnvVarKey = "NOMAD_ADDR"
+
 type nomadClientFactory func(cfg nomadapi.ClientCfg) (nomadapi.Client, error)
 
 type nomadTransportBuilder func(state *embeddedTransportNomadv1, ctx context.Context) (transport.Transport, error)
@@ -33,6 +37,24 @@ var defaultNomadTransportBuilder = func(state *embeddedTransportNomadv1, ctx con
 	if secretID, ok := state.SecretID.Get(); ok {
 		opts.SecretID = secretID
 	}
+	if namespace, ok := state.Namespace.Get(); ok {
+		opts.Namespace = namespace
+	}
+	if region, ok := state.Region.Get(); ok {
+		opts.Region = region
+	}
+	if caCert, ok := state.CACert.Get(); ok {
+		opts.CACert = caCert
+	}
+	if clientCert, ok := state.ClientCert.Get(); ok {
+		opts.ClientCert = clientCert
+	}
+	if clientKey, ok := state.ClientKey.Get(); ok {
+		opts.ClientKey = clientKey
+	}
+	if serverName, ok := state.TLSServerName.Get(); ok {
+		opts.TLSServerName = serverName
+	}
 	if taskName, ok := state.TaskName.Get(); ok {
 		opts.TaskName = taskName
 	}
@@ -40,7 +62,18 @@ var defaultNomadTransportBuilder = func(state *embeddedTransportNomadv1, ctx con
 	return nomad.NewTransport(opts)
 }
 
-var nomadAttributes = []string{"host", "secret_id", "allocation_id", "task_name"}
+var nomadAttributes = []string{
+	"host",
+	"secret_id",
+	"namespace",
+	"region",
+	"ca_cert",
+	"client_cert",
+	"client_key",
+	"tls_server_name",
+	"allocation_id",
+	"task_name",
+}
 
 var nomadTransportTmpl = template.Must(template.New("nomad_transport").Parse(`
 	nomad = {
@@ -55,10 +88,16 @@ type embeddedTransportNomadv1 struct {
 	nomadTransportBuilder nomadTransportBuilder
 	nomadClientFactory    nomadClientFactory
 
-	Host         *tfString
-	SecretID     *tfString
-	AllocationID *tfString
-	TaskName     *tfString
+	Host          *tfString
+	SecretID      *tfString
+	Namespace     *tfString
+	Region        *tfString
+	CACert        *tfString
+	ClientCert    *tfString
+	ClientKey     *tfString
+	TLSServerName *tfString
+	AllocationID  *tfString
+	TaskName      *tfString
 
 	// Values required for the same reason as stated in the embeddedTransportSSHv1.Values field
 	Values map[string]tftypes.Value
@@ -70,6 +109,12 @@ func newEmbeddedTransportNomadv1() *embeddedTransportNomadv1 {
 		nomadClientFactory:    nomadapi.NewClient,
 		Host:                  newTfString(),
 		SecretID:              newTfString(),
+		Namespace:             newTfString(),
+		Region:                newTfString(),
+		CACert:                newTfString(),
+		ClientCert:            newTfString(),
+		ClientKey:             newTfString(),
+		TLSServerName:         newTfString(),
 		AllocationID:          newTfString(),
 		TaskName:              newTfString(),
 		Values:                map[string]tftypes.Value{},
@@ -86,10 +131,16 @@ func (em *embeddedTransportNomadv1) Terraform5Value() tftypes.Value {
 	// If the values are empty it means that the transport configuration is unknown
 	if len(em.Values) == 0 {
 		return tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
-			"host":          tftypes.String,
-			"secret_id":     tftypes.String,
-			"allocation_id": tftypes.String,
-			"task_name":     tftypes.String,
+			"host":            tftypes.String,
+			"secret_id":       tftypes.String,
+			"namespace":       tftypes.String,
+			"region":          tftypes.String,
+			"ca_cert":         tftypes.String,
+			"client_cert":     tftypes.String,
+			"client_key":      tftypes.String,
+			"tls_server_name": tftypes.String,
+			"allocation_id":   tftypes.String,
+			"task_name":       tftypes.String,
 		}}, tftypes.UnknownValue)
 	}
 
@@ -110,10 +161,16 @@ func (em *embeddedTransportNomadv1) IsConfigured() bool {
 
 func (em *embeddedTransportNomadv1) FromTerraform5Value(val tftypes.Value) (err error) {
 	em.Values, err = mapAttributesTo(val, map[string]any{
-		"host":          em.Host,
-		"secret_id":     em.SecretID,
-		"allocation_id": em.AllocationID,
-		"task_name":     em.TaskName,
+		"host":            em.Host,
+		"secret_id":       em.SecretID,
+		"namespace":       em.Namespace,
+		"region":          em.Region,
+		"ca_cert":         em.CACert,
+		"client_cert":     em.ClientCert,
+		"client_key":      em.ClientKey,
+		"tls_server_name": em.TLSServerName,
+		"allocation_id":   em.AllocationID,
+		"task_name":       em.TaskName,
 	})
 	if err != nil {
 		return AttributePathError(
@@ -126,11 +183,17 @@ func (em *embeddedTransportNomadv1) FromTerraform5Value(val tftypes.Value) (err
 }
 
 func (em *embeddedTransportNomadv1) Validate(ctx context.Context) error {
-	for name, prop := range map[string]*tfString{
-		"host":          em.Host,
+	required := map[string]*tfString{
 		"allocation_id": em.AllocationID,
 		"task_name":     em.TaskName,
-	} {
+	}
+
+	// The host can be omitted if it has been set via the standard Nomad environment variable
+	if _, ok := os.LookupEnv(nomadAddrEnvVarKey); !ok {
+		required["host"] = em.Host
+	}
+
+	for name, prop := range required {
 		if _, ok := prop.Get(); !ok {
 			return ValidationError(
 				"missing value for required attribute: "+name,
@@ -139,6 +202,15 @@ func (em *embeddedTransportNomadv1) Validate(ctx context.Context) error {
 		}
 	}
 
+	_, hasCert := em.ClientCert.Get()
+	_, hasKey := em.ClientKey.Get()
+	if hasCert != hasKey {
+		return ValidationError(
+			"you must provide both the client_cert and client_key",
+			"transport", "nomad", "client_cert",
+		)
+	}
+
 	return nil
 }
 
@@ -148,10 +220,16 @@ func (em *embeddedTransportNomadv1) Client(ctx context.Context) (transport.Trans
 
 func (em *embeddedTransportNomadv1) Attributes() map[string]TFType {
 	return map[string]TFType{
-		"host":          em.Host,
-		"secret_id":     em.SecretID,
-		"allocation_id": em.AllocationID,
-		"task_name":     em.TaskName,
+		"host":            em.Host,
+		"secret_id":       em.SecretID,
+		"namespace":       em.Namespace,
+		"region":          em.Region,
+		"ca_cert":         em.CACert,
+		"client_cert":     em.ClientCert,
+		"client_key":      em.ClientKey,
+		"tls_server_name": em.TLSServerName,
+		"allocation_id":   em.AllocationID,
+		"task_name":       em.TaskName,
 	}
 }
 
@@ -165,6 +243,14 @@ func (em *embeddedTransportNomadv1) GetAttributesForReplace() []string {
 		attribsForReplace = append(attribsForReplace, "secret_id")
 	}
 
+	if _, ok := em.Values["namespace"]; ok {
+		attribsForReplace = append(attribsForReplace, "namespace")
+	}
+
+	if _, ok := em.Values["region"]; ok {
+		attribsForReplace = append(attribsForReplace, "region")
+	}
+
 	return attribsForReplace
 }
 
@@ -209,8 +295,14 @@ func (em *embeddedTransportNomadv1) debug() string {
 // nomadClient creates a nomad client for this transport.
 func (em *embeddedTransportNomadv1) nomadClient() (nomadapi.Client, error) {
 	client, err := em.nomadClientFactory(nomadapi.ClientCfg{
-		Host:     em.Host.Val,
-		SecretID: em.SecretID.Val,
+		Host:          em.Host.Val,
+		SecretID:      em.SecretID.Val,
+		Namespace:     em.Namespace.Val,
+		Region:        em.Region.Val,
+		CACert:        em.CACert.Val,
+		ClientCert:    em.ClientCert.Val,
+		ClientKey:     em.ClientKey.Val,
+		TLSServerName: em.TLSServerName.Val,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create nomad client due to: %w", err)
@@ -36,10 +36,16 @@ var (
 		"container":         "space_capsule",
 	}
 	nomadConfig = configmap{
-		"host":          "http://127.0.0.1:4646",
-		"secret_id":     "ILoveBananas",
-		"allocation_id": "efd67cdd",
-		"task_name":     "apples",
+		"host":            "https://127.0.0.1:4646",
+		"secret_id":       "ILoveBananas",
+		"namespace":       "orchard",
+		"region":          "north",
+		"ca_cert":         "/etc/nomad.d/ca.pem",
+		"client_cert":     "/etc/nomad.d/client.pem",
+		"client_key":      "/etc/nomad.d/client-key.pem",
+		"tls_server_name": "client.north.nomad",
+		"allocation_id":   "efd67cdd",
+		"task_name":       "apples",
 	}
 )
 
@@ -77,6 +83,12 @@ func TestEmbeddedTransportMarshalRoundTrip(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, nomadConfig["host"], nomad.Host.Value())
 	assert.Equal(t, nomadConfig["secret_id"], nomad.SecretID.Value())
+	assert.Equal(t, nomadConfig["namespace"], nomad.Namespace.Value())
+	assert.Equal(t, nomadConfig["region"], nomad.Region.Value())
+	assert.Equal(t, nomadConfig["ca_cert"], nomad.CACert.Value())
+	assert.Equal(t, nomadConfig["client_cert"], nomad.ClientCert.Value())
+	assert.Equal(t, nomadConfig["client_key"], nomad.ClientKey.Value())
+	assert.Equal(t, nomadConfig["tls_server_name"], nomad.TLSServerName.Value())
 	assert.Equal(t, nomadConfig["allocation_id"], nomad.AllocationID.Value())
 	assert.Equal(t, nomadConfig["task_name"], nomad.TaskName.Value())
 }
@@ -132,6 +144,12 @@ func TestProviderEmbeddedTransportFromTFValue(t *testing.T) {
 	partialNomadAttributesExpected := transportconfig{}.
 		nomadValue("host", "http://127.0.0.1:4646").
 		nomadValue("secret_id", nil).
+		nomadValue("namespace", nil).
+		nomadValue("region", nil).
+		nomadValue("ca_cert", nil).
+		nomadValue("client_cert", nil).
+		nomadValue("client_key", nil).
+		nomadValue("tls_server_name", nil).
 		nomadValue("allocation_id", "ddf76bc4").
 		nomadValue("task_name", nil)
 
@@ -339,6 +357,13 @@ func TestProviderEmbeddedTransportValidate(t *testing.T) {
 		"host": "http://127.0.0.1:4646",
 	})
 
+	invalidNomadTLS := transportconfig{}.nomad(configmap{
+		"host":          "https://127.0.0.1:4646",
+		"allocation_id": "df67f8c9",
+		"task_name":     "some_task",
+		"client_cert":   "/etc/nomad.d/client.pem",
+	})
+
 	//nolint:paralleltest// build() handles it
 	for _, test := range []struct {
 		name    string
@@ -356,6 +381,7 @@ func TestProviderEmbeddedTransportValidate(t *testing.T) {
 		{"invalid_ssh_configured", invalidSSH, true},
 		{"invalid_k8s_configured", invalidK8S, true},
 		{"invalid_nomad_configured", invalidNomad, true},
+		{"invalid_nomad_tls_configured", invalidNomadTLS, true},
 	} {
 		t.Run(test.name, func(tt *testing.T) {
 			transport := test.config.build(tt)
@@ -627,6 +653,18 @@ func requireTransportCfg(t *testing.T, transport *embeddedTransportV1, config tr
 						assert.Equal(t, value, nomad.Host.Val)
 					case "secret_id":
 						assert.Equal(t, value, nomad.SecretID.Val)
+					case "namespace":
+						assert.Equal(t, value, nomad.Namespace.Val)
+					case "region":
+						assert.Equal(t, value, nomad.Region.Val)
+					case "ca_cert":
+						assert.Equal(t, value, nomad.CACert.Val)
+					case "client_cert":
+						assert.Equal(t, value, nomad.ClientCert.Val)
+					case "client_key":
+						assert.Equal(t, value, nomad.ClientKey.Val)
+					case "tls_server_name":
+						assert.Equal(t, value, nomad.TLSServerName.Val)
 					case "allocation_id":
 						assert.Equal(t, value, nomad.AllocationID.Val)
 					case "task_name":
@@ -643,3 +681,31 @@ func requireTransportCfg(t *testing.T, transport *embeddedTransportV1, config tr
 		}
 	}
 }
+
+func TestEmbeddedTransportCopyForSSHHost(t *testing.T) {
+	t.Parallel()
+
+	em := newEmbeddedTransport()
+	ssh := newEmbeddedTransportSSH()
+	require.NoError(t, ssh.FromTerraform5Value(tftypes.NewValue(
+		tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"user":             tftypes.String,
+			"private_key_path": tftypes.String,
+		}},
+		map[string]tftypes.Value{
+			"user":             tftypes.NewValue(tftypes.String, "ubuntu"),
+			"private_key_path": tftypes.NewValue(tftypes.String, "/path/to/key.pem"),
+		},
+	)))
+	require.NoError(t, em.SetTransportState(ssh))
+
+	et, err := em.CopyForSSHHost(context.Background(), "10.0.0.2", newEmbeddedTransport())
+	require.NoError(t, err)
+	hostSSH, ok := et.SSH()
+	require.True(t, ok)
+	require.Equal(t, "10.0.0.2", hostSSH.Host.Value())
+	require.Equal(t, "ubuntu", hostSSH.User.Value())
+
+	// Make sure we didn't modify the original transport
+	require.Empty(t, ssh.Host.Value())
+}
@@ -81,6 +81,16 @@ func (m mockSystemdClient) RunSystemctlCommand(ctx context.Context, req *systemd
 	panic("implement me")
 }
 
+func (m mockSystemdClient) DisableService(ctx context.Context, unit string) error {
+	// intentionally not implemented
+	panic("implement me")
+}
+
+func (m mockSystemdClient) ReadUnitFile(ctx context.Context, path string) (string, bool, error) {
+	// intentionally not implemented
+	panic("implement me")
+}
+
 func (m mockSystemdClient) EnableService(ctx context.Context, unit string) error {
 	// intentionally not implemented
 	panic("implement me")
@@ -146,6 +156,36 @@ func (m mockNomadClient) GetLogs(ctx context.Context, req nomad.GetTaskLogsReque
 	}, nil
 }
 
+func (m mockNomadClient) SetVariable(ctx context.Context, req nomad.SetVariableRequest) error {
+	// intentionally not implemented
+	panic("implement me")
+}
+
+func (m mockNomadClient) RestartTask(ctx context.Context, req nomad.RestartTaskRequest) error {
+	// intentionally not implemented
+	panic("implement me")
+}
+
+func (m mockNomadClient) RegisterJob(ctx context.Context, req nomad.RegisterJobRequest) (*nomad.RegisterJobResponse, error) {
+	// intentionally not implemented
+	panic("implement me")
+}
+
+func (m mockNomadClient) WaitForJob(ctx context.Context, req nomad.WaitForJobRequest) error {
+	// intentionally not implemented
+	panic("implement me")
+}
+
+func (m mockNomadClient) DeregisterJob(ctx context.Context, req nomad.DeregisterJobRequest) error {
+	// intentionally not implemented
+	panic("implement me")
+}
+
+func (m mockNomadClient) ListAllocations(ctx context.Context, req nomad.ListAllocationsRequest) ([]nomad.AllocationInfo, error) {
+	// intentionally not implemented
+	panic("implement me")
+}
+
 func (m mockNomadClient) Close() {
 	// do nothing on purpose
 }
@@ -150,6 +150,7 @@ func unsetNomadEnv(t *testing.T) {
 func unsetProviderEnv(t *testing.T) {
 	t.Helper()
 	require.NoError(t, os.Unsetenv(enosDebugDataRootDirEnvVarKey))
+	require.NoError(t, os.Unsetenv(enosArtifactCacheDirEnvVarKey))
 }
 
 func setEnosSSHEnv(t *testing.T, et *embeddedTransportV1) {
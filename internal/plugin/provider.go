@@ -5,7 +5,9 @@ package plugin
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
+	"net/url"
 	"os"
 	"sync"
 
@@ -19,6 +21,7 @@ import (
 
 const (
 	enosDebugDataRootDirEnvVarKey = "ENOS_DEBUG_DATA_ROOT_DIR" // env var for setting the debug_data_root_dir.
+	enosArtifactCacheDirEnvVarKey = "ENOS_ARTIFACT_CACHE_DIR"  // env var for setting the artifact_cache_dir.
 )
 
 var (
@@ -41,16 +44,26 @@ type Provider struct {
 }
 
 type config struct {
-	mu               sync.Mutex
-	Transport        *embeddedTransportV1
-	DebugDataRootDir *tfString
+	mu                    sync.Mutex
+	Transport             *embeddedTransportV1
+	DebugDataRootDir      *tfString
+	ReleasesBaseURL       *tfString
+	ReleasesHeaders       *tfStringMap
+	ReleasesCABundle      *tfString
+	ArtifactCacheDir      *tfString
+	ArtifactCacheFallback *tfBool
 }
 
 func newProviderConfig() *config {
 	return &config{
-		mu:               sync.Mutex{},
-		Transport:        newEmbeddedTransport(),
-		DebugDataRootDir: newTfString(),
+		mu:                    sync.Mutex{},
+		Transport:             newEmbeddedTransport(),
+		DebugDataRootDir:      newTfString(),
+		ReleasesBaseURL:       newTfString(),
+		ReleasesHeaders:       newTfStringMap(),
+		ReleasesCABundle:      newTfString(),
+		ArtifactCacheDir:      newTfString(),
+		ArtifactCacheFallback: newTfBool(),
 	}
 }
 
@@ -70,6 +83,40 @@ func (p *Provider) Schema() *tfprotov6.Schema {
 If configured and the directory does not exist, it will be created.
 If the directory is not configured, diagnostic files will not be saved locally.`,
 				},
+				{
+					Name:     "releases_base_url",
+					Type:     tftypes.String,
+					Optional: true,
+					Description: `The base URL of the releases endpoint that is used when installing release bundles, eg: https://releases.example.com.
+Use this to install releases from a mirror of releases.hashicorp.com. Defaults to https://releases.hashicorp.com.`,
+				},
+				{
+					Name:        "releases_headers",
+					Type:        tftypes.Map{ElementType: tftypes.String},
+					Optional:    true,
+					Sensitive:   true,
+					Description: "Headers that are added to requests made to the releases endpoint, eg: an Authorization header for a mirror that requires authentication.",
+				},
+				{
+					Name:        "releases_ca_bundle",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "A PEM encoded CA certificate bundle that is used to verify the releases endpoint. Use this if your releases mirror is served with a certificate that is signed by a private CA.",
+				},
+				{
+					Name:     "artifact_cache_dir",
+					Type:     tftypes.String,
+					Optional: true,
+					Description: `A local directory where artifacts that are installed with enos_bundle_install are cached.
+If configured, release and artifactory artifacts are downloaded and verified once on the machine running Terraform and then copied to each target host.
+If configured and the directory does not exist, it will be created. If the directory is not configured, each target host will download the artifact itself.`,
+				},
+				{
+					Name:        "artifact_cache_fallback",
+					Type:        tftypes.Bool,
+					Optional:    true,
+					Description: "Whether or not to fall back to downloading the artifact on the target host if it cannot be downloaded into the artifact_cache_dir. Defaults to false.",
+				},
 			},
 			DescriptionKind: providerDescriptionKind,
 			Description:     providerDescription,
@@ -104,29 +151,75 @@ func (p *Provider) Validate(ctx context.Context, req *tfprotov6.ValidateProvider
 	}
 
 	if dir, ok := cfg.DebugDataRootDir.Get(); ok {
-		info, err := os.Stat(dir)
-		if err != nil {
-			if os.IsNotExist(err) {
-				return res, nil
-			}
+		if err := validateDir(dir, "configured diagnostics dir is not a directory", "debug_data_root_dir"); err != nil {
 			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Validation Error", err))
 
 			return res, nil
 		}
-		if !info.IsDir() {
-			res.Diagnostics = append(res.Diagnostics,
-				diags.ErrToDiagnostic(
-					"Validation Error",
-					ValidationError("configured diagnostics dir is not a directory", "debug_data_root_dir"),
-				))
+	}
 
-			return res, nil
+	if dir, ok := cfg.ArtifactCacheDir.Get(); ok {
+		if err := validateDir(dir, "configured artifact cache dir is not a directory", "artifact_cache_dir"); err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Validation Error", err))
+		}
+	}
+
+	if baseURL, ok := cfg.ReleasesBaseURL.Get(); ok {
+		if err := validateReleasesBaseURL(baseURL, "releases_base_url"); err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Validation Error", err))
+		}
+	}
+
+	if caBundle, ok := cfg.ReleasesCABundle.Get(); ok {
+		if err := validateReleasesCABundle(caBundle, "releases_ca_bundle"); err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Validation Error", err))
 		}
 	}
 
 	return res, nil
 }
 
+// validateDir validates that the path is a directory if it exists. Directories that do not exist
+// are valid as they will be created when the provider is configured.
+func validateDir(dir string, msg string, attrPath ...string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if !info.IsDir() {
+		return ValidationError(msg, attrPath...)
+	}
+
+	return nil
+}
+
+// validateReleasesBaseURL validates that the releases base URL is an http or https URL.
+func validateReleasesBaseURL(baseURL string, attrPath ...string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ValidationError(
+			fmt.Sprintf("invalid releases base URL %q, it must be an http or https URL", baseURL),
+			attrPath...,
+		)
+	}
+
+	return nil
+}
+
+// validateReleasesCABundle validates that the releases CA bundle contains PEM encoded certificates.
+func validateReleasesCABundle(caBundle string, attrPath ...string) error {
+	if ok := x509.NewCertPool().AppendCertsFromPEM([]byte(caBundle)); !ok {
+		return ValidationError("no PEM encoded certificates were found in the releases CA bundle", attrPath...)
+	}
+
+	return nil
+}
+
 // Configure is called to pass the user-specified provider configuration to the
 // provider.
 func (p *Provider) Configure(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
@@ -155,12 +248,22 @@ func (p *Provider) Configure(ctx context.Context, req *tfprotov6.ConfigureProvid
 		p.config.DebugDataRootDir.Set(debugDir)
 	}
 
-	if dir, ok := p.config.DebugDataRootDir.Get(); ok {
+	// the env var ENOS_ARTIFACT_CACHE_DIR should override the value configured in the provider block
+	if cacheDir, ok := os.LookupEnv(enosArtifactCacheDirEnvVarKey); ok {
+		p.config.ArtifactCacheDir.Set(cacheDir)
+	}
+
+	for _, attr := range []*tfString{p.config.DebugDataRootDir, p.config.ArtifactCacheDir} {
+		dir, ok := attr.Get()
+		if !ok {
+			continue
+		}
+
 		if _, err := os.Stat(dir); err != nil {
 			if os.IsNotExist(err) {
-				err := os.MkdirAll(dir, 0o755)
+				err = os.MkdirAll(dir, 0o755)
 				if err == nil {
-					return res, nil
+					continue
 				}
 			}
 			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Provider Config Error", err))
@@ -212,22 +315,50 @@ func (c *config) FromTerraform5Value(val tftypes.Value) error {
 		return fmt.Errorf("failed to unmarshal [debug_data_root_dir], due to: %w", err)
 	}
 
+	for name, attr := range map[string]TFType{
+		"releases_base_url":       c.ReleasesBaseURL,
+		"releases_headers":        c.ReleasesHeaders,
+		"releases_ca_bundle":      c.ReleasesCABundle,
+		"artifact_cache_dir":      c.ArtifactCacheDir,
+		"artifact_cache_fallback": c.ArtifactCacheFallback,
+	} {
+		val, ok := vals[name]
+		if !ok || !val.IsKnown() {
+			continue
+		}
+
+		err = attr.FromTFValue(val)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal [%s], due to: %w", name, err)
+		}
+	}
+
 	return err
 }
 
 // Terraform5Type is the provider as a tftypes.Type.
 func (c *config) Terraform5Type() tftypes.Type {
 	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
-		"transport":           c.Transport.Terraform5Type(),
-		"debug_data_root_dir": c.DebugDataRootDir.TFType(),
+		"transport":               c.Transport.Terraform5Type(),
+		"debug_data_root_dir":     c.DebugDataRootDir.TFType(),
+		"releases_base_url":       c.ReleasesBaseURL.TFType(),
+		"releases_headers":        c.ReleasesHeaders.TFType(),
+		"releases_ca_bundle":      c.ReleasesCABundle.TFType(),
+		"artifact_cache_dir":      c.ArtifactCacheDir.TFType(),
+		"artifact_cache_fallback": c.ArtifactCacheFallback.TFType(),
 	}}
 }
 
 // Terraform5Value is the provider as a tftypes.Value.
 func (c *config) Terraform5Value() tftypes.Value {
 	return tftypes.NewValue(c.Terraform5Type(), map[string]tftypes.Value{
-		"transport":           c.Transport.Terraform5Value(),
-		"debug_data_root_dir": c.DebugDataRootDir.TFValue(),
+		"transport":               c.Transport.Terraform5Value(),
+		"debug_data_root_dir":     c.DebugDataRootDir.TFValue(),
+		"releases_base_url":       c.ReleasesBaseURL.TFValue(),
+		"releases_headers":        c.ReleasesHeaders.TFValue(),
+		"releases_ca_bundle":      c.ReleasesCABundle.TFValue(),
+		"artifact_cache_dir":      c.ArtifactCacheDir.TFValue(),
+		"artifact_cache_fallback": c.ArtifactCacheFallback.TFValue(),
 	})
 }
 
@@ -245,5 +376,25 @@ func (c *config) Copy() (*config, error) {
 	dir.Set(c.DebugDataRootDir.Val)
 	newCopy.DebugDataRootDir = dir
 
+	if baseURL, ok := c.ReleasesBaseURL.Get(); ok {
+		newCopy.ReleasesBaseURL.Set(baseURL)
+	}
+
+	if caBundle, ok := c.ReleasesCABundle.Get(); ok {
+		newCopy.ReleasesCABundle.Set(caBundle)
+	}
+
+	if headers, ok := c.ReleasesHeaders.GetStrings(); ok {
+		newCopy.ReleasesHeaders.SetStrings(headers)
+	}
+
+	if cacheDir, ok := c.ArtifactCacheDir.Get(); ok {
+		newCopy.ArtifactCacheDir.Set(cacheDir)
+	}
+
+	if fallback, ok := c.ArtifactCacheFallback.Get(); ok {
+		newCopy.ArtifactCacheFallback.Set(fallback)
+	}
+
 	return newCopy, err
 }
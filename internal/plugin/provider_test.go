@@ -4,6 +4,8 @@
 package plugin
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -115,3 +117,162 @@ func TestDebugDataRootDirFromEnvVarOverridesProviderConfigured(t *testing.T) {
 
 	resetEnv(t)
 }
+
+func TestProviderReleasesMirrorMarshalRoundtripAndCopy(t *testing.T) {
+	t.Parallel()
+
+	cfg := newProviderConfig()
+	cfg.ReleasesBaseURL.Set("https://releases.example.com")
+	cfg.ReleasesHeaders.SetStrings(map[string]string{"Authorization": "Bearer token"})
+	cfg.ReleasesCABundle.Set("CA BUNDLE")
+
+	marshaled, err := state.Marshal(cfg)
+	require.NoError(t, err)
+
+	newCfg := newProviderConfig()
+	require.NoError(t, unmarshal(newCfg, marshaled))
+
+	cp, err := newCfg.Copy()
+	require.NoError(t, err)
+
+	for _, c := range []*config{newCfg, cp} {
+		baseURL, ok := c.ReleasesBaseURL.Get()
+		assert.True(t, ok)
+		assert.Equal(t, "https://releases.example.com", baseURL)
+		headers, ok := c.ReleasesHeaders.GetStrings()
+		assert.True(t, ok)
+		assert.Equal(t, map[string]string{"Authorization": "Bearer token"}, headers)
+		caBundle, ok := c.ReleasesCABundle.Get()
+		assert.True(t, ok)
+		assert.Equal(t, "CA BUNDLE", caBundle)
+	}
+
+	// Unset values should remain unset in the copy
+	cp, err = newProviderConfig().Copy()
+	require.NoError(t, err)
+	_, ok := cp.ReleasesBaseURL.Get()
+	assert.False(t, ok)
+	_, ok = cp.ReleasesHeaders.Get()
+	assert.False(t, ok)
+	_, ok = cp.ReleasesCABundle.Get()
+	assert.False(t, ok)
+}
+
+func TestProviderValidateReleasesMirror(t *testing.T) {
+	t.Parallel()
+
+	for desc, test := range map[string]struct {
+		baseURL  string
+		caBundle string
+		fails    bool
+	}{
+		"unset":             {},
+		"valid base url":    {baseURL: "https://releases.example.com/mirror"},
+		"no scheme":         {baseURL: "releases.example.com", fails: true},
+		"unsupported":       {baseURL: "ftp://releases.example.com", fails: true},
+		"invalid ca bundle": {caBundle: "not a certificate", fails: true},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newProviderConfig()
+			if test.baseURL != "" {
+				cfg.ReleasesBaseURL.Set(test.baseURL)
+			}
+			if test.caBundle != "" {
+				cfg.ReleasesCABundle.Set(test.caBundle)
+			}
+			val, err := state.Marshal(cfg)
+			require.NoError(t, err)
+
+			resp, err := newProvider().Validate(t.Context(), &tfprotov6.ValidateProviderConfigRequest{
+				Config: val,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, test.fails, diags.HasErrors(resp.Diagnostics))
+		})
+	}
+}
+
+func TestProviderArtifactCacheMarshalRoundtripAndCopy(t *testing.T) {
+	t.Parallel()
+
+	cfg := newProviderConfig()
+	cfg.ArtifactCacheDir.Set("/tmp/enos/artifacts")
+	cfg.ArtifactCacheFallback.Set(true)
+
+	marshaled, err := state.Marshal(cfg)
+	require.NoError(t, err)
+
+	newCfg := newProviderConfig()
+	require.NoError(t, unmarshal(newCfg, marshaled))
+
+	cp, err := newCfg.Copy()
+	require.NoError(t, err)
+
+	for _, c := range []*config{newCfg, cp} {
+		dir, ok := c.ArtifactCacheDir.Get()
+		assert.True(t, ok)
+		assert.Equal(t, "/tmp/enos/artifacts", dir)
+		fallback, ok := c.ArtifactCacheFallback.Get()
+		assert.True(t, ok)
+		assert.True(t, fallback)
+	}
+}
+
+func TestArtifactCacheDirFromEnvVarIsCreated(t *testing.T) {
+	cacheDir := filepath.Join(t.TempDir(), "artifacts")
+
+	cfg := newProviderConfig()
+	cfg.ArtifactCacheDir.Set("/this/is/where/I/thought/the/cache/should/be")
+	val, err := state.Marshal(cfg)
+	require.NoError(t, err)
+
+	t.Setenv(enosArtifactCacheDirEnvVarKey, cacheDir)
+
+	provider := newProvider()
+	resp, err := provider.Configure(t.Context(), &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.3",
+		Config:           val,
+	})
+	require.NoError(t, err)
+	assert.False(t, diags.HasErrors(resp.Diagnostics))
+	assert.Equal(t, cacheDir, provider.config.ArtifactCacheDir.Val)
+
+	info, err := os.Stat(cacheDir)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	resetEnv(t)
+}
+
+func TestProviderValidateArtifactCacheDir(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, []byte("not a directory"), 0o600))
+
+	for desc, test := range map[string]struct {
+		dir   string
+		fails bool
+	}{
+		"exists":         {dir: t.TempDir()},
+		"does not exist": {dir: filepath.Join(t.TempDir(), "artifacts")},
+		"not a dir":      {dir: file, fails: true},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newProviderConfig()
+			cfg.ArtifactCacheDir.Set(test.dir)
+			val, err := state.Marshal(cfg)
+			require.NoError(t, err)
+
+			resp, err := newProvider().Validate(t.Context(), &tfprotov6.ValidateProviderConfigRequest{
+				Config: val,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, test.fails, diags.HasErrors(resp.Diagnostics))
+		})
+	}
+}
@@ -0,0 +1,563 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/artifactory"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+)
+
+type artifactoryUpload struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*artifactoryUpload)(nil)
+
+type artifactoryUploadStateV1 struct {
+	ID         *tfString
+	Username   *tfString
+	Token      *tfString
+	Host       *tfString
+	Repo       *tfString
+	Path       *tfString
+	Name       *tfString
+	Source     *tfString
+	Properties *tfStringMap
+	URL        *tfString
+	SHA256     *tfString
+	SHA1       *tfString
+	MD5        *tfString
+	Size       *tfString
+
+	failureHandlers
+}
+
+var _ state.State = (*artifactoryUploadStateV1)(nil)
+
+func newArtifactoryUpload() *artifactoryUpload {
+	return &artifactoryUpload{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newArtifactoryUploadStateV1() *artifactoryUploadStateV1 {
+	return &artifactoryUploadStateV1{
+		ID:              newTfString(),
+		Username:        newTfString(),
+		Token:           newTfString(),
+		Host:            newTfString(),
+		Repo:            newTfString(),
+		Path:            newTfString(),
+		Name:            newTfString(),
+		Source:          newTfString(),
+		Properties:      newTfStringMap(),
+		URL:             newTfString(),
+		SHA256:          newTfString(),
+		SHA1:            newTfString(),
+		MD5:             newTfString(),
+		Size:            newTfString(),
+		failureHandlers: failureHandlers{},
+	}
+}
+
+func (r *artifactoryUpload) Name() string {
+	return "enos_artifactory_upload"
+}
+
+func (r *artifactoryUpload) Schema() *tfprotov6.Schema {
+	return newArtifactoryUploadStateV1().Schema()
+}
+
+func (r *artifactoryUpload) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *artifactoryUpload) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *artifactoryUpload) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newArtifactoryUploadStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *artifactoryUpload) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newArtifactoryUploadStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *artifactoryUpload) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newArtifactoryUploadStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *artifactoryUpload) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newArtifactoryUploadStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *artifactoryUpload) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newArtifactoryUploadStateV1()
+	proposedState := newArtifactoryUploadStateV1()
+	res.PlannedState = proposedState
+
+	select {
+	case <-ctx.Done():
+		res.Diagnostics = append(res.Diagnostics, ctxToDiagnostic(ctx))
+		return
+	default:
+	}
+
+	err := priorState.FromTerraform5Value(req.PriorState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+		return
+	}
+
+	err = proposedState.FromTerraform5Value(req.ProposedNewState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+		return
+	}
+
+	// Nothing to plan if we're being destroyed.
+	if req.ProposedNewState.IsNull() {
+		return
+	}
+
+	// Default the item name to the name of the source file.
+	if _, ok := proposedState.Name.Get(); !ok && !proposedState.Name.Unknown {
+		if src, ok := proposedState.Source.Get(); ok {
+			proposedState.Name.Set(filepath.Base(src))
+		} else {
+			proposedState.Name.Unknown = true
+		}
+	}
+
+	// If the source already exists we can determine whether or not it has changed. If it
+	// doesn't, it's likely that it will be created during the apply.
+	proposedState.SHA256.Unknown = true
+	if src, ok := proposedState.Source.Get(); ok {
+		if sum, err := fileSHA256(src); err == nil {
+			proposedState.SHA256.Set(sum)
+		}
+	}
+
+	_, hasPriorID := priorState.ID.Get()
+	if !hasPriorID {
+		proposedState.ID.Unknown = true
+		proposedState.URL.Unknown = true
+	}
+
+	priorSum, hasPriorSum := priorState.SHA256.Get()
+	proposedSum, hasProposedSum := proposedState.SHA256.Get()
+	if !hasPriorID || !hasPriorSum || !hasProposedSum || priorSum != proposedSum {
+		proposedState.SHA1.Unknown = true
+		proposedState.MD5.Unknown = true
+		proposedState.Size.Unknown = true
+	}
+
+	// Changing where the item lives requires us to delete the old item.
+	for _, attr := range []string{"host", "repo", "path", "name"} {
+		res.RequiresReplace = append(res.RequiresReplace, tftypes.NewAttributePathWithSteps(
+			[]tftypes.AttributePathStep{tftypes.AttributeName(attr)},
+		))
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *artifactoryUpload) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newArtifactoryUploadStateV1()
+	plannedState := newArtifactoryUploadStateV1()
+	res.NewState = plannedState
+
+	select {
+	case <-ctx.Done():
+		res.Diagnostics = append(res.Diagnostics, ctxToDiagnostic(ctx))
+		return
+	default:
+	}
+
+	err := plannedState.FromTerraform5Value(req.PlannedState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+		return
+	}
+
+	err = priorState.FromTerraform5Value(req.PriorState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+		return
+	}
+
+	if req.IsDelete() {
+		err = priorState.Delete(ctx)
+		if err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Delete Error", err))
+		}
+
+		return
+	}
+
+	err = plannedState.Validate(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Validation Error", err))
+		return
+	}
+
+	err = plannedState.Upload(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Upload Error", err))
+		return
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *artifactoryUploadStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_artifactory_upload^ resource uploads a local file to Artifactory. This is useful for
+publishing artifacts that a scenario generates, e.g. debug bundles, snapshots, or built binaries.
+
+The item is deployed by checksum when Artifactory already has content with the same checksums, in
+which case the file is not uploaded again. Otherwise the file is uploaded and verified with its
+^sha256^, ^sha1^, and ^md5^ checksums. Any ^properties^ are set on the item when it is deployed.
+
+If the ^source^ file changes the item will be uploaded again. Changing the ^host^, ^repo^, ^path^, or
+^name^ will replace the item. The item is deleted from Artifactory when the resource is destroyed.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "username",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The Artifactory API Key user name. Depending on your login scheme this is likely an email address. If no username is provided we'll assume you wish to use an identity token for Auth",
+				},
+				{
+					Name:        "token",
+					Type:        tftypes.String,
+					Required:    true,
+					Sensitive:   true,
+					Description: "The Artifactory API Key token or identity token. API keys are deprecated so it is best to use an identity token",
+				},
+				{
+					Name:        "host",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The Artifactory API host. It should be the fully qualified base URL",
+				},
+				{
+					Name:        "repo",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The Artifactory repository you want to upload to",
+				},
+				{
+					Name:        "path",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The sub-path inside the Artifactory repository to upload to",
+				},
+				{
+					Name:        "name",
+					Type:        tftypes.String,
+					Optional:    true,
+					Computed:    true,
+					Description: "The name of the item in Artifactory. Defaults to the name of the source file",
+				},
+				{
+					Name:        "source",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The path to the local file to upload",
+				},
+				{
+					Name:        "properties",
+					Type:        tftypes.Map{ElementType: tftypes.String},
+					Optional:    true,
+					Description: "A map of properties to set on the item",
+				},
+				{
+					Name:        "url",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "The fully qualified URL to the item",
+				},
+				{
+					Name:        "sha256",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "The SHA256 sum of the item",
+				},
+				{
+					Name:        "sha1",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "The SHA1 sum of the item",
+				},
+				{
+					Name:        "md5",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "The MD5 sum of the item",
+				},
+				{
+					Name:        "size",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "The size of the item",
+				},
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *artifactoryUploadStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if !s.Host.Unknown {
+		host, ok := s.Host.Get()
+		if !ok || !govalidator.IsURL(host) {
+			return ValidationError("the host must be a valid URL", "host")
+		}
+	}
+
+	for attr, val := range map[string]*tfString{
+		"repo":   s.Repo,
+		"path":   s.Path,
+		"source": s.Source,
+	} {
+		if val.Unknown {
+			continue
+		}
+
+		if v, ok := val.Get(); !ok || v == "" {
+			return ValidationError("you must provide a "+attr, attr)
+		}
+	}
+
+	if !s.Name.Unknown {
+		if name, ok := s.Name.Get(); ok && (name == "" || strings.Contains(name, "/")) {
+			return ValidationError("the name must not be empty or contain a path separator", "name")
+		}
+	}
+
+	return nil
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Vault with As().
+func (s *artifactoryUploadStateV1) FromTerraform5Value(val tftypes.Value) error {
+	_, err := mapAttributesTo(val, map[string]any{
+		"id":         s.ID,
+		"username":   s.Username,
+		"token":      s.Token,
+		"host":       s.Host,
+		"repo":       s.Repo,
+		"path":       s.Path,
+		"name":       s.Name,
+		"source":     s.Source,
+		"properties": s.Properties,
+		"url":        s.URL,
+		"sha256":     s.SHA256,
+		"sha1":       s.SHA1,
+		"md5":        s.MD5,
+		"size":       s.Size,
+	})
+
+	return err
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *artifactoryUploadStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":         s.ID.TFType(),
+		"username":   s.Username.TFType(),
+		"token":      s.Token.TFType(),
+		"host":       s.Host.TFType(),
+		"repo":       s.Repo.TFType(),
+		"path":       s.Path.TFType(),
+		"name":       s.Name.TFType(),
+		"source":     s.Source.TFType(),
+		"properties": s.Properties.TFType(),
+		"url":        s.URL.TFType(),
+		"sha256":     s.SHA256.TFType(),
+		"sha1":       s.SHA1.TFType(),
+		"md5":        s.MD5.TFType(),
+		"size":       s.Size.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *artifactoryUploadStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":         s.ID.TFValue(),
+		"username":   s.Username.TFValue(),
+		"token":      s.Token.TFValue(),
+		"host":       s.Host.TFValue(),
+		"repo":       s.Repo.TFValue(),
+		"path":       s.Path.TFValue(),
+		"name":       s.Name.TFValue(),
+		"source":     s.Source.TFValue(),
+		"properties": s.Properties.TFValue(),
+		"url":        s.URL.TFValue(),
+		"sha256":     s.SHA256.TFValue(),
+		"sha1":       s.SHA1.TFValue(),
+		"md5":        s.MD5.TFValue(),
+		"size":       s.Size.TFValue(),
+	})
+}
+
+// client returns a new artifactory client.
+func (s *artifactoryUploadStateV1) client() *artifactory.Client {
+	return artifactory.NewClient(
+		artifactory.WithHost(s.Host.Value()),
+		artifactory.WithUsername(s.Username.Value()),
+		artifactory.WithToken(s.Token.Value()),
+	)
+}
+
+// itemPath returns the path of the item in the repository.
+func (s *artifactoryUploadStateV1) itemPath() string {
+	name, ok := s.Name.Get()
+	if !ok {
+		name = filepath.Base(s.Source.Value())
+	}
+
+	return path.Join(s.Path.Value(), name)
+}
+
+// Upload uploads the source file to artifactory and sets the computed attributes.
+func (s *artifactoryUploadStateV1) Upload(ctx context.Context) error {
+	src := s.Source.Value()
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return AttributePathError(fmt.Errorf("failed to read source file, due to: %w", err), "source")
+	}
+
+	if plannedSum, ok := s.SHA256.Get(); ok {
+		sum, err := fileSHA256(src)
+		if err != nil {
+			return AttributePathError(fmt.Errorf("failed to read source file, due to: %w", err), "source")
+		}
+
+		if sum != plannedSum {
+			return AttributePathError(
+				errors.New("the source file has changed since the plan was created"), "source",
+			)
+		}
+	}
+
+	reqOpts := []artifactory.UploadOpt{
+		artifactory.WithUploadRepo(s.Repo.Value()),
+		artifactory.WithUploadPath(s.itemPath()),
+		artifactory.WithUploadSourcePath(src),
+	}
+	if props, ok := s.Properties.GetStrings(); ok {
+		reqOpts = append(reqOpts, artifactory.WithUploadProperties(props))
+	}
+
+	client := s.client()
+	res, err := client.Upload(ctx, artifactory.NewUploadRequest(reqOpts...))
+	if err != nil {
+		return fmt.Errorf("upload failed, due to: %w", err)
+	}
+
+	s.ID.Set("static")
+	s.Name.Set(path.Base(s.itemPath()))
+	s.URL.Set(client.ItemURL(s.Repo.Value(), s.itemPath()))
+	s.SHA256.Set(res.Checksums.SHA256)
+	s.SHA1.Set(res.Checksums.SHA1)
+	s.MD5.Set(res.Checksums.MD5)
+	s.Size.Set(strconv.FormatInt(info.Size(), 10))
+
+	return nil
+}
+
+// Delete deletes the item from artifactory.
+func (s *artifactoryUploadStateV1) Delete(ctx context.Context) error {
+	if _, ok := s.ID.Get(); !ok {
+		return nil
+	}
+
+	err := s.client().DeleteItem(ctx, s.Repo.Value(), s.itemPath())
+	if err != nil {
+		return fmt.Errorf("delete failed, due to: %w", err)
+	}
+
+	return nil
+}
+
+// fileSHA256 returns the hex encoded SHA256 sum of the file.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, f)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
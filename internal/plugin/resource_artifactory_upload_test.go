@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// newTestArtifactoryServer returns a stand-in for the Artifactory deploy and delete APIs that
+// stores uploaded items in memory.
+func newTestArtifactoryServer(t *testing.T) (*httptest.Server, map[string][]byte) {
+	t.Helper()
+
+	mu := sync.Mutex{}
+	items := map[string][]byte{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			if r.Header.Get("X-Checksum-Deploy") == "true" {
+				// Never have the content so that we always upload it
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			items[r.URL.Path] = body
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"checksums": map[string]string{"sha256": r.Header.Get("X-Checksum-Sha256")},
+			})
+		case http.MethodDelete:
+			delete(items, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, items
+}
+
+// TestAccResourceArtifactoryUpload tests the artifactory_upload resource.
+func TestAccResourceArtifactoryUpload(t *testing.T) {
+	t.Parallel()
+
+	cfg := template.Must(template.New("enos_artifactory_upload").Parse(`resource "enos_artifactory_upload" "{{.ID.Value}}" {
+  {{if .Username.Value}}
+  username = "{{.Username.Value}}"
+  {{end}}
+  token  = "{{.Token.Value}}"
+  host   = "{{.Host.Value}}"
+  repo   = "{{.Repo.Value}}"
+  path   = "{{.Path.Value}}"
+  source = "{{.Source.Value}}"
+  {{if .Name.Value}}
+  name = "{{.Name.Value}}"
+  {{end}}
+  {{if .Properties.StringValue}}
+  properties = {
+  {{range $name, $val := .Properties.StringValue}}
+    "{{$name}}": "{{$val}}",
+  {{end}}
+  }
+  {{end}}
+}`))
+
+	srv, _ := newTestArtifactoryServer(t)
+	src := filepath.Join(t.TempDir(), "debug.tar.gz")
+	require.NoError(t, os.WriteFile(src, []byte("debug"), 0o644))
+
+	cases := []testAccResourceTemplate{}
+
+	upload := newArtifactoryUploadStateV1()
+	upload.ID.Set("debug")
+	upload.Token.Set("token")
+	upload.Host.Set(srv.URL + "/artifactory")
+	upload.Repo.Set("enos-local")
+	upload.Path.Set("scenarios/smoke")
+	upload.Source.Set(src)
+	upload.Properties.SetStrings(map[string]string{"scenario": "smoke"})
+	cases = append(cases, testAccResourceTemplate{
+		"upload",
+		upload,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_artifactory_upload.debug", "id", regexp.MustCompile(`^static$`)),
+			resource.TestMatchResourceAttr("enos_artifactory_upload.debug", "name", regexp.MustCompile(`^debug.tar.gz$`)),
+			resource.TestMatchResourceAttr("enos_artifactory_upload.debug", "url", regexp.MustCompile(`/artifactory/enos-local/scenarios/smoke/debug.tar.gz$`)),
+			resource.TestMatchResourceAttr("enos_artifactory_upload.debug", "sha256", regexp.MustCompile(`^[a-f0-9]{64}$`)),
+			resource.TestMatchResourceAttr("enos_artifactory_upload.debug", "size", regexp.MustCompile(`^5$`)),
+		),
+		true,
+	})
+
+	named := newArtifactoryUploadStateV1()
+	named.ID.Set("named")
+	named.Token.Set("token")
+	named.Host.Set(srv.URL + "/artifactory")
+	named.Repo.Set("enos-local")
+	named.Path.Set("scenarios/smoke")
+	named.Name.Set("renamed.tar.gz")
+	named.Source.Set(src)
+	cases = append(cases, testAccResourceTemplate{
+		"named",
+		named,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_artifactory_upload.named", "name", regexp.MustCompile(`^renamed.tar.gz$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+func TestArtifactoryUploadUploadAndDelete(t *testing.T) {
+	t.Parallel()
+
+	srv, items := newTestArtifactoryServer(t)
+	src := filepath.Join(t.TempDir(), "debug.tar.gz")
+	require.NoError(t, os.WriteFile(src, []byte("debug"), 0o644))
+
+	upload := newArtifactoryUploadStateV1()
+	upload.Token.Set("token")
+	upload.Host.Set(srv.URL + "/artifactory")
+	upload.Repo.Set("enos-local")
+	upload.Path.Set("scenarios/smoke")
+	upload.Source.Set(src)
+	require.NoError(t, upload.Validate(t.Context()))
+
+	sum, err := fileSHA256(src)
+	require.NoError(t, err)
+	upload.SHA256.Set(sum)
+
+	require.NoError(t, upload.Upload(t.Context()))
+	require.Equal(t, "debug.tar.gz", upload.Name.Value())
+	require.Equal(t, srv.URL+"/artifactory/enos-local/scenarios/smoke/debug.tar.gz", upload.URL.Value())
+	require.Equal(t, sum, upload.SHA256.Value())
+	require.Len(t, upload.SHA1.Value(), 40)
+	require.Len(t, upload.MD5.Value(), 32)
+	require.Equal(t, "5", upload.Size.Value())
+	require.Equal(t, []byte("debug"), items["/artifactory/enos-local/scenarios/smoke/debug.tar.gz"])
+
+	require.NoError(t, upload.Delete(t.Context()))
+	require.Empty(t, items)
+
+	// If the source has changed since we planned we should fail rather than upload it
+	upload.SHA256.Set("0000000000000000000000000000000000000000000000000000000000000000")
+	require.Error(t, upload.Upload(t.Context()))
+}
+
+func TestArtifactoryUploadValidate(t *testing.T) {
+	t.Parallel()
+
+	for desc, test := range map[string]struct {
+		host  string
+		repo  string
+		path  string
+		name  string
+		fails bool
+	}{
+		"valid":        {"https://artifactory.example.com/artifactory", "enos-local", "debug", "", false},
+		"invalid host": {"not a host", "enos-local", "debug", "", true},
+		"no repo":      {"https://artifactory.example.com/artifactory", "", "debug", "", true},
+		"no path":      {"https://artifactory.example.com/artifactory", "enos-local", "", "", true},
+		"invalid name": {"https://artifactory.example.com/artifactory", "enos-local", "debug", "some/name", true},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			upload := newArtifactoryUploadStateV1()
+			upload.Token.Set("token")
+			upload.Host.Set(test.host)
+			upload.Source.Set("/tmp/debug.tar.gz")
+			if test.repo != "" {
+				upload.Repo.Set(test.repo)
+			}
+			if test.path != "" {
+				upload.Path.Set(test.path)
+			}
+			if test.name != "" {
+				upload.Name.Set(test.name)
+			}
+
+			err := upload.Validate(t.Context())
+			if test.fails {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
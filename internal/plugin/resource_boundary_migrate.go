@@ -0,0 +1,323 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/boundary"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+)
+
+type boundaryMigrate struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*boundaryMigrate)(nil)
+
+type boundaryMigrateStateV1 struct {
+	ID         *tfString
+	Transport  *embeddedTransportV1
+	BinName    *tfString
+	BinPath    *tfString
+	ConfigPath *tfString
+	License    *tfString
+
+	failureHandlers
+}
+
+var _ state.State = (*boundaryMigrateStateV1)(nil)
+
+func newBoundaryMigrate() *boundaryMigrate {
+	return &boundaryMigrate{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newBoundaryMigrateStateV1() *boundaryMigrateStateV1 {
+	transport := newEmbeddedTransport()
+	handlers := failureHandlers{TransportDebugFailureHandler(transport)}
+
+	return &boundaryMigrateStateV1{
+		ID:              newTfString(),
+		Transport:       transport,
+		BinName:         newTfString(),
+		BinPath:         newTfString(),
+		ConfigPath:      newTfString(),
+		License:         newTfString(),
+		failureHandlers: handlers,
+	}
+}
+
+func (r *boundaryMigrate) Name() string {
+	return "enos_boundary_migrate"
+}
+
+func (r *boundaryMigrate) Schema() *tfprotov6.Schema {
+	return newBoundaryMigrateStateV1().Schema()
+}
+
+func (r *boundaryMigrate) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *boundaryMigrate) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *boundaryMigrate) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newBoundaryMigrateStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *boundaryMigrate) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newBoundaryMigrateStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *boundaryMigrate) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newBoundaryMigrateStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *boundaryMigrate) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newBoundaryMigrateStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *boundaryMigrate) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newBoundaryMigrateStateV1()
+	proposedState := newBoundaryMigrateStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *boundaryMigrate) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newBoundaryMigrateStateV1()
+	plannedState := newBoundaryMigrateStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do for delete
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	// Only migrate if we're creating the resource or something has changed.
+	if _, ok := priorState.ID.Get(); ok && reflect.DeepEqual(plannedState, priorState) {
+		return
+	}
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	err = plannedState.Migrate(ctx, client)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Boundary Migrate Error", err))
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *boundaryMigrateStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+^enos_boundary_migrate^ is a resource that runs ^boundary database migrate^ to upgrade the schema
+of an existing Boundary database. It is intended to be used in upgrade scenarios after the new
+Boundary binary has been installed and before the controllers have been restarted with it.
+
+The migration is run when the resource is created or any of its attributes change.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "bin_name",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The name of boundary binary we're going to use when migrating the database",
+				},
+				{
+					Name:        "bin_path",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The path to the directory with binary we're going to use when migrating the database",
+				},
+				{
+					Name:        "config_path",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The path to the Boundary configuration to use when migrating the database",
+				},
+				{
+					Name:        "license",
+					Type:        tftypes.String,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The license for Boundary Enterprise",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH),
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *boundaryMigrateStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if _, ok := s.BinPath.Get(); !ok {
+		return ValidationError("you must provide the Boundary bin path", "bin_path")
+	}
+
+	if _, ok := s.ConfigPath.Get(); !ok {
+		return ValidationError("you must provide the Boundary config path", "config_path")
+	}
+
+	return nil
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Boundary with As().
+func (s *boundaryMigrateStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":          s.ID,
+		"bin_name":    s.BinName,
+		"bin_path":    s.BinPath,
+		"config_path": s.ConfigPath,
+		"license":     s.License,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *boundaryMigrateStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":          s.ID.TFType(),
+		"transport":   s.Transport.Terraform5Type(),
+		"bin_name":    s.BinName.TFType(),
+		"bin_path":    s.BinPath.TFType(),
+		"config_path": s.ConfigPath.TFType(),
+		"license":     s.License.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *boundaryMigrateStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":          s.ID.TFValue(),
+		"transport":   s.Transport.Terraform5Value(),
+		"bin_name":    s.BinName.TFValue(),
+		"bin_path":    s.BinPath.TFValue(),
+		"config_path": s.ConfigPath.TFValue(),
+		"license":     s.License.TFValue(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *boundaryMigrateStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+// Migrate migrates the Boundary database.
+func (s *boundaryMigrateStateV1) Migrate(ctx context.Context, client it.Transport) error {
+	req := s.buildMigrateRequest()
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	return boundary.Migrate(ctx, client, req)
+}
+
+// buildMigrateRequest builds a MigrateRequest with options set.
+func (s *boundaryMigrateStateV1) buildMigrateRequest() *boundary.MigrateRequest {
+	// defaults
+	binName := "boundary"
+	if name, ok := s.BinName.Get(); ok {
+		binName = name
+	}
+
+	opts := []boundary.MigrateRequestOpt{
+		boundary.WithMigrateRequestBinName(binName),
+		boundary.WithMigrateRequestBinPath(s.BinPath.Value()),
+		boundary.WithMigrateRequestConfigPath(s.ConfigPath.Value()),
+	}
+	if license, ok := s.License.Get(); ok {
+		opts = append(opts, boundary.WithMigrateRequestLicense(license))
+	}
+
+	return boundary.NewMigrateRequest(opts...)
+}
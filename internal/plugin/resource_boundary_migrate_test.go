@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccResourceBoundaryMigrate tests the boundary_migrate resource.
+func TestAccResourceBoundaryMigrate(t *testing.T) {
+	t.Parallel()
+
+	cfg := template.Must(template.New("enos_boundary_migrate").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_boundary_migrate" "{{.ID.Value}}" {
+  {{if .BinName.Value}}
+  bin_name = "{{.BinName.Value}}"
+  {{end}}
+
+  {{if .BinPath.Value}}
+  bin_path = "{{.BinPath.Value}}"
+  {{end}}
+
+  {{if .ConfigPath.Value}}
+  config_path = "{{.ConfigPath.Value}}"
+  {{end}}
+
+  {{ renderTransport .Transport }}
+}`))
+
+	cases := []testAccResourceTemplate{}
+
+	boundaryMigrate := newBoundaryMigrateStateV1()
+	boundaryMigrate.ID.Set("foo")
+	boundaryMigrate.BinName.Set("boundary")
+	boundaryMigrate.BinPath.Set("/opt/boundary/bin")
+	boundaryMigrate.ConfigPath.Set("/etc/boundary")
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh := newEmbeddedTransportSSH()
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, boundaryMigrate.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		boundaryMigrate,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_boundary_migrate.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_boundary_migrate.foo", "bin_name", regexp.MustCompile(`^boundary$`)),
+			resource.TestMatchResourceAttr("enos_boundary_migrate.foo", "bin_path", regexp.MustCompile(`^/opt/boundary/bin$`)),
+			resource.TestMatchResourceAttr("enos_boundary_migrate.foo", "config_path", regexp.MustCompile(`^/etc/boundary$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
@@ -0,0 +1,399 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/boundary"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+)
+
+type boundaryVerifySession struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*boundaryVerifySession)(nil)
+
+type boundaryVerifySessionStateV1 struct {
+	ID             *tfString
+	AuthLoginName  *tfString
+	AuthMethodID   *tfString
+	AuthPassword   *tfString
+	BinName        *tfString
+	BinPath        *tfString
+	ControllerAddr *tfString
+	SessionID      *tfString
+	TargetID       *tfString
+	Transport      *embeddedTransportV1
+
+	failureHandlers
+}
+
+var _ state.State = (*boundaryVerifySessionStateV1)(nil)
+
+func newBoundaryVerifySession() *boundaryVerifySession {
+	return &boundaryVerifySession{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newBoundaryVerifySessionStateV1() *boundaryVerifySessionStateV1 {
+	transport := newEmbeddedTransport()
+	handlers := failureHandlers{TransportDebugFailureHandler(transport)}
+
+	return &boundaryVerifySessionStateV1{
+		ID:              newTfString(),
+		AuthLoginName:   newTfString(),
+		AuthMethodID:    newTfString(),
+		AuthPassword:    newTfString(),
+		BinName:         newTfString(),
+		BinPath:         newTfString(),
+		ControllerAddr:  newTfString(),
+		SessionID:       newTfString(),
+		TargetID:        newTfString(),
+		Transport:       transport,
+		failureHandlers: handlers,
+	}
+}
+
+func (r *boundaryVerifySession) Name() string {
+	return "enos_boundary_verify_session"
+}
+
+func (r *boundaryVerifySession) Schema() *tfprotov6.Schema {
+	return newBoundaryVerifySessionStateV1().Schema()
+}
+
+func (r *boundaryVerifySession) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *boundaryVerifySession) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *boundaryVerifySession) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newBoundaryVerifySessionStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *boundaryVerifySession) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newBoundaryVerifySessionStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *boundaryVerifySession) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newBoundaryVerifySessionStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *boundaryVerifySession) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newBoundaryVerifySessionStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *boundaryVerifySession) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newBoundaryVerifySessionStateV1()
+	proposedState := newBoundaryVerifySessionStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+		proposedState.SessionID.Unknown = true
+
+		return
+	}
+
+	// A new session will be verified if anything has changed.
+	if !proposedState.Terraform5Value().Equal(priorState.Terraform5Value()) {
+		proposedState.SessionID.Unknown = true
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *boundaryVerifySession) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newBoundaryVerifySessionStateV1()
+	plannedState := newBoundaryVerifySessionStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do on delete
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	// Only verify if we're creating the resource or something has changed.
+	if _, ok := plannedState.SessionID.Get(); ok {
+		return
+	}
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	err = plannedState.Verify(ctx, client)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Boundary Verify Session Error", err))
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *boundaryVerifySessionStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_boundary_verify_session^ resource verifies that a Boundary session can be established to
+a target, e.g. the default target that ^enos_boundary_init^ creates. It is intended to be used after
+a cluster has been created or upgraded.
+
+The resource authenticates with the password auth method, authorizes a session to the target, and
+uses ^boundary connect^ on the target host of the ^transport^ to make a TCP connection through a
+worker to the target. The session must become active for the verification to succeed. It is
+canceled afterwards. Authorization and connection are retried for up to five minutes to allow
+workers to connect to the controllers.
+
+The session is verified when the resource is created or any of its attributes change.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "auth_login_name",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The login name to authenticate with, e.g. enos_boundary_init.auth_login_name",
+				},
+				{
+					Name:        "auth_method_id",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The password auth method ID to authenticate with, e.g. enos_boundary_init.auth_method_id",
+				},
+				{
+					Name:        "auth_password",
+					Type:        tftypes.String,
+					Required:    true,
+					Sensitive:   true,
+					Description: "The password to authenticate with, e.g. enos_boundary_init.auth_password",
+				},
+				{
+					Name:        "bin_name",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The name of boundary binary. Defaults to boundary",
+				},
+				{
+					Name:        "bin_path",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The path to the directory with the boundary binary",
+				},
+				{
+					Name:        "controller_addr",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The address of the Boundary controller API, e.g. http://10.0.0.1:9200",
+				},
+				{
+					Name:        "session_id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "The ID of the verified session",
+				},
+				{
+					Name:        "target_id",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The ID of the target to establish a session to, e.g. enos_boundary_init.target_id",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH),
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *boundaryVerifySessionStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if err := checkK8STransportNotConfigured(s, "enos_boundary_verify_session"); err != nil {
+		return err
+	}
+
+	for name, attr := range map[string]*tfString{
+		"bin_path":        s.BinPath,
+		"controller_addr": s.ControllerAddr,
+		"auth_method_id":  s.AuthMethodID,
+		"auth_login_name": s.AuthLoginName,
+		"auth_password":   s.AuthPassword,
+		"target_id":       s.TargetID,
+	} {
+		if _, ok := attr.Get(); !ok {
+			return ValidationError("you must provide the "+name+" attribute", name)
+		}
+	}
+
+	return nil
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Boundary with As().
+func (s *boundaryVerifySessionStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":              s.ID,
+		"auth_login_name": s.AuthLoginName,
+		"auth_method_id":  s.AuthMethodID,
+		"auth_password":   s.AuthPassword,
+		"bin_name":        s.BinName,
+		"bin_path":        s.BinPath,
+		"controller_addr": s.ControllerAddr,
+		"session_id":      s.SessionID,
+		"target_id":       s.TargetID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *boundaryVerifySessionStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":              s.ID.TFType(),
+		"auth_login_name": s.AuthLoginName.TFType(),
+		"auth_method_id":  s.AuthMethodID.TFType(),
+		"auth_password":   s.AuthPassword.TFType(),
+		"bin_name":        s.BinName.TFType(),
+		"bin_path":        s.BinPath.TFType(),
+		"controller_addr": s.ControllerAddr.TFType(),
+		"session_id":      s.SessionID.TFType(),
+		"target_id":       s.TargetID.TFType(),
+		"transport":       s.Transport.Terraform5Type(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *boundaryVerifySessionStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":              s.ID.TFValue(),
+		"auth_login_name": s.AuthLoginName.TFValue(),
+		"auth_method_id":  s.AuthMethodID.TFValue(),
+		"auth_password":   s.AuthPassword.TFValue(),
+		"bin_name":        s.BinName.TFValue(),
+		"bin_path":        s.BinPath.TFValue(),
+		"controller_addr": s.ControllerAddr.TFValue(),
+		"session_id":      s.SessionID.TFValue(),
+		"target_id":       s.TargetID.TFValue(),
+		"transport":       s.Transport.Terraform5Value(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *boundaryVerifySessionStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+// Verify authenticates with the controller and verifies that a session can be established to
+// the target.
+func (s *boundaryVerifySessionStateV1) Verify(ctx context.Context, client it.Transport) error {
+	opts := []boundary.APIRequestOpt{
+		boundary.WithAPIRequestBinPath(s.BinPath.Value()),
+		boundary.WithAPIRequestAddr(s.ControllerAddr.Value()),
+		boundary.WithAPIRequestAuthMethodID(s.AuthMethodID.Value()),
+		boundary.WithAPIRequestLoginName(s.AuthLoginName.Value()),
+		boundary.WithAPIRequestPassword(s.AuthPassword.Value()),
+	}
+	if name, ok := s.BinName.Get(); ok {
+		opts = append(opts, boundary.WithAPIRequestBinName(name))
+	}
+
+	req := boundary.NewAPIRequest(opts...)
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	// A reasonable amount of time for the workers to connect and the session to be established.
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	token, err := boundary.Authenticate(ctx, client, req)
+	if err != nil {
+		return err
+	}
+	req.Token = token
+
+	session, err := boundary.VerifySession(ctx, client, req, s.TargetID.Value())
+	if err != nil {
+		return err
+	}
+
+	s.SessionID.Set(session.ID)
+
+	return nil
+}
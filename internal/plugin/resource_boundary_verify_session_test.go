@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestBoundaryVerifySessionValidate(t *testing.T) {
+	t.Parallel()
+
+	newState := func() *boundaryVerifySessionStateV1 {
+		s := newBoundaryVerifySessionStateV1()
+		s.BinPath.Set("/opt/boundary/bin")
+		s.ControllerAddr.Set("http://10.0.0.1:9200")
+		s.AuthMethodID.Set("ampw_1234567890")
+		s.AuthLoginName.Set("admin")
+		s.AuthPassword.Set("password")
+		s.TargetID.Set("ttcp_1234567890")
+
+		return s
+	}
+
+	require.NoError(t, newState().Validate(context.Background()))
+
+	noTarget := newState()
+	noTarget.TargetID.Unknown = true
+	require.Error(t, noTarget.Validate(context.Background()))
+
+	noPassword := newState()
+	noPassword.AuthPassword.Unknown = true
+	require.Error(t, noPassword.Validate(context.Background()))
+}
+
+// TestAccResourceBoundaryVerifySession tests the boundary_verify_session resource.
+func TestAccResourceBoundaryVerifySession(t *testing.T) {
+	t.Parallel()
+
+	cfg := template.Must(template.New("enos_boundary_verify_session").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_boundary_verify_session" "{{.ID.Value}}" {
+		bin_path        = "{{.BinPath.Value}}"
+		controller_addr = "{{.ControllerAddr.Value}}"
+		auth_method_id  = "{{.AuthMethodID.Value}}"
+		auth_login_name = "{{.AuthLoginName.Value}}"
+		auth_password   = "{{.AuthPassword.Value}}"
+		target_id       = "{{.TargetID.Value}}"
+
+		{{ renderTransport .Transport }}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	verifySession := newBoundaryVerifySessionStateV1()
+	verifySession.ID.Set("foo")
+	verifySession.BinPath.Set("/opt/boundary/bin")
+	verifySession.ControllerAddr.Set("http://10.0.0.1:9200")
+	verifySession.AuthMethodID.Set("ampw_1234567890")
+	verifySession.AuthLoginName.Set("admin")
+	verifySession.AuthPassword.Set("password")
+	verifySession.TargetID.Set("ttcp_1234567890")
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh := newEmbeddedTransportSSH()
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, verifySession.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		verifySession,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_boundary_verify_session.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_boundary_verify_session.foo", "bin_path", regexp.MustCompile(`^/opt/boundary/bin$`)),
+			resource.TestMatchResourceAttr("enos_boundary_verify_session.foo", "controller_addr", regexp.MustCompile(`^http://10.0.0.1:9200$`)),
+			resource.TestMatchResourceAttr("enos_boundary_verify_session.foo", "target_id", regexp.MustCompile(`^ttcp_1234567890$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
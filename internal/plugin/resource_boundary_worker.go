@@ -0,0 +1,904 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/log"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/boundary"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/hcl"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/systemd"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	tfile "github.com/hashicorp-forge/terraform-provider-enos/internal/transport/file"
+)
+
+const (
+	boundaryWorkerRegistrationWorkerLed     = "worker-led"
+	boundaryWorkerRegistrationControllerLed = "controller-led"
+)
+
+type boundaryWorker struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*boundaryWorker)(nil)
+
+type boundaryWorkerStateV1 struct {
+	ID              *tfString
+	AuthLoginName   *tfString
+	AuthMethodID    *tfString
+	AuthPassword    *tfString
+	BinName         *tfString
+	BinPath         *tfString
+	Config          *boundaryWorkerConfig
+	ConfigName      *tfString
+	ConfigPath      *tfString
+	ControllerAddr  *tfString
+	License         *tfString
+	Registration    *tfString
+	ScopeID         *tfString
+	SystemdUnitName *tfString
+	Transport       *embeddedTransportV1
+	Username        *tfString
+	WorkerID        *tfString
+
+	failureHandlers
+}
+
+type boundaryWorkerConfig struct {
+	AuthStoragePath      *tfString
+	Description          *tfString
+	Extra                *dynamicPseudoTypeBlock
+	InitialUpstreams     *tfStringSlice
+	Listener             *dynamicPseudoTypeBlock
+	Name                 *tfString
+	PublicAddr           *tfString
+	RecordingStoragePath *tfString
+	Tags                 *dynamicPseudoTypeBlock
+}
+
+var _ state.State = (*boundaryWorkerStateV1)(nil)
+
+func newBoundaryWorker() *boundaryWorker {
+	return &boundaryWorker{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newBoundaryWorkerConfig() *boundaryWorkerConfig {
+	return &boundaryWorkerConfig{
+		AuthStoragePath:      newTfString(),
+		Description:          newTfString(),
+		Extra:                newDynamicPseudoTypeBlock(),
+		InitialUpstreams:     newTfStringSlice(),
+		Listener:             newDynamicPseudoTypeBlock(),
+		Name:                 newTfString(),
+		PublicAddr:           newTfString(),
+		RecordingStoragePath: newTfString(),
+		Tags:                 newDynamicPseudoTypeBlock(),
+	}
+}
+
+func newBoundaryWorkerStateV1() *boundaryWorkerStateV1 {
+	transport := newEmbeddedTransport()
+	fh := failureHandlers{
+		TransportDebugFailureHandler(transport),
+		GetApplicationLogsFailureHandler(transport, []string{"boundary-worker"}),
+	}
+
+	return &boundaryWorkerStateV1{
+		ID:              newTfString(),
+		AuthLoginName:   newTfString(),
+		AuthMethodID:    newTfString(),
+		AuthPassword:    newTfString(),
+		BinName:         newTfString(),
+		BinPath:         newTfString(),
+		Config:          newBoundaryWorkerConfig(),
+		ConfigName:      newTfString(),
+		ConfigPath:      newTfString(),
+		ControllerAddr:  newTfString(),
+		License:         newTfString(),
+		Registration:    newTfString(),
+		ScopeID:         newTfString(),
+		SystemdUnitName: newTfString(),
+		Transport:       transport,
+		Username:        newTfString(),
+		WorkerID:        newTfString(),
+		failureHandlers: fh,
+	}
+}
+
+func (r *boundaryWorker) Name() string {
+	return "enos_boundary_worker"
+}
+
+func (r *boundaryWorker) Schema() *tfprotov6.Schema {
+	return newBoundaryWorkerStateV1().Schema()
+}
+
+func (r *boundaryWorker) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *boundaryWorker) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *boundaryWorker) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newBoundaryWorkerStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *boundaryWorker) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newBoundaryWorkerStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *boundaryWorker) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newBoundaryWorkerStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *boundaryWorker) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newBoundaryWorkerStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *boundaryWorker) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newBoundaryWorkerStateV1()
+	proposedState := newBoundaryWorkerStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+		proposedState.WorkerID.Unknown = true
+	}
+
+	// Changing any of these requires the worker to be registered again.
+	for _, attr := range []string{"controller_addr", "registration", "scope_id"} {
+		res.RequiresReplace = append(res.RequiresReplace, tftypes.NewAttributePathWithSteps(
+			[]tftypes.AttributePathStep{tftypes.AttributeName(attr)},
+		))
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *boundaryWorker) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newBoundaryWorkerStateV1()
+	plannedState := newBoundaryWorkerStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do on delete
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	err = plannedState.startWorker(ctx, client)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Boundary Worker Start Error", err))
+		return
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *boundaryWorkerStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_boundary_worker^ resource configures and starts a Boundary worker on a host and registers
+it with a Boundary controller. It renders the worker configuration, manages the systemd unit, starts
+the worker, registers it with the controller, and waits for the worker to connect to the controller.
+
+Workers can be registered in one of two ways:
+- ^worker-led^: the worker is started and generates an auth registration request which is then
+  authorized on the controller.
+- ^controller-led^: the worker is created on the controller first and the worker is configured with
+  the activation token generated by the controller.
+
+Registration uses the Boundary CLI on the target host with the credentials that are returned by
+^enos_boundary_init^, so the host must be able to reach the controller API at ^controller_addr^.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "auth_login_name",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The login name to use when registering the worker, e.g. enos_boundary_init.auth_login_name",
+				},
+				{
+					Name:        "auth_method_id",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The password auth method ID to use when registering the worker, e.g. enos_boundary_init.auth_method_id",
+				},
+				{
+					Name:        "auth_password",
+					Type:        tftypes.String,
+					Required:    true,
+					Sensitive:   true,
+					Description: "The password to use when registering the worker, e.g. enos_boundary_init.auth_password",
+				},
+				{
+					Name:        "bin_name",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The name of boundary binary. Defaults to boundary",
+				},
+				{
+					Name:        "bin_path",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The path to the directory with the boundary binary",
+				},
+				{
+					Name:            "config",
+					Type:            s.Config.Terraform5Type(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description: docCaretToBacktick(`
+The Boundary worker configuration. All attributes are optional.
+- ^config.auth_storage_path^ (String) The worker [auth_storage_path](https://developer.hashicorp.com/boundary/docs/configuration/worker#auth_storage_path) value. Defaults to /var/lib/boundary/worker
+- ^config.description^ (String) The worker description
+- ^config.extra^ (Object) Any additional Boundary configuration that is not otherwise supported. The attributes are rendered at the top level of the configuration file
+- ^config.initial_upstreams^ (List of String) The worker [initial_upstreams](https://developer.hashicorp.com/boundary/docs/configuration/worker#initial_upstreams) value, i.e. the controller cluster addresses
+- ^config.listener^ (Object) The proxy [listener](https://developer.hashicorp.com/boundary/docs/configuration/listener/tcp). Defaults to ^{ address = "0.0.0.0:9202", purpose = "proxy" }^
+- ^config.name^ (String) The worker name
+- ^config.public_addr^ (String) The worker [public_addr](https://developer.hashicorp.com/boundary/docs/configuration/worker#public_addr) value
+- ^config.recording_storage_path^ (String) The worker [recording_storage_path](https://developer.hashicorp.com/boundary/docs/configuration/worker#recording_storage_path) value
+- ^config.tags^ (Object) The worker tags, e.g. ^tags = { type = ["prod", "us-east-1"] }^
+`),
+				},
+				{
+					Name:        "config_name",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The name of the worker configuration file. Defaults to worker.hcl",
+				},
+				{
+					Name:        "config_path",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The path to the directory where the worker configuration is written. Defaults to /etc/boundary",
+				},
+				{
+					Name:        "controller_addr",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The address of the Boundary controller API to register the worker with, e.g. http://10.0.0.1:9200",
+				},
+				{
+					Name:        "license",
+					Type:        tftypes.String,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "A Boundary Enterprise license",
+				},
+				{
+					Name:            "registration",
+					Type:            tftypes.String,
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "How the worker is registered, either `worker-led` or `controller-led`. Defaults to `worker-led`",
+				},
+				{
+					Name:        "scope_id",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The scope to create the worker in. Defaults to global",
+				},
+				{
+					Name:        "unit_name",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The name of the systemd unit. Defaults to boundary-worker",
+				},
+				{
+					Name:        "username",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The local username for the Boundary service. Defaults to boundary",
+				},
+				{
+					Name:        "worker_id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "The ID of the registered worker",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH),
+			},
+		},
+	}
+}
+
+// Validate validates the configuration. This will validate the required attributes
+// and that the transport configuration is valid.
+func (s *boundaryWorkerStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if err := checkK8STransportNotConfigured(s, "enos_boundary_worker"); err != nil {
+		return err
+	}
+
+	for name, attr := range map[string]*tfString{
+		"bin_path":        s.BinPath,
+		"controller_addr": s.ControllerAddr,
+		"auth_method_id":  s.AuthMethodID,
+		"auth_login_name": s.AuthLoginName,
+		"auth_password":   s.AuthPassword,
+	} {
+		if _, ok := attr.Get(); !ok {
+			return ValidationError("you must provide the "+name+" attribute", name)
+		}
+	}
+
+	if reg, ok := s.Registration.Get(); ok {
+		if !slices.Contains([]string{
+			boundaryWorkerRegistrationWorkerLed, boundaryWorkerRegistrationControllerLed,
+		}, reg) {
+			return ValidationError(
+				fmt.Sprintf("unsupported registration: %s, must be one of: %s, %s",
+					reg, boundaryWorkerRegistrationWorkerLed, boundaryWorkerRegistrationControllerLed,
+				),
+				"registration",
+			)
+		}
+	}
+
+	return nil
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Value with As().
+func (s *boundaryWorkerStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":              s.ID,
+		"auth_login_name": s.AuthLoginName,
+		"auth_method_id":  s.AuthMethodID,
+		"auth_password":   s.AuthPassword,
+		"bin_name":        s.BinName,
+		"bin_path":        s.BinPath,
+		"config_name":     s.ConfigName,
+		"config_path":     s.ConfigPath,
+		"controller_addr": s.ControllerAddr,
+		"license":         s.License,
+		"registration":    s.Registration,
+		"scope_id":        s.ScopeID,
+		"unit_name":       s.SystemdUnitName,
+		"username":        s.Username,
+		"worker_id":       s.WorkerID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if vals["config"].IsKnown() {
+		err = s.Config.FromTerraform5Value(vals["config"])
+		if err != nil {
+			return err
+		}
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *boundaryWorkerStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":              s.ID.TFType(),
+		"auth_login_name": s.AuthLoginName.TFType(),
+		"auth_method_id":  s.AuthMethodID.TFType(),
+		"auth_password":   s.AuthPassword.TFType(),
+		"bin_name":        s.BinName.TFType(),
+		"bin_path":        s.BinPath.TFType(),
+		"config":          s.Config.Terraform5Type(),
+		"config_name":     s.ConfigName.TFType(),
+		"config_path":     s.ConfigPath.TFType(),
+		"controller_addr": s.ControllerAddr.TFType(),
+		"license":         s.License.TFType(),
+		"registration":    s.Registration.TFType(),
+		"scope_id":        s.ScopeID.TFType(),
+		"transport":       s.Transport.Terraform5Type(),
+		"unit_name":       s.SystemdUnitName.TFType(),
+		"username":        s.Username.TFType(),
+		"worker_id":       s.WorkerID.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *boundaryWorkerStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":              s.ID.TFValue(),
+		"auth_login_name": s.AuthLoginName.TFValue(),
+		"auth_method_id":  s.AuthMethodID.TFValue(),
+		"auth_password":   s.AuthPassword.TFValue(),
+		"bin_name":        s.BinName.TFValue(),
+		"bin_path":        s.BinPath.TFValue(),
+		"config":          s.Config.Terraform5Value(),
+		"config_name":     s.ConfigName.TFValue(),
+		"config_path":     s.ConfigPath.TFValue(),
+		"controller_addr": s.ControllerAddr.TFValue(),
+		"license":         s.License.TFValue(),
+		"registration":    s.Registration.TFValue(),
+		"scope_id":        s.ScopeID.TFValue(),
+		"transport":       s.Transport.Terraform5Value(),
+		"unit_name":       s.SystemdUnitName.TFValue(),
+		"username":        s.Username.TFValue(),
+		"worker_id":       s.WorkerID.TFValue(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *boundaryWorkerStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+func (c *boundaryWorkerConfig) Terraform5Type() tftypes.Type {
+	return tftypes.Object{
+		AttributeTypes:     c.attrs(),
+		OptionalAttributes: c.optionalAttrs(),
+	}
+}
+
+func (c *boundaryWorkerConfig) attrs() map[string]tftypes.Type {
+	return map[string]tftypes.Type{
+		"auth_storage_path":      c.AuthStoragePath.TFType(),
+		"description":            c.Description.TFType(),
+		"extra":                  c.Extra.TFType(),
+		"initial_upstreams":      c.InitialUpstreams.TFType(),
+		"listener":               c.Listener.TFType(),
+		"name":                   c.Name.TFType(),
+		"public_addr":            c.PublicAddr.TFType(),
+		"recording_storage_path": c.RecordingStoragePath.TFType(),
+		"tags":                   c.Tags.TFType(),
+	}
+}
+
+func (c *boundaryWorkerConfig) optionalAttrs() map[string]struct{} {
+	optional := map[string]struct{}{}
+	for name := range c.attrs() {
+		optional[name] = struct{}{}
+	}
+
+	return optional
+}
+
+// dynamicBlocks returns the dynamic configuration blocks keyed by their attribute name.
+func (c *boundaryWorkerConfig) dynamicBlocks() map[string]*dynamicPseudoTypeBlock {
+	return map[string]*dynamicPseudoTypeBlock{
+		"extra":    c.Extra,
+		"listener": c.Listener,
+		"tags":     c.Tags,
+	}
+}
+
+func (c *boundaryWorkerConfig) Terraform5Value() tftypes.Value {
+	typ := tftypes.Object{
+		AttributeTypes: c.attrs(),
+	}
+
+	vals := map[string]tftypes.Value{
+		"auth_storage_path":      c.AuthStoragePath.TFValue(),
+		"description":            c.Description.TFValue(),
+		"initial_upstreams":      c.InitialUpstreams.TFValue(),
+		"name":                   c.Name.TFValue(),
+		"public_addr":            c.PublicAddr.TFValue(),
+		"recording_storage_path": c.RecordingStoragePath.TFValue(),
+	}
+
+	for name, block := range c.dynamicBlocks() {
+		val, err := block.TFValue()
+		if err != nil {
+			panic(err)
+		}
+		vals[name] = val
+	}
+
+	return tftypes.NewValue(typ, vals)
+}
+
+// FromTerraform5Value unmarshals the value to the struct.
+func (c *boundaryWorkerConfig) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"auth_storage_path":      c.AuthStoragePath,
+		"description":            c.Description,
+		"initial_upstreams":      c.InitialUpstreams,
+		"name":                   c.Name,
+		"public_addr":            c.PublicAddr,
+		"recording_storage_path": c.RecordingStoragePath,
+	})
+	if err != nil {
+		return err
+	}
+
+	for name, block := range c.dynamicBlocks() {
+		v, ok := vals[name]
+		if !ok {
+			continue
+		}
+
+		err = block.FromTFValue(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// authStoragePath returns the configured auth storage path or the default.
+func (c *boundaryWorkerConfig) authStoragePath() string {
+	if path, ok := c.AuthStoragePath.Get(); ok {
+		return path
+	}
+
+	return "/var/lib/boundary/worker"
+}
+
+// ToHCLConfig returns the worker config in the remoteflight HCLConfig format. If an
+// activation token is given it is rendered into the worker block for controller-led
+// registration.
+func (c *boundaryWorkerConfig) ToHCLConfig(activationToken string) *hcl.Builder {
+	hlcBuilder := hcl.NewBuilder()
+	hlcBuilder.AppendAttribute("disable_mlock", true)
+
+	if extra, ok := c.Extra.Object.GetObject(); ok {
+		hlcBuilder.AppendAttributesAsBlocks(extra)
+	}
+
+	listener := map[string]any{
+		"address": "0.0.0.0:9202",
+		"purpose": "proxy",
+	}
+	if attrs, ok := c.Listener.Object.GetObject(); ok {
+		listener = attrs
+	}
+	hlcBuilder.AppendBlock("listener", []string{"tcp"}).AppendAttributes(listener)
+
+	worker := hlcBuilder.AppendBlock("worker", nil)
+
+	if name, ok := c.Name.Get(); ok {
+		worker.AppendAttribute("name", name)
+	}
+
+	if description, ok := c.Description.Get(); ok {
+		worker.AppendAttribute("description", description)
+	}
+
+	if publicAddr, ok := c.PublicAddr.Get(); ok {
+		worker.AppendAttribute("public_addr", publicAddr)
+	}
+
+	if upstreams, ok := c.InitialUpstreams.GetStrings(); ok && len(upstreams) > 0 {
+		worker.AppendAttribute("initial_upstreams", upstreams)
+	}
+
+	worker.AppendAttribute("auth_storage_path", c.authStoragePath())
+
+	if path, ok := c.RecordingStoragePath.Get(); ok {
+		worker.AppendAttribute("recording_storage_path", path)
+	}
+
+	if activationToken != "" {
+		worker.AppendAttribute("controller_generated_activation_token", activationToken)
+	}
+
+	if tags, ok := c.Tags.Object.GetObject(); ok {
+		worker.AppendBlock("tags", nil).AppendAttributes(tags)
+	}
+
+	return hlcBuilder
+}
+
+func (s *boundaryWorkerStateV1) workerRequest() *boundary.APIRequest {
+	opts := []boundary.APIRequestOpt{
+		boundary.WithAPIRequestBinPath(s.BinPath.Value()),
+		boundary.WithAPIRequestAddr(s.ControllerAddr.Value()),
+		boundary.WithAPIRequestAuthMethodID(s.AuthMethodID.Value()),
+		boundary.WithAPIRequestLoginName(s.AuthLoginName.Value()),
+		boundary.WithAPIRequestPassword(s.AuthPassword.Value()),
+	}
+
+	if name, ok := s.BinName.Get(); ok {
+		opts = append(opts, boundary.WithAPIRequestBinName(name))
+	}
+
+	if scope, ok := s.ScopeID.Get(); ok {
+		opts = append(opts, boundary.WithAPIRequestScopeID(scope))
+	}
+
+	return boundary.NewAPIRequest(opts...)
+}
+
+func (s *boundaryWorkerStateV1) startWorker(ctx context.Context, transport it.Transport) error {
+	var err error
+
+	binName := "boundary"
+	if name, ok := s.BinName.Get(); ok {
+		binName = name
+	}
+
+	boundaryUser := "boundary"
+	if user, ok := s.Username.Get(); ok {
+		boundaryUser = user
+	}
+
+	configPath := "/etc/boundary"
+	if path, ok := s.ConfigPath.Get(); ok {
+		configPath = path
+	}
+
+	configName := "worker.hcl"
+	if name, ok := s.ConfigName.Get(); ok {
+		configName = name
+	}
+
+	unitName := "boundary-worker"
+	if unit, ok := s.SystemdUnitName.Get(); ok {
+		unitName = unit
+	}
+
+	registration := boundaryWorkerRegistrationWorkerLed
+	if reg, ok := s.Registration.Get(); ok {
+		registration = reg
+	}
+
+	configFilePath := filepath.Join(configPath, configName)
+	licensePath := filepath.Join(configPath, "boundary.lic")
+	envFilePath := filepath.Join(configPath, unitName+".env")
+	authStoragePath := s.Config.authStoragePath()
+	workerName, _ := s.Config.Name.Get()
+
+	// A reasonable amount of time for the worker to start, register and connect to the
+	// controller.
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	// Authenticate with the controller before we make any changes on the host
+	req := s.workerRequest()
+	if err = req.Validate(); err != nil {
+		return err
+	}
+
+	token, err := boundary.Authenticate(ctx, transport, req)
+	if err != nil {
+		return err
+	}
+	req.Token = token
+
+	// Create the OS user
+	_, err = remoteflight.CreateOrUpdateUser(ctx, transport, remoteflight.NewUser(
+		remoteflight.WithUserName(boundaryUser),
+		remoteflight.WithUserHomeDir(configPath),
+		remoteflight.WithUserShell("/bin/false"),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to find or create the boundary user, due to: %w", err)
+	}
+
+	dirs := []string{configPath, authStoragePath}
+	if path, ok := s.Config.RecordingStoragePath.Get(); ok {
+		dirs = append(dirs, path)
+	}
+	for _, dir := range dirs {
+		err = remoteflight.CreateDirectory(ctx, transport, remoteflight.NewCreateDirectoryRequest(
+			remoteflight.WithDirName(dir),
+			remoteflight.WithDirChown(fmt.Sprintf("%s:%s", boundaryUser, boundaryUser)),
+		))
+		if err != nil {
+			return fmt.Errorf("failed to create directory %s, due to: %w", dir, err)
+		}
+	}
+
+	var envVars []string
+
+	// Copy the license file if we have one
+	if license, ok := s.License.Get(); ok {
+		err = remoteflight.CopyFile(ctx, transport, remoteflight.NewCopyFileRequest(
+			remoteflight.WithCopyFileDestination(licensePath),
+			remoteflight.WithCopyFileChmod("640"),
+			remoteflight.WithCopyFileChown(fmt.Sprintf("%s:%s", boundaryUser, boundaryUser)),
+			remoteflight.WithCopyFileContent(tfile.NewReader(license)),
+		))
+		if err != nil {
+			return fmt.Errorf("failed to copy boundary license, due to: %w", err)
+		}
+
+		envVars = append(envVars, fmt.Sprintf("BOUNDARY_LICENSE=file:///%s\n", licensePath))
+	}
+
+	// Copy the env file regardless, even if envVars empty, so the systemd unit is happy
+	err = remoteflight.CopyFile(ctx, transport, remoteflight.NewCopyFileRequest(
+		remoteflight.WithCopyFileDestination(envFilePath),
+		remoteflight.WithCopyFileChmod("644"),
+		remoteflight.WithCopyFileChown(fmt.Sprintf("%s:%s", boundaryUser, boundaryUser)),
+		remoteflight.WithCopyFileContent(tfile.NewReader(strings.Join(envVars, "\n"))),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to create the boundary worker environment file, due to: %w", err)
+	}
+
+	// Controller-led workers are created on the controller before they are started. We only
+	// do this when we're creating the worker, after which the worker has stored its
+	// credentials in the auth storage path.
+	activationToken := ""
+	_, registered := s.WorkerID.Get()
+	if !registered && registration == boundaryWorkerRegistrationControllerLed {
+		worker, err := boundary.CreateControllerLedWorker(ctx, transport, req, workerName)
+		if err != nil {
+			return err
+		}
+		activationToken = worker.ControllerGeneratedActivationToken
+		s.WorkerID.Set(worker.ID)
+	}
+
+	err = hcl.CreateHCLConfigFile(ctx, transport, hcl.NewCreateHCLConfigFileRequest(
+		hcl.WithHCLConfigFilePath(configFilePath),
+		hcl.WithHCLConfigChmod("640"),
+		hcl.WithHCLConfigChown(fmt.Sprintf("%s:%s", boundaryUser, boundaryUser)),
+		hcl.WithHCLConfigFile(s.Config.ToHCLConfig(activationToken)),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to create the boundary worker configuration file, due to: %w", err)
+	}
+
+	unit := systemd.Unit{
+		"Unit": {
+			"Description":           "HashiCorp Boundary Worker",
+			"Documentation":         "https://www.boundaryproject.io/docs/",
+			"Requires":              "network-online.target",
+			"After":                 "network-online.target",
+			"ConditionFileNotEmpty": configFilePath,
+			"StartLimitIntervalSec": "60",
+			"StartLimitBurst":       "3",
+		},
+		"Service": {
+			"EnvironmentFile":       envFilePath,
+			"User":                  boundaryUser,
+			"Group":                 boundaryUser,
+			"ProtectSystem":         "full",
+			"ProtectHome":           "read-only",
+			"PrivateTmp":            "yes",
+			"PrivateDevices":        "yes",
+			"SecureBits":            "keep-caps",
+			"AmbientCapabilities":   "CAP_IPC_LOCK",
+			"Capabilities":          "CAP_IPC_LOCK+ep",
+			"CapabilityBoundingSet": "CAP_SYSLOG CAP_IPC_LOCK",
+			"NoNewPrivileges":       "yes",
+			"ExecStart":             fmt.Sprintf("%s/%s server -config %s", s.BinPath.Value(), binName, configFilePath),
+			"ExecReload":            "/bin/kill --signal HUP $MAINPID",
+			"KillMode":              "process",
+			"KillSignal":            "SIGINT",
+			"Restart":               "on-failure",
+			"RestartSec":            "5",
+			"TimeoutStopSec":        "30",
+			"LimitNOFILE":           "65536",
+			"LimitMEMLOCK":          "infinity",
+		},
+		"Install": {
+			"WantedBy": "multi-user.target",
+		},
+	}
+
+	sysd := systemd.NewClient(transport, log.NewLogger(ctx))
+
+	err = sysd.CreateUnitFile(ctx, systemd.NewCreateUnitFileRequest(
+		systemd.WithUnitUnitPath(fmt.Sprintf("/etc/systemd/system/%s.service", unitName)),
+		systemd.WithUnitChmod("644"),
+		systemd.WithUnitChown(fmt.Sprintf("%s:%s", boundaryUser, boundaryUser)),
+		systemd.WithUnitFile(unit),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to create the boundary worker systemd unit, due to: %w", err)
+	}
+
+	_, err = sysd.RunSystemctlCommand(ctx, systemd.NewRunSystemctlCommand(
+		systemd.WithSystemctlCommandSubCommand(systemd.SystemctlSubCommandDaemonReload),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to daemon-reload systemd after writing the boundary worker systemd unit, due to: %w", err)
+	}
+
+	err = sysd.RestartService(ctx, unitName)
+	if err != nil {
+		return fmt.Errorf("failed to start the boundary worker service, due to: %w", err)
+	}
+
+	// Worker-led workers generate an auth registration request when they start which we
+	// authorize on the controller.
+	if _, ok := s.WorkerID.Get(); !ok {
+		authRequestToken, err := boundary.WaitForWorkerAuthRequestToken(ctx, transport, authStoragePath)
+		if err != nil {
+			return err
+		}
+
+		worker, err := boundary.CreateWorkerLedWorker(ctx, transport, req, workerName, authRequestToken)
+		if err != nil {
+			return err
+		}
+		s.WorkerID.Set(worker.ID)
+	}
+
+	_, err = boundary.WaitForWorkerConnected(ctx, transport, req, s.WorkerID.Value())
+	if err != nil {
+		return fmt.Errorf("failed to start the boundary worker: %w", err)
+	}
+
+	return nil
+}
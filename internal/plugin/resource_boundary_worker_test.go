@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// newTestBoundaryWorkerConfig returns a boundary worker config with most attributes set.
+func newTestBoundaryWorkerConfig(t *testing.T) *boundaryWorkerConfig {
+	t.Helper()
+
+	workerCfg := newBoundaryWorkerConfig()
+	workerCfg.Name.Set("worker-0")
+	workerCfg.PublicAddr.Set("10.0.1.5:9202")
+	workerCfg.InitialUpstreams.SetStrings([]string{"10.0.0.1:9201", "10.0.0.2:9201"})
+
+	tagsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"type": tftypes.List{ElementType: tftypes.String},
+	}}
+	require.NoError(t, workerCfg.Tags.FromTFValue(tftypes.NewValue(tagsType, map[string]tftypes.Value{
+		"type": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "prod"),
+		}),
+	})))
+
+	return workerCfg
+}
+
+func TestBoundaryWorkerConfigOptionalAttrs(t *testing.T) {
+	t.Parallel()
+
+	workerCfg := newTestBoundaryWorkerConfig(t)
+
+	// Make sure we can create a dynamic value with optional attrs
+	val := workerCfg.Terraform5Value()
+	_, err := tfprotov6.NewDynamicValue(val.Type(), val)
+	require.NoError(t, err)
+
+	// Make sure we can round trip the value
+	newCfg := newBoundaryWorkerConfig()
+	require.NoError(t, newCfg.FromTerraform5Value(val))
+	name, ok := newCfg.Name.Get()
+	require.True(t, ok)
+	require.Equal(t, "worker-0", name)
+	upstreams, ok := newCfg.InitialUpstreams.GetStrings()
+	require.True(t, ok)
+	require.Equal(t, []string{"10.0.0.1:9201", "10.0.0.2:9201"}, upstreams)
+	_, ok = newCfg.Listener.Object.GetObject()
+	require.False(t, ok)
+}
+
+func TestBoundaryWorkerConfigToHCLConfig(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		activationToken string
+		expected        string
+	}{
+		"worker-led": {
+			"",
+			`disable_mlock = true
+listener "tcp" {
+  address = "0.0.0.0:9202"
+  purpose = "proxy"
+}
+worker {
+  name              = "worker-0"
+  public_addr       = "10.0.1.5:9202"
+  initial_upstreams = ["10.0.0.1:9201", "10.0.0.2:9201"]
+  auth_storage_path = "/var/lib/boundary/worker"
+  tags {
+    type = ["prod"]
+  }
+}
+`,
+		},
+		"controller-led": {
+			"neslat_2KrL",
+			`disable_mlock = true
+listener "tcp" {
+  address = "0.0.0.0:9202"
+  purpose = "proxy"
+}
+worker {
+  name                                  = "worker-0"
+  public_addr                           = "10.0.1.5:9202"
+  initial_upstreams                     = ["10.0.0.1:9201", "10.0.0.2:9201"]
+  auth_storage_path                     = "/var/lib/boundary/worker"
+  controller_generated_activation_token = "neslat_2KrL"
+  tags {
+    type = ["prod"]
+  }
+}
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			hcl, err := newTestBoundaryWorkerConfig(t).ToHCLConfig(test.activationToken).BuildHCL()
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, hcl)
+		})
+	}
+}
+
+func TestBoundaryWorkerValidate(t *testing.T) {
+	t.Parallel()
+
+	newState := func() *boundaryWorkerStateV1 {
+		s := newBoundaryWorkerStateV1()
+		s.BinPath.Set("/opt/boundary/bin")
+		s.ControllerAddr.Set("http://10.0.0.1:9200")
+		s.AuthMethodID.Set("ampw_1234567890")
+		s.AuthLoginName.Set("admin")
+		s.AuthPassword.Set("password")
+
+		return s
+	}
+
+	require.NoError(t, newState().Validate(context.Background()))
+
+	controllerLed := newState()
+	controllerLed.Registration.Set("controller-led")
+	require.NoError(t, controllerLed.Validate(context.Background()))
+
+	kms := newState()
+	kms.Registration.Set("kms")
+	require.Error(t, kms.Validate(context.Background()))
+
+	noAddr := newState()
+	noAddr.ControllerAddr.Unknown = true
+	require.Error(t, noAddr.Validate(context.Background()))
+}
+
+// TestAccResourceBoundaryWorker tests the boundary_worker resource.
+func TestAccResourceBoundaryWorker(t *testing.T) {
+	t.Parallel()
+
+	cfg := template.Must(template.New("enos_boundary_worker").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_boundary_worker" "{{.ID.Value}}" {
+		bin_path        = "{{.BinPath.Value}}"
+		controller_addr = "{{.ControllerAddr.Value}}"
+		auth_method_id  = "{{.AuthMethodID.Value}}"
+		auth_login_name = "{{.AuthLoginName.Value}}"
+		auth_password   = "{{.AuthPassword.Value}}"
+
+		{{if .Registration.Value}}
+		registration = "{{.Registration.Value}}"
+		{{end}}
+
+		config = {
+			name = "{{.Config.Name.Value}}"
+			public_addr = "{{.Config.PublicAddr.Value}}"
+			initial_upstreams = [{{range .Config.InitialUpstreams.Value -}}
+			"{{.}}",
+			{{end -}}
+			]
+			tags = {
+				type = ["prod"]
+			}
+		}
+
+		{{ renderTransport .Transport }}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	boundaryWorker := newBoundaryWorkerStateV1()
+	boundaryWorker.ID.Set("foo")
+	boundaryWorker.BinPath.Set("/opt/boundary/bin")
+	boundaryWorker.ControllerAddr.Set("http://10.0.0.1:9200")
+	boundaryWorker.AuthMethodID.Set("ampw_1234567890")
+	boundaryWorker.AuthLoginName.Set("admin")
+	boundaryWorker.AuthPassword.Set("password")
+	boundaryWorker.Registration.Set("controller-led")
+	boundaryWorker.Config.Name.Set("worker-0")
+	boundaryWorker.Config.PublicAddr.Set("10.0.1.5:9202")
+	boundaryWorker.Config.InitialUpstreams.SetStrings([]string{"10.0.0.1:9201"})
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh := newEmbeddedTransportSSH()
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, boundaryWorker.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		boundaryWorker,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_boundary_worker.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_boundary_worker.foo", "bin_path", regexp.MustCompile(`^/opt/boundary/bin$`)),
+			resource.TestMatchResourceAttr("enos_boundary_worker.foo", "controller_addr", regexp.MustCompile(`^http://10.0.0.1:9200$`)),
+			resource.TestMatchResourceAttr("enos_boundary_worker.foo", "registration", regexp.MustCompile(`^controller-led$`)),
+			resource.TestMatchResourceAttr("enos_boundary_worker.foo", "config.name", regexp.MustCompile(`^worker-0$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
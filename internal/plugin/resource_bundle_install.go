@@ -16,7 +16,9 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/artifactcache"
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/artifactory"
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/releases"
@@ -35,15 +37,20 @@ type bundleInstall struct {
 var _ resource.Resource = (*bundleInstall)(nil)
 
 type bundleInstallStateV1 struct {
-	ID          *tfString
-	Path        *tfString
-	Destination *tfString
-	Release     *bundleInstallStateV1Release
-	Artifactory *bundleInstallStateV1Artifactory
-	Transport   *embeddedTransportV1
-	Getter      *tfString
-	Installer   *tfString
-	Name        *tfString
+	ID             *tfString
+	Path           *tfString
+	Destination    *tfString
+	Release        *bundleInstallStateV1Release
+	ReleaseHeaders *tfStringMap
+	Artifactory    *bundleInstallStateV1Artifactory
+	Repository     *bundleInstallStateV1Repository
+	Transport      *embeddedTransportV1
+	Getter         *tfString
+	Installer      *tfString
+	Name           *tfString
+	Version        *tfString
+	SignatureKeyID *tfString
+	BinaryName     *tfString
 
 	failureHandlers
 }
@@ -55,10 +62,24 @@ type bundleInstallStateV1Artifactory struct {
 	SHA256   *tfString
 }
 
+type bundleInstallStateV1Repository struct {
+	Package      *tfString
+	Version      *tfString
+	Name         *tfString
+	URL          *tfString
+	GPGKeyURL    *tfString
+	Distribution *tfString
+	Components   *tfString
+	Configure    *tfBool
+}
+
 type bundleInstallStateV1Release struct {
-	Product *tfString
-	Version *tfString
-	Edition *tfString
+	Product     *tfString
+	Version     *tfString
+	Edition     *tfString
+	TrustedKeys *tfStringSlice
+	BaseURL     *tfString
+	CABundle    *tfString
 }
 
 var _ state.State = (*bundleInstallStateV1)(nil)
@@ -85,13 +106,30 @@ func newBundleInstallStateV1() *bundleInstallStateV1 {
 			SHA256:   newTfString(),
 		},
 		Release: &bundleInstallStateV1Release{
-			Product: newTfString(),
-			Version: newTfString(),
-			Edition: newTfString(),
+			Product:     newTfString(),
+			Version:     newTfString(),
+			Edition:     newTfString(),
+			TrustedKeys: newTfStringSlice(),
+			BaseURL:     newTfString(),
+			CABundle:    newTfString(),
+		},
+		ReleaseHeaders: newTfStringMap(),
+		Repository: &bundleInstallStateV1Repository{
+			Package:      newTfString(),
+			Version:      newTfString(),
+			Name:         newTfString(),
+			URL:          newTfString(),
+			GPGKeyURL:    newTfString(),
+			Distribution: newTfString(),
+			Components:   newTfString(),
+			Configure:    newTfBool(),
 		},
 		Getter:          newTfString(),
 		Installer:       newTfString(),
 		Name:            newTfString(),
+		Version:         newTfString(),
+		SignatureKeyID:  newTfString(),
+		BinaryName:      newTfString(),
 		Transport:       transport,
 		failureHandlers: fh,
 	}
@@ -168,14 +206,36 @@ func (r *bundleInstall) PlanResourceChange(ctx context.Context, req resource.Pla
 		proposedState.Getter.Unknown = true
 		proposedState.Installer.Unknown = true
 		proposedState.Name.Unknown = true
+		proposedState.Version.Unknown = true
+		proposedState.SignatureKeyID.Unknown = true
 	}
 
-	// Make sure that we set a default edition if we have a product
+	// Make sure that we set a default edition if we have a product. We have to do this
+	// before we compare the release to the prior state, which always has the edition set.
 	if _, ok := proposedState.Release.Product.Get(); ok {
 		if _, ok := proposedState.Release.Edition.Get(); !ok {
 			proposedState.Release.Edition.Set("ce")
 		}
 	}
+
+	// If we're installing from releases and the release changes we'll have to resolve the
+	// version and verify the new release SHA256SUMS. Otherwise we'll keep the version that we
+	// previously resolved so that "latest" and version constraints are stable across plans.
+	if !proposedState.ReleaseTerraform5Value().Equal(priorState.ReleaseTerraform5Value()) {
+		proposedState.SignatureKeyID.Unknown = true
+		if _, ok := proposedState.Release.Product.Get(); ok {
+			proposedState.Version.Unknown = true
+		}
+	}
+
+	// If we're installing from a repository and the requested package changes the resolved
+	// package and version will also change.
+	if _, ok := proposedState.Repository.Package.Get(); ok {
+		if !proposedState.RepositoryTerraform5Value().Equal(priorState.RepositoryTerraform5Value()) {
+			proposedState.Name.Unknown = true
+			proposedState.Version.Unknown = true
+		}
+	}
 }
 
 // ApplyResourceChange is the request Terraform sends when it needs to apply a
@@ -203,6 +263,12 @@ func (r *bundleInstall) ApplyResourceChange(ctx context.Context, req resource.Ap
 
 	plannedState.ID.Set("static")
 
+	providerConfig, err := r.GetProviderConfig()
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Provider Config Error", err))
+		return
+	}
+
 	client, err := transport.Client(ctx)
 	if err != nil {
 		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
@@ -211,7 +277,7 @@ func (r *bundleInstall) ApplyResourceChange(ctx context.Context, req resource.Ap
 	defer client.Close()
 
 	if !priorState.equaltTo(plannedState) {
-		err = plannedState.Install(ctx, client)
+		err = plannedState.Install(ctx, client, providerConfig)
 		if err != nil {
 			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Install Error", err))
 			return
@@ -239,13 +305,36 @@ func (s *bundleInstallStateV1) packageGetter() (*remoteflight.PackageInstallGett
 		return remoteflight.PackageInstallGetterArtifactory, nil
 	}
 
+	if _, ok := s.Repository.Package.Get(); ok {
+		return remoteflight.PackageInstallGetterRepository, nil
+	}
+
 	return nil, remoteflight.ErrPackageInstallGetterUnknown
 }
 
-// Install takes a context and transport and installs the artifact on the remote
-// host. Any errors that may be encountered are returned.
-func (s *bundleInstallStateV1) Install(ctx context.Context, client it.Transport) error {
+// releaseInstallVersion returns the release version to install. If the release has not changed
+// the version that we previously resolved will have been planned, in which case we install it
+// rather than resolving "latest" or the version constraint again, which might result in a
+// different version than we planned.
+func (s *bundleInstallStateV1) releaseInstallVersion() (string, bool) {
+	ver, ok := s.Release.Version.Get()
+	if !ok {
+		return "", false
+	}
+
+	if resolved, ok := s.Version.Get(); ok && !releases.IsVersionLiteral(ver) {
+		return resolved, true
+	}
+
+	return ver, true
+}
+
+// Install takes a context, transport, and provider configuration and installs the artifact on the
+// remote host. Any errors that may be encountered are returned.
+func (s *bundleInstallStateV1) Install(ctx context.Context, client it.Transport, providerConfig *config) error {
 	opts := []remoteflight.PackageInstallRequestOpt{}
+	signatureKeyID := ""
+	releaseVersion := ""
 
 	// Determine where we're going to get the package
 	getter, err := s.packageGetter()
@@ -265,9 +354,9 @@ func (s *bundleInstallStateV1) Install(ctx context.Context, client it.Transport)
 		}
 
 		installer := remoteflight.PackageInstallInstallerForFile(path)
-		if installer == remoteflight.PackageInstallInstallerZip {
-			// A destination is only required for zip bundles because other
-			// package types do not need to persist, as the package manager
+		if installer.RequiresDestination() {
+			// A destination is only required for archives and binaries because
+			// other package types do not need to persist, as the package manager
 			// will install them.
 			dest, ok := s.Destination.Get()
 			if !ok {
@@ -279,9 +368,7 @@ func (s *bundleInstallStateV1) Install(ctx context.Context, client it.Transport)
 
 		opts = append(opts, []remoteflight.PackageInstallRequestOpt{
 			remoteflight.WithPackageInstallCopyPath(path),
-			remoteflight.WithPackageInstallInstaller(
-				remoteflight.PackageInstallInstallerForFile(path),
-			),
+			remoteflight.WithPackageInstallInstaller(installer),
 		}...)
 	case remoteflight.PackageInstallGetterReleases:
 		// Install from releases.hashicorp.com. The releases distribution channel
@@ -299,7 +386,7 @@ func (s *bundleInstallStateV1) Install(ctx context.Context, client it.Transport)
 				"release", "product",
 			)
 		}
-		ver, ok := s.Release.Version.Get()
+		ver, ok := s.releaseInstallVersion()
 		if !ok {
 			return ValidationError(
 				"you must set a release version to install from releases.hashicorp.com",
@@ -336,27 +423,58 @@ func (s *bundleInstallStateV1) Install(ctx context.Context, client it.Transport)
 			return ValidationError("you must supply a release edition", "release", "edition")
 		}
 
-		release, err := releases.NewRelease(
+		releaseOpts := []releases.ReleaseOpt{
 			releases.WithReleaseProduct(prod),
 			releases.WithReleaseVersion(ver),
 			releases.WithReleaseEdition(ed),
 			releases.WithReleasePlatform(platform),
 			releases.WithReleaseArch(arch),
-		)
+		}
+		if keys, ok := s.Release.TrustedKeys.GetStrings(); ok && len(keys) > 0 {
+			releaseOpts = append(releaseOpts, releases.WithReleaseTrustedKeys(keys...))
+		}
+		releaseOpts = append(releaseOpts, s.releaseMirrorOpts(providerConfig)...)
+
+		release, err := releases.NewRelease(releaseOpts...)
 		if err != nil {
 			return fmt.Errorf("failed to create release, due to: %w", err)
 		}
 
+		// Resolve "latest", "latest-prerelease", or a version constraint to a release version.
+		releaseVersion, err = release.ResolveVersion()
+		if err != nil {
+			return AttributePathError(
+				fmt.Errorf("failed to resolve release version, due to: %w", err),
+				"release", "version",
+			)
+		}
+
+		// Determining the SHA256 verifies the SHA256SUMS signature with our trusted keys. If we
+		// can't verify it we'll fail rather than install an artifact we can't trust.
 		sha256, err := release.SHA256()
 		if err != nil {
 			return fmt.Errorf("failed to determine release SHA, due to: %w", err)
 		}
+		signatureKeyID = release.SignatureKeyID()
+
+		cachedPath, err := cacheArtifact(ctx, providerConfig, artifactcache.NewArtifact(
+			artifactcache.WithArtifactURL(release.BundleURL()),
+			artifactcache.WithArtifactSHA256(sha256),
+			artifactcache.WithArtifactHeaders(release.Headers),
+			artifactcache.WithArtifactCACert(release.CACert),
+		))
+		if err != nil {
+			return err
+		}
 
 		opts = append(opts, []remoteflight.PackageInstallRequestOpt{
 			remoteflight.WithPackageInstallDestination(dest),
+			remoteflight.WithPackageInstallCachedPath(cachedPath),
 			remoteflight.WithPackageInstallDownloadOpts(
 				remoteflight.WithDownloadRequestURL(release.BundleURL()),
 				remoteflight.WithDownloadRequestSHA256(sha256),
+				remoteflight.WithDownloadRequestHeaders(release.Headers),
+				remoteflight.WithDownloadRequestCACert(release.CACert),
 			),
 			remoteflight.WithPackageInstallInstaller(remoteflight.PackageInstallInstallerZip),
 		}...)
@@ -367,10 +485,10 @@ func (s *bundleInstallStateV1) Install(ctx context.Context, client it.Transport)
 			return ValidationError("you must supply an artifactory url", "artifactory", "url")
 		}
 
-		installer := remoteflight.PackageInstallInstallerForFile(filepath.Base(url))
-		if installer == remoteflight.PackageInstallInstallerZip {
-			// A destination is only required for zip bundles because other
-			// package types do not need to persist, as the package manager
+		installer := remoteflight.PackageInstallInstallerForURL(url)
+		if installer.RequiresDestination() {
+			// A destination is only required for archives and binaries because
+			// other package types do not need to persist, as the package manager
 			// will install them.
 			dest, ok := s.Destination.Get()
 			if !ok {
@@ -396,21 +514,70 @@ func (s *bundleInstallStateV1) Install(ctx context.Context, client it.Transport)
 			remoteflight.WithDownloadRequestURL(url),
 			remoteflight.WithDownloadRequestSHA256(sha),
 		}
+		artifactOpts := []artifactcache.ArtifactOpt{
+			artifactcache.WithArtifactURL(url),
+			artifactcache.WithArtifactSHA256(sha),
+		}
 		if okUsername {
 			downloadOpts = append(downloadOpts, remoteflight.WithDownloadRequestAuthUser(username), remoteflight.WithDownloadRequestAuthPassword(token))
+			artifactOpts = append(artifactOpts, artifactcache.WithArtifactAuthUser(username), artifactcache.WithArtifactAuthPassword(token))
 		} else { // if no username is provided, we can assume we're using an identity token for auth
 			downloadOpts = append(downloadOpts, remoteflight.WithDownloadRequestAuthToken(token))
+			artifactOpts = append(artifactOpts, artifactcache.WithArtifactAuthToken(token))
+		}
+
+		cachedPath, err := cacheArtifact(ctx, providerConfig, artifactcache.NewArtifact(artifactOpts...))
+		if err != nil {
+			return err
 		}
+
 		opts = append(opts, []remoteflight.PackageInstallRequestOpt{
+			remoteflight.WithPackageInstallCachedPath(cachedPath),
 			remoteflight.WithPackageInstallDownloadOpts(downloadOpts...),
 			remoteflight.WithPackageInstallInstaller(installer),
 		}...)
 	case remoteflight.PackageInstallGetterRepository:
-		return remoteflight.ErrPackageInstallGetterUnsupported
+		// Install from an apt or yum repository. We'll use whichever package manager is
+		// available on the target.
+		pkg, ok := s.Repository.Package.Get()
+		if !ok {
+			return ValidationError("you must supply a repository package", "repository", "package")
+		}
+
+		installer, err := remoteflight.PackageInstallInstallerForTarget(ctx, client)
+		if err != nil {
+			return AttributePathError(
+				fmt.Errorf("failed to determine target host package manager, due to: %w", err),
+				"transport",
+			)
+		}
+
+		opts = append(opts, []remoteflight.PackageInstallRequestOpt{
+			remoteflight.WithPackageInstallPackageName(pkg),
+			remoteflight.WithPackageInstallInstaller(installer),
+		}...)
+
+		if ver, ok := s.Repository.Version.Get(); ok {
+			opts = append(opts, remoteflight.WithPackageInstallPackageVersion(ver))
+		}
+
+		if configure, ok := s.Repository.Configure.Get(); !ok || configure {
+			opts = append(opts, remoteflight.WithPackageInstallRepository(&remoteflight.PackageRepository{
+				Name:         s.Repository.Name.Value(),
+				URL:          s.Repository.URL.Value(),
+				GPGKeyURL:    s.Repository.GPGKeyURL.Value(),
+				Distribution: s.Repository.Distribution.Value(),
+				Components:   s.Repository.Components.Value(),
+			}))
+		}
 	default:
 		return remoteflight.ErrPackageInstallGetterUnknown
 	}
 
+	if name, ok := s.BinaryName.Get(); ok {
+		opts = append(opts, remoteflight.WithPackageInstallBinaryName(name))
+	}
+
 	res, err := remoteflight.PackageInstall(ctx, client, remoteflight.NewPackageInstallRequest(opts...))
 	if err != nil {
 		return err
@@ -419,10 +586,73 @@ func (s *bundleInstallStateV1) Install(ctx context.Context, client it.Transport)
 	s.Name.Set(res.Name)
 	s.Getter.Set(string(res.GetterType))
 	s.Installer.Set(string(res.InstallerType))
+	if releaseVersion != "" {
+		s.Version.Set(releaseVersion)
+	} else {
+		s.Version.Set(res.Version)
+	}
+	s.SignatureKeyID.Set(signatureKeyID)
 
 	return err
 }
 
+// cacheArtifact gets the artifact from the provider artifact cache and returns the path to the
+// cached artifact. If no artifact cache has been configured an empty path is returned and the
+// artifact will be downloaded on the target. If we fail to cache the artifact and the provider
+// has been configured to fall back to downloading on the target we'll do that too.
+func cacheArtifact(ctx context.Context, providerConfig *config, artifact *artifactcache.Artifact) (string, error) {
+	if providerConfig == nil {
+		return "", nil
+	}
+
+	dir, ok := providerConfig.ArtifactCacheDir.Get()
+	if !ok || dir == "" {
+		return "", nil
+	}
+
+	cachedPath, err := artifactcache.New(dir).Get(ctx, artifact)
+	if err != nil {
+		if fallback, ok := providerConfig.ArtifactCacheFallback.Get(); ok && fallback {
+			tflog.Warn(ctx, "Unable to cache artifact, falling back to downloading it on the target", map[string]any{
+				"url":   artifact.URL,
+				"error": err.Error(),
+			})
+
+			return "", nil
+		}
+
+		return "", fmt.Errorf("failed to cache artifact, due to: %w", err)
+	}
+
+	return cachedPath, nil
+}
+
+// releaseMirrorOpts returns the release options for the releases endpoint. Any mirror
+// configuration on the resource takes precedence over the provider configuration.
+func (s *bundleInstallStateV1) releaseMirrorOpts(providerConfig *config) []releases.ReleaseOpt {
+	opts := []releases.ReleaseOpt{}
+
+	if baseURL, ok := s.Release.BaseURL.Get(); ok {
+		opts = append(opts, releases.WithReleaseBaseURL(baseURL))
+	} else if baseURL, ok := providerConfig.ReleasesBaseURL.Get(); ok {
+		opts = append(opts, releases.WithReleaseBaseURL(baseURL))
+	}
+
+	if headers, ok := s.ReleaseHeaders.GetStrings(); ok {
+		opts = append(opts, releases.WithReleaseHeaders(headers))
+	} else if headers, ok := providerConfig.ReleasesHeaders.GetStrings(); ok {
+		opts = append(opts, releases.WithReleaseHeaders(headers))
+	}
+
+	if caBundle, ok := s.Release.CABundle.Get(); ok {
+		opts = append(opts, releases.WithReleaseCACert(caBundle))
+	} else if caBundle, ok := providerConfig.ReleasesCABundle.Get(); ok {
+		opts = append(opts, releases.WithReleaseCACert(caBundle))
+	}
+
+	return opts
+}
+
 // Schema is the file states Terraform schema.
 func (s *bundleInstallStateV1) Schema() *tfprotov6.Schema {
 	return &tfprotov6.Schema{
@@ -432,12 +662,22 @@ func (s *bundleInstallStateV1) Schema() *tfprotov6.Schema {
 
 			Description: docCaretToBacktick(`
 The ^enos_bundle_install^ resource is capable of installing HashiCorp release bundles, Debian packages,
-or RPM packages, from a local path, releases.hashicorp.com, or from Artifactory directly onto a
-remote node. While it is possible to use to install any debian or RPM packages from Artifactory or
-from a local source, it has been designed for HashiCorp's release workflow.
-
-While all local artifact, releases.hashicorp.com, and Artifactory methods of install are supported,
-only one can be configured at a time.
+RPM packages, tar archives, or binaries, from a local path, releases.hashicorp.com, Artifactory, or an
+apt or yum package repository directly onto a remote node. While it is possible to use to install any
+debian or RPM packages from Artifactory or from a local source, it has been designed for HashiCorp's
+release workflow.
+
+The installation method is determined by the artifact name. Zip archives are expanded into the
+destination, tar archives (^.tar^, ^.tar.gz^, or ^.tgz^) are expanded into the destination, Debian and
+RPM packages are installed with the package manager, and artifacts without an extension are installed
+into the destination as executable binaries.
+
+While all local artifact, releases.hashicorp.com, Artifactory, and repository methods of install are
+supported, only one can be configured at a time.
+
+Release and Artifactory artifacts are downloaded on the remote node unless the provider has been
+configured with an ^artifact_cache_dir^, in which case they are downloaded and verified once on the
+machine executing Terraform and copied to each remote node.
 `),
 			Attributes: []*tfprotov6.SchemaAttribute{
 				{
@@ -451,13 +691,19 @@ only one can be configured at a time.
 					Type: tftypes.String,
 					// Required when using a zip bundle, optional for RPM and Deb artifacts
 					Optional:    true,
-					Description: "The destination directory of the installed binary, eg: /usr/local/bin/. This is required if the artifact is a zip archive and optional when installing RPM or Deb packages",
+					Description: "The destination directory of the installed binary, eg: /usr/local/bin/. This is required if the artifact is a zip or tar archive or a binary and optional when installing RPM or Deb packages",
 				},
 				{
 					Name:        "path",
 					Type:        tftypes.String,
 					Optional:    true,
-					Description: "The local path to a zip archive install bundle.",
+					Description: "The local path to the artifact. It can be a zip or tar archive, a deb or rpm package, or a binary.",
+				},
+				{
+					Name:        "binary_name",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The name of the installed binary when the artifact is a binary. Defaults to the artifact name",
 				},
 				{
 					Name:            "artifactory",
@@ -480,8 +726,36 @@ only one can be configured at a time.
 					DescriptionKind: tfprotov6.StringKindMarkdown,
 					Description: docCaretToBacktick(`
 - ^release.product^ (String) The product name that you wish to install, eg: 'vault' or 'consul'
-- ^release.version^ (String) The version of the product that you wish to install. Use the full semver version ('2.1.3' or 'latest')
+- ^release.version^ (String) The version of the product that you wish to install. Use the full semver version ('2.1.3'), 'latest', 'latest-prerelease', or a version constraint ('~> 2.1.0'). 'latest', 'latest-prerelease' and constraints are resolved to the greatest matching version of the edition in the releases index. The resolved version is kept until the release configuration changes or the resource is replaced
 - ^release.edition^ (String) The edition of the product that you wish to install. Eg: 'ce', 'ent', 'ent.hsm', 'ent.hsm.fips1403', etc.
+- ^release.trusted_keys^ (List of String) The armored PGP public keys that are trusted to sign the release SHA256SUMS. The SHA256SUMS signature is always verified before the release SHA256 is trusted. Defaults to the HashiCorp release signing key
+- ^release.base_url^ (String) The base URL of the releases endpoint, eg: 'https://releases.example.com'. Use this to install from a mirror of releases.hashicorp.com. Defaults to the provider ^releases_base_url^ or 'https://releases.hashicorp.com'
+- ^release.ca_bundle^ (String) A PEM encoded CA certificate bundle that is used to verify the releases endpoint. Defaults to the provider ^releases_ca_bundle^
+`),
+				},
+				{
+					Name:            "release_headers",
+					Type:            s.ReleaseHeaders.TFType(),
+					Sensitive:       true,
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     docCaretToBacktick("Headers that are added to requests made to the releases endpoint when installing a ^release^, eg: an 'Authorization' header. Defaults to the provider ^releases_headers^"),
+				},
+				{
+					Name:     "repository",
+					Type:     s.RepositoryTerraform5Type(),
+					Optional: true,
+
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description: docCaretToBacktick(`
+- ^repository.package^ (String) The name of the package that you wish to install, eg: 'vault' or 'vault-enterprise'
+- ^repository.version^ (String) The version of the package that you wish to install. Use the upstream version ('1.15.2+ent'), the full package version ('1.15.2+ent-1'), or 'latest'. Defaults to 'latest'
+- ^repository.configure^ (Bool) Whether or not to configure the repository on the target. Set to false to use the repositories that are already configured on the target. Defaults to true
+- ^repository.name^ (String) The repository identifier used to name the repository configuration files. Defaults to 'hashicorp'
+- ^repository.url^ (String) The base URL of the repository. Defaults to the HashiCorp apt or yum repository
+- ^repository.gpg_key_url^ (String) The URL of the ASCII armored repository signing key. Defaults to the HashiCorp signing key
+- ^repository.distribution^ (String) The apt distribution. Defaults to the target's distribution codename
+- ^repository.components^ (String) The apt components. Defaults to 'main'
 `),
 				},
 				{
@@ -505,6 +779,18 @@ only one can be configured at a time.
 					Computed:    true,
 					Description: "The name of the artifact that was installed",
 				},
+				{
+					Name:        "version",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "The resolved version of the release or package that was installed from releases or a repository",
+				},
+				{
+					Name:        "signature_key_id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "The ID of the key that signed the release SHA256SUMS when installing from releases",
+				},
 				s.Transport.SchemaAttributeTransport(supportsSSH),
 			},
 		},
@@ -538,10 +824,14 @@ func (s *bundleInstallStateV1) Validate(ctx context.Context) error {
 		sources++
 	}
 
+	if _, ok := s.Repository.Package.Get(); ok {
+		sources++
+	}
+
 	if sources == 0 {
-		return ValidationError(`no install source configured, you must configure one of ["path", "release", or "artifactory"]`)
+		return ValidationError(`no install source configured, you must configure one of ["path", "release", "artifactory", or "repository"]`)
 	} else if sources >= 2 {
-		return ValidationError(`only one of the install sources ["path", "release", or "artifactory"] can be configured`)
+		return ValidationError(`only one of the install sources ["path", "release", "artifactory", or "repository"] can be configured`)
 	}
 
 	// Make sure the path is valid if it is the install source
@@ -558,17 +848,28 @@ func (s *bundleInstallStateV1) Validate(ctx context.Context) error {
 			return ValidationError("path base directory does not exist", "path")
 		}
 
-		if remoteflight.PackageInstallInstallerForFile(path) == remoteflight.PackageInstallInstallerZip {
-			// A destination is only required for zip bundles because other
-			// package types do not need to persist, as the package manager
+		installer := remoteflight.PackageInstallInstallerForFile(path)
+		if installer == nil {
+			return ValidationError("unable to determine how to install the artifact, it must be a zip or tar archive, deb or rpm package, or a binary", "path")
+		}
+
+		if installer.RequiresDestination() {
+			// A destination is only required for archives and binaries because
+			// other package types do not need to persist, as the package manager
 			// will install them.
 			_, ok := s.Destination.Get()
 			if !ok {
-				return ValidationError("you must set a destination for a local copy install of a zip bundle", "destination")
+				return ValidationError(fmt.Sprintf("you must set a destination for a local copy install of a %s artifact", installer.Type), "destination")
 			}
 		}
 	}
 
+	if _, ok := s.ReleaseHeaders.Get(); ok {
+		if _, ok := s.Release.Product.Get(); !ok {
+			return ValidationError("release_headers can only be set when installing a release", "release_headers")
+		}
+	}
+
 	// Make sure our product is a valid combination
 	if prod, ok := s.Release.Product.Get(); ok {
 		_, ok := s.Destination.Get()
@@ -585,6 +886,24 @@ func (s *bundleInstallStateV1) Validate(ctx context.Context) error {
 				return ValidationError("unsupported vault edition: "+ed, "release", "edition")
 			}
 		}
+
+		if ver, ok := s.Release.Version.Get(); ok {
+			if err := releases.ValidateVersion(ver); err != nil {
+				return ValidationError(err.Error(), "release", "version")
+			}
+		}
+
+		if baseURL, ok := s.Release.BaseURL.Get(); ok {
+			if err := validateReleasesBaseURL(baseURL, "release", "base_url"); err != nil {
+				return err
+			}
+		}
+
+		if caBundle, ok := s.Release.CABundle.Get(); ok {
+			if err := validateReleasesCABundle(caBundle, "release", "ca_bundle"); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Make sure that artifactory URL is a valid URL
@@ -594,13 +913,18 @@ func (s *bundleInstallStateV1) Validate(ctx context.Context) error {
 			return ValidationError(fmt.Errorf("failed to parse artifactory URL due to: %w", err).Error(), "artifactory", "url")
 		}
 
-		if remoteflight.PackageInstallInstallerForFile(u) == remoteflight.PackageInstallInstallerZip {
-			// A destination is only required for zip bundles because other
-			// package types do not need to persist, as the package manager
+		installer := remoteflight.PackageInstallInstallerForURL(u)
+		if installer == nil {
+			return ValidationError("unable to determine how to install the artifact, it must be a zip or tar archive, deb or rpm package, or a binary", "artifactory", "url")
+		}
+
+		if installer.RequiresDestination() {
+			// A destination is only required for archives and binaries because
+			// other package types do not need to persist, as the package manager
 			// will install them.
 			_, ok := s.Destination.Get()
 			if !ok {
-				return ValidationError("you must set a destination for an artifactory install of a zip bundle", "destination")
+				return ValidationError(fmt.Sprintf("you must set a destination for an artifactory install of a %s artifact", installer.Type), "destination")
 			}
 		}
 	}
@@ -611,24 +935,50 @@ func (s *bundleInstallStateV1) Validate(ctx context.Context) error {
 // FromTerraform5Value is a callback to unmarshal from the tftypes.Value with As().
 func (s *bundleInstallStateV1) FromTerraform5Value(val tftypes.Value) error {
 	vals, err := mapAttributesTo(val, map[string]any{
-		"id":          s.ID,
-		"destination": s.Destination,
-		"path":        s.Path,
-		"getter":      s.Getter,
-		"installer":   s.Installer,
-		"name":        s.Name,
+		"id":               s.ID,
+		"destination":      s.Destination,
+		"path":             s.Path,
+		"getter":           s.Getter,
+		"installer":        s.Installer,
+		"name":             s.Name,
+		"version":          s.Version,
+		"signature_key_id": s.SignatureKeyID,
+		"binary_name":      s.BinaryName,
+		"release_headers":  s.ReleaseHeaders,
 	})
 	if err != nil {
 		return err
 	}
 
+	repo, ok := vals["repository"]
+	if ok {
+		if repo.IsKnown() && !repo.IsNull() {
+			_, err = mapAttributesTo(repo, map[string]any{
+				"package":      s.Repository.Package,
+				"version":      s.Repository.Version,
+				"name":         s.Repository.Name,
+				"url":          s.Repository.URL,
+				"gpg_key_url":  s.Repository.GPGKeyURL,
+				"distribution": s.Repository.Distribution,
+				"components":   s.Repository.Components,
+				"configure":    s.Repository.Configure,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	release, ok := vals["release"]
 	if ok {
 		if release.IsKnown() && !release.IsNull() {
 			_, err = mapAttributesTo(release, map[string]any{
-				"product": s.Release.Product,
-				"version": s.Release.Version,
-				"edition": s.Release.Edition,
+				"product":      s.Release.Product,
+				"version":      s.Release.Version,
+				"edition":      s.Release.Edition,
+				"trusted_keys": s.Release.TrustedKeys,
+				"base_url":     s.Release.BaseURL,
+				"ca_bundle":    s.Release.CABundle,
 			})
 			if err != nil {
 				return err
@@ -661,30 +1011,40 @@ func (s *bundleInstallStateV1) FromTerraform5Value(val tftypes.Value) error {
 // Terraform5Type is the file state tftypes.Type.
 func (s *bundleInstallStateV1) Terraform5Type() tftypes.Type {
 	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
-		"id":          s.ID.TFType(),
-		"destination": s.Destination.TFType(),
-		"path":        s.Path.TFType(),
-		"artifactory": s.ArtifactoryTerraform5Type(),
-		"release":     s.ReleaseTerraform5Type(),
-		"getter":      s.Getter.TFType(),
-		"installer":   s.Installer.TFType(),
-		"name":        s.Name.TFType(),
-		"transport":   s.Transport.Terraform5Type(),
+		"id":               s.ID.TFType(),
+		"destination":      s.Destination.TFType(),
+		"path":             s.Path.TFType(),
+		"artifactory":      s.ArtifactoryTerraform5Type(),
+		"release":          s.ReleaseTerraform5Type(),
+		"release_headers":  s.ReleaseHeaders.TFType(),
+		"repository":       s.RepositoryTerraform5Type(),
+		"getter":           s.Getter.TFType(),
+		"installer":        s.Installer.TFType(),
+		"name":             s.Name.TFType(),
+		"version":          s.Version.TFType(),
+		"signature_key_id": s.SignatureKeyID.TFType(),
+		"binary_name":      s.BinaryName.TFType(),
+		"transport":        s.Transport.Terraform5Type(),
 	}}
 }
 
 // Terraform5Type is the file state tftypes.Value.
 func (s *bundleInstallStateV1) Terraform5Value() tftypes.Value {
 	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
-		"id":          s.ID.TFValue(),
-		"destination": s.Destination.TFValue(),
-		"path":        s.Path.TFValue(),
-		"artifactory": s.ArtifactoryTerraform5Value(),
-		"release":     s.ReleaseTerraform5Value(),
-		"getter":      s.Getter.TFValue(),
-		"installer":   s.Installer.TFValue(),
-		"name":        s.Name.TFValue(),
-		"transport":   s.Transport.Terraform5Value(),
+		"id":               s.ID.TFValue(),
+		"destination":      s.Destination.TFValue(),
+		"path":             s.Path.TFValue(),
+		"artifactory":      s.ArtifactoryTerraform5Value(),
+		"release":          s.ReleaseTerraform5Value(),
+		"release_headers":  s.ReleaseHeaders.TFValue(),
+		"repository":       s.RepositoryTerraform5Value(),
+		"getter":           s.Getter.TFValue(),
+		"installer":        s.Installer.TFValue(),
+		"name":             s.Name.TFValue(),
+		"version":          s.Version.TFValue(),
+		"signature_key_id": s.SignatureKeyID.TFValue(),
+		"binary_name":      s.BinaryName.TFValue(),
+		"transport":        s.Transport.Terraform5Value(),
 	})
 }
 
@@ -698,11 +1058,53 @@ func (s *bundleInstallStateV1) ArtifactoryTerraform5Type() tftypes.Type {
 }
 
 func (s *bundleInstallStateV1) ReleaseTerraform5Type() tftypes.Type {
-	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
-		"product": s.Release.Product.TFType(),
-		"version": s.Release.Version.TFType(),
-		"edition": s.Release.Edition.TFType(),
-	}}
+	return tftypes.Object{
+		AttributeTypes: s.releaseAttrs(),
+		OptionalAttributes: map[string]struct{}{
+			"trusted_keys": {},
+			"base_url":     {},
+			"ca_bundle":    {},
+		},
+	}
+}
+
+func (s *bundleInstallStateV1) releaseAttrs() map[string]tftypes.Type {
+	return map[string]tftypes.Type{
+		"product":      s.Release.Product.TFType(),
+		"version":      s.Release.Version.TFType(),
+		"edition":      s.Release.Edition.TFType(),
+		"trusted_keys": s.Release.TrustedKeys.TFType(),
+		"base_url":     s.Release.BaseURL.TFType(),
+		"ca_bundle":    s.Release.CABundle.TFType(),
+	}
+}
+
+func (s *bundleInstallStateV1) RepositoryTerraform5Type() tftypes.Type {
+	return tftypes.Object{
+		AttributeTypes: s.repositoryAttrs(),
+		OptionalAttributes: map[string]struct{}{
+			"version":      {},
+			"name":         {},
+			"url":          {},
+			"gpg_key_url":  {},
+			"distribution": {},
+			"components":   {},
+			"configure":    {},
+		},
+	}
+}
+
+func (s *bundleInstallStateV1) repositoryAttrs() map[string]tftypes.Type {
+	return map[string]tftypes.Type{
+		"package":      s.Repository.Package.TFType(),
+		"version":      s.Repository.Version.TFType(),
+		"name":         s.Repository.Name.TFType(),
+		"url":          s.Repository.URL.TFType(),
+		"gpg_key_url":  s.Repository.GPGKeyURL.TFType(),
+		"distribution": s.Repository.Distribution.TFType(),
+		"components":   s.Repository.Components.TFType(),
+		"configure":    s.Repository.Configure.TFType(),
+	}
 }
 
 func (s *bundleInstallStateV1) ArtifactoryTerraform5Value() tftypes.Value {
@@ -720,16 +1122,41 @@ func (s *bundleInstallStateV1) ArtifactoryTerraform5Value() tftypes.Value {
 }
 
 func (s *bundleInstallStateV1) ReleaseTerraform5Value() tftypes.Value {
+	typ := tftypes.Object{AttributeTypes: s.releaseAttrs()}
+
 	// As this is an optional value, return a nil object instead of nil values
 	if tfStringsSetOrUnknown(s.Release.Product, s.Release.Version, s.Release.Edition) {
-		return tftypes.NewValue(s.ReleaseTerraform5Type(), map[string]tftypes.Value{
-			"product": s.Release.Product.TFValue(),
-			"version": s.Release.Version.TFValue(),
-			"edition": s.Release.Edition.TFValue(),
+		return tftypes.NewValue(typ, map[string]tftypes.Value{
+			"product":      s.Release.Product.TFValue(),
+			"version":      s.Release.Version.TFValue(),
+			"edition":      s.Release.Edition.TFValue(),
+			"trusted_keys": s.Release.TrustedKeys.TFValue(),
+			"base_url":     s.Release.BaseURL.TFValue(),
+			"ca_bundle":    s.Release.CABundle.TFValue(),
 		})
 	}
 
-	return tftypes.NewValue(s.ReleaseTerraform5Type(), nil)
+	return tftypes.NewValue(typ, nil)
+}
+
+func (s *bundleInstallStateV1) RepositoryTerraform5Value() tftypes.Value {
+	typ := tftypes.Object{AttributeTypes: s.repositoryAttrs()}
+
+	// As this is an optional value, return a nil object instead of nil values
+	if !tfStringsSetOrUnknown(s.Repository.Package) {
+		return tftypes.NewValue(typ, nil)
+	}
+
+	return tftypes.NewValue(typ, map[string]tftypes.Value{
+		"package":      s.Repository.Package.TFValue(),
+		"version":      s.Repository.Version.TFValue(),
+		"name":         s.Repository.Name.TFValue(),
+		"url":          s.Repository.URL.TFValue(),
+		"gpg_key_url":  s.Repository.GPGKeyURL.TFValue(),
+		"distribution": s.Repository.Distribution.TFValue(),
+		"components":   s.Repository.Components.TFValue(),
+		"configure":    s.Repository.Configure.TFValue(),
+	})
 }
 
 func (s *bundleInstallStateV1) equaltTo(p *bundleInstallStateV1) bool {
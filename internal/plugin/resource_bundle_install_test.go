@@ -5,14 +5,26 @@ package plugin
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 	"text/template"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/artifactcache"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/releases"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
 )
 
 // TestAccResourceBundleInstall tests the bundle_install resource.
@@ -29,11 +41,26 @@ func TestAccResourceBundleInstall(t *testing.T) {
 		  path = "{{ .Path.Value }}"
 		  {{ end -}}
 
+		  {{ if .BinaryName.Value -}}
+		  binary_name = "{{ .BinaryName.Value }}"
+		  {{ end -}}
+
 		  {{ if .Release.Product.Value -}}
 		  release = {
 			product  = "{{ .Release.Product.Value }}"
 			version  = "{{ .Release.Version.Value }}"
 			edition  = "{{ .Release.Edition.Value }}"
+			{{ if .Release.BaseURL.Value -}}
+			base_url = "{{ .Release.BaseURL.Value }}"
+			{{ end -}}
+		  }
+		  {{ end -}}
+
+		  {{ if .ReleaseHeaders.Val -}}
+		  release_headers = {
+			{{ range $k, $v := .ReleaseHeaders.Val -}}
+			"{{ $k }}" = "{{ $v.Value }}"
+			{{ end -}}
 		  }
 		  {{ end -}}
 
@@ -46,6 +73,13 @@ func TestAccResourceBundleInstall(t *testing.T) {
 		  }
 		  {{ end -}}
 
+		  {{ if .Repository.Package.Value -}}
+		  repository = {
+			package = "{{ .Repository.Package.Value }}"
+			version = "{{ .Repository.Version.Value }}"
+		  }
+		  {{ end -}}
+
 		  {{ renderTransport .Transport }}
 		}`),
 	)
@@ -76,6 +110,27 @@ func TestAccResourceBundleInstall(t *testing.T) {
 		false,
 	})
 
+	installBundleBinary := newBundleInstallStateV1()
+	installBundleBinary.ID.Set("binary")
+	installBundleBinary.Destination.Set("/usr/local/bin")
+	installBundleBinary.Path.Set("/some/local/vault_1.15.2_linux_amd64")
+	installBundleBinary.BinaryName.Set("vault")
+	ssh = newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	ssh.Host.Set("localhost")
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, installBundleBinary.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"binary",
+		installBundleBinary,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_bundle_install.binary", "id", regexp.MustCompile(`^binary$`)),
+			resource.TestMatchResourceAttr("enos_bundle_install.binary", "path", regexp.MustCompile(`^/some/local/vault_1.15.2_linux_amd64$`)),
+			resource.TestMatchResourceAttr("enos_bundle_install.binary", "binary_name", regexp.MustCompile(`^vault$`)),
+		),
+		false,
+	})
+
 	installBundleRelease := newBundleInstallStateV1()
 	installBundleRelease.ID.Set("release")
 	installBundleRelease.Destination.Set("/usr/local/bin/vault")
@@ -102,6 +157,31 @@ func TestAccResourceBundleInstall(t *testing.T) {
 		false,
 	})
 
+	installBundleReleaseMirror := newBundleInstallStateV1()
+	installBundleReleaseMirror.ID.Set("mirror")
+	installBundleReleaseMirror.Destination.Set("/usr/local/bin/vault")
+	installBundleReleaseMirror.Release.Product.Set("vault")
+	installBundleReleaseMirror.Release.Version.Set("1.7.0")
+	installBundleReleaseMirror.Release.Edition.Set("ent")
+	installBundleReleaseMirror.Release.BaseURL.Set("https://releases.example.com/mirror")
+	installBundleReleaseMirror.ReleaseHeaders.SetStrings(map[string]string{"Authorization": "Bearer token"})
+	ssh = newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	ssh.Host.Set("localhost")
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, installBundleReleaseMirror.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"release_mirror",
+		installBundleReleaseMirror,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_bundle_install.mirror", "id", regexp.MustCompile(`^mirror$`)),
+			resource.TestMatchResourceAttr("enos_bundle_install.mirror", "release.product", regexp.MustCompile(`^vault$`)),
+			resource.TestMatchResourceAttr("enos_bundle_install.mirror", "release.base_url", regexp.MustCompile(`^https://releases.example.com/mirror$`)),
+			resource.TestMatchResourceAttr("enos_bundle_install.mirror", "release_headers.Authorization", regexp.MustCompile(`^Bearer token$`)),
+		),
+		false,
+	})
+
 	installBundleArtifactory := newBundleInstallStateV1()
 	installBundleArtifactory.ID.Set("art")
 	installBundleArtifactory.Destination.Set("/opt/vault/bin")
@@ -130,6 +210,28 @@ func TestAccResourceBundleInstall(t *testing.T) {
 		false,
 	})
 
+	installBundleRepository := newBundleInstallStateV1()
+	installBundleRepository.ID.Set("repo")
+	installBundleRepository.Repository.Package.Set("vault-enterprise")
+	installBundleRepository.Repository.Version.Set("1.15.2+ent")
+	ssh = newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	ssh.Host.Set("localhost")
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, installBundleRepository.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"repository",
+		installBundleRepository,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_bundle_install.repo", "id", regexp.MustCompile(`^repo$`)),
+			resource.TestMatchResourceAttr("enos_bundle_install.repo", "repository.package", regexp.MustCompile(`^vault-enterprise$`)),
+			resource.TestMatchResourceAttr("enos_bundle_install.repo", "repository.version", regexp.MustCompile(`^1.15.2\+ent$`)),
+			resource.TestMatchResourceAttr("enos_bundle_install.repo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
+			resource.TestMatchResourceAttr("enos_bundle_install.repo", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
+		),
+		false,
+	})
+
 	// To do a real test, set the environment variables when running `make test-acc`
 	enosVars, ok := ensureEnosTransportEnvVars(t)
 	if ok {
@@ -225,3 +327,375 @@ func TestAccResourceBundleInstall(t *testing.T) {
 		})
 	}
 }
+
+// TestBundleInstallRepositoryOptionalAttrs tests that we can marshal and unmarshal the repository
+// with only some of the optional attributes set.
+func TestBundleInstallRepositoryOptionalAttrs(t *testing.T) {
+	t.Parallel()
+
+	bundleInstall := newBundleInstallStateV1()
+	bundleInstall.Repository.Package.Set("vault")
+	bundleInstall.Repository.Version.Set("1.15.2")
+	bundleInstall.Repository.Configure.Set(false)
+
+	// Make sure we can create a dynamic value with optional attrs
+	val := bundleInstall.Terraform5Value()
+	_, err := tfprotov6.NewDynamicValue(val.Type(), val)
+	require.NoError(t, err)
+
+	// Make sure we can round trip the value
+	newBundleInstall := newBundleInstallStateV1()
+	require.NoError(t, newBundleInstall.FromTerraform5Value(val))
+	pkg, ok := newBundleInstall.Repository.Package.Get()
+	require.True(t, ok)
+	require.Equal(t, "vault", pkg)
+	configure, ok := newBundleInstall.Repository.Configure.Get()
+	require.True(t, ok)
+	require.False(t, configure)
+	_, ok = newBundleInstall.Repository.URL.Get()
+	require.False(t, ok)
+
+	getter, err := newBundleInstall.packageGetter()
+	require.NoError(t, err)
+	require.Equal(t, remoteflight.PackageInstallGetterRepository, getter)
+}
+
+// TestBundleInstallReleaseMirrorOpts tests that the release mirror configuration on the resource
+// takes precedence over the provider configuration.
+func TestBundleInstallReleaseMirrorOpts(t *testing.T) {
+	t.Parallel()
+
+	providerConfig := newProviderConfig()
+	providerConfig.ReleasesBaseURL.Set("https://provider.example.com/")
+	providerConfig.ReleasesHeaders.SetStrings(map[string]string{"Authorization": "Bearer provider"})
+	providerConfig.ReleasesCABundle.Set("PROVIDER CA")
+
+	newRelease := func(t *testing.T, s *bundleInstallStateV1, cfg *config) *releases.Release {
+		t.Helper()
+
+		rel, err := releases.NewRelease(append([]releases.ReleaseOpt{
+			releases.WithReleaseProduct("vault"),
+			releases.WithReleaseVersion("1.15.2"),
+			releases.WithReleaseEdition("ce"),
+			releases.WithReleasePlatform("linux"),
+			releases.WithReleaseArch("amd64"),
+		}, s.releaseMirrorOpts(cfg)...)...)
+		require.NoError(t, err)
+
+		return rel
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		rel := newRelease(t, newBundleInstallStateV1(), newProviderConfig())
+		require.Equal(t, "https://releases.hashicorp.com/vault/1.15.2/vault_1.15.2_linux_amd64.zip", rel.BundleURL())
+		require.Empty(t, rel.Headers)
+		require.Empty(t, rel.CACert)
+	})
+
+	t.Run("provider", func(t *testing.T) {
+		t.Parallel()
+
+		rel := newRelease(t, newBundleInstallStateV1(), providerConfig)
+		require.Equal(t, "https://provider.example.com/vault/1.15.2/vault_1.15.2_linux_amd64.zip", rel.BundleURL())
+		require.Equal(t, map[string]string{"Authorization": "Bearer provider"}, rel.Headers)
+		require.Equal(t, "PROVIDER CA", rel.CACert)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+		t.Parallel()
+
+		s := newBundleInstallStateV1()
+		s.Release.BaseURL.Set("https://resource.example.com")
+		s.ReleaseHeaders.SetStrings(map[string]string{"Authorization": "Bearer resource"})
+		s.Release.CABundle.Set("RESOURCE CA")
+
+		rel := newRelease(t, s, providerConfig)
+		require.Equal(t, "https://resource.example.com/vault/1.15.2/vault_1.15.2_linux_amd64.zip", rel.BundleURL())
+		require.Equal(t, map[string]string{"Authorization": "Bearer resource"}, rel.Headers)
+		require.Equal(t, "RESOURCE CA", rel.CACert)
+	})
+}
+
+// TestBundleInstallValidateReleaseVersion tests that we validate the release version.
+func TestBundleInstallValidateReleaseVersion(t *testing.T) {
+	t.Parallel()
+
+	for ver, valid := range map[string]bool{
+		"1.15.2":              true,
+		"latest":              true,
+		"latest-prerelease":   true,
+		"~> 1.15.0":           true,
+		">= 1.14.0, < 1.16":   true,
+		"newest":              false,
+		"~> not-a-constraint": false,
+	} {
+		t.Run(ver, func(t *testing.T) {
+			t.Parallel()
+
+			s := newBundleInstallStateV1()
+			s.Destination.Set("/opt/vault/bin")
+			s.Release.Product.Set("vault")
+			s.Release.Version.Set(ver)
+			s.Release.Edition.Set("ent")
+
+			err := s.Validate(t.Context())
+			if valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+// TestBundleInstallReleaseHeaders tests that only the release headers are sensitive and that they
+// can only be set when installing a release.
+func TestBundleInstallReleaseHeaders(t *testing.T) {
+	t.Parallel()
+
+	s := newBundleInstallStateV1()
+	for _, attr := range s.Schema().Block.Attributes {
+		switch attr.Name {
+		case "release":
+			require.False(t, attr.Sensitive)
+		case "release_headers":
+			require.True(t, attr.Sensitive)
+		}
+	}
+
+	s.Destination.Set("/opt/vault/bin")
+	s.Path.Set("/tmp/vault.zip")
+	s.ReleaseHeaders.SetStrings(map[string]string{"Authorization": "Bearer token"})
+	require.Error(t, s.Validate(t.Context()))
+
+	s = newBundleInstallStateV1()
+	s.Destination.Set("/opt/vault/bin")
+	s.ReleaseHeaders.SetStrings(map[string]string{"Authorization": "Bearer token"})
+	s.Release.Product.Set("vault")
+	s.Release.Version.Set("1.15.2")
+	s.Release.Edition.Set("ent")
+	require.NoError(t, s.Validate(t.Context()))
+}
+
+// TestBundleInstallValidateArtifactInstallers tests that we require a destination for artifacts
+// that need to persist and that we can determine how to install them.
+func TestBundleInstallValidateArtifactInstallers(t *testing.T) {
+	t.Parallel()
+
+	for desc, test := range map[string]struct {
+		path        string
+		url         string
+		destination string
+		valid       bool
+	}{
+		"zip with destination":    {path: "/tmp/vault.zip", destination: "/opt/vault/bin", valid: true},
+		"zip":                     {path: "/tmp/vault.zip"},
+		"tar.gz with destination": {path: "/tmp/vault.tar.gz", destination: "/opt/vault/bin", valid: true},
+		"tgz":                     {path: "/tmp/vault.tgz"},
+		"binary with destination": {path: "/tmp/vault", destination: "/opt/vault/bin", valid: true},
+		"binary":                  {path: "/tmp/vault"},
+		"deb":                     {path: "/tmp/vault.deb", valid: true},
+		"unknown":                 {path: "/tmp/vault.unknown", destination: "/opt/vault/bin"},
+		"artifactory tar":         {url: "https://artifactory.example.com/vault.tar.gz", destination: "/opt/vault/bin", valid: true},
+		"artifactory binary":      {url: "https://artifactory.example.com/vault?x=y"},
+		"artifactory rpm":         {url: "https://artifactory.example.com/vault.rpm", valid: true},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			s := newBundleInstallStateV1()
+			if test.path != "" {
+				s.Path.Set(test.path)
+			}
+			if test.url != "" {
+				s.Artifactory.URL.Set(test.url)
+				s.Artifactory.Token.Set("token")
+				s.Artifactory.SHA256.Set("abcd")
+			}
+			if test.destination != "" {
+				s.Destination.Set(test.destination)
+			}
+
+			err := s.Validate(t.Context())
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestBundleInstallCacheArtifact(t *testing.T) {
+	t.Parallel()
+
+	body := []byte("vault bundle")
+	sum := sha256.Sum256(body)
+	sha := hex.EncodeToString(sum[:])
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/vault.zip" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+
+	newArtifact := func(name string) *artifactcache.Artifact {
+		return artifactcache.NewArtifact(
+			artifactcache.WithArtifactURL(srv.URL+"/"+name),
+			artifactcache.WithArtifactSHA256(sha),
+		)
+	}
+
+	t.Run("no cache dir", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := cacheArtifact(t.Context(), newProviderConfig(), newArtifact("vault.zip"))
+		require.NoError(t, err)
+		require.Empty(t, p)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newProviderConfig()
+		cfg.ArtifactCacheDir.Set(t.TempDir())
+		p, err := cacheArtifact(t.Context(), cfg, newArtifact("vault.zip"))
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(cfg.ArtifactCacheDir.Value(), sha, "vault.zip"), p)
+	})
+
+	t.Run("fails without fallback", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newProviderConfig()
+		cfg.ArtifactCacheDir.Set(t.TempDir())
+		_, err := cacheArtifact(t.Context(), cfg, newArtifact("missing.zip"))
+		require.Error(t, err)
+	})
+
+	t.Run("falls back to remote download", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newProviderConfig()
+		cfg.ArtifactCacheDir.Set(t.TempDir())
+		cfg.ArtifactCacheFallback.Set(true)
+		p, err := cacheArtifact(t.Context(), cfg, newArtifact("missing.zip"))
+		require.NoError(t, err)
+		require.Empty(t, p)
+	})
+}
+
+// TestBundleInstallPlanReleaseEditionDefault tests that omitting the release edition does not
+// cause the resolved release attributes to be unknown on every plan.
+func TestBundleInstallPlanReleaseEditionDefault(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+
+	newState := func(edition string) *bundleInstallStateV1 {
+		s := newBundleInstallStateV1()
+		s.ID.Set("found")
+		s.Destination.Set("/opt/vault/bin")
+		s.Release.Product.Set("vault")
+		s.Release.Version.Set("1.15.2")
+		if edition != "" {
+			s.Release.Edition.Set(edition)
+		}
+		s.Getter.Set("releases")
+		s.Installer.Set("zip")
+		s.Name.Set("vault")
+		s.Version.Set("1.15.2")
+		s.SignatureKeyID.Set("72D7468F")
+		ssh := newEmbeddedTransportSSH()
+		ssh.User.Set("ubuntu")
+		ssh.Host.Set("localhost")
+		ssh.PrivateKey.Set(privateKey)
+		require.NoError(t, s.Transport.SetTransportState(ssh))
+
+		return s
+	}
+
+	for desc, test := range map[string]struct {
+		proposed      *bundleInstallStateV1
+		expectUnknown bool
+	}{
+		"edition omitted":   {newState(""), false},
+		"default edition":   {newState("ce"), false},
+		"different edition": {newState("ent"), true},
+		"different destination": {
+			func() *bundleInstallStateV1 {
+				s := newState("")
+				s.Destination.Set("/usr/local/bin")
+
+				return s
+			}(),
+			false,
+		},
+		"different version": {
+			func() *bundleInstallStateV1 {
+				s := newState("")
+				s.Release.Version.Set("1.16.0")
+
+				return s
+			}(),
+			true,
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			res := &resourcerouter.PlanResourceChangeResponse{}
+			newBundleInstall().PlanResourceChange(context.Background(), resourcerouter.PlanResourceChangeRequest{
+				PriorState:       newState("ce").Terraform5Value(),
+				ProposedNewState: test.proposed.Terraform5Value(),
+			}, res)
+			require.Empty(t, res.Diagnostics)
+
+			planned, ok := res.PlannedState.(*bundleInstallStateV1)
+			require.True(t, ok)
+			require.Equal(t, test.expectUnknown, planned.SignatureKeyID.Unknown)
+			require.Equal(t, test.expectUnknown, planned.Version.Unknown)
+		})
+	}
+}
+
+// TestBundleInstallReleaseInstallVersion tests that we install the planned version that we
+// previously resolved rather than resolving the release version again.
+func TestBundleInstallReleaseInstallVersion(t *testing.T) {
+	t.Parallel()
+
+	for desc, test := range map[string]struct {
+		release  string
+		planned  string
+		unknown  bool
+		expected string
+	}{
+		"latest resolved":       {"latest", "1.15.2", false, "1.15.2"},
+		"latest unresolved":     {"latest", "", true, "latest"},
+		"constraint resolved":   {"~> 1.15.0", "1.15.2", false, "1.15.2"},
+		"constraint unresolved": {"~> 1.15.0", "", true, "~> 1.15.0"},
+		"literal":               {"1.16.0", "1.15.2", false, "1.16.0"},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			s := newBundleInstallStateV1()
+			s.Release.Version.Set(test.release)
+			if test.unknown {
+				s.Version.Unknown = true
+			} else {
+				s.Version.Set(test.planned)
+			}
+
+			ver, ok := s.releaseInstallVersion()
+			require.True(t, ok)
+			require.Equal(t, test.expected, ver)
+		})
+	}
+}
@@ -0,0 +1,535 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/consul"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+)
+
+type consulACL struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*consulACL)(nil)
+
+type consulACLStateV1 struct {
+	ID               *tfString
+	BinPath          *tfString
+	ConsulAddr       *tfString
+	ManagementToken  *tfString
+	Policies         *tfStringMap
+	Tokens           *tfObjectSlice
+	TokenAccessorIDs *tfStringMap
+	TokenSecretIDs   *tfStringMap
+	TLS              *tlsClientConfig
+	Transport        *embeddedTransportV1
+
+	failureHandlers
+}
+
+var _ state.State = (*consulACLStateV1)(nil)
+
+func newConsulACL() *consulACL {
+	return &consulACL{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newConsulACLStateV1() *consulACLStateV1 {
+	transport := newEmbeddedTransport()
+	fh := failureHandlers{
+		TransportDebugFailureHandler(transport),
+		GetApplicationLogsFailureHandler(transport, []string{"consul"}),
+	}
+
+	tokens := newTfObjectSlice()
+	tokens.AttrTypes = map[string]tftypes.Type{
+		"name":     tftypes.String,
+		"policies": tftypes.List{ElementType: tftypes.String},
+	}
+
+	return &consulACLStateV1{
+		ID:               newTfString(),
+		BinPath:          newTfString(),
+		ConsulAddr:       newTfString(),
+		ManagementToken:  newTfString(),
+		Policies:         newTfStringMap(),
+		Tokens:           tokens,
+		TokenAccessorIDs: newTfStringMap(),
+		TokenSecretIDs:   newTfStringMap(),
+		TLS:              newTLSClientConfig(),
+		Transport:        transport,
+		failureHandlers:  fh,
+	}
+}
+
+func (r *consulACL) Name() string {
+	return "enos_consul_acl"
+}
+
+func (r *consulACL) Schema() *tfprotov6.Schema {
+	return newConsulACLStateV1().Schema()
+}
+
+func (r *consulACL) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *consulACL) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *consulACL) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newConsulACLStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *consulACL) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newConsulACLStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *consulACL) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newConsulACLStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *consulACL) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newConsulACLStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *consulACL) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newConsulACLStateV1()
+	proposedState := newConsulACLStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+		if _, ok := proposedState.ManagementToken.Get(); !ok {
+			proposedState.ManagementToken.Unknown = true
+		}
+		proposedState.TokenAccessorIDs.Unknown = true
+		proposedState.TokenSecretIDs.Unknown = true
+
+		return
+	}
+
+	// Our computed token IDs might change if the tokens or the policies change.
+	if !reflect.DeepEqual(priorState.Tokens, proposedState.Tokens) ||
+		!reflect.DeepEqual(priorState.Policies, proposedState.Policies) {
+		proposedState.TokenAccessorIDs.Unknown = true
+		proposedState.TokenSecretIDs.Unknown = true
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *consulACL) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newConsulACLStateV1()
+	plannedState := newConsulACLStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// Consul does not support resetting the ACL system so there's nothing to do.
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	// Only apply if we're creating the resource or something has changed.
+	if _, ok := priorState.ID.Get(); ok && reflect.DeepEqual(plannedState, priorState) {
+		return
+	}
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	_, hasManagementToken := plannedState.ManagementToken.Get()
+
+	err = plannedState.Apply(ctx, client)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Consul ACL Error", err))
+
+		// The management token can only be retrieved when we bootstrap the ACL system so we have to
+		// save it even though we failed to write the policies or tokens.
+		if _, ok := plannedState.ManagementToken.Get(); ok && !hasManagementToken {
+			plannedState.setUnknownTokenIDsNull()
+			res.SaveStateOnError = true
+			res.Diagnostics = append(res.Diagnostics, &tfprotov6.Diagnostic{
+				Severity: tfprotov6.DiagnosticSeverityWarning,
+				Summary:  "Consul ACL Bootstrapped",
+				Detail: "The ACL system was bootstrapped before the failure and the management token has been " +
+					"saved to the management_token attribute. As the ACL system cannot be bootstrapped again you " +
+					"must set management_token to the saved token if the resource is recreated.",
+			})
+		}
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *consulACLStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_consul_acl^ resource bootstraps the Consul ACL system, writes ACL policies and creates
+ACL tokens that are attached to them. It is used to configure an ACL enabled Consul cluster, e.g.
+when Consul is used as the storage backend for Vault.
+
+The ACL system is bootstrapped with ^consul acl bootstrap^ and the resulting management token is
+exported as ^management_token^. If the ACL system has already been bootstrapped, e.g. because
+an initial management token was set in the agent configuration, the ^management_token^ attribute
+must be set.
+
+Policies are identified by their name and tokens by their name, which is used as the token
+description. Existing policies and tokens are updated rather than recreated, making it safe to
+re-apply the resource. The accessor and secret IDs of each token are exported in maps keyed by the
+token name.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        s.ID.TFType(),
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "bin_path",
+					Type:        s.BinPath.TFType(),
+					Required:    true,
+					Description: "The fully qualified path to the consul binary",
+				},
+				{
+					Name:            "consul_addr",
+					Type:            s.ConsulAddr.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The address of the Consul HTTP API. Defaults to `http://127.0.0.1:8500`",
+				},
+				{
+					Name:            "management_token",
+					Type:            s.ManagementToken.TFType(),
+					Optional:        true,
+					Computed:        true,
+					Sensitive:       true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The ACL management token. If unset the ACL system will be bootstrapped and the resulting token exported. It is required if the ACL system has already been bootstrapped",
+				},
+				{
+					Name:            "policies",
+					Type:            s.Policies.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "A map of ACL policy names to policy rules, e.g. `{ vault = file(\"vault-storage.hcl\") }`",
+				},
+				s.TLS.SchemaAttribute("Consul"),
+				{
+					Name:            "token_accessor_ids",
+					Type:            s.TokenAccessorIDs.TFType(),
+					Computed:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "A map of `tokens` names to their accessor IDs",
+				},
+				{
+					Name:            "token_secret_ids",
+					Type:            s.TokenSecretIDs.TFType(),
+					Computed:        true,
+					Sensitive:       true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "A map of `tokens` names to their secret IDs",
+				},
+				{
+					Name:            "tokens",
+					Type:            s.Tokens.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "A list of ACL tokens to create. Each token is an object with a `name` and a list of `policies` names to attach",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH),
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *consulACLStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if _, ok := s.BinPath.Get(); !ok {
+		return ValidationError("you must provide the Consul bin path", "bin_path")
+	}
+
+	if policies, ok := s.Policies.GetStrings(); ok {
+		for name, rules := range policies {
+			if name == "" {
+				return ValidationError("policy names cannot be empty", "policies")
+			}
+			if rules == "" {
+				return ValidationError(fmt.Sprintf("policy %s must have rules", name), "policies")
+			}
+		}
+	}
+
+	if tokens, ok := s.Tokens.GetObjects(); ok {
+		names := []string{}
+		for _, token := range tokens {
+			name, _ := token["name"].(string)
+			if name == "" {
+				return ValidationError("token names cannot be empty", "tokens")
+			}
+			if slices.Contains(names, name) {
+				return ValidationError(fmt.Sprintf("token name %s is not unique", name), "tokens")
+			}
+			names = append(names, name)
+		}
+	}
+
+	return s.TLS.Validate()
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Vault with As().
+func (s *consulACLStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":                 s.ID,
+		"bin_path":           s.BinPath,
+		"consul_addr":        s.ConsulAddr,
+		"management_token":   s.ManagementToken,
+		"policies":           s.Policies,
+		"token_accessor_ids": s.TokenAccessorIDs,
+		"token_secret_ids":   s.TokenSecretIDs,
+		"tokens":             s.Tokens,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.TLS.FromTerraform5Value(vals["tls"])
+	if err != nil {
+		return err
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *consulACLStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":                 s.ID.TFType(),
+		"bin_path":           s.BinPath.TFType(),
+		"consul_addr":        s.ConsulAddr.TFType(),
+		"management_token":   s.ManagementToken.TFType(),
+		"policies":           s.Policies.TFType(),
+		"tls":                s.TLS.Terraform5Type(),
+		"token_accessor_ids": s.TokenAccessorIDs.TFType(),
+		"token_secret_ids":   s.TokenSecretIDs.TFType(),
+		"tokens":             s.Tokens.TFType(),
+		"transport":          s.Transport.Terraform5Type(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *consulACLStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":                 s.ID.TFValue(),
+		"bin_path":           s.BinPath.TFValue(),
+		"consul_addr":        s.ConsulAddr.TFValue(),
+		"management_token":   s.ManagementToken.TFValue(),
+		"policies":           s.Policies.TFValue(),
+		"tls":                s.TLS.Terraform5Value(),
+		"token_accessor_ids": s.TokenAccessorIDs.TFValue(),
+		"token_secret_ids":   s.TokenSecretIDs.TFValue(),
+		"tokens":             s.Tokens.TFValue(),
+		"transport":          s.Transport.Terraform5Value(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *consulACLStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+// Apply bootstraps the ACL system if necessary and writes the policies and tokens.
+func (s *consulACLStateV1) Apply(ctx context.Context, client it.Transport) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	cli := s.buildCLIRequest()
+
+	if _, ok := s.ManagementToken.Get(); !ok {
+		token, err := consul.ACLBootstrap(ctx, client, cli)
+		if err != nil {
+			if errors.Is(err, consul.ErrACLAlreadyBootstrapped) {
+				return fmt.Errorf("%w: you must set management_token to manage ACLs", err)
+			}
+
+			return err
+		}
+		s.ManagementToken.Set(token.SecretID)
+	}
+	cli.Token = s.ManagementToken.Value()
+
+	for _, req := range s.buildACLPolicyRequests(cli) {
+		if _, err := consul.WriteACLPolicy(ctx, client, req); err != nil {
+			return err
+		}
+	}
+
+	accessorIDs := map[string]string{}
+	secretIDs := map[string]string{}
+	for _, req := range s.buildACLTokenRequests(cli) {
+		token, err := consul.WriteACLToken(ctx, client, req)
+		if err != nil {
+			return err
+		}
+		accessorIDs[req.Description] = token.AccessorID
+		secretIDs[req.Description] = token.SecretID
+	}
+	s.TokenAccessorIDs.SetStrings(accessorIDs)
+	s.TokenSecretIDs.SetStrings(secretIDs)
+
+	return nil
+}
+
+// setUnknownTokenIDsNull sets the token IDs to null if they are unknown so that the state can be
+// saved after a failed apply.
+func (s *consulACLStateV1) setUnknownTokenIDsNull() {
+	for _, ids := range []*tfStringMap{s.TokenAccessorIDs, s.TokenSecretIDs} {
+		if ids.Unknown {
+			ids.Unknown = false
+			ids.Null = true
+		}
+	}
+}
+
+func (s *consulACLStateV1) buildCLIRequest() *consul.CLIRequest {
+	cli := &consul.CLIRequest{
+		BinPath:    s.BinPath.Value(),
+		ConsulAddr: "http://127.0.0.1:8500",
+		TLSConfig:  s.TLS.TLSConfig(),
+	}
+
+	if addr, ok := s.ConsulAddr.Get(); ok {
+		cli.ConsulAddr = addr
+	}
+
+	if token, ok := s.ManagementToken.Get(); ok {
+		cli.Token = token
+	}
+
+	return cli
+}
+
+func (s *consulACLStateV1) buildACLPolicyRequests(cli *consul.CLIRequest) []*consul.ACLPolicyRequest {
+	reqs := []*consul.ACLPolicyRequest{}
+
+	policies, ok := s.Policies.GetStrings()
+	if !ok {
+		return reqs
+	}
+
+	// Write the policies in a stable order.
+	names := []string{}
+	for name := range policies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		reqs = append(reqs, consul.NewACLPolicyRequest(
+			consul.WithACLPolicyRequestCLIRequest(cli),
+			consul.WithACLPolicyRequestName(name),
+			consul.WithACLPolicyRequestDescription("Managed by enos"),
+			consul.WithACLPolicyRequestRules(policies[name]),
+		))
+	}
+
+	return reqs
+}
+
+func (s *consulACLStateV1) buildACLTokenRequests(cli *consul.CLIRequest) []*consul.ACLTokenRequest {
+	reqs := []*consul.ACLTokenRequest{}
+
+	tokens, ok := s.Tokens.GetObjects()
+	if !ok {
+		return reqs
+	}
+
+	for _, token := range tokens {
+		name, _ := token["name"].(string)
+		policies, _ := token["policies"].([]string)
+		reqs = append(reqs, consul.NewACLTokenRequest(
+			consul.WithACLTokenRequestCLIRequest(cli),
+			consul.WithACLTokenRequestDescription(name),
+			consul.WithACLTokenRequestPolicies(policies),
+		))
+	}
+
+	return reqs
+}
@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+)
+
+// TestAccResourceConsulACL tests the consul_acl resource.
+func TestAccResourceConsulACL(t *testing.T) {
+	t.Parallel()
+	cfg := template.Must(template.New("enos_consul_acl").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_consul_acl" "{{.ID.Value}}" {
+		{{if .BinPath.Value}}
+		bin_path = "{{.BinPath.Value}}"
+		{{end}}
+
+		{{if .ConsulAddr.Value}}
+		consul_addr = "{{.ConsulAddr.Value}}"
+		{{end}}
+
+		{{if .ManagementToken.Value}}
+		management_token = "{{.ManagementToken.Value}}"
+		{{end}}
+
+		{{if .Policies.StringValue}}
+		policies = {
+		{{range $name, $rules := .Policies.StringValue}}
+		  "{{$name}}" = <<EOF
+{{$rules}}
+EOF
+		{{end}}
+		}
+		{{end}}
+
+		{{if .Tokens.ObjectsValue}}
+		tokens = [
+		{{range .Tokens.ObjectsValue}}
+		  {
+		    name     = "{{.name}}"
+		    policies = [{{range .policies}}"{{.}}",{{end}}]
+		  },
+		{{end}}
+		]
+		{{end}}
+
+		{{ renderTransport .Transport }}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	consulACL := newConsulACLStateV1()
+	consulACL.ID.Set("foo")
+	consulACL.BinPath.Set("/opt/consul/bin/consul")
+	consulACL.ConsulAddr.Set("http://127.0.0.1:8500")
+	consulACL.Policies.SetStrings(map[string]string{
+		"vault": `key_prefix "vault/" { policy = "write" }`,
+	})
+	consulACL.Tokens.SetObjects([]map[string]any{
+		{"name": "vault", "policies": []string{"vault"}},
+	})
+	ssh := newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	ssh.Host.Set("localhost")
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, consulACL.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		consulACL,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_consul_acl.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_consul_acl.foo", "bin_path", regexp.MustCompile(`^/opt/consul/bin/consul$`)),
+			resource.TestMatchResourceAttr("enos_consul_acl.foo", "consul_addr", regexp.MustCompile(`^http://127.0.0.1:8500$`)),
+			resource.TestMatchResourceAttr("enos_consul_acl.foo", "tokens.0.name", regexp.MustCompile(`^vault$`)),
+			resource.TestMatchResourceAttr("enos_consul_acl.foo", "tokens.0.policies.0", regexp.MustCompile(`^vault$`)),
+			resource.TestMatchResourceAttr("enos_consul_acl.foo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
+			resource.TestMatchResourceAttr("enos_consul_acl.foo", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+func TestConsulACLValidate(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		setup     func(*consulACLStateV1)
+		expectErr bool
+	}{
+		"valid": {
+			setup: func(s *consulACLStateV1) {
+				s.BinPath.Set("/opt/consul/bin/consul")
+				s.Policies.SetStrings(map[string]string{"vault": `key_prefix "vault/" { policy = "write" }`})
+				s.Tokens.SetObjects([]map[string]any{{"name": "vault", "policies": []string{"vault"}}})
+			},
+		},
+		"missing bin path": {
+			setup:     func(s *consulACLStateV1) {},
+			expectErr: true,
+		},
+		"policy without rules": {
+			setup: func(s *consulACLStateV1) {
+				s.BinPath.Set("/opt/consul/bin/consul")
+				s.Policies.SetStrings(map[string]string{"vault": ""})
+			},
+			expectErr: true,
+		},
+		"duplicate token names": {
+			setup: func(s *consulACLStateV1) {
+				s.BinPath.Set("/opt/consul/bin/consul")
+				s.Tokens.SetObjects([]map[string]any{
+					{"name": "vault", "policies": []string{"vault"}},
+					{"name": "vault", "policies": []string{"other"}},
+				})
+			},
+			expectErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newConsulACLStateV1()
+			test.setup(s)
+			err := s.Validate(context.Background())
+			if test.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConsulACLBuildRequests(t *testing.T) {
+	t.Parallel()
+
+	s := newConsulACLStateV1()
+	s.BinPath.Set("/opt/consul/bin/consul")
+	s.ManagementToken.Set("root")
+	s.Policies.SetStrings(map[string]string{
+		"vault":  `key_prefix "vault/" { policy = "write" }`,
+		"agents": `node_prefix "" { policy = "write" }`,
+	})
+	s.Tokens.SetObjects([]map[string]any{
+		{"name": "vault", "policies": []string{"vault", "agents"}},
+	})
+
+	cli := s.buildCLIRequest()
+	require.Equal(t, "http://127.0.0.1:8500", cli.ConsulAddr)
+	require.Equal(t, "root", cli.Token)
+
+	policies := s.buildACLPolicyRequests(cli)
+	require.Len(t, policies, 2)
+	require.Equal(t, "agents", policies[0].Name)
+	require.Equal(t, "vault", policies[1].Name)
+	for _, req := range policies {
+		require.NoError(t, req.Validate())
+	}
+
+	tokens := s.buildACLTokenRequests(cli)
+	require.Len(t, tokens, 1)
+	require.Equal(t, "vault", tokens[0].Description)
+	require.Equal(t, []string{"vault", "agents"}, tokens[0].Policies)
+	require.NoError(t, tokens[0].Validate())
+}
+
+// TestConsulACLSaveBootstrapTokenOnError tests that we can save the bootstrapped management token
+// even if the apply fails after bootstrapping.
+func TestConsulACLSaveBootstrapTokenOnError(t *testing.T) {
+	t.Parallel()
+
+	s := newConsulACLStateV1()
+	s.ID.Set("static")
+	s.ManagementToken.Set("bootstrap-secret")
+	s.TokenAccessorIDs.Unknown = true
+	s.TokenSecretIDs.Unknown = true
+	s.setUnknownTokenIDsNull()
+	require.False(t, s.TokenAccessorIDs.Unknown)
+	require.False(t, s.TokenSecretIDs.Unknown)
+
+	errDiags := []*tfprotov6.Diagnostic{{Severity: tfprotov6.DiagnosticSeverityError, Summary: "Consul ACL Error"}}
+
+	res := resourcerouter.ApplyResourceChangeResponse{NewState: s, Diagnostics: errDiags}
+	require.Nil(t, res.ToTFProto6Response(false).NewState)
+
+	res.SaveStateOnError = true
+	require.NotNil(t, res.ToTFProto6Response(false).NewState)
+
+	val := s.Terraform5Value()
+	require.True(t, val.IsFullyKnown())
+	saved := newConsulACLStateV1()
+	require.NoError(t, saved.FromTerraform5Value(val))
+	token, ok := saved.ManagementToken.Get()
+	require.True(t, ok)
+	require.Equal(t, "bootstrap-secret", token)
+}
@@ -0,0 +1,464 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/consul"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	istrings "github.com/hashicorp-forge/terraform-provider-enos/internal/strings"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+)
+
+type consulKV struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*consulKV)(nil)
+
+type consulKVStateV1 struct {
+	ID         *tfString
+	BinPath    *tfString
+	ConsulAddr *tfString
+	Data       *tfStringMap
+	Hosts      *tfStringSlice
+	Mode       *tfString
+	Token      *tfString
+	TLS        *tlsClientConfig
+	Transport  *embeddedTransportV1
+
+	failureHandlers
+}
+
+var _ state.State = (*consulKVStateV1)(nil)
+
+const (
+	consulKVModeWrite  = "write"
+	consulKVModeVerify = "verify"
+)
+
+func newConsulKV() *consulKV {
+	return &consulKV{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newConsulKVStateV1() *consulKVStateV1 {
+	transport := newEmbeddedTransport()
+	fh := failureHandlers{
+		TransportDebugFailureHandler(transport),
+		GetApplicationLogsFailureHandler(transport, []string{"consul"}),
+	}
+
+	return &consulKVStateV1{
+		ID:              newTfString(),
+		BinPath:         newTfString(),
+		ConsulAddr:      newTfString(),
+		Data:            newTfStringMap(),
+		Hosts:           newTfStringSlice(),
+		Mode:            newTfString(),
+		Token:           newTfString(),
+		TLS:             newTLSClientConfig(),
+		Transport:       transport,
+		failureHandlers: fh,
+	}
+}
+
+func (r *consulKV) Name() string {
+	return "enos_consul_kv"
+}
+
+func (r *consulKV) Schema() *tfprotov6.Schema {
+	return newConsulKVStateV1().Schema()
+}
+
+func (r *consulKV) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *consulKV) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *consulKV) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newConsulKVStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *consulKV) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newConsulKVStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *consulKV) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newConsulKVStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *consulKV) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newConsulKVStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *consulKV) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newConsulKVStateV1()
+	proposedState := newConsulKVStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *consulKV) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newConsulKVStateV1()
+	plannedState := newConsulKVStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do on delete
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	// Only write or verify if we're creating the resource or something has changed.
+	if _, ok := priorState.ID.Get(); ok && reflect.DeepEqual(plannedState, priorState) {
+		return
+	}
+
+	if plannedState.Mode.Value() == consulKVModeVerify {
+		providerConfig, err := r.GetProviderConfig()
+		if err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic(
+				"Apply Error",
+				fmt.Errorf("failed to get provider config, due to: %w", err),
+			))
+
+			return
+		}
+
+		err = plannedState.Verify(ctx, transport, providerConfig.Transport)
+		if err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Consul Verify KV Error", err))
+		}
+
+		return
+	}
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	err = plannedState.Write(ctx, client)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Consul Write KV Error", err))
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *consulKVStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_consul_kv^ resource is used to verify that data written to the Consul KV store is durable.
+The common pattern is to write data before an operation, e.g. an upgrade or a snapshot restore,
+and then read it back afterwards.
+
+When ^mode^ is ^write^ the resource writes every key and value in ^data^ with ^consul kv put^.
+When ^mode^ is ^verify^ the resource reads every key with ^consul kv get -stale^ on each host in
+^hosts^, or the ^transport^ host if ^hosts^ is not set, and ensures that the agent on every host has
+the expected ^data^. Stale reads are served by the server the agent is connected to rather than
+the leader, so the read is retried for a time to allow data to replicate. Any mismatches are
+reported for each host.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        s.ID.TFType(),
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "bin_path",
+					Type:        s.BinPath.TFType(),
+					Required:    true,
+					Description: "The fully qualified path to the consul binary",
+				},
+				{
+					Name:            "consul_addr",
+					Type:            s.ConsulAddr.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The address of the Consul HTTP API on each host. Defaults to `http://127.0.0.1:8500`",
+				},
+				{
+					Name:        "data",
+					Type:        s.Data.TFType(),
+					Required:    true,
+					Description: "A map of KV keys and values to write or verify",
+				},
+				{
+					Name:            "hosts",
+					Type:            s.Hosts.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "A list of hosts to verify the data from when `mode` is `verify`. The `transport` configuration is used for each host. Defaults to the `transport` host",
+				},
+				{
+					Name:            "mode",
+					Type:            s.Mode.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "Either `write` or `verify`. Defaults to `write`",
+				},
+				s.TLS.SchemaAttribute("Consul"),
+				{
+					Name:        "token",
+					Type:        s.Token.TFType(),
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The Consul ACL token to use. Required if ACLs are enabled",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH),
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *consulKVStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if _, ok := s.BinPath.Get(); !ok {
+		return ValidationError("you must provide the Consul bin path", "bin_path")
+	}
+
+	if data, ok := s.Data.GetStrings(); ok {
+		if len(data) < 1 {
+			return ValidationError("you must provide data", "data")
+		}
+
+		for key := range data {
+			if key == "" {
+				return ValidationError("data keys cannot be empty", "data")
+			}
+		}
+	}
+
+	if mode, ok := s.Mode.Get(); ok {
+		switch mode {
+		case consulKVModeWrite, consulKVModeVerify:
+		default:
+			return ValidationError(
+				fmt.Sprintf("unsupported mode: %s, must be one of: %s, %s",
+					mode, consulKVModeWrite, consulKVModeVerify,
+				),
+				"mode",
+			)
+		}
+	}
+
+	return s.TLS.Validate()
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Vault with As().
+func (s *consulKVStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":          s.ID,
+		"bin_path":    s.BinPath,
+		"consul_addr": s.ConsulAddr,
+		"data":        s.Data,
+		"hosts":       s.Hosts,
+		"mode":        s.Mode,
+		"token":       s.Token,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.TLS.FromTerraform5Value(vals["tls"])
+	if err != nil {
+		return err
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *consulKVStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":          s.ID.TFType(),
+		"bin_path":    s.BinPath.TFType(),
+		"consul_addr": s.ConsulAddr.TFType(),
+		"data":        s.Data.TFType(),
+		"hosts":       s.Hosts.TFType(),
+		"mode":        s.Mode.TFType(),
+		"tls":         s.TLS.Terraform5Type(),
+		"token":       s.Token.TFType(),
+		"transport":   s.Transport.Terraform5Type(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *consulKVStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":          s.ID.TFValue(),
+		"bin_path":    s.BinPath.TFValue(),
+		"consul_addr": s.ConsulAddr.TFValue(),
+		"data":        s.Data.TFValue(),
+		"hosts":       s.Hosts.TFValue(),
+		"mode":        s.Mode.TFValue(),
+		"tls":         s.TLS.Terraform5Value(),
+		"token":       s.Token.TFValue(),
+		"transport":   s.Transport.Terraform5Value(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *consulKVStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+// Write writes the data to the KV store.
+func (s *consulKVStateV1) Write(ctx context.Context, client it.Transport) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	return consul.PutKV(ctx, client, s.buildKVRequest())
+}
+
+// Verify reads the data back on every host and reports any mismatches for
+// each host.
+func (s *consulKVStateV1) Verify(ctx context.Context, transport *embeddedTransportV1, defaults *embeddedTransportV1) error {
+	hosts, ok := s.Hosts.GetStrings()
+	if !ok || len(hosts) < 1 {
+		client, err := transport.Client(ctx)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		return s.verifyHost(ctx, client)
+	}
+
+	var err error
+	for _, host := range hosts {
+		hostErr := func() error {
+			et, err := s.Transport.CopyForSSHHost(ctx, host, defaults)
+			if err != nil {
+				return err
+			}
+
+			client, err := et.Client(ctx)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			return s.verifyHost(ctx, client)
+		}()
+		if hostErr != nil {
+			err = errors.Join(err, fmt.Errorf("host %s:\n%s", host, istrings.Indent("  ", hostErr.Error())))
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("verifying data on consul hosts: %w", err)
+	}
+
+	return nil
+}
+
+func (s *consulKVStateV1) verifyHost(ctx context.Context, client it.Transport) error {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	return consul.WaitForKV(ctx, client, s.buildKVRequest(consul.WithKVRequestStale()))
+}
+
+func (s *consulKVStateV1) buildKVRequest(opts ...consul.KVRequestOpt) *consul.KVRequest {
+	cli := &consul.CLIRequest{
+		BinPath:    s.BinPath.Value(),
+		ConsulAddr: "http://127.0.0.1:8500",
+		TLSConfig:  s.TLS.TLSConfig(),
+	}
+
+	if addr, ok := s.ConsulAddr.Get(); ok {
+		cli.ConsulAddr = addr
+	}
+
+	if token, ok := s.Token.Get(); ok {
+		cli.Token = token
+	}
+
+	opts = append([]consul.KVRequestOpt{
+		consul.WithKVRequestCLIRequest(cli),
+		consul.WithKVRequestData(s.Data.StringValue()),
+	}, opts...)
+
+	return consul.NewKVRequest(opts...)
+}
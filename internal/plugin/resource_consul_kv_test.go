@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/consul"
+)
+
+// TestAccResourceConsulKV tests the consul_kv resource.
+func TestAccResourceConsulKV(t *testing.T) {
+	t.Parallel()
+	cfg := template.Must(template.New("enos_consul_kv").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_consul_kv" "{{.ID.Value}}" {
+		{{if .BinPath.Value}}
+		bin_path = "{{.BinPath.Value}}"
+		{{end}}
+
+		{{if .ConsulAddr.Value}}
+		consul_addr = "{{.ConsulAddr.Value}}"
+		{{end}}
+
+		{{if .Mode.Value}}
+		mode = "{{.Mode.Value}}"
+		{{end}}
+
+		{{if .Data.StringValue}}
+		data = {
+		{{range $key, $val := .Data.StringValue}}
+		  "{{$key}}" = "{{$val}}"
+		{{end}}
+		}
+		{{end}}
+
+		{{if .Hosts.StringValue}}
+		hosts = [{{range .Hosts.StringValue}}"{{.}}",{{end}}]
+		{{end}}
+
+		{{ renderTransport .Transport }}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	consulKV := newConsulKVStateV1()
+	consulKV.ID.Set("foo")
+	consulKV.BinPath.Set("/opt/consul/bin/consul")
+	consulKV.ConsulAddr.Set("http://127.0.0.1:8500")
+	consulKV.Mode.Set("verify")
+	consulKV.Data.SetStrings(map[string]string{"enos/foo": "bar"})
+	consulKV.Hosts.SetStrings([]string{"10.0.0.1", "10.0.0.2"})
+	ssh := newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	ssh.Host.Set("localhost")
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, consulKV.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		consulKV,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_consul_kv.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_consul_kv.foo", "bin_path", regexp.MustCompile(`^/opt/consul/bin/consul$`)),
+			resource.TestMatchResourceAttr("enos_consul_kv.foo", "consul_addr", regexp.MustCompile(`^http://127.0.0.1:8500$`)),
+			resource.TestMatchResourceAttr("enos_consul_kv.foo", "mode", regexp.MustCompile(`^verify$`)),
+			resource.TestMatchResourceAttr("enos_consul_kv.foo", "data.enos/foo", regexp.MustCompile(`^bar$`)),
+			resource.TestMatchResourceAttr("enos_consul_kv.foo", "hosts.1", regexp.MustCompile(`^10.0.0.2$`)),
+			resource.TestMatchResourceAttr("enos_consul_kv.foo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
+			resource.TestMatchResourceAttr("enos_consul_kv.foo", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+func TestConsulKVValidate(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		setup     func(*consulKVStateV1)
+		expectErr bool
+	}{
+		"valid": {
+			setup: func(s *consulKVStateV1) {
+				s.BinPath.Set("/opt/consul/bin/consul")
+				s.Data.SetStrings(map[string]string{"enos/foo": "bar"})
+			},
+		},
+		"missing bin path": {
+			setup: func(s *consulKVStateV1) {
+				s.Data.SetStrings(map[string]string{"enos/foo": "bar"})
+			},
+			expectErr: true,
+		},
+		"empty data": {
+			setup: func(s *consulKVStateV1) {
+				s.BinPath.Set("/opt/consul/bin/consul")
+				s.Data.SetStrings(map[string]string{})
+			},
+			expectErr: true,
+		},
+		"invalid mode": {
+			setup: func(s *consulKVStateV1) {
+				s.BinPath.Set("/opt/consul/bin/consul")
+				s.Data.SetStrings(map[string]string{"enos/foo": "bar"})
+				s.Mode.Set("read")
+			},
+			expectErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newConsulKVStateV1()
+			test.setup(s)
+			err := s.Validate(context.Background())
+			if test.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConsulKVBuildKVRequest(t *testing.T) {
+	t.Parallel()
+
+	s := newConsulKVStateV1()
+	s.BinPath.Set("/opt/consul/bin/consul")
+	s.ConsulAddr.Set("https://127.0.0.1:8501")
+	s.Token.Set("root")
+	s.Data.SetStrings(map[string]string{"enos/foo": "bar"})
+
+	req := s.buildKVRequest()
+	require.Equal(t, "https://127.0.0.1:8501", req.ConsulAddr)
+	require.Equal(t, "root", req.Token)
+	require.Equal(t, map[string]string{"enos/foo": "bar"}, req.Data)
+	require.False(t, req.Stale)
+	require.NoError(t, req.Validate())
+
+	require.True(t, s.buildKVRequest(consul.WithKVRequestStale()).Stale)
+}
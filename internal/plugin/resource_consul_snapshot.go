@@ -0,0 +1,483 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/consul"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	tfile "github.com/hashicorp-forge/terraform-provider-enos/internal/transport/file"
+)
+
+type consulSnapshot struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*consulSnapshot)(nil)
+
+type consulSnapshotStateV1 struct {
+	ID         *tfString
+	BinPath    *tfString
+	ConsulAddr *tfString
+	Index      *tfNum
+	LocalPath  *tfString
+	Mode       *tfString
+	Path       *tfString
+	Token      *tfString
+	TLS        *tlsClientConfig
+	Transport  *embeddedTransportV1
+
+	failureHandlers
+}
+
+var _ state.State = (*consulSnapshotStateV1)(nil)
+
+const (
+	consulSnapshotModeSave    = "save"
+	consulSnapshotModeRestore = "restore"
+)
+
+func newConsulSnapshot() *consulSnapshot {
+	return &consulSnapshot{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newConsulSnapshotStateV1() *consulSnapshotStateV1 {
+	transport := newEmbeddedTransport()
+	fh := failureHandlers{
+		TransportDebugFailureHandler(transport),
+		GetApplicationLogsFailureHandler(transport, []string{"consul"}),
+	}
+
+	return &consulSnapshotStateV1{
+		ID:              newTfString(),
+		BinPath:         newTfString(),
+		ConsulAddr:      newTfString(),
+		Index:           newTfNum(),
+		LocalPath:       newTfString(),
+		Mode:            newTfString(),
+		Path:            newTfString(),
+		Token:           newTfString(),
+		TLS:             newTLSClientConfig(),
+		Transport:       transport,
+		failureHandlers: fh,
+	}
+}
+
+func (r *consulSnapshot) Name() string {
+	return "enos_consul_snapshot"
+}
+
+func (r *consulSnapshot) Schema() *tfprotov6.Schema {
+	return newConsulSnapshotStateV1().Schema()
+}
+
+func (r *consulSnapshot) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *consulSnapshot) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *consulSnapshot) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newConsulSnapshotStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *consulSnapshot) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newConsulSnapshotStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *consulSnapshot) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newConsulSnapshotStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *consulSnapshot) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newConsulSnapshotStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *consulSnapshot) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newConsulSnapshotStateV1()
+	proposedState := newConsulSnapshotStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+	}
+
+	if proposedState.mode() != consulSnapshotModeSave {
+		proposedState.Index.Null = true
+		return
+	}
+
+	// We'll take a new snapshot if we're creating the resource or something has changed,
+	// otherwise we'll keep the index of the snapshot that we previously took.
+	if _, ok := priorState.ID.Get(); !ok || !proposedState.inputsEqual(priorState) {
+		proposedState.Index.Unknown = true
+	} else {
+		proposedState.Index = priorState.Index
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *consulSnapshot) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newConsulSnapshotStateV1()
+	plannedState := newConsulSnapshotStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do on delete
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	// Only save or restore if we're creating the resource or something has changed.
+	if _, ok := priorState.ID.Get(); ok && plannedState.inputsEqual(priorState) {
+		return
+	}
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	if plannedState.mode() == consulSnapshotModeRestore {
+		err = plannedState.Restore(ctx, client)
+		if err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Consul Snapshot Restore Error", err))
+		}
+
+		return
+	}
+
+	err = plannedState.Save(ctx, client)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Consul Snapshot Save Error", err))
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *consulSnapshotStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_consul_snapshot^ resource is used to verify that Consul data survives operations like
+upgrades or cluster migrations. A snapshot is taken from one cluster and later restored, either to
+the same cluster or to a different one.
+
+When ^mode^ is ^save^ the resource runs ^consul snapshot save^ and writes the snapshot to ^path^
+on the target host. Consul forwards the request to the leader, so any agent in the cluster can be
+used. If ^local_path^ is set the snapshot is also downloaded to the machine running Terraform.
+
+When ^mode^ is ^restore^ the resource runs ^consul snapshot restore^ with the snapshot at ^path^.
+If ^local_path^ is set the local snapshot is first uploaded to ^path^ on the target host, which
+allows restoring a snapshot that was saved on another host.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        s.ID.TFType(),
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "bin_path",
+					Type:        s.BinPath.TFType(),
+					Required:    true,
+					Description: "The fully qualified path to the consul binary",
+				},
+				{
+					Name:            "consul_addr",
+					Type:            s.ConsulAddr.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The address of the Consul HTTP API. Defaults to `http://127.0.0.1:8500`",
+				},
+				{
+					Name:            "index",
+					Type:            s.Index.TFType(),
+					Computed:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The raft index of the saved snapshot when `mode` is `save`",
+				},
+				{
+					Name:            "local_path",
+					Type:            s.LocalPath.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "A path on the machine running Terraform to download the snapshot to when `mode` is `save`, or to upload the snapshot from when `mode` is `restore`",
+				},
+				{
+					Name:            "mode",
+					Type:            s.Mode.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "Either `save` or `restore`. Defaults to `save`",
+				},
+				{
+					Name:        "path",
+					Type:        s.Path.TFType(),
+					Required:    true,
+					Description: "The path of the snapshot file on the target host",
+				},
+				s.TLS.SchemaAttribute("Consul"),
+				{
+					Name:        "token",
+					Type:        s.Token.TFType(),
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The Consul ACL token to use. Required if ACLs are enabled",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH),
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *consulSnapshotStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if _, ok := s.BinPath.Get(); !ok {
+		return ValidationError("you must provide the Consul bin path", "bin_path")
+	}
+
+	if path, ok := s.Path.Get(); ok && path == "" {
+		return ValidationError("you must provide a snapshot path", "path")
+	}
+
+	if mode, ok := s.Mode.Get(); ok {
+		switch mode {
+		case consulSnapshotModeSave, consulSnapshotModeRestore:
+		default:
+			return ValidationError(
+				fmt.Sprintf("unsupported mode: %s, must be one of: %s, %s",
+					mode, consulSnapshotModeSave, consulSnapshotModeRestore,
+				),
+				"mode",
+			)
+		}
+	}
+
+	if local, ok := s.LocalPath.Get(); ok && local == "" {
+		return ValidationError("local_path cannot be empty", "local_path")
+	}
+
+	return s.TLS.Validate()
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Vault with As().
+func (s *consulSnapshotStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":          s.ID,
+		"bin_path":    s.BinPath,
+		"consul_addr": s.ConsulAddr,
+		"index":       s.Index,
+		"local_path":  s.LocalPath,
+		"mode":        s.Mode,
+		"path":        s.Path,
+		"token":       s.Token,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.TLS.FromTerraform5Value(vals["tls"])
+	if err != nil {
+		return err
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *consulSnapshotStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":          s.ID.TFType(),
+		"bin_path":    s.BinPath.TFType(),
+		"consul_addr": s.ConsulAddr.TFType(),
+		"index":       s.Index.TFType(),
+		"local_path":  s.LocalPath.TFType(),
+		"mode":        s.Mode.TFType(),
+		"path":        s.Path.TFType(),
+		"tls":         s.TLS.Terraform5Type(),
+		"token":       s.Token.TFType(),
+		"transport":   s.Transport.Terraform5Type(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *consulSnapshotStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":          s.ID.TFValue(),
+		"bin_path":    s.BinPath.TFValue(),
+		"consul_addr": s.ConsulAddr.TFValue(),
+		"index":       s.Index.TFValue(),
+		"local_path":  s.LocalPath.TFValue(),
+		"mode":        s.Mode.TFValue(),
+		"path":        s.Path.TFValue(),
+		"tls":         s.TLS.Terraform5Value(),
+		"token":       s.Token.TFValue(),
+		"transport":   s.Transport.Terraform5Value(),
+	})
+}
+
+// inputsEqual returns whether or not the attributes that determine the snapshot are equal. We
+// can't compare the entire state as it includes the computed index and failure handlers.
+func (s *consulSnapshotStateV1) inputsEqual(o *consulSnapshotStateV1) bool {
+	return s.BinPath.Eq(o.BinPath) &&
+		s.ConsulAddr.Eq(o.ConsulAddr) &&
+		s.LocalPath.Eq(o.LocalPath) &&
+		s.Mode.Eq(o.Mode) &&
+		s.Path.Eq(o.Path) &&
+		s.Token.Eq(o.Token) &&
+		s.TLS.Terraform5Value().Equal(o.TLS.Terraform5Value())
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *consulSnapshotStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+// Save saves the snapshot and downloads it if a local path has been set.
+func (s *consulSnapshotStateV1) Save(ctx context.Context, client it.Transport) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	req := s.buildSnapshotRequest()
+
+	index, err := consul.SaveSnapshot(ctx, client, req)
+	if err != nil {
+		return err
+	}
+	s.Index.Set(int(index))
+
+	if local, ok := s.LocalPath.Get(); ok {
+		return consul.DownloadSnapshot(ctx, client, req, local)
+	}
+
+	return nil
+}
+
+// Restore uploads the snapshot if a local path has been set and restores it.
+func (s *consulSnapshotStateV1) Restore(ctx context.Context, client it.Transport) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	req := s.buildSnapshotRequest()
+
+	if local, ok := s.LocalPath.Get(); ok {
+		snapshot, err := tfile.Open(local)
+		if err != nil {
+			return fmt.Errorf("opening local snapshot: %w", err)
+		}
+		defer snapshot.Close()
+
+		err = remoteflight.CopyFile(ctx, client, remoteflight.NewCopyFileRequest(
+			remoteflight.WithCopyFileDestination(req.Path),
+			remoteflight.WithCopyFileContent(snapshot),
+		))
+		if err != nil {
+			return fmt.Errorf("uploading snapshot: %w", err)
+		}
+	}
+
+	return consul.RestoreSnapshot(ctx, client, req)
+}
+
+func (s *consulSnapshotStateV1) mode() string {
+	if mode, ok := s.Mode.Get(); ok {
+		return mode
+	}
+
+	return consulSnapshotModeSave
+}
+
+func (s *consulSnapshotStateV1) buildSnapshotRequest() *consul.SnapshotRequest {
+	cli := &consul.CLIRequest{
+		BinPath:    s.BinPath.Value(),
+		ConsulAddr: "http://127.0.0.1:8500",
+		TLSConfig:  s.TLS.TLSConfig(),
+	}
+
+	if addr, ok := s.ConsulAddr.Get(); ok {
+		cli.ConsulAddr = addr
+	}
+
+	if token, ok := s.Token.Get(); ok {
+		cli.Token = token
+	}
+
+	return consul.NewSnapshotRequest(
+		consul.WithSnapshotRequestCLIRequest(cli),
+		consul.WithSnapshotRequestPath(s.Path.Value()),
+	)
+}
@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+)
+
+// TestAccResourceConsulSnapshot tests the consul_snapshot resource.
+func TestAccResourceConsulSnapshot(t *testing.T) {
+	t.Parallel()
+	cfg := template.Must(template.New("enos_consul_snapshot").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_consul_snapshot" "{{.ID.Value}}" {
+		{{if .BinPath.Value}}
+		bin_path = "{{.BinPath.Value}}"
+		{{end}}
+
+		{{if .ConsulAddr.Value}}
+		consul_addr = "{{.ConsulAddr.Value}}"
+		{{end}}
+
+		{{if .Mode.Value}}
+		mode = "{{.Mode.Value}}"
+		{{end}}
+
+		{{if .Path.Value}}
+		path = "{{.Path.Value}}"
+		{{end}}
+
+		{{if .LocalPath.Value}}
+		local_path = "{{.LocalPath.Value}}"
+		{{end}}
+
+		{{if .Token.Value}}
+		token = "{{.Token.Value}}"
+		{{end}}
+
+		{{ renderTransport .Transport }}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	consulSnapshot := newConsulSnapshotStateV1()
+	consulSnapshot.ID.Set("foo")
+	consulSnapshot.BinPath.Set("/opt/consul/bin/consul")
+	consulSnapshot.ConsulAddr.Set("http://127.0.0.1:8500")
+	consulSnapshot.Mode.Set("save")
+	consulSnapshot.Path.Set("/tmp/consul.snap")
+	consulSnapshot.LocalPath.Set("./consul.snap")
+	consulSnapshot.Token.Set("root")
+	ssh := newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	ssh.Host.Set("localhost")
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, consulSnapshot.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		consulSnapshot,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_consul_snapshot.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_consul_snapshot.foo", "bin_path", regexp.MustCompile(`^/opt/consul/bin/consul$`)),
+			resource.TestMatchResourceAttr("enos_consul_snapshot.foo", "consul_addr", regexp.MustCompile(`^http://127.0.0.1:8500$`)),
+			resource.TestMatchResourceAttr("enos_consul_snapshot.foo", "mode", regexp.MustCompile(`^save$`)),
+			resource.TestMatchResourceAttr("enos_consul_snapshot.foo", "path", regexp.MustCompile(`^/tmp/consul.snap$`)),
+			resource.TestMatchResourceAttr("enos_consul_snapshot.foo", "local_path", regexp.MustCompile(`^./consul.snap$`)),
+			resource.TestMatchResourceAttr("enos_consul_snapshot.foo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
+			resource.TestMatchResourceAttr("enos_consul_snapshot.foo", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+func TestConsulSnapshotValidate(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		setup     func(*consulSnapshotStateV1)
+		expectErr bool
+	}{
+		"valid": {
+			setup: func(s *consulSnapshotStateV1) {
+				s.BinPath.Set("/opt/consul/bin/consul")
+				s.Path.Set("/tmp/consul.snap")
+				s.Mode.Set("restore")
+			},
+		},
+		"missing bin path": {
+			setup: func(s *consulSnapshotStateV1) {
+				s.Path.Set("/tmp/consul.snap")
+			},
+			expectErr: true,
+		},
+		"invalid mode": {
+			setup: func(s *consulSnapshotStateV1) {
+				s.BinPath.Set("/opt/consul/bin/consul")
+				s.Path.Set("/tmp/consul.snap")
+				s.Mode.Set("backup")
+			},
+			expectErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newConsulSnapshotStateV1()
+			test.setup(s)
+			err := s.Validate(context.Background())
+			if test.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConsulSnapshotBuildSnapshotRequest(t *testing.T) {
+	t.Parallel()
+
+	s := newConsulSnapshotStateV1()
+	s.BinPath.Set("/opt/consul/bin/consul")
+	s.Path.Set("/tmp/consul.snap")
+	s.Token.Set("root")
+
+	require.Equal(t, consulSnapshotModeSave, s.mode())
+
+	req := s.buildSnapshotRequest()
+	require.Equal(t, "http://127.0.0.1:8500", req.ConsulAddr)
+	require.Equal(t, "root", req.Token)
+	require.Equal(t, "/tmp/consul.snap", req.Path)
+	require.NoError(t, req.Validate())
+}
+
+// TestConsulSnapshotPlanIndex tests that we keep the index of the previous snapshot unless the
+// snapshot inputs have changed.
+func TestConsulSnapshotPlanIndex(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+
+	newState := func(path string) *consulSnapshotStateV1 {
+		s := newConsulSnapshotStateV1()
+		s.ID.Set("static")
+		s.Mode.Set(consulSnapshotModeSave)
+		s.Path.Set(path)
+		s.Index.Set(42)
+		ssh := newEmbeddedTransportSSH()
+		ssh.User.Set("ubuntu")
+		ssh.Host.Set("localhost")
+		ssh.PrivateKey.Set(privateKey)
+		require.NoError(t, s.Transport.SetTransportState(ssh))
+
+		return s
+	}
+
+	for desc, test := range map[string]struct {
+		proposed      *consulSnapshotStateV1
+		expectUnknown bool
+	}{
+		"unchanged":    {newState("/tmp/consul.snap"), false},
+		"path changed": {newState("/tmp/other.snap"), true},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			res := &resourcerouter.PlanResourceChangeResponse{}
+			newConsulSnapshot().PlanResourceChange(context.Background(), resourcerouter.PlanResourceChangeRequest{
+				PriorState:       newState("/tmp/consul.snap").Terraform5Value(),
+				ProposedNewState: test.proposed.Terraform5Value(),
+			}, res)
+			require.Empty(t, res.Diagnostics)
+
+			planned, ok := res.PlannedState.(*consulSnapshotStateV1)
+			require.True(t, ok)
+			require.Equal(t, test.expectUnknown, planned.Index.Unknown)
+			if !test.expectUnknown {
+				index, ok := planned.Index.Get()
+				require.True(t, ok)
+				require.Equal(t, 42, index)
+			}
+		})
+	}
+}
@@ -37,11 +37,14 @@ var _ resource.Resource = (*consulStart)(nil)
 type consulStartStateV1 struct {
 	ID              *tfString
 	BinPath         *tfString
+	ConsulAddr      *tfString
 	ConfigDir       *tfString
 	DataDir         *tfString
 	Config          *consulConfig
+	GossipKey       *tfString
 	License         *tfString
 	SystemdUnitName *tfString
+	TLS             *tlsClientConfig
 	Transport       *embeddedTransportV1
 	Username        *tfString
 
@@ -49,14 +52,23 @@ type consulStartStateV1 struct {
 }
 
 type consulConfig struct {
+	ACL             *dynamicPseudoTypeBlock
+	AutoConfig      *dynamicPseudoTypeBlock
+	AutoEncrypt     *dynamicPseudoTypeBlock
 	BindAddr        *tfString
+	BootstrapExpect *tfNum
+	ClientAddr      *tfString
+	Connect         *dynamicPseudoTypeBlock
 	Datacenter      *tfString
 	DataDir         *tfString
-	RetryJoin       *tfStringSlice
-	Server          *tfBool
-	BootstrapExpect *tfNum
+	Extra           *dynamicPseudoTypeBlock
 	LogFile         *tfString
 	LogLevel        *tfString
+	Ports           *dynamicPseudoTypeBlock
+	RetryJoin       *tfStringSlice
+	Server          *tfBool
+	TLS             *dynamicPseudoTypeBlock
+	UIConfig        *dynamicPseudoTypeBlock
 }
 
 var _ state.State = (*consulStartStateV1)(nil)
@@ -68,6 +80,28 @@ func newConsulStart() *consulStart {
 	}
 }
 
+func newConsulConfig() *consulConfig {
+	return &consulConfig{
+		ACL:             newDynamicPseudoTypeBlock(),
+		AutoConfig:      newDynamicPseudoTypeBlock(),
+		AutoEncrypt:     newDynamicPseudoTypeBlock(),
+		BindAddr:        newTfString(),
+		BootstrapExpect: newTfNum(),
+		ClientAddr:      newTfString(),
+		Connect:         newDynamicPseudoTypeBlock(),
+		Datacenter:      newTfString(),
+		DataDir:         newTfString(),
+		Extra:           newDynamicPseudoTypeBlock(),
+		LogFile:         newTfString(),
+		LogLevel:        newTfString(),
+		Ports:           newDynamicPseudoTypeBlock(),
+		RetryJoin:       newTfStringSlice(),
+		Server:          newTfBool(),
+		TLS:             newDynamicPseudoTypeBlock(),
+		UIConfig:        newDynamicPseudoTypeBlock(),
+	}
+}
+
 func newConsulStartStateV1() *consulStartStateV1 {
 	transport := newEmbeddedTransport()
 	fh := failureHandlers{
@@ -76,22 +110,16 @@ func newConsulStartStateV1() *consulStartStateV1 {
 	}
 
 	return &consulStartStateV1{
-		ID:        newTfString(),
-		BinPath:   newTfString(),
-		ConfigDir: newTfString(),
-		DataDir:   newTfString(),
-		Config: &consulConfig{
-			BindAddr:        newTfString(),
-			Datacenter:      newTfString(),
-			DataDir:         newTfString(),
-			RetryJoin:       newTfStringSlice(),
-			Server:          newTfBool(),
-			BootstrapExpect: newTfNum(),
-			LogFile:         newTfString(),
-			LogLevel:        newTfString(),
-		},
+		ID:              newTfString(),
+		BinPath:         newTfString(),
+		ConsulAddr:      newTfString(),
+		ConfigDir:       newTfString(),
+		DataDir:         newTfString(),
+		Config:          newConsulConfig(),
+		GossipKey:       newTfString(),
 		License:         newTfString(),
 		SystemdUnitName: newTfString(),
+		TLS:             newTLSClientConfig(),
 		Transport:       transport,
 		Username:        newTfString(),
 		failureHandlers: fh,
@@ -262,16 +290,33 @@ The ^enos_consul_start^ resource is capable of configuring a Consul service on a
 					Optional:        true,
 					DescriptionKind: tfprotov6.StringKindMarkdown,
 					Description: docCaretToBacktick(`
+The Consul agent configuration. All attributes are optional. Attributes that accept an object are rendered as a block of the same name, and any nested objects are rendered as nested blocks.
+- ^config.acl^ (Object) The Consul [acl](https://developer.hashicorp.com/consul/docs/agent/config/config-files#acl) block
+- ^config.auto_config^ (Object) The Consul [auto_config](https://developer.hashicorp.com/consul/docs/agent/config/config-files#auto_config) block
+- ^config.auto_encrypt^ (Object) The Consul [auto_encrypt](https://developer.hashicorp.com/consul/docs/agent/config/config-files#auto_encrypt) block
 - ^config.bind_addr^ (String) The Consul [bind_addr](https://developer.hashicorp.com/consul/docs/agent/config/config-files#bind_addr) value
+- ^config.bootstrap_expect^ (Number) The Consul [bootstrap_expect](https://developer.hashicorp.com/consul/docs/agent/config/config-files#bootstrap_expect) value
+- ^config.client_addr^ (String) The Consul [client_addr](https://developer.hashicorp.com/consul/docs/agent/config/config-files#client_addr) value
+- ^config.connect^ (Object) The Consul [connect](https://developer.hashicorp.com/consul/docs/agent/config/config-files#connect) block
 - ^config.datacenter^ (String) The Consul [datacenter](https://developer.hashicorp.com/consul/docs/agent/config/config-files#datacenter) value
 - ^config.data_dir^ (String) The Consul [data_dir](https://developer.hashicorp.com/consul/docs/agent/config/config-files#data_dir) value
-- ^config.retry_join^ (List of String) The Consul [retry_join](https://developer.hashicorp.com/consul/docs/agent/config/config-files#retry_join) value
-- ^config.bootstrap_expect^ (Number) The Consul [bootstrap_expect](https://developer.hashicorp.com/consul/docs/agent/config/config-files#bootstrap_expect) value
-- ^config.server^ (Bool) The Consul [server](https://developer.hashicorp.com/consul/docs/agent/config/config-files#server_rpc_port) value
+- ^config.extra^ (Object) Any additional Consul agent configuration that is not otherwise supported. The attributes are rendered at the top level of the configuration file
 - ^config.log_file^ (String) The Consul [log_file](https://developer.hashicorp.com/consul/docs/agent/config/config-files#log_file) value
 - ^config.log_level^ (String) The Consul [log_level](https://developer.hashicorp.com/consul/docs/agent/config/config-files#log_level) value
+- ^config.ports^ (Object) The Consul [ports](https://developer.hashicorp.com/consul/docs/agent/config/config-files#ports) block
+- ^config.retry_join^ (List of String) The Consul [retry_join](https://developer.hashicorp.com/consul/docs/agent/config/config-files#retry_join) value
+- ^config.server^ (Bool) The Consul [server](https://developer.hashicorp.com/consul/docs/agent/config/config-files#server_rpc_port) value
+- ^config.tls^ (Object) The Consul [tls](https://developer.hashicorp.com/consul/docs/agent/config/config-files#tls) block, e.g. ^tls = { defaults = { ca_file = "/etc/consul.d/tls/ca.pem" } }^
+- ^config.ui_config^ (Object) The Consul [ui_config](https://developer.hashicorp.com/consul/docs/agent/config/config-files#ui_config) block
 `),
 				},
+				{
+					Name:            "consul_addr",
+					Type:            tftypes.String,
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The address of the Consul HTTP API to use when waiting for the cluster to be healthy. Defaults to `http://127.0.0.1:8500`",
+				},
 				{
 					Name:        "config_dir", // where to write consul config
 					Type:        tftypes.String,
@@ -284,6 +329,14 @@ The ^enos_consul_start^ resource is capable of configuring a Consul service on a
 					Optional:    true,
 					Description: "The directory where Consul state will be stored",
 				},
+				{
+					Name:            "gossip_key",
+					Type:            tftypes.String,
+					Optional:        true,
+					Sensitive:       true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The Consul gossip encryption key. It is rendered as the [encrypt](https://developer.hashicorp.com/consul/docs/agent/config/config-files#encrypt) value of the agent configuration",
+				},
 				{
 					Name:        "license", // the consul license
 					Type:        tftypes.String,
@@ -303,63 +356,13 @@ The ^enos_consul_start^ resource is capable of configuring a Consul service on a
 					Optional:    true,
 					Description: "The name of the local user for the consul service",
 				},
+				s.TLS.SchemaAttribute("Consul"),
 				s.Transport.SchemaAttributeTransport(supportsSSH),
 			},
 		},
 	}
 }
 
-// Schema is the file states Terraform schema.
-func (c *consulConfig) Schema() *tfprotov6.Schema {
-	return &tfprotov6.Schema{
-		Version: 1,
-		Block: &tfprotov6.SchemaBlock{
-			Attributes: []*tfprotov6.SchemaAttribute{
-				{
-					Name:     "bind_addr",
-					Type:     tftypes.String,
-					Optional: true,
-				},
-				{
-					Name:     "datacenter",
-					Type:     tftypes.String,
-					Optional: true,
-				},
-				{
-					Name:     "data_dir",
-					Type:     tftypes.String,
-					Optional: true,
-				},
-				{
-					Name:     "retry_join",
-					Type:     tftypes.String,
-					Optional: true,
-				},
-				{
-					Name:     "server",
-					Type:     tftypes.Bool,
-					Optional: true,
-				},
-				{
-					Name:     "bootstrap_expect",
-					Type:     tftypes.Number,
-					Optional: true,
-				},
-				{
-					Name:     "log_file",
-					Type:     tftypes.String,
-					Optional: true,
-				},
-				{
-					Name:     "log_level",
-					Type:     tftypes.String,
-					Optional: true,
-				},
-			},
-		},
-	}
-}
-
 // Validate validates the configuration. This will validate the source file
 // exists and that the transport configuration is valid.
 func (s *consulStartStateV1) Validate(ctx context.Context) error {
@@ -377,24 +380,31 @@ func (s *consulStartStateV1) Validate(ctx context.Context) error {
 		return ValidationError("you must provide a consul binary path", "attribute")
 	}
 
-	return nil
+	return s.TLS.Validate()
 }
 
 // FromTerraform5Value is a callback to unmarshal from the tftypes.Consul with As().
 func (s *consulStartStateV1) FromTerraform5Value(val tftypes.Value) error {
 	vals, err := mapAttributesTo(val, map[string]any{
-		"bin_path":   s.BinPath,
-		"config_dir": s.ConfigDir,
-		"data_dir":   s.DataDir,
-		"id":         s.ID,
-		"license":    s.License,
-		"unit_name":  s.SystemdUnitName,
-		"username":   s.Username,
+		"bin_path":    s.BinPath,
+		"consul_addr": s.ConsulAddr,
+		"config_dir":  s.ConfigDir,
+		"data_dir":    s.DataDir,
+		"gossip_key":  s.GossipKey,
+		"id":          s.ID,
+		"license":     s.License,
+		"unit_name":   s.SystemdUnitName,
+		"username":    s.Username,
 	})
 	if err != nil {
 		return err
 	}
 
+	err = s.TLS.FromTerraform5Value(vals["tls"])
+	if err != nil {
+		return err
+	}
+
 	if vals["config"].IsKnown() {
 		err = s.Config.FromTerraform5Value(vals["config"])
 		if err != nil {
@@ -418,50 +428,82 @@ func (c *consulConfig) Terraform5Type() tftypes.Type {
 
 func (c *consulConfig) attrs() map[string]tftypes.Type {
 	return map[string]tftypes.Type{
+		"acl":              c.ACL.TFType(),
+		"auto_config":      c.AutoConfig.TFType(),
+		"auto_encrypt":     c.AutoEncrypt.TFType(),
 		"bind_addr":        c.BindAddr.TFType(),
+		"bootstrap_expect": c.BootstrapExpect.TFType(),
+		"client_addr":      c.ClientAddr.TFType(),
+		"connect":          c.Connect.TFType(),
 		"datacenter":       c.Datacenter.TFType(),
 		"data_dir":         c.DataDir.TFType(),
-		"retry_join":       c.RetryJoin.TFType(),
-		"server":           c.Server.TFType(),
-		"bootstrap_expect": c.BootstrapExpect.TFType(),
+		"extra":            c.Extra.TFType(),
 		"log_file":         c.LogFile.TFType(),
 		"log_level":        c.LogLevel.TFType(),
+		"ports":            c.Ports.TFType(),
+		"retry_join":       c.RetryJoin.TFType(),
+		"server":           c.Server.TFType(),
+		"tls":              c.TLS.TFType(),
+		"ui_config":        c.UIConfig.TFType(),
 	}
 }
 
 func (c *consulConfig) optionalAttrs() map[string]struct{} {
-	return map[string]struct{}{
-		"bind_addr": {},
+	optional := map[string]struct{}{}
+	for name := range c.attrs() {
+		optional[name] = struct{}{}
+	}
+
+	return optional
+}
+
+// dynamicBlocks returns the dynamic configuration blocks keyed by their attribute name.
+func (c *consulConfig) dynamicBlocks() map[string]*dynamicPseudoTypeBlock {
+	return map[string]*dynamicPseudoTypeBlock{
+		"acl":          c.ACL,
+		"auto_config":  c.AutoConfig,
+		"auto_encrypt": c.AutoEncrypt,
+		"connect":      c.Connect,
+		"extra":        c.Extra,
+		"ports":        c.Ports,
+		"tls":          c.TLS,
+		"ui_config":    c.UIConfig,
 	}
 }
 
 // Terraform5Type is the file state tftypes.Type.
 func (s *consulStartStateV1) Terraform5Type() tftypes.Type {
 	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
-		"bin_path":   s.BinPath.TFType(),
-		"config":     s.Config.Terraform5Type(),
-		"data_dir":   s.DataDir.TFType(),
-		"config_dir": s.ConfigDir.TFType(),
-		"id":         s.ID.TFType(),
-		"license":    s.License.TFType(),
-		"unit_name":  s.SystemdUnitName.TFType(),
-		"transport":  s.Transport.Terraform5Type(),
-		"username":   s.Username.TFType(),
+		"bin_path":    s.BinPath.TFType(),
+		"config":      s.Config.Terraform5Type(),
+		"consul_addr": s.ConsulAddr.TFType(),
+		"data_dir":    s.DataDir.TFType(),
+		"config_dir":  s.ConfigDir.TFType(),
+		"gossip_key":  s.GossipKey.TFType(),
+		"id":          s.ID.TFType(),
+		"license":     s.License.TFType(),
+		"unit_name":   s.SystemdUnitName.TFType(),
+		"tls":         s.TLS.Terraform5Type(),
+		"transport":   s.Transport.Terraform5Type(),
+		"username":    s.Username.TFType(),
 	}}
 }
 
 // Terraform5Value is the file state tftypes.Value.
 func (s *consulStartStateV1) Terraform5Value() tftypes.Value {
 	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
-		"bin_path":   s.BinPath.TFValue(),
-		"config":     s.Config.Terraform5Value(),
-		"data_dir":   s.DataDir.TFValue(),
-		"config_dir": s.ConfigDir.TFValue(),
-		"id":         s.ID.TFValue(),
-		"license":    s.License.TFValue(),
-		"unit_name":  s.SystemdUnitName.TFValue(),
-		"transport":  s.Transport.Terraform5Value(),
-		"username":   s.Username.TFValue(),
+		"bin_path":    s.BinPath.TFValue(),
+		"config":      s.Config.Terraform5Value(),
+		"consul_addr": s.ConsulAddr.TFValue(),
+		"data_dir":    s.DataDir.TFValue(),
+		"config_dir":  s.ConfigDir.TFValue(),
+		"gossip_key":  s.GossipKey.TFValue(),
+		"id":          s.ID.TFValue(),
+		"license":     s.License.TFValue(),
+		"unit_name":   s.SystemdUnitName.TFValue(),
+		"tls":         s.TLS.Terraform5Value(),
+		"transport":   s.Transport.Terraform5Value(),
+		"username":    s.Username.TFValue(),
 	})
 }
 
@@ -475,34 +517,58 @@ func (c *consulConfig) Terraform5Value() tftypes.Value {
 		AttributeTypes: c.attrs(),
 	}
 
-	return tftypes.NewValue(typ, map[string]tftypes.Value{
+	vals := map[string]tftypes.Value{
 		"bind_addr":        c.BindAddr.TFValue(),
+		"bootstrap_expect": c.BootstrapExpect.TFValue(),
+		"client_addr":      c.ClientAddr.TFValue(),
 		"data_dir":         c.DataDir.TFValue(),
 		"datacenter":       c.Datacenter.TFValue(),
-		"retry_join":       c.RetryJoin.TFValue(),
-		"server":           c.Server.TFValue(),
-		"bootstrap_expect": c.BootstrapExpect.TFValue(),
 		"log_file":         c.LogFile.TFValue(),
 		"log_level":        c.LogLevel.TFValue(),
-	})
+		"retry_join":       c.RetryJoin.TFValue(),
+		"server":           c.Server.TFValue(),
+	}
+
+	for name, block := range c.dynamicBlocks() {
+		val, err := block.TFValue()
+		if err != nil {
+			panic(err)
+		}
+		vals[name] = val
+	}
+
+	return tftypes.NewValue(typ, vals)
 }
 
 // FromTerraform5Value unmarshals the value to the struct.
 func (c *consulConfig) FromTerraform5Value(val tftypes.Value) error {
-	_, err := mapAttributesTo(val, map[string]any{
+	vals, err := mapAttributesTo(val, map[string]any{
 		"bind_addr":        c.BindAddr,
+		"bootstrap_expect": c.BootstrapExpect,
+		"client_addr":      c.ClientAddr,
 		"data_dir":         c.DataDir,
 		"datacenter":       c.Datacenter,
-		"retry_join":       c.RetryJoin,
-		"server":           c.Server,
-		"bootstrap_expect": c.BootstrapExpect,
 		"log_file":         c.LogFile,
 		"log_level":        c.LogLevel,
+		"retry_join":       c.RetryJoin,
+		"server":           c.Server,
 	})
 	if err != nil {
 		return err
 	}
 
+	for name, block := range c.dynamicBlocks() {
+		v, ok := vals[name]
+		if !ok {
+			continue
+		}
+
+		err = block.FromTFValue(v)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -542,9 +608,34 @@ func (c *consulConfig) ToHCLConfig() *hcl.Builder {
 		hlcBuilder.AppendAttribute("log_level", logLevel)
 	}
 
+	if clientAddr, ok := c.ClientAddr.Get(); ok {
+		hlcBuilder.AppendAttribute("client_addr", clientAddr)
+	}
+
+	if extra, ok := c.Extra.Object.GetObject(); ok {
+		hlcBuilder.AppendAttributesAsBlocks(extra)
+	}
+
+	for _, name := range []string{"acl", "auto_config", "auto_encrypt", "connect", "ports", "tls", "ui_config"} {
+		if attrs, ok := c.dynamicBlocks()[name].Object.GetObject(); ok {
+			hlcBuilder.AppendBlock(name, nil).AppendAttributesAsBlocks(attrs)
+		}
+	}
+
 	return hlcBuilder
 }
 
+// hclConfig returns the consul agent configuration, including the gossip encryption key.
+func (s *consulStartStateV1) hclConfig() *hcl.Builder {
+	config := s.Config.ToHCLConfig()
+
+	if gossipKey, ok := s.GossipKey.Get(); ok {
+		config.AppendAttribute("encrypt", gossipKey)
+	}
+
+	return config
+}
+
 func (s *consulStartStateV1) startConsul(ctx context.Context, transport it.Transport) error {
 	var err error
 
@@ -649,7 +740,7 @@ func (s *consulStartStateV1) startConsul(ctx context.Context, transport it.Trans
 		return fmt.Errorf("failed to daemon-reload systemd after writing the consul systemd unit, due to: %w", err)
 	}
 
-	config := s.Config.ToHCLConfig()
+	config := s.hclConfig()
 
 	// Create the consul HCL configuration file
 	err = hcl.CreateHCLConfigFile(ctx, transport, hcl.NewCreateHCLConfigFileRequest(
@@ -717,10 +808,17 @@ func (s *consulStartStateV1) startConsul(ctx context.Context, transport it.Trans
 		)
 	}
 
-	state, err := consul.WaitForState(ctx, transport, consul.NewStateRequest(
+	stateOpts := []consul.StateRequestOpt{
 		consul.WithStateRequestFlightControlUseHomeDir(),
 		consul.WithStateRequestSystemdUnitName(unitName),
-	), checks...)
+		consul.WithStateRequestTLSConfig(s.TLS.TLSConfig()),
+	}
+
+	if addr, ok := s.ConsulAddr.Get(); ok {
+		stateOpts = append(stateOpts, consul.WithStateRequestConsulAddr(addr))
+	}
+
+	state, err := consul.WaitForState(ctx, transport, consul.NewStateRequest(stateOpts...), checks...)
 	if err != nil {
 		err = fmt.Errorf("failed to start the consul service: %w", err)
 		if state != nil {
@@ -12,9 +12,136 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
+// newTestConsulConfig returns a consul config with all attributes set.
+func newTestConsulConfig(t *testing.T) *consulConfig {
+	t.Helper()
+
+	consulCfg := newConsulConfig()
+	consulCfg.Datacenter.Set("dc1")
+	consulCfg.RetryJoin.SetStrings([]string{"10.0.0.1", "10.0.0.2"})
+	consulCfg.ClientAddr.Set("0.0.0.0")
+
+	defaultsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"ca_file":         tftypes.String,
+		"verify_incoming": tftypes.Bool,
+	}}
+	tlsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"defaults": defaultsType}}
+	require.NoError(t, consulCfg.TLS.FromTFValue(tftypes.NewValue(tlsType, map[string]tftypes.Value{
+		"defaults": tftypes.NewValue(defaultsType, map[string]tftypes.Value{
+			"ca_file":         tftypes.NewValue(tftypes.String, "/etc/consul.d/tls/ca.pem"),
+			"verify_incoming": tftypes.NewValue(tftypes.Bool, true),
+		}),
+	})))
+
+	aclType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"enabled":        tftypes.Bool,
+		"default_policy": tftypes.String,
+	}}
+	require.NoError(t, consulCfg.ACL.FromTFValue(tftypes.NewValue(aclType, map[string]tftypes.Value{
+		"enabled":        tftypes.NewValue(tftypes.Bool, true),
+		"default_policy": tftypes.NewValue(tftypes.String, "deny"),
+	})))
+
+	for block, attr := range map[*dynamicPseudoTypeBlock]string{
+		consulCfg.AutoEncrypt: "allow_tls",
+		consulCfg.UIConfig:    "enabled",
+		consulCfg.Extra:       "disable_update_check",
+	} {
+		typ := tftypes.Object{AttributeTypes: map[string]tftypes.Type{attr: tftypes.Bool}}
+		require.NoError(t, block.FromTFValue(tftypes.NewValue(typ, map[string]tftypes.Value{
+			attr: tftypes.NewValue(tftypes.Bool, true),
+		})))
+	}
+
+	portsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"https": tftypes.Number}}
+	require.NoError(t, consulCfg.Ports.FromTFValue(tftypes.NewValue(portsType, map[string]tftypes.Value{
+		"https": tftypes.NewValue(tftypes.Number, 8501),
+	})))
+
+	return consulCfg
+}
+
+func TestConsulStartConfigOptionalAttrs(t *testing.T) {
+	t.Parallel()
+
+	consulCfg := newTestConsulConfig(t)
+
+	// Make sure we can create a dynamic value with optional attrs
+	val := consulCfg.Terraform5Value()
+	_, err := tfprotov6.NewDynamicValue(val.Type(), val)
+	require.NoError(t, err)
+
+	// Make sure we can round trip the value
+	newCfg := newConsulConfig()
+	require.NoError(t, newCfg.FromTerraform5Value(val))
+	dc, ok := newCfg.Datacenter.Get()
+	require.True(t, ok)
+	require.Equal(t, "dc1", dc)
+	_, ok = newCfg.TLS.Object.GetObject()
+	require.True(t, ok)
+	_, ok = newCfg.Connect.Object.GetObject()
+	require.False(t, ok)
+}
+
+func TestConsulConfigToHCLConfig(t *testing.T) {
+	t.Parallel()
+
+	hcl, err := newTestConsulConfig(t).ToHCLConfig().BuildHCL()
+	require.NoError(t, err)
+
+	assert.Equal(t, `datacenter           = "dc1"
+retry_join           = ["10.0.0.1", "10.0.0.2"]
+client_addr          = "0.0.0.0"
+disable_update_check = true
+acl {
+  default_policy = "deny"
+  enabled        = true
+}
+auto_encrypt {
+  allow_tls = true
+}
+ports {
+  https = 8501
+}
+tls {
+  defaults {
+    ca_file         = "/etc/consul.d/tls/ca.pem"
+    verify_incoming = true
+  }
+}
+ui_config {
+  enabled = true
+}
+`, hcl)
+}
+
+// TestConsulStartGossipKey tests that the gossip key is sensitive and that it is rendered as the
+// encrypt value of the agent configuration.
+func TestConsulStartGossipKey(t *testing.T) {
+	t.Parallel()
+
+	s := newConsulStartStateV1()
+	for _, attr := range s.Schema().Block.Attributes {
+		if attr.Name == "gossip_key" {
+			require.True(t, attr.Sensitive)
+		}
+	}
+
+	s.Config.Datacenter.Set("dc1")
+	s.GossipKey.Set("pUqJrVyVRj5jsiYEkM/tFQYfWyJIv4s3XkvDwy7Cu5s=")
+
+	hcl, err := s.hclConfig().BuildHCL()
+	require.NoError(t, err)
+	assert.Equal(t, `datacenter = "dc1"
+encrypt    = "pUqJrVyVRj5jsiYEkM/tFQYfWyJIv4s3XkvDwy7Cu5s="
+`, hcl)
+}
+
 // TestAccResourceConsulStart tests the consul_start resource.
 func TestAccResourceConsulStart(t *testing.T) {
 	t.Parallel()
@@ -26,6 +153,10 @@ func TestAccResourceConsulStart(t *testing.T) {
 		bin_path = "{{.BinPath.Value}}"
 		{{end}}
 
+		{{if .ConsulAddr.Value}}
+		consul_addr = "{{.ConsulAddr.Value}}"
+		{{end}}
+
 		config = {
 			datacenter = "{{.Config.Datacenter.Value}}"
 			data_dir = "{{.Config.DataDir.Value}}"
@@ -37,6 +168,9 @@ func TestAccResourceConsulStart(t *testing.T) {
 			bootstrap_expect = {{.Config.BootstrapExpect.Value}}
 			log_file = "{{.Config.LogFile.Value}}"
 			log_level = "{{.Config.LogLevel.Value}}"
+			{{if .Config.ClientAddr.Value}}
+			client_addr = "{{.Config.ClientAddr.Value}}"
+			{{end}}
 		}
 
 		{{if .ConfigDir.Value}}
@@ -47,6 +181,10 @@ func TestAccResourceConsulStart(t *testing.T) {
 		data_dir = "{{.DataDir.Value}}"
 		{{end}}
 
+		{{if .GossipKey.Value}}
+		gossip_key = "{{.GossipKey.Value}}"
+		{{end}}
+
 		{{if .License.Value}}
 		license = "{{.License.Value}}"
 		{{end}}
@@ -59,6 +197,14 @@ func TestAccResourceConsulStart(t *testing.T) {
 		username = "{{.Username.Value}}"
 		{{end}}
 
+		{{if not .TLS.Null}}
+		tls = {
+			ca_cert     = "{{.TLS.CACert.Value}}"
+			client_cert = "{{.TLS.ClientCert.Value}}"
+			client_key  = "{{.TLS.ClientKey.Value}}"
+		}
+		{{end}}
+
 		{{renderTransport .Transport}}
 	}`))
 
@@ -75,9 +221,16 @@ func TestAccResourceConsulStart(t *testing.T) {
 	consulStart.Config.BootstrapExpect.Set(3)
 	consulStart.Config.LogFile.Set("/var/log")
 	consulStart.Config.LogLevel.Set("INFO")
+	consulStart.Config.ClientAddr.Set("0.0.0.0")
+	consulStart.GossipKey.Set("pUqJrVyVRj5jsiYEkM/tFQYfWyJIv4s3XkvDwy7Cu5s=")
 	consulStart.License.Set("some-license-key")
 	consulStart.SystemdUnitName.Set("consul")
 	consulStart.Username.Set("consul")
+	consulStart.ConsulAddr.Set("https://127.0.0.1:8501")
+	consulStart.TLS.Null = false
+	consulStart.TLS.CACert.Set("/etc/consul.d/tls/ca.pem")
+	consulStart.TLS.ClientCert.Set("/etc/consul.d/tls/client.pem")
+	consulStart.TLS.ClientKey.Set("/etc/consul.d/tls/client-key.pem")
 	ssh := newEmbeddedTransportSSH()
 	ssh.User.Set("ubuntu")
 	ssh.Host.Set("localhost")
@@ -101,9 +254,12 @@ func TestAccResourceConsulStart(t *testing.T) {
 			resource.TestMatchResourceAttr("enos_consul_start.foo", "config.bootstrap_expect", regexp.MustCompile(`^3$`)),
 			resource.TestMatchResourceAttr("enos_consul_start.foo", "config.log_file", regexp.MustCompile(`^/var/log$`)),
 			resource.TestMatchResourceAttr("enos_consul_start.foo", "config.log_level", regexp.MustCompile(`^INFO$`)),
+			resource.TestMatchResourceAttr("enos_consul_start.foo", "config.client_addr", regexp.MustCompile(`^0.0.0.0$`)),
 			resource.TestMatchResourceAttr("enos_consul_start.foo", "config.license", regexp.MustCompile(`^some-license-key$`)),
 			resource.TestMatchResourceAttr("enos_consul_start.foo", "config.unit_name", regexp.MustCompile(`^consul$`)),
 			resource.TestMatchResourceAttr("enos_consul_start.foo", "config.username", regexp.MustCompile(`^consul$`)),
+			resource.TestMatchResourceAttr("enos_consul_start.foo", "consul_addr", regexp.MustCompile(`^https://127.0.0.1:8501$`)),
+			resource.TestMatchResourceAttr("enos_consul_start.foo", "tls.ca_cert", regexp.MustCompile(`^/etc/consul.d/tls/ca.pem$`)),
 			resource.TestMatchResourceAttr("enos_consul_start.foo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
 			resource.TestMatchResourceAttr("enos_consul_start.foo", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
 		),
@@ -0,0 +1,499 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/consul"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+)
+
+type consulVerifyCluster struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*consulVerifyCluster)(nil)
+
+type consulVerifyClusterStateV1 struct {
+	ID              *tfString
+	Hosts           *tfStringSlice
+	ConsulAddr      *tfString
+	SystemdUnitName *tfString
+	TLS             *tlsClientConfig
+	Leader          *tfBool
+	Voters          *tfNum
+	MinVoters       *tfNum
+	MinHealthyNodes *tfNum
+	ChecksPassing   *tfBool
+	Timeout         *tfString
+	Transport       *embeddedTransportV1
+
+	failureHandlers
+}
+
+var _ state.State = (*consulVerifyClusterStateV1)(nil)
+
+func newConsulVerifyCluster() *consulVerifyCluster {
+	return &consulVerifyCluster{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newConsulVerifyClusterStateV1() *consulVerifyClusterStateV1 {
+	transport := newEmbeddedTransport()
+	fh := failureHandlers{
+		TransportDebugFailureHandler(transport),
+	}
+
+	return &consulVerifyClusterStateV1{
+		ID:              newTfString(),
+		Hosts:           newTfStringSlice(),
+		ConsulAddr:      newTfString(),
+		SystemdUnitName: newTfString(),
+		TLS:             newTLSClientConfig(),
+		Leader:          newTfBool(),
+		Voters:          newTfNum(),
+		MinVoters:       newTfNum(),
+		MinHealthyNodes: newTfNum(),
+		ChecksPassing:   newTfBool(),
+		Timeout:         newTfString(),
+		Transport:       transport,
+		failureHandlers: fh,
+	}
+}
+
+func (r *consulVerifyCluster) Name() string {
+	return "enos_consul_verify_cluster"
+}
+
+func (r *consulVerifyCluster) Schema() *tfprotov6.Schema {
+	return newConsulVerifyClusterStateV1().Schema()
+}
+
+func (r *consulVerifyCluster) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *consulVerifyCluster) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *consulVerifyCluster) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newConsulVerifyClusterStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *consulVerifyCluster) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newConsulVerifyClusterStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *consulVerifyCluster) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newConsulVerifyClusterStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *consulVerifyCluster) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newConsulVerifyClusterStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *consulVerifyCluster) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newConsulVerifyClusterStateV1()
+	proposedState := newConsulVerifyClusterStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *consulVerifyCluster) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newConsulVerifyClusterStateV1()
+	plannedState := newConsulVerifyClusterStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do on delete
+		return
+	}
+
+	err := plannedState.Validate(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Validation Error", err))
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	// Only verify if we're creating the resource or something has changed.
+	if _, ok := priorState.ID.Get(); ok && reflect.DeepEqual(plannedState, priorState) {
+		return
+	}
+
+	providerConfig, err := r.GetProviderConfig()
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic(
+			"Apply Error",
+			fmt.Errorf("failed to get provider config, due to: %w", err),
+		))
+
+		return
+	}
+
+	err = plannedState.Verify(ctx, providerConfig.Transport)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Consul Verify Cluster Error", err))
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *consulVerifyClusterStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_consul_verify_cluster^ resource waits until every host in a Consul cluster agrees with
+the expected cluster state. The Consul agent on each host is queried in parallel until all of the
+configured expectations are met or the timeout is reached.
+
+The ^transport^ is shared by all hosts and the ^transport.ssh.host^ is replaced by each entry of
+^hosts^. The Consul systemd unit is always expected to be enabled and running.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        s.ID.TFType(),
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "hosts",
+					Type:        s.Hosts.TFType(),
+					Required:    true,
+					Description: "The addresses of the Consul hosts to verify",
+				},
+				{
+					Name:            "consul_addr",
+					Type:            s.ConsulAddr.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The address of the Consul HTTP API on each host. Defaults to `http://127.0.0.1:8500`",
+				},
+				{
+					Name:            "unit_name",
+					Type:            s.SystemdUnitName.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The name of the Consul systemd unit. Defaults to `consul`",
+				},
+				s.TLS.SchemaAttribute("Consul"),
+				{
+					Name:        "leader",
+					Type:        s.Leader.TFType(),
+					Optional:    true,
+					Description: "Expect the cluster to have a raft leader",
+				},
+				{
+					Name:            "voters",
+					Type:            s.Voters.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "Expect the cluster to have exactly this many raft voters. Conflicts with `min_voters`",
+				},
+				{
+					Name:            "min_voters",
+					Type:            s.MinVoters.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "Expect the cluster to have at least this many raft voters. Conflicts with `voters`",
+				},
+				{
+					Name:        "min_healthy_nodes",
+					Type:        s.MinHealthyNodes.TFType(),
+					Optional:    true,
+					Description: "Expect the cluster to have at least this many nodes with passing health checks",
+				},
+				{
+					Name:        "checks_passing",
+					Type:        s.ChecksPassing.TFType(),
+					Optional:    true,
+					Description: "Expect all of the health checks for each host's node to be passing",
+				},
+				{
+					Name:            "timeout",
+					Type:            s.Timeout.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The maximum amount of time to wait for the expectations to be met. Defaults to `5m`",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH),
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *consulVerifyClusterStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if hosts, ok := s.Hosts.GetStrings(); !ok || len(hosts) < 1 {
+		return ValidationError("you must provide at least one host", "hosts")
+	}
+
+	_, okVoters := s.Voters.Get()
+	_, okMinVoters := s.MinVoters.Get()
+	if okVoters && okMinVoters {
+		return ValidationError("voters and min_voters are mutually exclusive", "voters")
+	}
+
+	for attr, num := range map[string]*tfNum{
+		"voters":            s.Voters,
+		"min_voters":        s.MinVoters,
+		"min_healthy_nodes": s.MinHealthyNodes,
+	} {
+		if n, ok := num.Get(); ok && n < 0 {
+			return ValidationError(attr+" must not be negative", attr)
+		}
+	}
+
+	if timeout, ok := s.Timeout.Get(); ok {
+		if _, err := time.ParseDuration(timeout); err != nil {
+			return ValidationError(fmt.Sprintf("failed to parse duration [%s]", timeout), "timeout")
+		}
+	}
+
+	return s.TLS.Validate()
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Consul with As().
+func (s *consulVerifyClusterStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":                s.ID,
+		"checks_passing":    s.ChecksPassing,
+		"consul_addr":       s.ConsulAddr,
+		"hosts":             s.Hosts,
+		"leader":            s.Leader,
+		"min_healthy_nodes": s.MinHealthyNodes,
+		"min_voters":        s.MinVoters,
+		"timeout":           s.Timeout,
+		"unit_name":         s.SystemdUnitName,
+		"voters":            s.Voters,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.TLS.FromTerraform5Value(vals["tls"])
+	if err != nil {
+		return err
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *consulVerifyClusterStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":                s.ID.TFType(),
+		"checks_passing":    s.ChecksPassing.TFType(),
+		"consul_addr":       s.ConsulAddr.TFType(),
+		"hosts":             s.Hosts.TFType(),
+		"leader":            s.Leader.TFType(),
+		"min_healthy_nodes": s.MinHealthyNodes.TFType(),
+		"min_voters":        s.MinVoters.TFType(),
+		"timeout":           s.Timeout.TFType(),
+		"tls":               s.TLS.Terraform5Type(),
+		"transport":         s.Transport.Terraform5Type(),
+		"unit_name":         s.SystemdUnitName.TFType(),
+		"voters":            s.Voters.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *consulVerifyClusterStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":                s.ID.TFValue(),
+		"checks_passing":    s.ChecksPassing.TFValue(),
+		"consul_addr":       s.ConsulAddr.TFValue(),
+		"hosts":             s.Hosts.TFValue(),
+		"leader":            s.Leader.TFValue(),
+		"min_healthy_nodes": s.MinHealthyNodes.TFValue(),
+		"min_voters":        s.MinVoters.TFValue(),
+		"timeout":           s.Timeout.TFValue(),
+		"tls":               s.TLS.Terraform5Value(),
+		"transport":         s.Transport.Terraform5Value(),
+		"unit_name":         s.SystemdUnitName.TFValue(),
+		"voters":            s.Voters.TFValue(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *consulVerifyClusterStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+// Verify waits for every host to satisfy the expected cluster state.
+func (s *consulVerifyClusterStateV1) Verify(ctx context.Context, defaults *embeddedTransportV1) error {
+	timeout := 5 * time.Minute
+	if t, ok := s.Timeout.Get(); ok {
+		var err error
+		timeout, err = time.ParseDuration(t)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	hosts, _ := s.Hosts.GetStrings()
+	req := s.buildStateRequest()
+	checks := s.checks()
+
+	wg := sync.WaitGroup{}
+	errC := make(chan error, len(hosts))
+	for _, host := range hosts {
+		wg.Go(func() {
+			errC <- s.verifyHost(ctx, host, defaults, req, checks)
+		})
+	}
+	wg.Wait()
+	close(errC)
+
+	var err error
+	for e := range errC {
+		err = errors.Join(err, e)
+	}
+
+	return err
+}
+
+func (s *consulVerifyClusterStateV1) verifyHost(
+	ctx context.Context,
+	host string,
+	defaults *embeddedTransportV1,
+	req *consul.StateRequest,
+	checks []consul.CheckStater,
+) error {
+	et, err := s.Transport.CopyForSSHHost(ctx, host, defaults)
+	if err != nil {
+		return fmt.Errorf("%s: %w", host, err)
+	}
+
+	client, err := et.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", host, err)
+	}
+	defer client.Close()
+
+	state, err := consul.WaitForState(ctx, client, req, checks...)
+	if err != nil {
+		if state != nil {
+			return fmt.Errorf("%s: %w, last known state:\n%s", host, err, state.String())
+		}
+
+		return fmt.Errorf("%s: %w", host, err)
+	}
+
+	return nil
+}
+
+func (s *consulVerifyClusterStateV1) buildStateRequest() *consul.StateRequest {
+	opts := []consul.StateRequestOpt{
+		consul.WithStateRequestFlightControlUseHomeDir(),
+		consul.WithStateRequestTLSConfig(s.TLS.TLSConfig()),
+	}
+
+	if addr, ok := s.ConsulAddr.Get(); ok {
+		opts = append(opts, consul.WithStateRequestConsulAddr(addr))
+	}
+
+	if unit, ok := s.SystemdUnitName.Get(); ok {
+		opts = append(opts, consul.WithStateRequestSystemdUnitName(unit))
+	}
+
+	return consul.NewStateRequest(opts...)
+}
+
+func (s *consulVerifyClusterStateV1) checks() []consul.CheckStater {
+	checks := []consul.CheckStater{
+		consul.CheckStateHasSystemdEnabledAndRunningProperties(),
+	}
+
+	if leader, ok := s.Leader.Get(); ok && leader {
+		checks = append(checks, consul.CheckStateClusterHasLeader())
+	}
+
+	if voters, ok := s.Voters.Get(); ok {
+		checks = append(checks, consul.CheckStateClusterHasNVoters(uint(voters)))
+	}
+
+	if voters, ok := s.MinVoters.Get(); ok {
+		checks = append(checks, consul.CheckStateClusterHasMinNVoters(uint(voters)))
+	}
+
+	if nodes, ok := s.MinHealthyNodes.Get(); ok {
+		checks = append(checks, consul.CheckStateClusterHasMinNHealthyNodes(uint(nodes)))
+	}
+
+	if passing, ok := s.ChecksPassing.Get(); ok && passing {
+		checks = append(checks, consul.CheckStateNodeChecksArePassing())
+	}
+
+	return checks
+}
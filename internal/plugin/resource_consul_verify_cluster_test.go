@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccResourceConsulVerifyCluster tests the consul_verify_cluster resource.
+func TestAccResourceConsulVerifyCluster(t *testing.T) {
+	t.Parallel()
+	cfg := template.Must(template.New("enos_consul_verify_cluster").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_consul_verify_cluster" "{{.ID.Value}}" {
+		hosts = [{{range .Hosts.StringValue}}"{{.}}",{{end}}]
+
+		{{if .ConsulAddr.Value}}
+		consul_addr = "{{.ConsulAddr.Value}}"
+		{{end}}
+
+		{{if .Leader.Value}}
+		leader = {{.Leader.Value}}
+		{{end}}
+
+		{{if .MinVoters.Value}}
+		min_voters = {{.MinVoters.Value}}
+		{{end}}
+
+		{{if .MinHealthyNodes.Value}}
+		min_healthy_nodes = {{.MinHealthyNodes.Value}}
+		{{end}}
+
+		{{if .ChecksPassing.Value}}
+		checks_passing = {{.ChecksPassing.Value}}
+		{{end}}
+
+		{{if .Timeout.Value}}
+		timeout = "{{.Timeout.Value}}"
+		{{end}}
+
+		{{ renderTransport .Transport }}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	verifyCluster := newConsulVerifyClusterStateV1()
+	verifyCluster.ID.Set("foo")
+	verifyCluster.Hosts.SetStrings([]string{"10.0.0.1", "10.0.0.2", "10.0.0.3"})
+	verifyCluster.ConsulAddr.Set("http://127.0.0.1:8500")
+	verifyCluster.Leader.Set(true)
+	verifyCluster.MinVoters.Set(3)
+	verifyCluster.MinHealthyNodes.Set(3)
+	verifyCluster.ChecksPassing.Set(true)
+	verifyCluster.Timeout.Set("2m")
+	ssh := newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, verifyCluster.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		verifyCluster,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_consul_verify_cluster.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_cluster.foo", "hosts.0", regexp.MustCompile(`^10.0.0.1$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_cluster.foo", "consul_addr", regexp.MustCompile(`^http://127.0.0.1:8500$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_cluster.foo", "leader", regexp.MustCompile(`^true$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_cluster.foo", "min_voters", regexp.MustCompile(`^3$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_cluster.foo", "min_healthy_nodes", regexp.MustCompile(`^3$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_cluster.foo", "checks_passing", regexp.MustCompile(`^true$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_cluster.foo", "timeout", regexp.MustCompile(`^2m$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_cluster.foo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+func TestConsulVerifyClusterValidate(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		state      func() *consulVerifyClusterStateV1
+		shouldFail bool
+	}{
+		"no-hosts": {
+			func() *consulVerifyClusterStateV1 {
+				return newConsulVerifyClusterStateV1()
+			},
+			true,
+		},
+		"voters-and-min-voters": {
+			func() *consulVerifyClusterStateV1 {
+				s := newConsulVerifyClusterStateV1()
+				s.Hosts.SetStrings([]string{"10.0.0.1"})
+				s.Voters.Set(3)
+				s.MinVoters.Set(3)
+
+				return s
+			},
+			true,
+		},
+		"invalid-timeout": {
+			func() *consulVerifyClusterStateV1 {
+				s := newConsulVerifyClusterStateV1()
+				s.Hosts.SetStrings([]string{"10.0.0.1"})
+				s.Timeout.Set("five minutes")
+
+				return s
+			},
+			true,
+		},
+		"valid": {
+			func() *consulVerifyClusterStateV1 {
+				s := newConsulVerifyClusterStateV1()
+				s.Hosts.SetStrings([]string{"10.0.0.1", "10.0.0.2"})
+				s.Leader.Set(true)
+				s.Voters.Set(3)
+				s.Timeout.Set("5m")
+
+				return s
+			},
+			false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.state().Validate(context.Background())
+			if test.shouldFail {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
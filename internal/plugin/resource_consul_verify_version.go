@@ -0,0 +1,365 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/consul"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+)
+
+type consulVerifyVersion struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*consulVerifyVersion)(nil)
+
+type consulVerifyVersionStateV1 struct {
+	ID         *tfString
+	BinPath    *tfString
+	ConsulAddr *tfString
+	Version    *tfString
+	Edition    *tfString
+	Revision   *tfString
+	BuildDate  *tfString
+	Transport  *embeddedTransportV1
+
+	failureHandlers
+}
+
+var _ state.State = (*consulVerifyVersionStateV1)(nil)
+
+func newConsulVerifyVersion() *consulVerifyVersion {
+	return &consulVerifyVersion{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newConsulVerifyVersionStateV1() *consulVerifyVersionStateV1 {
+	transport := newEmbeddedTransport()
+	fh := failureHandlers{
+		TransportDebugFailureHandler(transport),
+		GetApplicationLogsFailureHandler(transport, []string{"consul"}),
+	}
+
+	return &consulVerifyVersionStateV1{
+		ID:              newTfString(),
+		BinPath:         newTfString(),
+		ConsulAddr:      newTfString(),
+		Version:         newTfString(),
+		Edition:         newTfString(),
+		Revision:        newTfString(),
+		BuildDate:       newTfString(),
+		Transport:       transport,
+		failureHandlers: fh,
+	}
+}
+
+func (r *consulVerifyVersion) Name() string {
+	return "enos_consul_verify_version"
+}
+
+func (r *consulVerifyVersion) Schema() *tfprotov6.Schema {
+	return newConsulVerifyVersionStateV1().Schema()
+}
+
+func (r *consulVerifyVersion) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *consulVerifyVersion) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *consulVerifyVersion) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newConsulVerifyVersionStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *consulVerifyVersion) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newConsulVerifyVersionStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *consulVerifyVersion) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newConsulVerifyVersionStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *consulVerifyVersion) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newConsulVerifyVersionStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *consulVerifyVersion) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newConsulVerifyVersionStateV1()
+	proposedState := newConsulVerifyVersionStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *consulVerifyVersion) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newConsulVerifyVersionStateV1()
+	plannedState := newConsulVerifyVersionStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do on delete
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	// Only verify if we're creating the resource or something has changed.
+	if _, ok := priorState.ID.Get(); ok && reflect.DeepEqual(plannedState, priorState) {
+		return
+	}
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	err = plannedState.Verify(ctx, client)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Consul Verify Version Error", err))
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *consulVerifyVersionStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_consul_verify_version^ resource verifies that the installed Consul binary and the running
+Consul agent report the expected version and build metadata. It is used to catch packaging
+regressions.
+
+The binary is verified with ^consul version^ and the running agent with the ^/v1/agent/self^ API.
+Only the attributes that are set are verified.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        s.ID.TFType(),
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:            "consul_addr",
+					Type:            s.ConsulAddr.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The address of the Consul HTTP API. Defaults to `http://127.0.0.1:8500`",
+				},
+				{
+					Name:        "bin_path",
+					Type:        s.BinPath.TFType(),
+					Required:    true,
+					Description: "The fully qualified path to the consul binary",
+				},
+				{
+					Name:            "build_date",
+					Type:            s.BuildDate.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The expected RFC3339 build date, e.g. `2023-11-03T14:56:56Z`",
+				},
+				{
+					Name:            "edition",
+					Type:            s.Edition.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The expected edition, e.g. `ce`, `ent`, `ent.fips1402`",
+				},
+				{
+					Name:        "revision",
+					Type:        s.Revision.TFType(),
+					Optional:    true,
+					Description: "The expected git revision SHA. Abbreviated SHAs of at least seven characters are allowed",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH),
+				{
+					Name:            "version",
+					Type:            s.Version.TFType(),
+					Required:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The expected version, e.g. `1.17.0` or `1.17.0-rc1`. Use `edition` to verify the build metadata",
+				},
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *consulVerifyVersionStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if _, ok := s.BinPath.Get(); !ok {
+		return ValidationError("you must provide the Consul bin path", "bin_path")
+	}
+
+	if err := s.buildVerifyVersionRequest().ExpectedVersion.Validate(); err != nil {
+		return ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Consul with As().
+func (s *consulVerifyVersionStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":          s.ID,
+		"bin_path":    s.BinPath,
+		"build_date":  s.BuildDate,
+		"consul_addr": s.ConsulAddr,
+		"edition":     s.Edition,
+		"revision":    s.Revision,
+		"version":     s.Version,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *consulVerifyVersionStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":          s.ID.TFType(),
+		"bin_path":    s.BinPath.TFType(),
+		"build_date":  s.BuildDate.TFType(),
+		"consul_addr": s.ConsulAddr.TFType(),
+		"edition":     s.Edition.TFType(),
+		"revision":    s.Revision.TFType(),
+		"transport":   s.Transport.Terraform5Type(),
+		"version":     s.Version.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *consulVerifyVersionStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":          s.ID.TFValue(),
+		"bin_path":    s.BinPath.TFValue(),
+		"build_date":  s.BuildDate.TFValue(),
+		"consul_addr": s.ConsulAddr.TFValue(),
+		"edition":     s.Edition.TFValue(),
+		"revision":    s.Revision.TFValue(),
+		"transport":   s.Transport.Terraform5Value(),
+		"version":     s.Version.TFValue(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *consulVerifyVersionStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+// Verify verifies the consul version.
+func (s *consulVerifyVersionStateV1) Verify(ctx context.Context, client it.Transport) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	err := consul.VerifyVersion(ctx, client, s.buildVerifyVersionRequest())
+	if err != nil {
+		return fmt.Errorf("failed to verify the consul version: %w", err)
+	}
+
+	return nil
+}
+
+func (s *consulVerifyVersionStateV1) buildVerifyVersionRequest() *consul.VerifyVersionRequest {
+	opts := []consul.VerifyVersionRequestOpt{
+		consul.WithVerifyVersionRequestFlightControlUseHomeDir(),
+		consul.WithVerifyVersionRequestBinPath(s.BinPath.Value()),
+	}
+
+	if addr, ok := s.ConsulAddr.Get(); ok {
+		opts = append(opts, consul.WithVerifyVersionRequestConsulAddr(addr))
+	}
+
+	if version, ok := s.Version.Get(); ok {
+		opts = append(opts, consul.WithVerifyVersionRequestVersion(version))
+	}
+
+	if edition, ok := s.Edition.Get(); ok {
+		opts = append(opts, consul.WithVerifyVersionRequestEdition(edition))
+	}
+
+	if revision, ok := s.Revision.Get(); ok {
+		opts = append(opts, consul.WithVerifyVersionRequestRevision(revision))
+	}
+
+	if date, ok := s.BuildDate.Get(); ok {
+		opts = append(opts, consul.WithVerifyVersionRequestBuildDate(date))
+	}
+
+	return consul.NewVerifyVersionRequest(opts...)
+}
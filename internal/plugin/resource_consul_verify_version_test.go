@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccResourceConsulVerifyVersion tests the consul_verify_version resource.
+func TestAccResourceConsulVerifyVersion(t *testing.T) {
+	t.Parallel()
+	cfg := template.Must(template.New("enos_consul_verify_version").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_consul_verify_version" "{{.ID.Value}}" {
+		{{if .BinPath.Value}}
+		bin_path = "{{.BinPath.Value}}"
+		{{end}}
+
+		{{if .ConsulAddr.Value}}
+		consul_addr = "{{.ConsulAddr.Value}}"
+		{{end}}
+
+		{{if .Version.Value}}
+		version = "{{.Version.Value}}"
+		{{end}}
+
+		{{if .Edition.Value}}
+		edition = "{{.Edition.Value}}"
+		{{end}}
+
+		{{if .Revision.Value}}
+		revision = "{{.Revision.Value}}"
+		{{end}}
+
+		{{if .BuildDate.Value}}
+		build_date = "{{.BuildDate.Value}}"
+		{{end}}
+
+		{{ renderTransport .Transport }}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	consulVerifyVersion := newConsulVerifyVersionStateV1()
+	consulVerifyVersion.ID.Set("foo")
+	consulVerifyVersion.BinPath.Set("/opt/consul/bin/consul")
+	consulVerifyVersion.ConsulAddr.Set("http://127.0.0.1:8500")
+	consulVerifyVersion.Version.Set("1.17.0")
+	consulVerifyVersion.Edition.Set("ent")
+	consulVerifyVersion.Revision.Set("4e3f428b")
+	consulVerifyVersion.BuildDate.Set("2023-11-03T14:56:56Z")
+	ssh := newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	ssh.Host.Set("localhost")
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, consulVerifyVersion.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		consulVerifyVersion,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_consul_verify_version.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_version.foo", "bin_path", regexp.MustCompile(`^/opt/consul/bin/consul$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_version.foo", "consul_addr", regexp.MustCompile(`^http://127.0.0.1:8500$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_version.foo", "version", regexp.MustCompile(`^1.17.0$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_version.foo", "edition", regexp.MustCompile(`^ent$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_version.foo", "revision", regexp.MustCompile(`^4e3f428b$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_version.foo", "build_date", regexp.MustCompile(`^2023-11-03T14:56:56Z$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_version.foo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
+			resource.TestMatchResourceAttr("enos_consul_verify_version.foo", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
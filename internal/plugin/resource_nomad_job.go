@@ -0,0 +1,477 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/log"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/nomad"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+)
+
+const defaultNomadJobWaitTimeout = 5 * time.Minute
+
+type nomadJob struct {
+	providerConfig *config
+	mu             sync.Mutex
+	clientFactory  nomadClientFactory
+}
+
+var _ resource.Resource = (*nomadJob)(nil)
+
+type nomadJobStateV1 struct {
+	ID           *tfString
+	Host         *tfString
+	SecretID     *tfString
+	Namespace    *tfString
+	Jobspec      *tfString
+	Purge        *tfBool
+	WaitTimeout  *tfString
+	JobID        *tfString
+	JobNamespace *tfString
+	JobType      *tfString
+
+	failureHandlers
+}
+
+var _ state.State = (*nomadJobStateV1)(nil)
+
+func newNomadJob() *nomadJob {
+	return &nomadJob{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+		clientFactory:  nomad.NewClient,
+	}
+}
+
+func newNomadJobStateV1() *nomadJobStateV1 {
+	return &nomadJobStateV1{
+		ID:              newTfString(),
+		Host:            newTfString(),
+		SecretID:        newTfString(),
+		Namespace:       newTfString(),
+		Jobspec:         newTfString(),
+		Purge:           newTfBool(),
+		WaitTimeout:     newTfString(),
+		JobID:           newTfString(),
+		JobNamespace:    newTfString(),
+		JobType:         newTfString(),
+		failureHandlers: failureHandlers{},
+	}
+}
+
+func (r *nomadJob) Name() string {
+	return "enos_nomad_job"
+}
+
+func (r *nomadJob) Schema() *tfprotov6.Schema {
+	return newNomadJobStateV1().Schema()
+}
+
+func (r *nomadJob) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *nomadJob) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *nomadJob) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newNomadJobStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *nomadJob) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	transportUtil.UpgradeResourceState(ctx, newNomadJobStateV1(), req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *nomadJob) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	select {
+	case <-ctx.Done():
+		res.Diagnostics = append(res.Diagnostics, ctxToDiagnostic(ctx))
+		return
+	default:
+	}
+
+	newState := newNomadJobStateV1()
+
+	err := unmarshal(newState, req.CurrentState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+		return
+	}
+
+	res.NewState, err = state.Marshal(newState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+	}
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *nomadJob) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	transportUtil.ImportResourceState(ctx, newNomadJobStateV1(), req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *nomadJob) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	select {
+	case <-ctx.Done():
+		res.Diagnostics = append(res.Diagnostics, ctxToDiagnostic(ctx))
+		return
+	default:
+	}
+
+	priorState := newNomadJobStateV1()
+	proposedState := newNomadJobStateV1()
+	res.PlannedState = proposedState
+
+	err := priorState.FromTerraform5Value(req.PriorState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+		return
+	}
+
+	err = proposedState.FromTerraform5Value(req.ProposedNewState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+		return
+	}
+
+	// Nothing to plan when we're being destroyed
+	if req.ProposedNewState.IsNull() {
+		return
+	}
+
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+	}
+
+	// The job attributes are read from the jobspec so they might change when the jobspec changes.
+	if _, ok := priorState.ID.Get(); !ok || !proposedState.Jobspec.Eq(priorState.Jobspec) {
+		proposedState.JobID.Unknown = true
+		proposedState.JobNamespace.Unknown = true
+		proposedState.JobType.Unknown = true
+	}
+
+	res.RequiresReplace = []*tftypes.AttributePath{
+		tftypes.NewAttributePathWithSteps([]tftypes.AttributePathStep{
+			tftypes.AttributeName("host"),
+		}),
+		tftypes.NewAttributePathWithSteps([]tftypes.AttributePathStep{
+			tftypes.AttributeName("namespace"),
+		}),
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *nomadJob) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newNomadJobStateV1()
+	plannedState := newNomadJobStateV1()
+	res.NewState = plannedState
+
+	err := plannedState.FromTerraform5Value(req.PlannedState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+		return
+	}
+
+	err = priorState.FromTerraform5Value(req.PriorState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+		return
+	}
+
+	switch {
+	case req.IsDelete():
+		err = priorState.deregister(ctx, r.clientFactory)
+		if err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Nomad Job Error", err))
+		}
+
+	case req.IsCreate(), req.IsUpdate():
+		if err = plannedState.Validate(ctx); err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Validation Error", err))
+			return
+		}
+
+		// Only run the job if it's new or the jobspec has changed.
+		if _, ok := priorState.ID.Get(); ok && plannedState.Jobspec.Eq(priorState.Jobspec) {
+			return
+		}
+
+		err = plannedState.run(ctx, r.clientFactory)
+		if err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Nomad Job Error", err))
+			return
+		}
+
+		// If the jobspec now has a different ID we need to stop the previous job.
+		if priorJobID, ok := priorState.JobID.Get(); ok && priorJobID != plannedState.JobID.Value() {
+			err = priorState.deregister(ctx, r.clientFactory)
+			if err != nil {
+				res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Nomad Job Error", err))
+			}
+		}
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *nomadJobStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_nomad_job^ resource submits a jobspec to a Nomad cluster and waits for the job to become
+healthy. Service jobs are healthy when the deployment of the latest job version is successful. Jobs
+that do not create deployments are healthy when all of the allocations of the latest job version are
+running, or have completed for batch jobs.
+
+Changes to the jobspec update the job in place. The job is stopped when the resource is destroyed.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "host",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The address of the Nomad HTTP API, e.g. http://23.56.78.9:4646",
+				},
+				{
+					Name:        "secret_id",
+					Type:        tftypes.String,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The Nomad ACL token secret ID to use when the cluster has ACLs enabled",
+				},
+				{
+					Name:        "namespace",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The namespace of the job. If not set the namespace in the jobspec is used",
+				},
+				{
+					Name:        "jobspec",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The HCL or JSON jobspec. HCL jobspecs are parsed by the Nomad API",
+				},
+				{
+					Name:        "purge",
+					Type:        tftypes.Bool,
+					Optional:    true,
+					Description: "Whether or not to purge the job from the cluster when it is stopped. Defaults to false",
+				},
+				{
+					Name:        "wait_timeout",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The amount of time to wait for the job to become healthy. If not provided a default of 5m will be used",
+				},
+				{
+					Name:        "job_id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "The ID of the job",
+				},
+				{
+					Name:        "job_namespace",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "The namespace of the job",
+				},
+				{
+					Name:        "job_type",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "The type of the job, e.g. service, batch, system or sysbatch",
+				},
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *nomadJobStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if _, ok := s.Host.Get(); !ok {
+		return ValidationError("you must provide the nomad host", "host")
+	}
+
+	if jobspec, ok := s.Jobspec.Get(); ok && jobspec == "" {
+		return ValidationError("the jobspec cannot be empty", "jobspec")
+	}
+
+	if timeout, ok := s.WaitTimeout.Get(); ok {
+		_, err := time.ParseDuration(timeout)
+		if err != nil {
+			return ValidationError(fmt.Sprintf("failed to parse duration [%s]", timeout), "wait_timeout")
+		}
+	}
+
+	return nil
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Value with As().
+func (s *nomadJobStateV1) FromTerraform5Value(val tftypes.Value) error {
+	_, err := mapAttributesTo(val, map[string]any{
+		"id":            s.ID,
+		"host":          s.Host,
+		"secret_id":     s.SecretID,
+		"namespace":     s.Namespace,
+		"jobspec":       s.Jobspec,
+		"purge":         s.Purge,
+		"wait_timeout":  s.WaitTimeout,
+		"job_id":        s.JobID,
+		"job_namespace": s.JobNamespace,
+		"job_type":      s.JobType,
+	})
+
+	return err
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *nomadJobStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":            s.ID.TFType(),
+		"host":          s.Host.TFType(),
+		"secret_id":     s.SecretID.TFType(),
+		"namespace":     s.Namespace.TFType(),
+		"jobspec":       s.Jobspec.TFType(),
+		"purge":         s.Purge.TFType(),
+		"wait_timeout":  s.WaitTimeout.TFType(),
+		"job_id":        s.JobID.TFType(),
+		"job_namespace": s.JobNamespace.TFType(),
+		"job_type":      s.JobType.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *nomadJobStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":            s.ID.TFValue(),
+		"host":          s.Host.TFValue(),
+		"secret_id":     s.SecretID.TFValue(),
+		"namespace":     s.Namespace.TFValue(),
+		"jobspec":       s.Jobspec.TFValue(),
+		"purge":         s.Purge.TFValue(),
+		"wait_timeout":  s.WaitTimeout.TFValue(),
+		"job_id":        s.JobID.TFValue(),
+		"job_namespace": s.JobNamespace.TFValue(),
+		"job_type":      s.JobType.TFValue(),
+	})
+}
+
+// client creates a nomad client for the configured host.
+func (s *nomadJobStateV1) client(factory nomadClientFactory) (nomad.Client, error) {
+	client, err := factory(nomad.ClientCfg{
+		Host:     s.Host.Value(),
+		SecretID: s.SecretID.Value(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create nomad client due to: %w", err)
+	}
+
+	return client, nil
+}
+
+// run registers the job and waits for it to become healthy.
+func (s *nomadJobStateV1) run(ctx context.Context, factory nomadClientFactory) error {
+	client, err := s.client(factory)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	res, err := client.RegisterJob(ctx, nomad.RegisterJobRequest{
+		Jobspec:   s.Jobspec.Value(),
+		Namespace: s.Namespace.Value(),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.ID.Set("static")
+	s.JobID.Set(res.JobID)
+	s.JobNamespace.Set(res.Namespace)
+	s.JobType.Set(res.Type)
+
+	timeout := defaultNomadJobWaitTimeout
+	if t, ok := s.WaitTimeout.Get(); ok {
+		timeout, err = time.ParseDuration(t)
+		if err != nil {
+			return fmt.Errorf("failed to parse 'wait_timeout': %s", t)
+		}
+	}
+
+	log.NewLogger(ctx).WithValues(map[string]any{
+		"job_id":    res.JobID,
+		"namespace": res.Namespace,
+		"eval_id":   res.EvalID,
+	}).Debug("waiting for nomad job to become healthy")
+
+	return client.WaitForJob(ctx, nomad.WaitForJobRequest{
+		Namespace: res.Namespace,
+		JobID:     res.JobID,
+		Type:      res.Type,
+		Timeout:   timeout,
+	})
+}
+
+// deregister stops the job and purges it if configured to.
+func (s *nomadJobStateV1) deregister(ctx context.Context, factory nomadClientFactory) error {
+	jobID, ok := s.JobID.Get()
+	if !ok || jobID == "" {
+		return nil
+	}
+
+	client, err := s.client(factory)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	purge, _ := s.Purge.Get()
+
+	return client.DeregisterJob(ctx, nomad.DeregisterJobRequest{
+		Namespace: s.JobNamespace.Value(),
+		JobID:     jobID,
+		Purge:     purge,
+	})
+}
@@ -0,0 +1,250 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/nomad"
+	rr "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+)
+
+// mockNomadJobClient is a nomad client that records the job requests.
+type mockNomadJobClient struct {
+	mockNomadClient
+	registered   []nomad.RegisterJobRequest
+	deregistered []nomad.DeregisterJobRequest
+	jobID        string
+}
+
+func (m *mockNomadJobClient) RegisterJob(ctx context.Context, req nomad.RegisterJobRequest) (*nomad.RegisterJobResponse, error) {
+	m.registered = append(m.registered, req)
+
+	return &nomad.RegisterJobResponse{
+		JobID:     m.jobID,
+		Namespace: "enos",
+		Type:      "service",
+		EvalID:    "d092fdc0-e1fd-2536-67d8-43af8ca798ac",
+	}, nil
+}
+
+func (m *mockNomadJobClient) WaitForJob(ctx context.Context, req nomad.WaitForJobRequest) error {
+	return nil
+}
+
+func (m *mockNomadJobClient) DeregisterJob(ctx context.Context, req nomad.DeregisterJobRequest) error {
+	m.deregistered = append(m.deregistered, req)
+
+	return nil
+}
+
+func TestNomadJobApply(t *testing.T) {
+	t.Parallel()
+
+	newJobState := func(jobspec string) *nomadJobStateV1 {
+		s := newNomadJobStateV1()
+		s.Host.Set("http://127.0.0.1:4646")
+		s.Jobspec.Set(jobspec)
+		s.Purge.Set(true)
+
+		return s
+	}
+
+	client := &mockNomadJobClient{jobID: "vault"}
+	job := newNomadJob()
+	job.clientFactory = func(cfg nomad.ClientCfg) (nomad.Client, error) {
+		require.Equal(t, "http://127.0.0.1:4646", cfg.Host)
+		return client, nil
+	}
+
+	// Create
+	planned := newJobState(`job "vault" {}`)
+	res := &rr.ApplyResourceChangeResponse{}
+	job.ApplyResourceChange(context.Background(), rr.ApplyResourceChangeRequest{
+		PriorState:   tftypes.NewValue(planned.Terraform5Type(), nil),
+		PlannedState: planned.Terraform5Value(),
+	}, res)
+	require.False(t, diags.HasErrors(res.Diagnostics))
+	require.Len(t, client.registered, 1)
+	created, ok := res.NewState.(*nomadJobStateV1)
+	require.True(t, ok)
+	require.Equal(t, "vault", created.JobID.Value())
+	require.Equal(t, "enos", created.JobNamespace.Value())
+	require.Equal(t, "service", created.JobType.Value())
+
+	// Update without a jobspec change does nothing
+	res = &rr.ApplyResourceChangeResponse{}
+	job.ApplyResourceChange(context.Background(), rr.ApplyResourceChangeRequest{
+		PriorState:   created.Terraform5Value(),
+		PlannedState: created.Terraform5Value(),
+	}, res)
+	require.False(t, diags.HasErrors(res.Diagnostics))
+	require.Len(t, client.registered, 1)
+
+	// Update with a new job ID stops the previous job
+	client.jobID = "vault-2"
+	planned = newJobState(`job "vault-2" {}`)
+	res = &rr.ApplyResourceChangeResponse{}
+	job.ApplyResourceChange(context.Background(), rr.ApplyResourceChangeRequest{
+		PriorState:   created.Terraform5Value(),
+		PlannedState: planned.Terraform5Value(),
+	}, res)
+	require.False(t, diags.HasErrors(res.Diagnostics))
+	require.Len(t, client.registered, 2)
+	require.Equal(t, []nomad.DeregisterJobRequest{{Namespace: "enos", JobID: "vault", Purge: true}}, client.deregistered)
+	updated, ok := res.NewState.(*nomadJobStateV1)
+	require.True(t, ok)
+
+	// Delete
+	res = &rr.ApplyResourceChangeResponse{}
+	job.ApplyResourceChange(context.Background(), rr.ApplyResourceChangeRequest{
+		PriorState:   updated.Terraform5Value(),
+		PlannedState: tftypes.NewValue(updated.Terraform5Type(), nil),
+	}, res)
+	require.False(t, diags.HasErrors(res.Diagnostics))
+	require.Len(t, client.deregistered, 2)
+	require.Equal(t, "vault-2", client.deregistered[1].JobID)
+}
+
+func TestNomadJobValidate(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		state      func() *nomadJobStateV1
+		shouldFail bool
+	}{
+		"valid": {
+			func() *nomadJobStateV1 {
+				s := newNomadJobStateV1()
+				s.Host.Set("http://127.0.0.1:4646")
+				s.Jobspec.Set(`job "vault" {}`)
+				s.WaitTimeout.Set("10m")
+
+				return s
+			},
+			false,
+		},
+		"missing-host": {
+			func() *nomadJobStateV1 {
+				s := newNomadJobStateV1()
+				s.Jobspec.Set(`job "vault" {}`)
+
+				return s
+			},
+			true,
+		},
+		"empty-jobspec": {
+			func() *nomadJobStateV1 {
+				s := newNomadJobStateV1()
+				s.Host.Set("http://127.0.0.1:4646")
+				s.Jobspec.Set("")
+
+				return s
+			},
+			true,
+		},
+		"invalid-timeout": {
+			func() *nomadJobStateV1 {
+				s := newNomadJobStateV1()
+				s.Host.Set("http://127.0.0.1:4646")
+				s.Jobspec.Set(`job "vault" {}`)
+				s.WaitTimeout.Set("ten minutes")
+
+				return s
+			},
+			true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if test.shouldFail {
+				require.Error(t, test.state().Validate(context.Background()))
+			} else {
+				require.NoError(t, test.state().Validate(context.Background()))
+			}
+		})
+	}
+}
+
+// TestAccResourceNomadJob tests the nomad_job resource.
+func TestAccResourceNomadJob(t *testing.T) {
+	t.Parallel()
+
+	cfg := template.Must(template.New("enos_nomad_job").Parse(`resource "enos_nomad_job" "{{.ID.Value}}" {
+		host = "{{.Host.Value}}"
+		jobspec = <<JOBSPEC
+{{.Jobspec.Value}}
+JOBSPEC
+		{{if .Namespace.Value}}
+		namespace = "{{.Namespace.Value}}"
+		{{end}}
+		{{if .WaitTimeout.Value}}
+		wait_timeout = "{{.WaitTimeout.Value}}"
+		{{end}}
+		purge = {{.Purge.Value}}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	nomadJob := newNomadJobStateV1()
+	nomadJob.ID.Set("foo")
+	nomadJob.Host.Set("http://127.0.0.1:4646")
+	nomadJob.Jobspec.Set(`job "vault" {
+  group "vault" {
+    task "vault" {
+      driver = "docker"
+    }
+  }
+}`)
+	nomadJob.Namespace.Set("enos")
+	nomadJob.WaitTimeout.Set("10m")
+	nomadJob.Purge.Set(true)
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		nomadJob,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_nomad_job.foo", "host", regexp.MustCompile(`^http://127.0.0.1:4646$`)),
+			resource.TestMatchResourceAttr("enos_nomad_job.foo", "namespace", regexp.MustCompile(`^enos$`)),
+			resource.TestMatchResourceAttr("enos_nomad_job.foo", "wait_timeout", regexp.MustCompile(`^10m$`)),
+			resource.TestMatchResourceAttr("enos_nomad_job.foo", "purge", regexp.MustCompile(`^true$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
@@ -0,0 +1,863 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/log"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/hcl"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/nomad"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/systemd"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	istrings "github.com/hashicorp-forge/terraform-provider-enos/internal/strings"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	tfile "github.com/hashicorp-forge/terraform-provider-enos/internal/transport/file"
+)
+
+type nomadStart struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*nomadStart)(nil)
+
+type nomadStartStateV1 struct {
+	ID              *tfString
+	BinPath         *tfString
+	NomadAddr       *tfString
+	ConfigDir       *tfString
+	DataDir         *tfString
+	Config          *nomadAgentConfig
+	License         *tfString
+	SystemdUnitName *tfString
+	TLS             *tlsClientConfig
+	Token           *tfString
+	Transport       *embeddedTransportV1
+	Username        *tfString
+
+	failureHandlers
+}
+
+type nomadAgentConfig struct {
+	ACL        *dynamicPseudoTypeBlock
+	Advertise  *dynamicPseudoTypeBlock
+	BindAddr   *tfString
+	Client     *dynamicPseudoTypeBlock
+	Consul     *dynamicPseudoTypeBlock
+	Datacenter *tfString
+	DataDir    *tfString
+	Extra      *dynamicPseudoTypeBlock
+	LogFile    *tfString
+	LogLevel   *tfString
+	Name       *tfString
+	Plugin     *dynamicPseudoTypeBlock
+	Ports      *dynamicPseudoTypeBlock
+	Region     *tfString
+	RetryJoin  *tfStringSlice
+	Server     *dynamicPseudoTypeBlock
+	Telemetry  *dynamicPseudoTypeBlock
+	TLS        *dynamicPseudoTypeBlock
+}
+
+var _ state.State = (*nomadStartStateV1)(nil)
+
+func newNomadStart() *nomadStart {
+	return &nomadStart{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newNomadAgentConfig() *nomadAgentConfig {
+	return &nomadAgentConfig{
+		ACL:        newDynamicPseudoTypeBlock(),
+		Advertise:  newDynamicPseudoTypeBlock(),
+		BindAddr:   newTfString(),
+		Client:     newDynamicPseudoTypeBlock(),
+		Consul:     newDynamicPseudoTypeBlock(),
+		Datacenter: newTfString(),
+		DataDir:    newTfString(),
+		Extra:      newDynamicPseudoTypeBlock(),
+		LogFile:    newTfString(),
+		LogLevel:   newTfString(),
+		Name:       newTfString(),
+		Plugin:     newDynamicPseudoTypeBlock(),
+		Ports:      newDynamicPseudoTypeBlock(),
+		Region:     newTfString(),
+		RetryJoin:  newTfStringSlice(),
+		Server:     newDynamicPseudoTypeBlock(),
+		Telemetry:  newDynamicPseudoTypeBlock(),
+		TLS:        newDynamicPseudoTypeBlock(),
+	}
+}
+
+func newNomadStartStateV1() *nomadStartStateV1 {
+	transport := newEmbeddedTransport()
+	fh := failureHandlers{
+		TransportDebugFailureHandler(transport),
+		GetApplicationLogsFailureHandler(transport, []string{"nomad"}),
+	}
+
+	return &nomadStartStateV1{
+		ID:              newTfString(),
+		BinPath:         newTfString(),
+		NomadAddr:       newTfString(),
+		ConfigDir:       newTfString(),
+		DataDir:         newTfString(),
+		Config:          newNomadAgentConfig(),
+		License:         newTfString(),
+		SystemdUnitName: newTfString(),
+		TLS:             newTLSClientConfig(),
+		Token:           newTfString(),
+		Transport:       transport,
+		Username:        newTfString(),
+		failureHandlers: fh,
+	}
+}
+
+func (r *nomadStart) Name() string {
+	return "enos_nomad_start"
+}
+
+func (r *nomadStart) Schema() *tfprotov6.Schema {
+	return newNomadStartStateV1().Schema()
+}
+
+func (r *nomadStart) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *nomadStart) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *nomadStart) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newNomadStartStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *nomadStart) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newNomadStartStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *nomadStart) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newNomadStartStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *nomadStart) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newNomadStartStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *nomadStart) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newNomadStartStateV1()
+	proposedState := newNomadStartStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *nomadStart) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newNomadStartStateV1()
+	plannedState := newNomadStartStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do on delete
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	// If our priorState ID is blank then we're creating the resource
+	if _, ok := priorState.ID.Get(); !ok {
+		err = plannedState.startNomad(ctx, client)
+		if err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Nomad Start Error", err))
+			return
+		}
+	} else if reflect.DeepEqual(plannedState, priorState) {
+		err = plannedState.startNomad(ctx, client)
+		if err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Nomad Start Error", err))
+			return
+		}
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *nomadStartStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_nomad_start^ resource is capable of configuring a Nomad agent on a host. It handles creating the necessary configuration, configures licensing for Nomad Enterprise, manages the systemd unit, starts the nomad service, and waits for the cluster to elect a leader and for client nodes to become ready.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "bin_path", // where the nomad binary is
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The fully qualified path to the Nomad binary",
+				},
+				{
+					Name:            "config",
+					Type:            s.Config.Terraform5Type(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description: docCaretToBacktick(`
+The Nomad agent configuration. All attributes are optional. Attributes that accept an object are rendered as a block of the same name, and any nested objects are rendered as nested blocks.
+- ^config.acl^ (Object) The Nomad [acl](https://developer.hashicorp.com/nomad/docs/configuration/acl) block
+- ^config.advertise^ (Object) The Nomad [advertise](https://developer.hashicorp.com/nomad/docs/configuration#advertise) block
+- ^config.bind_addr^ (String) The Nomad [bind_addr](https://developer.hashicorp.com/nomad/docs/configuration#bind_addr) value
+- ^config.client^ (Object) The Nomad [client](https://developer.hashicorp.com/nomad/docs/configuration/client) block, e.g. ^client = { enabled = true }^
+- ^config.consul^ (Object) The Nomad [consul](https://developer.hashicorp.com/nomad/docs/configuration/consul) block
+- ^config.datacenter^ (String) The Nomad [datacenter](https://developer.hashicorp.com/nomad/docs/configuration#datacenter) value
+- ^config.data_dir^ (String) The Nomad [data_dir](https://developer.hashicorp.com/nomad/docs/configuration#data_dir) value. Defaults to the ^data_dir^ attribute
+- ^config.extra^ (Object) Any additional Nomad agent configuration that is not otherwise supported. The attributes are rendered at the top level of the configuration file
+- ^config.log_file^ (String) The Nomad [log_file](https://developer.hashicorp.com/nomad/docs/configuration#log_file) value
+- ^config.log_level^ (String) The Nomad [log_level](https://developer.hashicorp.com/nomad/docs/configuration#log_level) value
+- ^config.name^ (String) The Nomad [name](https://developer.hashicorp.com/nomad/docs/configuration#name) value
+- ^config.plugin^ (Object) Nomad [plugin](https://developer.hashicorp.com/nomad/docs/configuration/plugin) blocks keyed by the plugin name, e.g. ^plugin = { docker = { config = { allow_privileged = true } } }^
+- ^config.ports^ (Object) The Nomad [ports](https://developer.hashicorp.com/nomad/docs/configuration#ports) block
+- ^config.region^ (String) The Nomad [region](https://developer.hashicorp.com/nomad/docs/configuration#region) value
+- ^config.retry_join^ (List of String) The Nomad [retry_join](https://developer.hashicorp.com/nomad/docs/configuration/server_join#retry_join) value. It is rendered in the ^server_join^ block of the ^server^ and ^client^ blocks
+- ^config.server^ (Object) The Nomad [server](https://developer.hashicorp.com/nomad/docs/configuration/server) block, e.g. ^server = { enabled = true, bootstrap_expect = 3 }^
+- ^config.telemetry^ (Object) The Nomad [telemetry](https://developer.hashicorp.com/nomad/docs/configuration/telemetry) block
+- ^config.tls^ (Object) The Nomad [tls](https://developer.hashicorp.com/nomad/docs/configuration/tls) block
+`),
+				},
+				{
+					Name:        "config_dir", // where to write nomad config
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The directory where the Nomad configuration resides. Defaults to /etc/nomad.d",
+				},
+				{
+					Name:        "data_dir", // where to write nomad data
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The directory where Nomad state will be stored. Defaults to /opt/nomad/data",
+				},
+				{
+					Name:        "license", // the nomad license
+					Type:        tftypes.String,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "A Nomad Enterprise license. This is only required if you are starting a Nomad Enterprise cluster",
+				},
+				{
+					Name:            "nomad_addr",
+					Type:            tftypes.String,
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The address of the Nomad HTTP API to use when waiting for the cluster to be ready. Defaults to `http://127.0.0.1:4646`",
+				},
+				{
+					Name:        "token",
+					Type:        tftypes.String,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "A Nomad ACL token to use when waiting for the cluster to be ready. This is only required if ACLs are enabled and have been bootstrapped",
+				},
+				{
+					Name:        "unit_name", // sysmted unit name
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The name of the systemd unit to use. Defaults to nomad",
+				},
+				{
+					Name:        "username", // nomad username
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The name of the local user that owns the Nomad configuration and data. Defaults to nomad",
+				},
+				s.TLS.SchemaAttribute("Nomad"),
+				s.Transport.SchemaAttributeTransport(supportsSSH),
+			},
+		},
+	}
+}
+
+// Validate validates the configuration. This will validate the binary path is
+// set and that the transport configuration is valid.
+func (s *nomadStartStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if err := checkK8STransportNotConfigured(s, "enos_nomad_start"); err != nil {
+		return err
+	}
+
+	if _, ok := s.BinPath.Get(); !ok {
+		return ValidationError("you must provide a nomad binary path", "attribute")
+	}
+
+	return s.TLS.Validate()
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Value with As().
+func (s *nomadStartStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"bin_path":   s.BinPath,
+		"config_dir": s.ConfigDir,
+		"data_dir":   s.DataDir,
+		"id":         s.ID,
+		"license":    s.License,
+		"nomad_addr": s.NomadAddr,
+		"token":      s.Token,
+		"unit_name":  s.SystemdUnitName,
+		"username":   s.Username,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.TLS.FromTerraform5Value(vals["tls"])
+	if err != nil {
+		return err
+	}
+
+	if vals["config"].IsKnown() {
+		err = s.Config.FromTerraform5Value(vals["config"])
+		if err != nil {
+			return err
+		}
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *nomadStartStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"bin_path":   s.BinPath.TFType(),
+		"config":     s.Config.Terraform5Type(),
+		"config_dir": s.ConfigDir.TFType(),
+		"data_dir":   s.DataDir.TFType(),
+		"id":         s.ID.TFType(),
+		"license":    s.License.TFType(),
+		"nomad_addr": s.NomadAddr.TFType(),
+		"tls":        s.TLS.Terraform5Type(),
+		"token":      s.Token.TFType(),
+		"transport":  s.Transport.Terraform5Type(),
+		"unit_name":  s.SystemdUnitName.TFType(),
+		"username":   s.Username.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *nomadStartStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"bin_path":   s.BinPath.TFValue(),
+		"config":     s.Config.Terraform5Value(),
+		"config_dir": s.ConfigDir.TFValue(),
+		"data_dir":   s.DataDir.TFValue(),
+		"id":         s.ID.TFValue(),
+		"license":    s.License.TFValue(),
+		"nomad_addr": s.NomadAddr.TFValue(),
+		"tls":        s.TLS.Terraform5Value(),
+		"token":      s.Token.TFValue(),
+		"transport":  s.Transport.Terraform5Value(),
+		"unit_name":  s.SystemdUnitName.TFValue(),
+		"username":   s.Username.TFValue(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *nomadStartStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+func (c *nomadAgentConfig) Terraform5Type() tftypes.Type {
+	return tftypes.Object{
+		AttributeTypes:     c.attrs(),
+		OptionalAttributes: c.optionalAttrs(),
+	}
+}
+
+func (c *nomadAgentConfig) attrs() map[string]tftypes.Type {
+	return map[string]tftypes.Type{
+		"acl":        c.ACL.TFType(),
+		"advertise":  c.Advertise.TFType(),
+		"bind_addr":  c.BindAddr.TFType(),
+		"client":     c.Client.TFType(),
+		"consul":     c.Consul.TFType(),
+		"datacenter": c.Datacenter.TFType(),
+		"data_dir":   c.DataDir.TFType(),
+		"extra":      c.Extra.TFType(),
+		"log_file":   c.LogFile.TFType(),
+		"log_level":  c.LogLevel.TFType(),
+		"name":       c.Name.TFType(),
+		"plugin":     c.Plugin.TFType(),
+		"ports":      c.Ports.TFType(),
+		"region":     c.Region.TFType(),
+		"retry_join": c.RetryJoin.TFType(),
+		"server":     c.Server.TFType(),
+		"telemetry":  c.Telemetry.TFType(),
+		"tls":        c.TLS.TFType(),
+	}
+}
+
+func (c *nomadAgentConfig) optionalAttrs() map[string]struct{} {
+	optional := map[string]struct{}{}
+	for name := range c.attrs() {
+		optional[name] = struct{}{}
+	}
+
+	return optional
+}
+
+// dynamicBlocks returns the dynamic configuration blocks keyed by their attribute name.
+func (c *nomadAgentConfig) dynamicBlocks() map[string]*dynamicPseudoTypeBlock {
+	return map[string]*dynamicPseudoTypeBlock{
+		"acl":       c.ACL,
+		"advertise": c.Advertise,
+		"client":    c.Client,
+		"consul":    c.Consul,
+		"extra":     c.Extra,
+		"plugin":    c.Plugin,
+		"ports":     c.Ports,
+		"server":    c.Server,
+		"telemetry": c.Telemetry,
+		"tls":       c.TLS,
+	}
+}
+
+func (c *nomadAgentConfig) Terraform5Value() tftypes.Value {
+	typ := tftypes.Object{
+		AttributeTypes: c.attrs(),
+	}
+
+	vals := map[string]tftypes.Value{
+		"bind_addr":  c.BindAddr.TFValue(),
+		"data_dir":   c.DataDir.TFValue(),
+		"datacenter": c.Datacenter.TFValue(),
+		"log_file":   c.LogFile.TFValue(),
+		"log_level":  c.LogLevel.TFValue(),
+		"name":       c.Name.TFValue(),
+		"region":     c.Region.TFValue(),
+		"retry_join": c.RetryJoin.TFValue(),
+	}
+
+	for name, block := range c.dynamicBlocks() {
+		val, err := block.TFValue()
+		if err != nil {
+			panic(err)
+		}
+		vals[name] = val
+	}
+
+	return tftypes.NewValue(typ, vals)
+}
+
+// FromTerraform5Value unmarshals the value to the struct.
+func (c *nomadAgentConfig) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"bind_addr":  c.BindAddr,
+		"data_dir":   c.DataDir,
+		"datacenter": c.Datacenter,
+		"log_file":   c.LogFile,
+		"log_level":  c.LogLevel,
+		"name":       c.Name,
+		"region":     c.Region,
+		"retry_join": c.RetryJoin,
+	})
+	if err != nil {
+		return err
+	}
+
+	for name, block := range c.dynamicBlocks() {
+		v, ok := vals[name]
+		if !ok {
+			continue
+		}
+
+		err = block.FromTFValue(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// serverEnabled returns whether or not the agent is configured to run as a server.
+func (c *nomadAgentConfig) serverEnabled() bool {
+	return c.blockEnabled(c.Server)
+}
+
+// clientEnabled returns whether or not the agent is configured to run as a client.
+func (c *nomadAgentConfig) clientEnabled() bool {
+	return c.blockEnabled(c.Client)
+}
+
+// blockEnabled returns whether or not the block has an enabled attribute that is true.
+func (c *nomadAgentConfig) blockEnabled(block *dynamicPseudoTypeBlock) bool {
+	attrs, ok := block.Object.GetObject()
+	if !ok {
+		return false
+	}
+
+	enabled, ok := attrs["enabled"].(bool)
+
+	return ok && enabled
+}
+
+// bootstrapExpect returns the server bootstrap_expect value if it is set.
+func (c *nomadAgentConfig) bootstrapExpect() (int, bool) {
+	attrs, ok := c.Server.Object.GetObject()
+	if !ok {
+		return 0, false
+	}
+
+	expect, ok := attrs["bootstrap_expect"].(int)
+
+	return expect, ok
+}
+
+// ToHCLConfig returns the nomad config in the remoteflight HCLConfig format.
+func (c *nomadAgentConfig) ToHCLConfig() *hcl.Builder {
+	hlcBuilder := hcl.NewBuilder()
+
+	if name, ok := c.Name.Get(); ok {
+		hlcBuilder.AppendAttribute("name", name)
+	}
+
+	if region, ok := c.Region.Get(); ok {
+		hlcBuilder.AppendAttribute("region", region)
+	}
+
+	if dataCenter, ok := c.Datacenter.Get(); ok {
+		hlcBuilder.AppendAttribute("datacenter", dataCenter)
+	}
+
+	if bindAddr, ok := c.BindAddr.Get(); ok {
+		hlcBuilder.AppendAttribute("bind_addr", bindAddr)
+	}
+
+	if dataDir, ok := c.DataDir.Get(); ok {
+		hlcBuilder.AppendAttribute("data_dir", dataDir)
+	}
+
+	if logFile, ok := c.LogFile.Get(); ok {
+		hlcBuilder.AppendAttribute("log_file", logFile)
+	}
+
+	if logLevel, ok := c.LogLevel.Get(); ok {
+		hlcBuilder.AppendAttribute("log_level", logLevel)
+	}
+
+	if extra, ok := c.Extra.Object.GetObject(); ok {
+		hlcBuilder.AppendAttributesAsBlocks(extra)
+	}
+
+	for _, name := range []string{"acl", "advertise", "consul", "ports", "telemetry", "tls"} {
+		if attrs, ok := c.dynamicBlocks()[name].Object.GetObject(); ok {
+			hlcBuilder.AppendBlock(name, nil).AppendAttributesAsBlocks(attrs)
+		}
+	}
+
+	// Both servers and clients join the cluster with the server_join block
+	// nested inside of their respective stanzas.
+	retryJoin, hasRetryJoin := c.RetryJoin.GetStrings()
+	for _, name := range []string{"server", "client"} {
+		attrs, ok := c.dynamicBlocks()[name].Object.GetObject()
+		if !ok {
+			continue
+		}
+
+		block := hlcBuilder.AppendBlock(name, nil).AppendAttributesAsBlocks(attrs)
+		if _, ok := attrs["server_join"]; !ok && hasRetryJoin && len(retryJoin) > 0 {
+			block.AppendBlock("server_join", nil).AppendAttribute("retry_join", retryJoin)
+		}
+	}
+
+	// Plugins are labeled blocks, e.g. plugin "docker" { config { ... } }
+	if plugins, ok := c.Plugin.Object.GetObject(); ok {
+		for _, name := range slices.Sorted(maps.Keys(plugins)) {
+			block := hlcBuilder.AppendBlock("plugin", []string{name})
+			if attrs, ok := plugins[name].(map[string]any); ok {
+				block.AppendAttributesAsBlocks(attrs)
+			}
+		}
+	}
+
+	return hlcBuilder
+}
+
+func (s *nomadStartStateV1) startNomad(ctx context.Context, transport it.Transport) error {
+	var err error
+
+	nomadUsername := "nomad"
+	if user, ok := s.Username.Get(); ok {
+		nomadUsername = user
+	}
+
+	configDir := "/etc/nomad.d"
+	if cdir, ok := s.ConfigDir.Get(); ok {
+		configDir = cdir
+	}
+
+	dataDir := "/opt/nomad/data"
+	if ddir, ok := s.DataDir.Get(); ok {
+		dataDir = ddir
+	}
+
+	// Ensure that the nomad user is created
+	_, err = remoteflight.CreateOrUpdateUser(ctx, transport, remoteflight.NewUser(
+		remoteflight.WithUserName(nomadUsername),
+		remoteflight.WithUserHomeDir(dataDir),
+		remoteflight.WithUserShell("/bin/false"),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to find or create the nomad user, due to: %w", err)
+	}
+
+	configFilePath := filepath.Join(configDir, "nomad.hcl")
+
+	unitName := "nomad"
+	if unit, ok := s.SystemdUnitName.Get(); ok {
+		unitName = unit
+	}
+
+	// Nomad clients need to run as root to manage task isolation so we always
+	// run the agent as root.
+	unit := systemd.Unit{
+		"Unit": {
+			"Description":           "HashiCorp Nomad - A workload orchestrator",
+			"Documentation":         "https://developer.hashicorp.com/nomad/docs",
+			"Wants":                 "network-online.target",
+			"After":                 "network-online.target",
+			"ConditionFileNotEmpty": configFilePath,
+		},
+		"Service": {
+			"User":           "root",
+			"Group":          "root",
+			"ExecStart":      fmt.Sprintf("%s agent -config %s", s.BinPath.Value(), configDir),
+			"ExecReload":     "/bin/kill --signal HUP $MAINPID",
+			"KillMode":       "process",
+			"KillSignal":     "SIGINT",
+			"Restart":        "on-failure",
+			"RestartSec":     "2",
+			"LimitNOFILE":    "65536",
+			"LimitNPROC":     "infinity",
+			"TasksMax":       "infinity",
+			"OOMScoreAdjust": "-1000",
+		},
+		"Install": {
+			"WantedBy": "multi-user.target",
+		},
+	}
+
+	if license, ok := s.License.Get(); ok {
+		licensePath := filepath.Join(configDir, "nomad.hclic")
+		err = remoteflight.CopyFile(ctx, transport, remoteflight.NewCopyFileRequest(
+			remoteflight.WithCopyFileDestination(licensePath),
+			remoteflight.WithCopyFileChmod("644"),
+			remoteflight.WithCopyFileChown(fmt.Sprintf("%s:%s", nomadUsername, nomadUsername)),
+			remoteflight.WithCopyFileContent(tfile.NewReader(license)),
+		))
+		if err != nil {
+			return fmt.Errorf("failed to copy nomad license, due to: %w", err)
+		}
+
+		unit["Service"]["Environment"] = "NOMAD_LICENSE_PATH=" + licensePath
+	}
+
+	sysd := systemd.NewClient(transport, log.NewLogger(ctx))
+
+	// Write the systemd unit
+	err = sysd.CreateUnitFile(ctx, systemd.NewCreateUnitFileRequest(
+		systemd.WithUnitUnitPath(fmt.Sprintf("/etc/systemd/system/%s.service", unitName)),
+		systemd.WithUnitChmod("644"),
+		systemd.WithUnitChown(fmt.Sprintf("%s:%s", nomadUsername, nomadUsername)),
+		systemd.WithUnitFile(unit),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to create the nomad systemd unit, due to: %w", err)
+	}
+
+	_, err = sysd.RunSystemctlCommand(ctx, systemd.NewRunSystemctlCommand(
+		systemd.WithSystemctlCommandSubCommand(systemd.SystemctlSubCommandDaemonReload),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to daemon-reload systemd after writing the nomad systemd unit, due to: %w", err)
+	}
+
+	config := s.Config.ToHCLConfig()
+
+	// The agent always needs a data directory. Default it to the data_dir attribute
+	// if it has not been configured.
+	if _, ok := s.Config.DataDir.Get(); !ok {
+		config.AppendAttribute("data_dir", dataDir)
+	}
+
+	// Create the nomad HCL configuration file
+	err = hcl.CreateHCLConfigFile(ctx, transport, hcl.NewCreateHCLConfigFileRequest(
+		hcl.WithHCLConfigFilePath(configFilePath),
+		hcl.WithHCLConfigChmod("644"),
+		hcl.WithHCLConfigChown(fmt.Sprintf("%s:%s", nomadUsername, nomadUsername)),
+		hcl.WithHCLConfigFile(config),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to create the nomad configuration file, due to: %w", err)
+	}
+
+	// Create the nomad data directory
+	err = remoteflight.CreateDirectory(ctx, transport, remoteflight.NewCreateDirectoryRequest(
+		remoteflight.WithDirName(dataDir),
+		remoteflight.WithDirChown(nomadUsername),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to change ownership on data directory, due to: %w", err)
+	}
+
+	// Create the nomad config directory
+	err = remoteflight.CreateDirectory(ctx, transport, remoteflight.NewCreateDirectoryRequest(
+		remoteflight.WithDirName(configDir),
+		remoteflight.WithDirChown(nomadUsername),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to change ownership on config directory, due to: %w", err)
+	}
+
+	// Validate the nomad config file
+	err = nomad.ValidateConfig(ctx, transport, nomad.NewValidateConfigRequest(
+		nomad.WithValidateConfigBinPath(s.BinPath.Value()),
+		nomad.WithValidateConfigFilePath(configFilePath),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to validate nomad configuration file, due to: %w", err)
+	}
+
+	// A reasonable amount of time for all cluster nodes to come online, discover
+	// each other, elect a leader and register client nodes.
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer cancel()
+
+	// Restart the service and wait for until systemd thinks that the process
+	// is active and running.
+	err = sysd.RestartService(ctx, unitName)
+	if err != nil {
+		return fmt.Errorf("failed to start the nomad service, due to: %w", err)
+	}
+
+	// Wait for the nomad cluster to be ready to schedule work
+	checks := []nomad.CheckStater{
+		nomad.CheckStateHasSystemdEnabledAndRunningProperties(),
+		nomad.CheckStateClusterHasLeader(),
+	}
+
+	if minPeers, ok := s.Config.bootstrapExpect(); ok && s.Config.serverEnabled() {
+		checks = append(checks, nomad.CheckStateClusterHasMinNPeers(uint(minPeers)))
+	}
+
+	if s.Config.clientEnabled() {
+		checks = append(checks, nomad.CheckStateNodeIsReady())
+	}
+
+	stateOpts := []nomad.StateRequestOpt{
+		nomad.WithStateRequestFlightControlUseHomeDir(),
+		nomad.WithStateRequestSystemdUnitName(unitName),
+		nomad.WithStateRequestTLSConfig(s.TLS.TLSConfig()),
+	}
+
+	if addr, ok := s.NomadAddr.Get(); ok {
+		stateOpts = append(stateOpts, nomad.WithStateRequestNomadAddr(addr))
+	}
+
+	if token, ok := s.Token.Get(); ok {
+		stateOpts = append(stateOpts, nomad.WithStateRequestToken(token))
+	}
+
+	state, err := nomad.WaitForState(ctx, transport, nomad.NewStateRequest(stateOpts...), checks...)
+	if err != nil {
+		err = fmt.Errorf("failed to start the nomad service: %w", err)
+		if state != nil {
+			err = fmt.Errorf(
+				"%w\nNomad State after starting the nomad systemd service:\n%s",
+				err, istrings.Indent("  ", state.String()),
+			)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,273 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// newTestNomadAgentConfig returns a nomad agent config with most attributes set.
+func newTestNomadAgentConfig(t *testing.T) *nomadAgentConfig {
+	t.Helper()
+
+	nomadCfg := newNomadAgentConfig()
+	nomadCfg.Datacenter.Set("dc1")
+	nomadCfg.Region.Set("global")
+	nomadCfg.RetryJoin.SetStrings([]string{"10.0.0.1", "10.0.0.2"})
+	nomadCfg.LogLevel.Set("INFO")
+
+	serverType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"enabled":          tftypes.Bool,
+		"bootstrap_expect": tftypes.Number,
+	}}
+	require.NoError(t, nomadCfg.Server.FromTFValue(tftypes.NewValue(serverType, map[string]tftypes.Value{
+		"enabled":          tftypes.NewValue(tftypes.Bool, true),
+		"bootstrap_expect": tftypes.NewValue(tftypes.Number, 3),
+	})))
+
+	clientType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"enabled": tftypes.Bool}}
+	require.NoError(t, nomadCfg.Client.FromTFValue(tftypes.NewValue(clientType, map[string]tftypes.Value{
+		"enabled": tftypes.NewValue(tftypes.Bool, true),
+	})))
+
+	pluginConfigType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"allow_privileged": tftypes.Bool}}
+	dockerType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"config": pluginConfigType}}
+	pluginType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"docker": dockerType}}
+	require.NoError(t, nomadCfg.Plugin.FromTFValue(tftypes.NewValue(pluginType, map[string]tftypes.Value{
+		"docker": tftypes.NewValue(dockerType, map[string]tftypes.Value{
+			"config": tftypes.NewValue(pluginConfigType, map[string]tftypes.Value{
+				"allow_privileged": tftypes.NewValue(tftypes.Bool, true),
+			}),
+		}),
+	})))
+
+	aclType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"enabled": tftypes.Bool}}
+	require.NoError(t, nomadCfg.ACL.FromTFValue(tftypes.NewValue(aclType, map[string]tftypes.Value{
+		"enabled": tftypes.NewValue(tftypes.Bool, true),
+	})))
+
+	extraType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"disable_update_check": tftypes.Bool}}
+	require.NoError(t, nomadCfg.Extra.FromTFValue(tftypes.NewValue(extraType, map[string]tftypes.Value{
+		"disable_update_check": tftypes.NewValue(tftypes.Bool, true),
+	})))
+
+	return nomadCfg
+}
+
+func TestNomadStartConfigOptionalAttrs(t *testing.T) {
+	t.Parallel()
+
+	nomadCfg := newTestNomadAgentConfig(t)
+
+	// Make sure we can create a dynamic value with optional attrs
+	val := nomadCfg.Terraform5Value()
+	_, err := tfprotov6.NewDynamicValue(val.Type(), val)
+	require.NoError(t, err)
+
+	// Make sure we can round trip the value
+	newCfg := newNomadAgentConfig()
+	require.NoError(t, newCfg.FromTerraform5Value(val))
+	dc, ok := newCfg.Datacenter.Get()
+	require.True(t, ok)
+	require.Equal(t, "dc1", dc)
+	require.True(t, newCfg.serverEnabled())
+	require.True(t, newCfg.clientEnabled())
+	expect, ok := newCfg.bootstrapExpect()
+	require.True(t, ok)
+	require.Equal(t, 3, expect)
+	_, ok = newCfg.Consul.Object.GetObject()
+	require.False(t, ok)
+}
+
+func TestNomadAgentConfigToHCLConfig(t *testing.T) {
+	t.Parallel()
+
+	hcl, err := newTestNomadAgentConfig(t).ToHCLConfig().BuildHCL()
+	require.NoError(t, err)
+
+	assert.Equal(t, `region               = "global"
+datacenter           = "dc1"
+log_level            = "INFO"
+disable_update_check = true
+acl {
+  enabled = true
+}
+server {
+  bootstrap_expect = 3
+  enabled          = true
+  server_join {
+    retry_join = ["10.0.0.1", "10.0.0.2"]
+  }
+}
+client {
+  enabled = true
+  server_join {
+    retry_join = ["10.0.0.1", "10.0.0.2"]
+  }
+}
+plugin "docker" {
+  config {
+    allow_privileged = true
+  }
+}
+`, hcl)
+}
+
+func TestNomadAgentConfigDisabledRoles(t *testing.T) {
+	t.Parallel()
+
+	nomadCfg := newNomadAgentConfig()
+	require.False(t, nomadCfg.serverEnabled())
+	require.False(t, nomadCfg.clientEnabled())
+	_, ok := nomadCfg.bootstrapExpect()
+	require.False(t, ok)
+}
+
+// TestAccResourceNomadStart tests the nomad_start resource.
+func TestAccResourceNomadStart(t *testing.T) {
+	t.Parallel()
+
+	cfg := template.Must(template.New("enos_nomad_start").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_nomad_start" "{{.ID.Value}}" {
+		{{if .BinPath.Value}}
+		bin_path = "{{.BinPath.Value}}"
+		{{end}}
+
+		{{if .NomadAddr.Value}}
+		nomad_addr = "{{.NomadAddr.Value}}"
+		{{end}}
+
+		config = {
+			datacenter = "{{.Config.Datacenter.Value}}"
+			region = "{{.Config.Region.Value}}"
+			retry_join = [{{range .Config.RetryJoin.Value -}}
+			"{{.}}",
+			{{end -}}
+			]
+			log_level = "{{.Config.LogLevel.Value}}"
+			server = {
+				enabled = true
+				bootstrap_expect = 3
+			}
+			client = {
+				enabled = true
+			}
+			plugin = {
+				docker = {
+					config = {
+						allow_privileged = true
+					}
+				}
+			}
+		}
+
+		{{if .ConfigDir.Value}}
+		config_dir = "{{.ConfigDir.Value}}"
+		{{end}}
+
+		{{if .DataDir.Value}}
+		data_dir = "{{.DataDir.Value}}"
+		{{end}}
+
+		{{if .License.Value}}
+		license = "{{.License.Value}}"
+		{{end}}
+
+		{{if .Token.Value}}
+		token = "{{.Token.Value}}"
+		{{end}}
+
+		{{if .SystemdUnitName.Value}}
+		unit_name = "{{.SystemdUnitName.Value}}"
+		{{end}}
+
+		{{if .Username.Value}}
+		username = "{{.Username.Value}}"
+		{{end}}
+
+		{{renderTransport .Transport}}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	nomadStart := newNomadStartStateV1()
+	nomadStart.ID.Set("foo")
+	nomadStart.BinPath.Set("/opt/nomad/bin/nomad")
+	nomadStart.ConfigDir.Set("/etc/nomad.d")
+	nomadStart.DataDir.Set("/opt/nomad/data")
+	nomadStart.Config.Datacenter.Set("dc1")
+	nomadStart.Config.Region.Set("global")
+	nomadStart.Config.RetryJoin.SetStrings([]string{"provider=aws tag_key=Type tag_value=nomad"})
+	nomadStart.Config.LogLevel.Set("INFO")
+	nomadStart.License.Set("some-license-key")
+	nomadStart.Token.Set("some-token")
+	nomadStart.SystemdUnitName.Set("nomad")
+	nomadStart.Username.Set("nomad")
+	nomadStart.NomadAddr.Set("http://127.0.0.1:4646")
+	ssh := newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	ssh.Host.Set("localhost")
+	require.NoError(t, nomadStart.Transport.SetTransportState(ssh))
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh, ok := nomadStart.Transport.SSH()
+	assert.True(t, ok)
+	ssh.PrivateKey.Set(privateKey)
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		nomadStart,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_nomad_start.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_nomad_start.foo", "bin_path", regexp.MustCompile(`^/opt/nomad/bin/nomad$`)),
+			resource.TestMatchResourceAttr("enos_nomad_start.foo", "config_dir", regexp.MustCompile(`^/etc/nomad.d$`)),
+			resource.TestMatchResourceAttr("enos_nomad_start.foo", "data_dir", regexp.MustCompile(`^/opt/nomad/data$`)),
+			resource.TestMatchResourceAttr("enos_nomad_start.foo", "config.datacenter", regexp.MustCompile(`^dc1$`)),
+			resource.TestMatchResourceAttr("enos_nomad_start.foo", "config.region", regexp.MustCompile(`^global$`)),
+			resource.TestMatchResourceAttr("enos_nomad_start.foo", "config.log_level", regexp.MustCompile(`^INFO$`)),
+			resource.TestMatchResourceAttr("enos_nomad_start.foo", "nomad_addr", regexp.MustCompile(`^http://127.0.0.1:4646$`)),
+			resource.TestMatchResourceAttr("enos_nomad_start.foo", "unit_name", regexp.MustCompile(`^nomad$`)),
+			resource.TestMatchResourceAttr("enos_nomad_start.foo", "username", regexp.MustCompile(`^nomad$`)),
+			resource.TestMatchResourceAttr("enos_nomad_start.foo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
+			resource.TestMatchResourceAttr("enos_nomad_start.foo", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
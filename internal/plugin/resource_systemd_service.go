@@ -0,0 +1,825 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/log"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/systemd"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+)
+
+// systemdServiceProperties are the unit properties that we expose as the properties attribute.
+var systemdServiceProperties = []string{
+	"ActiveState",
+	"DropInPaths",
+	"FragmentPath",
+	"LoadState",
+	"SubState",
+	"UnitFileState",
+}
+
+type systemdService struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*systemdService)(nil)
+
+type systemdServiceStateV1 struct {
+	ID              *tfString
+	Active          *tfBool
+	DropIns         *dynamicPseudoTypeBlock
+	Enabled         *tfBool
+	Name            *tfString
+	Properties      *tfStringMap
+	RestartOnChange *tfBool
+	Transport       *embeddedTransportV1
+	Unit            *dynamicPseudoTypeBlock
+	UnitDir         *tfString
+
+	failureHandlers
+}
+
+var _ state.State = (*systemdServiceStateV1)(nil)
+
+func newSystemdService() *systemdService {
+	return &systemdService{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newSystemdServiceStateV1() *systemdServiceStateV1 {
+	transport := newEmbeddedTransport()
+	fh := failureHandlers{TransportDebugFailureHandler(transport)}
+
+	return &systemdServiceStateV1{
+		ID:              newTfString(),
+		Active:          newTfBool(),
+		DropIns:         newDynamicPseudoTypeBlock(),
+		Enabled:         newTfBool(),
+		Name:            newTfString(),
+		Properties:      newTfStringMap(),
+		RestartOnChange: newTfBool(),
+		Transport:       transport,
+		Unit:            newDynamicPseudoTypeBlock(),
+		UnitDir:         newTfString(),
+		failureHandlers: fh,
+	}
+}
+
+func (r *systemdService) Name() string {
+	return "enos_systemd_service"
+}
+
+func (r *systemdService) Schema() *tfprotov6.Schema {
+	return newSystemdServiceStateV1().Schema()
+}
+
+func (r *systemdService) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *systemdService) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *systemdService) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newSystemdServiceStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *systemdService) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newSystemdServiceStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest state for the resource.
+// We use the unit properties and the unit files on the host to detect drift from the desired state.
+// We'll exit gracefully if we're unable to read the unit since it's possible that the host does not
+// exist yet.
+func (r *systemdService) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	select {
+	case <-ctx.Done():
+		res.Diagnostics = append(res.Diagnostics, ctxToDiagnostic(ctx))
+		return
+	default:
+	}
+
+	currentState := newSystemdServiceStateV1()
+
+	// Make sure we marshal our new state when we return
+	defer func() {
+		var err error
+		res.NewState, err = state.Marshal(currentState)
+		if err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
+		}
+	}()
+
+	transport := transportUtil.ReadUnmarshalAndBuildTransport(ctx, currentState, r, req, res)
+	if transport == nil {
+		return
+	}
+
+	if _, ok := currentState.ID.Get(); !ok {
+		return
+	}
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	currentState.ReadDrift(ctx, systemd.NewClient(client, log.NewLogger(ctx)))
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *systemdService) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newSystemdServiceStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *systemdService) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newSystemdServiceStateV1()
+	proposedState := newSystemdServiceStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+		proposedState.Properties.Unknown = true
+
+		return
+	}
+
+	// The unit properties will change if we're going to change anything.
+	if !proposedState.Terraform5Value().Equal(priorState.Terraform5Value()) {
+		proposedState.Properties.Unknown = true
+	}
+
+	// Changing the unit name means we're managing a different unit.
+	res.RequiresReplace = append(res.RequiresReplace, tftypes.NewAttributePathWithSteps(
+		[]tftypes.AttributePathStep{tftypes.AttributeName("name")},
+	))
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *systemdService) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newSystemdServiceStateV1()
+	plannedState := newSystemdServiceStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do on delete
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	// Nothing has changed so there's nothing to converge.
+	if _, ok := plannedState.Properties.Get(); ok {
+		return
+	}
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	err = plannedState.Converge(ctx, client, priorState)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Systemd Service Error", err))
+	}
+}
+
+// Schema is the systemd service states Terraform schema.
+func (s *systemdServiceStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_systemd_service^ resource manages an arbitrary systemd unit on a target machine, e.g. a
+service, a timer, or drop-in configuration for a unit that a package installed.
+
+The ^unit^ and ^drop_ins^ are rendered as systemd unit files. Each top level key is a section and
+the nested keys and values are the section fields, e.g.:
+
+^^^hcl
+unit = {
+  Unit = {
+    Description = "My App"
+    After       = "network-online.target"
+  }
+  Service = {
+    ExecStart = "/usr/local/bin/my-app"
+    Restart   = "on-failure"
+  }
+  Install = {
+    WantedBy = "multi-user.target"
+  }
+}
+^^^
+
+Only one value per field is supported. If a unit requires the same field more than once, e.g.
+multiple ^ExecStartPre^ commands, use a script or a drop-in for each.
+
+When the unit or drop-ins change the systemd daemon is reloaded and, if ^restart_on_change^ is
+^true^, the unit is restarted. The unit is then enabled or disabled, and started or stopped, to
+match ^enabled^ and ^active^. During refresh the resource uses the unit properties and unit files
+on the host to detect drift from the desired state and will converge the unit on the next apply.
+
+Destroying the resource does not stop the unit or remove any unit files.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "active",
+					Type:        tftypes.Bool,
+					Optional:    true,
+					Description: "Whether or not the unit should be active, i.e. started. Defaults to true",
+				},
+				{
+					Name:            "drop_ins",
+					Type:            tftypes.DynamicPseudoType,
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description: docCaretToBacktick(`
+Drop-in configuration for the unit. The keys are the drop-in names and values are the sections and
+fields of each drop-in, e.g. ^{ "10-limits" = { Service = { LimitNOFILE = "65536" } } }^. Each
+drop-in is written to ^<unit_dir>/<name>.d/<drop-in>.conf^. Drop-ins that are removed from the
+configuration are removed from the host.
+`),
+				},
+				{
+					Name:        "enabled",
+					Type:        tftypes.Bool,
+					Optional:    true,
+					Description: "Whether or not the unit should be enabled. Defaults to true",
+				},
+				{
+					Name:            "name",
+					Type:            tftypes.String,
+					Required:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The name of the unit, e.g. `my-app` or `my-app.timer`. If the name does not have a unit type suffix it is assumed to be a service",
+				},
+				{
+					Name:        "properties",
+					Type:        s.Properties.TFType(),
+					Computed:    true,
+					Description: "A subset of the unit properties after the unit has been converged, e.g. ActiveState, SubState, LoadState and UnitFileState",
+				},
+				{
+					Name:        "restart_on_change",
+					Type:        tftypes.Bool,
+					Optional:    true,
+					Description: "Whether or not to restart an active unit when the unit or its drop-ins change. Defaults to true",
+				},
+				{
+					Name:            "unit",
+					Type:            tftypes.DynamicPseudoType,
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The sections and fields of the unit file. If not set the unit must already exist on the host",
+				},
+				{
+					Name:        "unit_dir",
+					Type:        tftypes.String,
+					Optional:    true,
+					Description: "The directory to write the unit and drop-in files to. Defaults to /etc/systemd/system",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH),
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *systemdServiceStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if err := checkK8STransportNotConfigured(s, "enos_systemd_service"); err != nil {
+		return err
+	}
+
+	if name, ok := s.Name.Get(); ok && name == "" {
+		return ValidationError("you must provide a unit name", "name")
+	}
+
+	if s.Unit.Object.FullyKnown() {
+		if _, err := s.unit(); err != nil {
+			return ValidationError(err.Error(), "unit")
+		}
+	}
+
+	if s.DropIns.Object.FullyKnown() {
+		if _, err := s.dropIns(); err != nil {
+			return ValidationError(err.Error(), "drop_ins")
+		}
+	}
+
+	return nil
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Value with As().
+func (s *systemdServiceStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":                s.ID,
+		"active":            s.Active,
+		"enabled":           s.Enabled,
+		"name":              s.Name,
+		"properties":        s.Properties,
+		"restart_on_change": s.RestartOnChange,
+		"unit_dir":          s.UnitDir,
+	})
+	if err != nil {
+		return err
+	}
+
+	for name, block := range s.dynamicBlocks() {
+		v, ok := vals[name]
+		if !ok {
+			continue
+		}
+
+		err = block.FromTFValue(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *systemdServiceStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":                s.ID.TFType(),
+		"active":            s.Active.TFType(),
+		"drop_ins":          s.DropIns.TFType(),
+		"enabled":           s.Enabled.TFType(),
+		"name":              s.Name.TFType(),
+		"properties":        s.Properties.TFType(),
+		"restart_on_change": s.RestartOnChange.TFType(),
+		"transport":         s.Transport.Terraform5Type(),
+		"unit":              s.Unit.TFType(),
+		"unit_dir":          s.UnitDir.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *systemdServiceStateV1) Terraform5Value() tftypes.Value {
+	vals := map[string]tftypes.Value{
+		"id":                s.ID.TFValue(),
+		"active":            s.Active.TFValue(),
+		"enabled":           s.Enabled.TFValue(),
+		"name":              s.Name.TFValue(),
+		"properties":        s.Properties.TFValue(),
+		"restart_on_change": s.RestartOnChange.TFValue(),
+		"transport":         s.Transport.Terraform5Value(),
+		"unit_dir":          s.UnitDir.TFValue(),
+	}
+
+	for name, block := range s.dynamicBlocks() {
+		val, err := block.TFValue()
+		if err != nil {
+			panic(err)
+		}
+		vals[name] = val
+	}
+
+	return tftypes.NewValue(s.Terraform5Type(), vals)
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *systemdServiceStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+// dynamicBlocks returns the dynamic attributes keyed by their attribute name.
+func (s *systemdServiceStateV1) dynamicBlocks() map[string]*dynamicPseudoTypeBlock {
+	return map[string]*dynamicPseudoTypeBlock{
+		"drop_ins": s.DropIns,
+		"unit":     s.Unit,
+	}
+}
+
+// unitName returns the name of the unit with its unit type suffix.
+func (s *systemdServiceStateV1) unitName() string {
+	name := s.Name.Value()
+	if _, ok := systemd.UnitTypeFromName(name); ok {
+		return name
+	}
+
+	return fmt.Sprintf("%s.%s", name, systemd.UnitTypeService)
+}
+
+// unitDir returns the directory for unit files.
+func (s *systemdServiceStateV1) unitDir() string {
+	if dir, ok := s.UnitDir.Get(); ok {
+		return dir
+	}
+
+	return "/etc/systemd/system"
+}
+
+// unit returns the configured unit, or nil if no unit has been configured.
+func (s *systemdServiceStateV1) unit() (systemd.Unit, error) {
+	obj, ok := s.Unit.Object.GetObject()
+	if !ok {
+		return nil, nil
+	}
+
+	return systemdUnitFromObject(obj)
+}
+
+// dropIns returns the configured drop-ins keyed by their file name.
+func (s *systemdServiceStateV1) dropIns() (map[string]systemd.Unit, error) {
+	dropIns := map[string]systemd.Unit{}
+
+	obj, ok := s.DropIns.Object.GetObject()
+	if !ok {
+		return dropIns, nil
+	}
+
+	for name, val := range obj {
+		dropIn, ok := val.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("drop-in %s must be an object of sections", name)
+		}
+
+		unit, err := systemdUnitFromObject(dropIn)
+		if err != nil {
+			return nil, fmt.Errorf("drop-in %s: %w", name, err)
+		}
+
+		if !strings.HasSuffix(name, ".conf") {
+			name += ".conf"
+		}
+		dropIns[name] = unit
+	}
+
+	return dropIns, nil
+}
+
+// desiredActive returns whether the unit should be active.
+func (s *systemdServiceStateV1) desiredActive() bool {
+	if active, ok := s.Active.Get(); ok {
+		return active
+	}
+
+	return true
+}
+
+// desiredEnabled returns whether the unit should be enabled.
+func (s *systemdServiceStateV1) desiredEnabled() bool {
+	if enabled, ok := s.Enabled.Get(); ok {
+		return enabled
+	}
+
+	return true
+}
+
+// ReadDrift compares the unit on the host with our state. If the unit has drifted from the desired
+// state we'll update our state to reflect the host so that Terraform plans to converge it.
+func (s *systemdServiceStateV1) ReadDrift(ctx context.Context, sysd systemd.Client) {
+	unitName := s.unitName()
+
+	props, err := sysd.ShowProperties(ctx, unitName)
+	if err != nil {
+		return
+	}
+	s.setProperties(props)
+
+	if enabled, static := systemdUnitIsEnabled(props); !static && enabled != s.desiredEnabled() {
+		s.Enabled.Set(enabled)
+	}
+
+	if active := systemdUnitIsActive(props); active != s.desiredActive() {
+		s.Active.Set(active)
+	}
+
+	unit, err := s.unit()
+	if err == nil && unit != nil {
+		changed, err := systemdUnitFileChanged(ctx, sysd, filepath.Join(s.unitDir(), unitName), unit)
+		if err == nil && changed {
+			s.Unit = newDynamicPseudoTypeBlock()
+		}
+	}
+
+	dropIns, err := s.dropIns()
+	if err == nil {
+		dropInDir := filepath.Join(s.unitDir(), unitName+".d")
+		for name, dropIn := range dropIns {
+			changed, err := systemdUnitFileChanged(ctx, sysd, filepath.Join(dropInDir, name), dropIn)
+			if err == nil && changed {
+				s.DropIns = newDynamicPseudoTypeBlock()
+
+				break
+			}
+		}
+	}
+}
+
+// Converge writes the unit and drop-ins and ensures that the unit is in the desired state.
+func (s *systemdServiceStateV1) Converge(ctx context.Context, transport it.Transport, prior *systemdServiceStateV1) error {
+	sysd := systemd.NewClient(transport, log.NewLogger(ctx))
+	unitName := s.unitName()
+	changed := false
+
+	unit, err := s.unit()
+	if err != nil {
+		return err
+	}
+
+	if unit != nil {
+		path := filepath.Join(s.unitDir(), unitName)
+		written, err := systemdWriteUnitFile(ctx, sysd, path, unit)
+		if err != nil {
+			return err
+		}
+		changed = changed || written
+	}
+
+	dropIns, err := s.dropIns()
+	if err != nil {
+		return err
+	}
+
+	dropInDir := filepath.Join(s.unitDir(), unitName+".d")
+	if len(dropIns) > 0 {
+		err = remoteflight.CreateDirectory(ctx, transport, remoteflight.NewCreateDirectoryRequest(
+			remoteflight.WithDirName(dropInDir),
+		))
+		if err != nil {
+			return fmt.Errorf("failed to create drop-in directory %s, due to: %w", dropInDir, err)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(dropIns)) {
+		written, err := systemdWriteUnitFile(ctx, sysd, filepath.Join(dropInDir, name), dropIns[name])
+		if err != nil {
+			return err
+		}
+		changed = changed || written
+	}
+
+	// Remove any drop-ins that we previously managed but are no longer configured.
+	if prior != nil {
+		priorDropIns, err := prior.dropIns()
+		if err == nil {
+			for name := range priorDropIns {
+				if _, ok := dropIns[name]; ok {
+					continue
+				}
+
+				err = remoteflight.DeleteFile(ctx, transport, remoteflight.NewDeleteFileRequest(
+					remoteflight.WithDeleteFilePath(filepath.Join(dropInDir, name)),
+				))
+				if err != nil {
+					return fmt.Errorf("failed to remove drop-in %s, due to: %w", name, err)
+				}
+				changed = true
+			}
+		}
+	}
+
+	if changed {
+		res, err := sysd.RunSystemctlCommand(ctx, systemd.NewRunSystemctlCommand(
+			systemd.WithSystemctlCommandSubCommand(systemd.SystemctlSubCommandDaemonReload),
+		))
+		if err != nil {
+			return fmt.Errorf("failed to daemon-reload systemd, due to: %w, stderr: %s", err, res.Stderr)
+		}
+	}
+
+	props, err := sysd.ShowProperties(ctx, unitName)
+	if err != nil {
+		return err
+	}
+
+	if props.HasProperties(systemd.UnitProperties{"LoadState": "not-found"}) {
+		return fmt.Errorf("the unit %s does not exist, you must either configure the unit or install it", unitName)
+	}
+
+	if enabled, static := systemdUnitIsEnabled(props); !static && enabled != s.desiredEnabled() {
+		if s.desiredEnabled() {
+			res, err := sysd.RunSystemctlCommand(ctx, systemd.NewRunSystemctlCommand(
+				systemd.WithSystemctlCommandUnitName(unitName),
+				systemd.WithSystemctlCommandSubCommand(systemd.SystemctlSubCommandEnable),
+			))
+			if err != nil {
+				return fmt.Errorf("failed to enable %s, due to: %w, stderr: %s", unitName, err, res.Stderr)
+			}
+		} else {
+			err = sysd.DisableService(ctx, unitName)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	active := systemdUnitIsActive(props)
+	switch {
+	case s.desiredActive() && !active:
+		err = sysd.StartService(ctx, unitName)
+	case s.desiredActive() && changed && s.restartOnChange():
+		var res *systemd.SystemctlCommandRes
+		res, err = sysd.RunSystemctlCommand(ctx, systemd.NewRunSystemctlCommand(
+			systemd.WithSystemctlCommandUnitName(unitName),
+			systemd.WithSystemctlCommandSubCommand(systemd.SystemctlSubCommandRestart),
+		))
+		if err != nil {
+			err = fmt.Errorf("failed to restart %s, due to: %w, stderr: %s", unitName, err, res.Stderr)
+		}
+	case !s.desiredActive() && active:
+		err = sysd.StopService(ctx, unitName)
+	}
+	if err != nil {
+		return err
+	}
+
+	props, err = sysd.ShowProperties(ctx, unitName)
+	if err != nil {
+		return err
+	}
+
+	if props.HasProperties(systemd.UnitProperties{"ActiveState": "failed"}) {
+		return fmt.Errorf("the unit %s has failed, properties: %s", unitName, props)
+	}
+
+	s.setProperties(props)
+
+	return nil
+}
+
+// restartOnChange returns whether or not the unit should be restarted when it changes.
+func (s *systemdServiceStateV1) restartOnChange() bool {
+	if restart, ok := s.RestartOnChange.Get(); ok {
+		return restart
+	}
+
+	return true
+}
+
+// setProperties sets our properties attribute from the unit properties.
+func (s *systemdServiceStateV1) setProperties(props systemd.UnitProperties) {
+	found := map[string]string{}
+	for _, name := range systemdServiceProperties {
+		if val, ok := props[name]; ok {
+			found[name] = val
+		}
+	}
+
+	s.Properties.SetStrings(found)
+}
+
+// systemdUnitFromObject converts an object of sections and fields into a systemd unit.
+func systemdUnitFromObject(obj map[string]any) (systemd.Unit, error) {
+	unit := systemd.Unit{}
+
+	for section, fields := range obj {
+		unit[section] = map[string]string{}
+
+		switch f := fields.(type) {
+		case map[string]string:
+			maps.Copy(unit[section], f)
+		case map[string]any:
+			for name, val := range f {
+				switch v := val.(type) {
+				case string, bool, int:
+					unit[section][name] = fmt.Sprint(v)
+				default:
+					return nil, fmt.Errorf("the field %s.%s must be a string, number or bool", section, name)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("the section %s must be an object of fields", section)
+		}
+	}
+
+	return unit, nil
+}
+
+// systemdUnitFileChanged determines whether or not the unit file on the host differs from the unit.
+func systemdUnitFileChanged(ctx context.Context, sysd systemd.Client, path string, unit systemd.Unit) (bool, error) {
+	ini, err := unit.ToIni()
+	if err != nil {
+		return false, err
+	}
+
+	content, exists, err := sysd.ReadUnitFile(ctx, path)
+	if err != nil {
+		return false, err
+	}
+
+	return !exists || content != ini, nil
+}
+
+// systemdWriteUnitFile writes the unit file if it has changed. It returns whether or not it was
+// written.
+func systemdWriteUnitFile(ctx context.Context, sysd systemd.Client, path string, unit systemd.Unit) (bool, error) {
+	changed, err := systemdUnitFileChanged(ctx, sysd, path, unit)
+	if err != nil {
+		return false, fmt.Errorf("failed to read unit file %s, due to: %w", path, err)
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	err = sysd.CreateUnitFile(ctx, systemd.NewCreateUnitFileRequest(
+		systemd.WithUnitUnitPath(path),
+		systemd.WithUnitChmod("644"),
+		systemd.WithUnitFile(unit),
+	))
+	if err != nil {
+		return false, errors.Join(fmt.Errorf("failed to write unit file %s", path), err)
+	}
+
+	return true, nil
+}
+
+// systemdUnitIsActive returns whether or not the unit is active or becoming active.
+func systemdUnitIsActive(props systemd.UnitProperties) bool {
+	return slices.Contains([]string{"active", "activating", "reloading"}, props["ActiveState"])
+}
+
+// systemdUnitIsEnabled returns whether or not the unit is enabled and whether or not it is static.
+// Static units do not have an [Install] section and cannot be enabled or disabled.
+func systemdUnitIsEnabled(props systemd.UnitProperties) (bool, bool) {
+	switch props["UnitFileState"] {
+	case "static", "generated", "transient":
+		return true, true
+	case "enabled", "enabled-runtime", "alias", "indirect":
+		return true, false
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/systemd"
+)
+
+func newTestSystemdServiceUnitValue() tftypes.Value {
+	unitType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"Unit": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"Description": tftypes.String,
+		}},
+		"Service": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"ExecStart":        tftypes.String,
+			"LimitNOFILE":      tftypes.Number,
+			"NoNewPrivileges":  tftypes.Bool,
+			"RemainAfterExit":  tftypes.Bool,
+			"TimeoutStartSec":  tftypes.Number,
+			"WorkingDirectory": tftypes.String,
+		}},
+	}}
+
+	return tftypes.NewValue(unitType, map[string]tftypes.Value{
+		"Unit": tftypes.NewValue(unitType.AttributeTypes["Unit"], map[string]tftypes.Value{
+			"Description": tftypes.NewValue(tftypes.String, "My App"),
+		}),
+		"Service": tftypes.NewValue(unitType.AttributeTypes["Service"], map[string]tftypes.Value{
+			"ExecStart":        tftypes.NewValue(tftypes.String, "/usr/local/bin/my-app"),
+			"LimitNOFILE":      tftypes.NewValue(tftypes.Number, 65536),
+			"NoNewPrivileges":  tftypes.NewValue(tftypes.Bool, true),
+			"RemainAfterExit":  tftypes.NewValue(tftypes.Bool, false),
+			"TimeoutStartSec":  tftypes.NewValue(tftypes.Number, 30),
+			"WorkingDirectory": tftypes.NewValue(tftypes.String, "/opt/my-app"),
+		}),
+	})
+}
+
+func TestSystemdServiceUnit(t *testing.T) {
+	t.Parallel()
+
+	s := newSystemdServiceStateV1()
+	s.Name.Set("my-app")
+	require.NoError(t, s.Unit.FromTFValue(newTestSystemdServiceUnitValue()))
+
+	unit, err := s.unit()
+	require.NoError(t, err)
+	require.Equal(t, systemd.Unit{
+		"Unit": {
+			"Description": "My App",
+		},
+		"Service": {
+			"ExecStart":        "/usr/local/bin/my-app",
+			"LimitNOFILE":      "65536",
+			"NoNewPrivileges":  "true",
+			"RemainAfterExit":  "false",
+			"TimeoutStartSec":  "30",
+			"WorkingDirectory": "/opt/my-app",
+		},
+	}, unit)
+
+	ini, err := unit.ToIni()
+	require.NoError(t, err)
+	require.Equal(t, `[Unit]
+Description=My App
+
+[Service]
+ExecStart=/usr/local/bin/my-app
+LimitNOFILE=65536
+NoNewPrivileges=true
+RemainAfterExit=false
+TimeoutStartSec=30
+WorkingDirectory=/opt/my-app`, ini)
+
+	// Make sure we can create a dynamic value and round trip it
+	val := s.Terraform5Value()
+	_, err = tfprotov6.NewDynamicValue(val.Type(), val)
+	require.NoError(t, err)
+	newState := newSystemdServiceStateV1()
+	require.NoError(t, newState.FromTerraform5Value(val))
+	require.True(t, val.Equal(newState.Terraform5Value()))
+}
+
+func TestSystemdServiceDropIns(t *testing.T) {
+	t.Parallel()
+
+	dropInType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"Service": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"LimitNOFILE": tftypes.Number,
+		}},
+	}}
+	dropInsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"10-limits":      dropInType,
+		"20-limits.conf": dropInType,
+	}}
+	dropIn := tftypes.NewValue(dropInType, map[string]tftypes.Value{
+		"Service": tftypes.NewValue(dropInType.AttributeTypes["Service"], map[string]tftypes.Value{
+			"LimitNOFILE": tftypes.NewValue(tftypes.Number, 65536),
+		}),
+	})
+
+	s := newSystemdServiceStateV1()
+	s.Name.Set("my-app")
+	require.NoError(t, s.DropIns.FromTFValue(tftypes.NewValue(dropInsType, map[string]tftypes.Value{
+		"10-limits":      dropIn,
+		"20-limits.conf": dropIn,
+	})))
+
+	dropIns, err := s.dropIns()
+	require.NoError(t, err)
+	require.Equal(t, map[string]systemd.Unit{
+		"10-limits.conf": {"Service": {"LimitNOFILE": "65536"}},
+		"20-limits.conf": {"Service": {"LimitNOFILE": "65536"}},
+	}, dropIns)
+}
+
+func TestSystemdServiceUnitName(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]string{
+		"my-app":          "my-app.service",
+		"my-app.service":  "my-app.service",
+		"backup.timer":    "backup.timer",
+		"vault-1.16":      "vault-1.16.service",
+		"data.mount":      "data.mount",
+		"my-app.instance": "my-app.instance.service",
+	} {
+		s := newSystemdServiceStateV1()
+		s.Name.Set(name)
+		require.Equal(t, expected, s.unitName())
+	}
+}
+
+func TestSystemdServiceValidate(t *testing.T) {
+	t.Parallel()
+
+	s := newSystemdServiceStateV1()
+	s.Name.Set("my-app")
+	require.NoError(t, s.Unit.FromTFValue(newTestSystemdServiceUnitValue()))
+	require.NoError(t, s.Validate(context.Background()))
+
+	noName := newSystemdServiceStateV1()
+	noName.Name.Set("")
+	require.Error(t, noName.Validate(context.Background()))
+
+	badSection := newSystemdServiceStateV1()
+	badSection.Name.Set("my-app")
+	require.NoError(t, badSection.Unit.FromTFValue(tftypes.NewValue(
+		tftypes.Object{AttributeTypes: map[string]tftypes.Type{"Service": tftypes.String}},
+		map[string]tftypes.Value{"Service": tftypes.NewValue(tftypes.String, "ExecStart=/bin/true")},
+	)))
+	require.Error(t, badSection.Validate(context.Background()))
+}
+
+func TestSystemdUnitIsEnabledAndActive(t *testing.T) {
+	t.Parallel()
+
+	enabled, static := systemdUnitIsEnabled(systemd.UnitProperties{"UnitFileState": "enabled"})
+	require.True(t, enabled)
+	require.False(t, static)
+
+	enabled, static = systemdUnitIsEnabled(systemd.UnitProperties{"UnitFileState": "disabled"})
+	require.False(t, enabled)
+	require.False(t, static)
+
+	_, static = systemdUnitIsEnabled(systemd.UnitProperties{"UnitFileState": "static"})
+	require.True(t, static)
+
+	require.True(t, systemdUnitIsActive(systemd.UnitProperties{"ActiveState": "active"}))
+	require.True(t, systemdUnitIsActive(systemd.UnitProperties{"ActiveState": "activating"}))
+	require.False(t, systemdUnitIsActive(systemd.UnitProperties{"ActiveState": "inactive"}))
+	require.False(t, systemdUnitIsActive(systemd.UnitProperties{"ActiveState": "failed"}))
+}
+
+// TestAccResourceSystemdService tests the systemd_service resource.
+func TestAccResourceSystemdService(t *testing.T) {
+	t.Parallel()
+
+	cfg := template.Must(template.New("enos_systemd_service").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_systemd_service" "{{.ID.Value}}" {
+		name = "{{.Name.Value}}"
+		{{if .Enabled.Value}}
+		enabled = {{.Enabled.Value}}
+		{{end}}
+		{{if .UnitDir.Value}}
+		unit_dir = "{{.UnitDir.Value}}"
+		{{end}}
+
+		unit = {
+		  Unit = {
+		    Description = "My App"
+		  }
+		  Service = {
+		    ExecStart = "/usr/local/bin/my-app"
+		  }
+		  Install = {
+		    WantedBy = "multi-user.target"
+		  }
+		}
+
+		{{ renderTransport .Transport }}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	systemdService := newSystemdServiceStateV1()
+	systemdService.ID.Set("foo")
+	systemdService.Name.Set("my-app")
+	systemdService.Enabled.Set(true)
+	systemdService.UnitDir.Set("/usr/lib/systemd/system")
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh := newEmbeddedTransportSSH()
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, systemdService.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		systemdService,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_systemd_service.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_systemd_service.foo", "name", regexp.MustCompile(`^my-app$`)),
+			resource.TestMatchResourceAttr("enos_systemd_service.foo", "enabled", regexp.MustCompile(`^true$`)),
+			resource.TestMatchResourceAttr("enos_systemd_service.foo", "unit_dir", regexp.MustCompile(`^/usr/lib/systemd/system$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
@@ -33,6 +33,7 @@ type vaultInitStateV1 struct {
 	BinPath         *tfString
 	SystemdUnitName *tfString // when using systemd to manage service
 	VaultAddr       *tfString
+	TLS             *tlsClientConfig
 	Transport       *embeddedTransportV1
 	// inputs
 	KeyShares         *tfNum
@@ -80,6 +81,7 @@ func newVaultInitStateV1() *vaultInitStateV1 {
 		BinPath:         newTfString(),
 		VaultAddr:       newTfString(),
 		SystemdUnitName: newTfString(),
+		TLS:             newTLSClientConfig(),
 		Transport:       transport,
 		// inputs
 		KeyShares:         newTfNum(),
@@ -282,6 +284,7 @@ The ^enos_vault_init^ resource is capable initializing a Vault cluster.
 					DescriptionKind: tfprotov6.StringKindMarkdown,
 					Description:     "The [api_addr](https://developer.hashicorp.com/vault/docs/configuration#api_addr) of the Vault cluster",
 				},
+				s.TLS.SchemaAttribute("Vault"),
 				s.Transport.SchemaAttributeTransport(supportsSSH | supportsK8s | supportsNomad),
 				// Input args
 				{
@@ -431,6 +434,10 @@ func (s *vaultInitStateV1) Validate(ctx context.Context) error {
 	default:
 	}
 
+	if err := s.TLS.Validate(); err != nil {
+		return err
+	}
+
 	return s.buildInitRequest().Validate()
 }
 
@@ -467,6 +474,11 @@ func (s *vaultInitStateV1) FromTerraform5Value(val tftypes.Value) error {
 		return err
 	}
 
+	err = s.TLS.FromTerraform5Value(vals["tls"])
+	if err != nil {
+		return err
+	}
+
 	if !vals["transport"].IsKnown() {
 		return nil
 	}
@@ -480,6 +492,7 @@ func (s *vaultInitStateV1) Terraform5Type() tftypes.Type {
 		"id":         s.ID.TFType(),
 		"bin_path":   s.BinPath.TFType(),
 		"unit_name":  s.SystemdUnitName.TFType(),
+		"tls":        s.TLS.Terraform5Type(),
 		"transport":  s.Transport.Terraform5Type(),
 		"vault_addr": s.VaultAddr.TFType(),
 		// inputs
@@ -511,6 +524,7 @@ func (s *vaultInitStateV1) Terraform5Value() tftypes.Value {
 	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
 		"id":         s.ID.TFValue(),
 		"bin_path":   s.BinPath.TFValue(),
+		"tls":        s.TLS.Terraform5Value(),
 		"transport":  s.Transport.Terraform5Value(),
 		"unit_name":  s.SystemdUnitName.TFValue(),
 		"vault_addr": s.VaultAddr.TFValue(),
@@ -624,6 +638,8 @@ func (s *vaultInitStateV1) buildInitRequest() *vault.InitRequest {
 		stateOpts = append(stateOpts, vault.WithStateRequestVaultAddr(vaultAddr))
 	}
 
+	stateOpts = append(stateOpts, vault.WithStateRequestTLSConfig(s.TLS.TLSConfig()))
+
 	unitName := "vault"
 	if unit, ok := s.SystemdUnitName.Get(); ok {
 		unitName = unit
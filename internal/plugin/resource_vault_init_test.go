@@ -72,6 +72,13 @@ func TestAccResourceVaultInit(t *testing.T) {
 		stored_shares = {{.StoredShares.Value}}
 		{{end}}
 
+		{{if not .TLS.Null}}
+		tls = {
+			ca_cert     = "{{.TLS.CACert.Value}}"
+			server_name = "{{.TLS.ServerName.Value}}"
+		}
+		{{end}}
+
 		{{ renderTransport .Transport }}
 	}`))
 
@@ -92,6 +99,9 @@ func TestAccResourceVaultInit(t *testing.T) {
 	vaultInit.ConsulAuto.Set(true)
 	vaultInit.ConsulService.Set("vault")
 	vaultInit.StoredShares.Set(7)
+	vaultInit.TLS.Null = false
+	vaultInit.TLS.CACert.Set("/etc/vault.d/tls/ca.pem")
+	vaultInit.TLS.ServerName.Set("vault.example.com")
 	ssh := newEmbeddedTransportSSH()
 	ssh.User.Set("ubuntu")
 	ssh.Host.Set("localhost")
@@ -116,6 +126,8 @@ func TestAccResourceVaultInit(t *testing.T) {
 			resource.TestMatchResourceAttr("enos_vault_init.foo", "consul_auto", regexp.MustCompile(`^true$`)),
 			resource.TestMatchResourceAttr("enos_vault_init.foo", "consul_service", regexp.MustCompile(`^vault$`)),
 			resource.TestMatchResourceAttr("enos_vault_init.foo", "stored_shares", regexp.MustCompile(`^7$`)),
+			resource.TestMatchResourceAttr("enos_vault_init.foo", "tls.ca_cert", regexp.MustCompile(`^/etc/vault.d/tls/ca.pem$`)),
+			resource.TestMatchResourceAttr("enos_vault_init.foo", "tls.server_name", regexp.MustCompile(`^vault.example.com$`)),
 			resource.TestMatchResourceAttr("enos_vault_init.foo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
 			resource.TestMatchResourceAttr("enos_vault_init.foo", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
 		),
@@ -0,0 +1,502 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/vault"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	istrings "github.com/hashicorp-forge/terraform-provider-enos/internal/strings"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	tfile "github.com/hashicorp-forge/terraform-provider-enos/internal/transport/file"
+)
+
+type vaultLicense struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*vaultLicense)(nil)
+
+type vaultLicenseStateV1 struct {
+	ID               *tfString
+	BinPath          *tfString
+	VaultAddr        *tfString
+	Token            *tfString
+	License          *tfString
+	LicensePath      *tfString
+	Username         *tfString
+	ExpirationWindow *tfString
+	LicenseID        *tfString
+	StartTime        *tfString
+	ExpirationTime   *tfString
+	TerminationTime  *tfString
+	Features         *tfStringSlice
+	Transport        *embeddedTransportV1
+
+	failureHandlers
+}
+
+var _ state.State = (*vaultLicenseStateV1)(nil)
+
+func newVaultLicense() *vaultLicense {
+	return &vaultLicense{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newVaultLicenseStateV1() *vaultLicenseStateV1 {
+	transport := newEmbeddedTransport()
+	fh := failureHandlers{
+		TransportDebugFailureHandler(transport),
+		GetApplicationLogsFailureHandler(transport, []string{"vault"}),
+	}
+
+	return &vaultLicenseStateV1{
+		ID:               newTfString(),
+		BinPath:          newTfString(),
+		VaultAddr:        newTfString(),
+		Token:            newTfString(),
+		License:          newTfString(),
+		LicensePath:      newTfString(),
+		Username:         newTfString(),
+		ExpirationWindow: newTfString(),
+		LicenseID:        newTfString(),
+		StartTime:        newTfString(),
+		ExpirationTime:   newTfString(),
+		TerminationTime:  newTfString(),
+		Features:         newTfStringSlice(),
+		Transport:        transport,
+		failureHandlers:  fh,
+	}
+}
+
+func (r *vaultLicense) Name() string {
+	return "enos_vault_license"
+}
+
+func (r *vaultLicense) Schema() *tfprotov6.Schema {
+	return newVaultLicenseStateV1().Schema()
+}
+
+func (r *vaultLicense) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *vaultLicense) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *vaultLicense) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newVaultLicenseStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *vaultLicense) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newVaultLicenseStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *vaultLicense) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newVaultLicenseStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *vaultLicense) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newVaultLicenseStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *vaultLicense) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newVaultLicenseStateV1()
+	proposedState := newVaultLicenseStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+	}
+
+	// If we haven't applied before or anything has changed we'll write and reload the license and
+	// read the status again, which means the license status will be known after apply.
+	if _, ok := priorState.ID.Get(); !ok || !proposedState.Terraform5Value().Equal(priorState.Terraform5Value()) {
+		proposedState.LicenseID.Unknown = true
+		proposedState.StartTime.Unknown = true
+		proposedState.ExpirationTime.Unknown = true
+		proposedState.TerminationTime.Unknown = true
+		proposedState.Features.Unknown = true
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *vaultLicense) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newVaultLicenseStateV1()
+	plannedState := newVaultLicenseStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do on delete
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	// Only apply if we're creating the resource or something has changed.
+	if _, ok := priorState.ID.Get(); ok && plannedState.Terraform5Value().Equal(priorState.Terraform5Value()) {
+		return
+	}
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	err = plannedState.Apply(ctx, client)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Vault License Error", err))
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *vaultLicenseStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_vault_license^ resource manages the autoloaded license of a Vault Enterprise node and
+reads the status of it.
+
+If a ^license^ is provided it will be written to the ^license_path^ and the license will be reloaded
+with the ^/v1/sys/config/reload/license^ API. Vault must have been configured to autoload the
+license from that path, either with the ^license_path^ configuration stanza or the
+^VAULT_LICENSE_PATH^ environment variable. ^enos_vault_start^ configures the latter when it is
+given a license.
+
+The license is written to and reloaded on the target host of the ^transport^ only, as Vault only
+reloads the license of the node that receives the reload request. Use an instance of the resource
+for each node in the cluster, e.g. with ^for_each^, and set the ^vault_addr^ to the address of that
+node.
+
+The status of the autoloaded license is read from the ^/v1/sys/license/status^ API. If an
+^expiration_window^ is provided the resource will fail if the license expires within it.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        s.ID.TFType(),
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "bin_path",
+					Type:        s.BinPath.TFType(),
+					Required:    true,
+					Description: "The fully qualified path to the vault binary",
+				},
+				{
+					Name:            "expiration_time",
+					Type:            s.ExpirationTime.TFType(),
+					Computed:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The expiration time of the autoloaded license",
+				},
+				{
+					Name:            "expiration_window",
+					Type:            s.ExpirationWindow.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "Fail if the license expires within the duration, e.g. `720h`",
+				},
+				{
+					Name:        "features",
+					Type:        s.Features.TFType(),
+					Computed:    true,
+					Description: "The features of the autoloaded license",
+				},
+				{
+					Name:        "license",
+					Type:        s.License.TFType(),
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The Vault Enterprise license to apply",
+				},
+				{
+					Name:        "license_id",
+					Type:        s.LicenseID.TFType(),
+					Computed:    true,
+					Description: "The ID of the autoloaded license",
+				},
+				{
+					Name:            "license_path",
+					Type:            s.LicensePath.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The path vault autoloads the license from. Defaults to `/etc/vault.d/vault.lic`",
+				},
+				{
+					Name:        "start_time",
+					Type:        s.StartTime.TFType(),
+					Computed:    true,
+					Description: "The start time of the autoloaded license",
+				},
+				{
+					Name:        "termination_time",
+					Type:        s.TerminationTime.TFType(),
+					Computed:    true,
+					Description: "The termination time of the autoloaded license",
+				},
+				{
+					Name:        "token",
+					Type:        s.Token.TFType(),
+					Required:    true,
+					Sensitive:   true,
+					Description: "The Vault token to use",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH),
+				{
+					Name:            "username",
+					Type:            s.Username.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The user that owns the license file. Defaults to `vault`",
+				},
+				{
+					Name:            "vault_addr",
+					Type:            s.VaultAddr.TFType(),
+					Required:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The configured `api_addr` from `enos_vault_start`",
+				},
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *vaultLicenseStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if _, ok := s.BinPath.Get(); !ok {
+		return ValidationError("you must provide the Vault bin path", "bin_path")
+	}
+
+	if _, ok := s.VaultAddr.Get(); !ok {
+		return ValidationError("you must provide the Vault address", "vault_addr")
+	}
+
+	if window, ok := s.ExpirationWindow.Get(); ok {
+		if _, err := time.ParseDuration(window); err != nil {
+			return ValidationError(
+				fmt.Sprintf("unable to parse expiration_window as a duration: %s", err.Error()),
+				"expiration_window",
+			)
+		}
+	}
+
+	return nil
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Vault with As().
+func (s *vaultLicenseStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":                s.ID,
+		"bin_path":          s.BinPath,
+		"expiration_time":   s.ExpirationTime,
+		"expiration_window": s.ExpirationWindow,
+		"features":          s.Features,
+		"license":           s.License,
+		"license_id":        s.LicenseID,
+		"license_path":      s.LicensePath,
+		"start_time":        s.StartTime,
+		"termination_time":  s.TerminationTime,
+		"token":             s.Token,
+		"username":          s.Username,
+		"vault_addr":        s.VaultAddr,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *vaultLicenseStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":                s.ID.TFType(),
+		"bin_path":          s.BinPath.TFType(),
+		"expiration_time":   s.ExpirationTime.TFType(),
+		"expiration_window": s.ExpirationWindow.TFType(),
+		"features":          s.Features.TFType(),
+		"license":           s.License.TFType(),
+		"license_id":        s.LicenseID.TFType(),
+		"license_path":      s.LicensePath.TFType(),
+		"start_time":        s.StartTime.TFType(),
+		"termination_time":  s.TerminationTime.TFType(),
+		"token":             s.Token.TFType(),
+		"transport":         s.Transport.Terraform5Type(),
+		"username":          s.Username.TFType(),
+		"vault_addr":        s.VaultAddr.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *vaultLicenseStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":                s.ID.TFValue(),
+		"bin_path":          s.BinPath.TFValue(),
+		"expiration_time":   s.ExpirationTime.TFValue(),
+		"expiration_window": s.ExpirationWindow.TFValue(),
+		"features":          s.Features.TFValue(),
+		"license":           s.License.TFValue(),
+		"license_id":        s.LicenseID.TFValue(),
+		"license_path":      s.LicensePath.TFValue(),
+		"start_time":        s.StartTime.TFValue(),
+		"termination_time":  s.TerminationTime.TFValue(),
+		"token":             s.Token.TFValue(),
+		"transport":         s.Transport.Terraform5Value(),
+		"username":          s.Username.TFValue(),
+		"vault_addr":        s.VaultAddr.TFValue(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *vaultLicenseStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+// Apply writes and reloads the license if one has been provided, reads the
+// license status, and verifies the expiration window.
+func (s *vaultLicenseStateV1) Apply(ctx context.Context, client it.Transport) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	cliReq := &vault.CLIRequest{
+		BinPath:   s.BinPath.Value(),
+		VaultAddr: s.VaultAddr.Value(),
+		Token:     s.Token.Value(),
+	}
+
+	if license, ok := s.License.Get(); ok {
+		licensePath := filepath.Join("/etc/vault.d", "vault.lic")
+		if path, ok := s.LicensePath.Get(); ok {
+			licensePath = path
+		}
+
+		username := "vault"
+		if user, ok := s.Username.Get(); ok {
+			username = user
+		}
+
+		err := remoteflight.CopyFile(ctx, client, remoteflight.NewCopyFileRequest(
+			remoteflight.WithCopyFileDestination(licensePath),
+			remoteflight.WithCopyFileChmod("640"),
+			remoteflight.WithCopyFileChown(fmt.Sprintf("%s:%s", username, username)),
+			remoteflight.WithCopyFileContent(tfile.NewReader(license)),
+		))
+		if err != nil {
+			return fmt.Errorf("failed to copy vault license, due to: %w", err)
+		}
+
+		err = vault.ReloadLicense(ctx, client, cliReq)
+		if err != nil {
+			return err
+		}
+	}
+
+	status, err := vault.GetLicenseStatus(ctx, client, cliReq)
+	if err != nil {
+		return err
+	}
+
+	license, err := status.License()
+	if err != nil {
+		return err
+	}
+
+	s.LicenseID.Set(license.LicenseID)
+	s.StartTime.Set(license.StartTime)
+	s.ExpirationTime.Set(license.ExpirationTime)
+	s.TerminationTime.Set(license.TerminationTime)
+	s.Features.SetStrings(license.Features)
+
+	if window, ok := s.ExpirationWindow.Get(); ok {
+		dur, err := time.ParseDuration(window)
+		if err != nil {
+			return err
+		}
+
+		expires, err := license.ExpiresWithin(dur, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if expires {
+			return fmt.Errorf(
+				"the vault license expires within the expiration window of %s\n%s",
+				window, istrings.Indent("  ", status.String()),
+			)
+		}
+	}
+
+	return nil
+}
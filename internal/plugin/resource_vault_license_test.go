@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+)
+
+// TestAccResourceVaultLicense tests the vault_license resource.
+func TestAccResourceVaultLicense(t *testing.T) {
+	t.Parallel()
+	cfg := template.Must(template.New("enos_vault_license").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_vault_license" "{{.ID.Value}}" {
+		{{if .BinPath.Value}}
+		bin_path = "{{.BinPath.Value}}"
+		{{end}}
+
+		{{if .VaultAddr.Value}}
+		vault_addr = "{{.VaultAddr.Value}}"
+		{{end}}
+
+		{{if .Token.Value}}
+		token = "{{.Token.Value}}"
+		{{end}}
+
+		{{if .License.Value}}
+		license = "{{.License.Value}}"
+		{{end}}
+
+		{{if .LicensePath.Value}}
+		license_path = "{{.LicensePath.Value}}"
+		{{end}}
+
+		{{if .Username.Value}}
+		username = "{{.Username.Value}}"
+		{{end}}
+
+		{{if .ExpirationWindow.Value}}
+		expiration_window = "{{.ExpirationWindow.Value}}"
+		{{end}}
+
+		{{ renderTransport .Transport }}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	vaultLicense := newVaultLicenseStateV1()
+	vaultLicense.ID.Set("foo")
+	vaultLicense.BinPath.Set("/opt/vault/bin/vault")
+	vaultLicense.VaultAddr.Set("http://127.0.0.1:8200")
+	vaultLicense.Token.Set("root")
+	vaultLicense.License.Set("some-license")
+	vaultLicense.LicensePath.Set("/etc/vault.d/vault.hclic")
+	vaultLicense.Username.Set("vault")
+	vaultLicense.ExpirationWindow.Set("720h")
+	ssh := newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	ssh.Host.Set("localhost")
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, vaultLicense.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		vaultLicense,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_vault_license.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_vault_license.foo", "bin_path", regexp.MustCompile(`^/opt/vault/bin/vault$`)),
+			resource.TestMatchResourceAttr("enos_vault_license.foo", "vault_addr", regexp.MustCompile(`^http://127.0.0.1:8200$`)),
+			resource.TestMatchResourceAttr("enos_vault_license.foo", "license_path", regexp.MustCompile(`^/etc/vault.d/vault.hclic$`)),
+			resource.TestMatchResourceAttr("enos_vault_license.foo", "username", regexp.MustCompile(`^vault$`)),
+			resource.TestMatchResourceAttr("enos_vault_license.foo", "expiration_window", regexp.MustCompile(`^720h$`)),
+			resource.TestMatchResourceAttr("enos_vault_license.foo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
+			resource.TestMatchResourceAttr("enos_vault_license.foo", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+// TestVaultLicensePlanStatus tests that the license status is unknown whenever anything has changed
+// because we'll reload the license and read the status again.
+func TestVaultLicensePlanStatus(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+
+	newState := func(license string, token string) *vaultLicenseStateV1 {
+		s := newVaultLicenseStateV1()
+		s.ID.Set("static")
+		s.BinPath.Set("/opt/vault/bin/vault")
+		s.VaultAddr.Set("http://127.0.0.1:8200")
+		s.Token.Set(token)
+		s.License.Set(license)
+		s.LicenseID.Set("some-id")
+		s.StartTime.Set("2026-01-01T00:00:00Z")
+		s.ExpirationTime.Set("2027-01-01T00:00:00Z")
+		s.TerminationTime.Set("2027-01-01T00:00:00Z")
+		s.Features.SetStrings([]string{"HSM"})
+		ssh := newEmbeddedTransportSSH()
+		ssh.User.Set("ubuntu")
+		ssh.Host.Set("localhost")
+		ssh.PrivateKey.Set(privateKey)
+		require.NoError(t, s.Transport.SetTransportState(ssh))
+
+		return s
+	}
+
+	for desc, test := range map[string]struct {
+		proposed      *vaultLicenseStateV1
+		expectUnknown bool
+	}{
+		"unchanged":       {newState("some-license", "root"), false},
+		"license changed": {newState("other-license", "root"), true},
+		"token changed":   {newState("some-license", "other-root"), true},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			res := &resourcerouter.PlanResourceChangeResponse{}
+			newVaultLicense().PlanResourceChange(context.Background(), resourcerouter.PlanResourceChangeRequest{
+				PriorState:       newState("some-license", "root").Terraform5Value(),
+				ProposedNewState: test.proposed.Terraform5Value(),
+			}, res)
+			require.Empty(t, res.Diagnostics)
+
+			planned, ok := res.PlannedState.(*vaultLicenseStateV1)
+			require.True(t, ok)
+			require.Equal(t, test.expectUnknown, planned.LicenseID.Unknown)
+			require.Equal(t, test.expectUnknown, planned.StartTime.Unknown)
+			require.Equal(t, test.expectUnknown, planned.ExpirationTime.Unknown)
+			require.Equal(t, test.expectUnknown, planned.TerminationTime.Unknown)
+			require.Equal(t, test.expectUnknown, planned.Features.Unknown)
+		})
+	}
+}
@@ -51,10 +51,13 @@ type vaultStartStateV1 struct {
 	Config          *vaultConfig
 	ConfigDir       *tfString
 	ConfigMode      *tfString
+	Kubernetes      *vaultStartKubernetesConfig
 	License         *tfString
+	Nomad           *vaultStartNomadConfig
 	Status          *tfNum
 	SystemdUnitName *tfString
 	ManageService   *tfBool
+	TLS             *tlsClientConfig
 	Transport       *embeddedTransportV1
 	Username        *tfString
 	Environment     *tfStringMap
@@ -97,10 +100,13 @@ func newVaultStartStateV1() *vaultStartStateV1 {
 		Config:          newVaultConfig(),
 		ConfigDir:       newTfString(),
 		ConfigMode:      newTfString(),
+		Kubernetes:      newVaultStartKubernetesConfig(),
 		License:         newTfString(),
+		Nomad:           newVaultStartNomadConfig(),
 		Status:          newTfNum(),
 		SystemdUnitName: newTfString(),
 		ManageService:   newTfBool(),
+		TLS:             newTLSClientConfig(),
 		Transport:       transport,
 		Username:        newTfString(),
 		Environment:     newTfStringMap(),
@@ -274,6 +280,10 @@ The ^enos_vault_start^ resource is capable of configuring and starting a Vault
 service. It handles creating the configuration directory, the configuration file,
 the license file, the systemd unit, and starting the service.
 
+When used with the kubernetes or nomad transports the same ^config^ is rendered into a
+ConfigMap and Secret or a Nomad variable, and the Vault workload is restarted via the respective
+API. See the ^kubernetes^ and ^nomad^ attributes for details.
+
 *NOTE: Until recently we were not able to implement optional attributes for the config attribute.
 As such, you will need to provide _all_ values except for ^seals^ until we make all config optional.*
 `),
@@ -385,7 +395,10 @@ The Vault status code returned when starting the service.
 					Type:        tftypes.Map{ElementType: tftypes.String},
 					Optional:    true,
 				},
-				s.Transport.SchemaAttributeTransport(supportsSSH),
+				s.Kubernetes.SchemaAttribute(),
+				s.Nomad.SchemaAttribute(),
+				s.TLS.SchemaAttribute("Vault"),
+				s.Transport.SchemaAttributeTransport(supportsSSH | supportsK8s | supportsNomad),
 			},
 		},
 	}
@@ -400,15 +413,21 @@ func (s *vaultStartStateV1) Validate(ctx context.Context) error {
 	default:
 	}
 
-	if err := checkK8STransportNotConfigured(s, "enos_vault_start"); err != nil {
-		return err
-	}
-
 	if _, ok := s.BinPath.Get(); !ok {
 		return ValidationError("you must provide a vault binary path", "bin_path")
 	}
 
-	return nil
+	if _, ok := s.Transport.K8S(); ok && s.Kubernetes.Null && !s.Kubernetes.Unknown {
+		return ValidationError(
+			"you must provide the kubernetes attribute when using the kubernetes transport", "kubernetes",
+		)
+	}
+
+	if err := s.Kubernetes.Validate(); err != nil {
+		return err
+	}
+
+	return s.TLS.Validate()
 }
 
 // FromTerraform5Value is a callback to unmarshal from the tftypes.Vault with As().
@@ -436,6 +455,21 @@ func (s *vaultStartStateV1) FromTerraform5Value(val tftypes.Value) error {
 		}
 	}
 
+	err = s.Kubernetes.FromTerraform5Value(vals["kubernetes"])
+	if err != nil {
+		return err
+	}
+
+	err = s.Nomad.FromTerraform5Value(vals["nomad"])
+	if err != nil {
+		return err
+	}
+
+	err = s.TLS.FromTerraform5Value(vals["tls"])
+	if err != nil {
+		return err
+	}
+
 	if vals["transport"].IsKnown() {
 		err = s.Transport.FromTerraform5Value(vals["transport"])
 		if err != nil {
@@ -454,10 +488,13 @@ func (s *vaultStartStateV1) Terraform5Type() tftypes.Type {
 		"config_dir":     s.ConfigDir.TFType(),
 		"config_mode":    s.ConfigMode.TFType(),
 		"id":             s.ID.TFType(),
+		"kubernetes":     s.Kubernetes.Terraform5Type(),
 		"license":        s.License.TFType(),
+		"nomad":          s.Nomad.Terraform5Type(),
 		"status":         s.Status.TFType(),
 		"unit_name":      s.SystemdUnitName.TFType(),
 		"manage_service": s.ManageService.TFType(),
+		"tls":            s.TLS.Terraform5Type(),
 		"transport":      s.Transport.Terraform5Type(),
 		"username":       s.Username.TFType(),
 		"environment":    s.Environment.TFType(),
@@ -472,10 +509,13 @@ func (s *vaultStartStateV1) Terraform5Value() tftypes.Value {
 		"config_dir":     s.ConfigDir.TFValue(),
 		"config_mode":    s.ConfigMode.TFValue(),
 		"id":             s.ID.TFValue(),
+		"kubernetes":     s.Kubernetes.Terraform5Value(),
 		"license":        s.License.TFValue(),
+		"nomad":          s.Nomad.Terraform5Value(),
 		"status":         s.Status.TFValue(),
 		"unit_name":      s.SystemdUnitName.TFValue(),
 		"manage_service": s.ManageService.TFValue(),
+		"tls":            s.TLS.Terraform5Value(),
 		"transport":      s.Transport.Terraform5Value(),
 		"username":       s.Username.TFValue(),
 		"environment":    s.Environment.TFValue(),
@@ -770,13 +810,98 @@ func (c *vaultConfig) Render(configMode string) (*hcl.Builder, map[string]string
 	return hclBuilder, envVars, nil
 }
 
+// startVault configures and starts vault using the process manager of the target. For SSH targets
+// we manage vault with systemd, for Kubernetes and Nomad targets we use the respective APIs.
 func (s *vaultStartStateV1) startVault(ctx context.Context, transport it.Transport) error {
-	var err error
-
 	// Set the status to unknown. After we start vault and wait for it to be running
 	// we'll update the status again.
 	s.Status.Set(int(vault.StatusUnknown))
 
+	switch transport.Type() {
+	case it.TransportType("kubernetes"):
+		return s.startVaultKubernetes(ctx, transport)
+	case it.TransportType("nomad"):
+		return s.startVaultNomad(ctx, transport)
+	default:
+		return s.startVaultSystemd(ctx, transport)
+	}
+}
+
+// renderConfig renders the vault configuration into HCL and environment variables. Any user
+// provided environment variables are included.
+func (s *vaultStartStateV1) renderConfig() (*hcl.Builder, map[string]string, error) {
+	if _, ok := s.Config.LogLevel.Get(); !ok {
+		s.Config.LogLevel.Set("info")
+	}
+
+	envVars := map[string]string{}
+	if environment, ok := s.Environment.Get(); ok {
+		for key, value := range environment {
+			if val, valOk := value.Get(); valOk {
+				envVars[key] = val
+			}
+		}
+	}
+
+	// Render our vault configuration into HCL and/or environment variables.
+	configMode, ok := s.ConfigMode.Get()
+	if configMode == "" || !ok {
+		configMode = defaultVaultConfigMode
+	}
+
+	hclConfig, configEnv, err := s.Config.Render(configMode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the vault HCL configuration, due to: %w", err)
+	}
+
+	maps.Copy(envVars, configEnv)
+
+	return hclConfig, envVars, nil
+}
+
+// waitForVault waits for vault to reach the expected state and updates our status.
+func (s *vaultStartStateV1) waitForVault(
+	ctx context.Context,
+	transport it.Transport,
+	process string,
+	opts []vault.StateRequestOpt,
+	checks ...vault.CheckStater,
+) error {
+	opts = append([]vault.StateRequestOpt{
+		vault.WithStateRequestFlightControlUseHomeDir(),
+		vault.WithStateRequestBinPath(s.BinPath.Value()),
+		vault.WithStateRequestVaultAddr(s.Config.APIAddr.Value()),
+		vault.WithStateRequestTLSConfig(s.TLS.TLSConfig()),
+	}, opts...)
+
+	state, err := vault.WaitForState(ctx, transport, vault.NewStateRequest(opts...), checks...)
+
+	statusCode := vault.StatusUnknown
+	if state != nil {
+		var err1 error
+		statusCode, err1 = state.StatusCode()
+		err = errors.Join(err, err1)
+	}
+
+	s.Status.Set(int(statusCode))
+
+	if err != nil {
+		err = fmt.Errorf("failed to start the vault service: %w", err)
+		if state != nil {
+			err = fmt.Errorf(
+				"%w\nCluster State after starting the vault %s:\n%s",
+				err, process, istrings.Indent("  ", state.String()),
+			)
+		}
+	}
+
+	return err
+}
+
+// startVaultSystemd configures and starts vault as a systemd service.
+func (s *vaultStartStateV1) startVaultSystemd(ctx context.Context, transport it.Transport) error {
+	var err error
+
 	// Set up defaults
 	vaultUsername := "vault"
 	if user, ok := s.Username.Get(); ok {
@@ -789,20 +914,11 @@ func (s *vaultStartStateV1) startVault(ctx context.Context, transport it.Transpo
 	}
 	configFilePath := filepath.Join(configDir, "vault.hcl")
 	licensePath := filepath.Join(configDir, "vault.lic")
-
-	if _, ok := s.Config.LogLevel.Get(); !ok {
-		s.Config.LogLevel.Set("info")
-	}
-
 	envFilePath := "/etc/vault.d/vault.env"
 
-	envVars := map[string]string{}
-	if environment, ok := s.Environment.Get(); ok {
-		for key, value := range environment {
-			if val, valOk := value.Get(); valOk {
-				envVars[key] = val
-			}
-		}
+	hclConfig, envVars, err := s.renderConfig()
+	if err != nil {
+		return err
 	}
 
 	_, err = remoteflight.CreateOrUpdateUser(ctx, transport, remoteflight.NewUser(
@@ -829,19 +945,6 @@ func (s *vaultStartStateV1) startVault(ctx context.Context, transport it.Transpo
 		envVars["VAULT_LICENSE_PATH"] = licensePath
 	}
 
-	// Render our vault configuration into HCL and/or environment variables.
-	configMode, ok := s.ConfigMode.Get()
-	if configMode == "" || !ok {
-		configMode = defaultVaultConfigMode
-	}
-
-	hclConfig, configEnv, err := s.Config.Render(configMode)
-	if err != nil {
-		return fmt.Errorf("failed to create the vault HCL configuration, due to: %w", err)
-	}
-
-	maps.Copy(envVars, configEnv)
-
 	// Write our config file
 	err = hcl.CreateHCLConfigFile(ctx, transport, hcl.NewCreateHCLConfigFileRequest(
 		hcl.WithHCLConfigFilePath(configFilePath),
@@ -955,36 +1058,11 @@ func (s *vaultStartStateV1) startVault(ctx context.Context, transport it.Transpo
 		return fmt.Errorf("failed to start the vault service, due to: %w", err)
 	}
 
-	state, err := vault.WaitForState(
-		timeoutCtx, transport, vault.NewStateRequest(
-			vault.WithStateRequestFlightControlUseHomeDir(),
-			vault.WithStateRequestBinPath(s.BinPath.Value()),
-			vault.WithStateRequestVaultAddr(s.Config.APIAddr.Value()),
-			vault.WithStateRequestSystemdUnitName(unitName),
-		), vault.CheckStateHasSystemdEnabledAndRunningProperties(),
+	return s.waitForVault(timeoutCtx, transport, "systemd service",
+		[]vault.StateRequestOpt{vault.WithStateRequestSystemdUnitName(unitName)},
+		vault.CheckStateHasSystemdEnabledAndRunningProperties(),
 		vault.CheckStateSealStateIsKnown(),
 	)
-
-	statusCode := vault.StatusUnknown
-	if state != nil {
-		var err1 error
-		statusCode, err1 = state.StatusCode()
-		err = errors.Join(err, err1)
-	}
-
-	s.Status.Set(int(statusCode))
-
-	if err != nil {
-		err = fmt.Errorf("failed to start the vault service: %w", err)
-		if state != nil {
-			err = fmt.Errorf(
-				"%w\nCluster State after starting the vault systemd service:\n%s",
-				err, istrings.Indent("  ", state.String()),
-			)
-		}
-	}
-
-	return err
 }
 
 type sealAttrEnvVarTranslator struct{}
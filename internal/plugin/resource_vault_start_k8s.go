@@ -0,0 +1,499 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/kubernetes"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/vault"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/retry"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/k8s"
+)
+
+const (
+	defaultVaultK8sConfigMapName = "vault-config"
+	defaultVaultK8sSecretName    = "vault-env"
+)
+
+// vaultStartKubernetesConfig is the configuration enos_vault_start uses when
+// starting vault with the kubernetes transport.
+type vaultStartKubernetesConfig struct {
+	ConfigMapName  *tfString
+	SecretName     *tfString
+	WorkloadKind   *tfString
+	WorkloadName   *tfString
+	LabelSelectors *tfStringSlice
+	WaitForReady   *tfBool
+
+	Unknown bool
+	Null    bool
+}
+
+func newVaultStartKubernetesConfig() *vaultStartKubernetesConfig {
+	return &vaultStartKubernetesConfig{
+		ConfigMapName:  newTfString(),
+		SecretName:     newTfString(),
+		WorkloadKind:   newTfString(),
+		WorkloadName:   newTfString(),
+		LabelSelectors: newTfStringSlice(),
+		WaitForReady:   newTfBool(),
+		Unknown:        false,
+		Null:           true,
+	}
+}
+
+// SchemaAttribute returns the schema attribute for the kubernetes attribute.
+func (c *vaultStartKubernetesConfig) SchemaAttribute() *tfprotov6.SchemaAttribute {
+	return &tfprotov6.SchemaAttribute{
+		Name:            "kubernetes",
+		Type:            c.Terraform5Type(),
+		Optional:        true,
+		DescriptionKind: tfprotov6.StringKindMarkdown,
+		Description: docCaretToBacktick(`
+How to start Vault when using the kubernetes transport. It is required when using the kubernetes transport. The rendered ^config^ is written to the ^vault.hcl^ key of a ConfigMap and any environment variables, including the license as ^VAULT_LICENSE^, are written to a Secret. The Vault workload is expected to mount the ConfigMap into its configuration directory and load the Secret with ^envFrom^. After both have been written the workload is restarted.
+- ^kubernetes.config_map_name^ (String) The name of the ConfigMap. Defaults to ^vault-config^
+- ^kubernetes.secret_name^ (String) The name of the Secret. Defaults to ^vault-env^
+- ^kubernetes.workload_kind^ (String) Required, the kind of workload to restart, one of ^pod^, ^statefulset^, or ^deployment^. The ^pod^ kind deletes the pod and relies on its controller to recreate it. The ^statefulset^ and ^deployment^ kinds perform a rolling restart and wait for the rollout to replace all of the pods. Rolling updates only replace the next pod once the previous pod is ready, so the Vault pods must either become ready while sealed or be auto-unsealed. Stateful sets that use the ^OnDelete^ update strategy are not supported, use the ^pod^ kind instead
+- ^kubernetes.workload_name^ (String) The name of the workload to restart. Required for ^statefulset^ and ^deployment^ workloads, defaults to the transport pod for the ^pod^ kind
+- ^kubernetes.label_selectors^ (List of String) Required, label selectors that match the Vault pods. After the restart we wait for the restarted pods to be replaced and for all matching pods to be running before checking them. If the transport pod has been replaced, the remaining checks are run in one of the replacement pods
+- ^kubernetes.wait_for_ready^ (Bool) Wait for all pod containers to be ready. Only enable this if the readiness probe passes while Vault is sealed
+`),
+	}
+}
+
+// Validate validates the kubernetes configuration.
+func (c *vaultStartKubernetesConfig) Validate() error {
+	if c == nil || c.Null || c.Unknown {
+		return nil
+	}
+
+	selectors, ok := c.LabelSelectors.GetStrings()
+	if (c.LabelSelectors.Null && !c.LabelSelectors.Unknown) || (ok && len(selectors) == 0) {
+		return ValidationError(
+			"you must provide label_selectors that match the vault pods", "kubernetes", "label_selectors",
+		)
+	}
+
+	if c.WorkloadKind.Null && !c.WorkloadKind.Unknown {
+		return ValidationError(
+			"you must provide the workload_kind of the workload to restart", "kubernetes", "workload_kind",
+		)
+	}
+
+	if kind, ok := c.WorkloadKind.Get(); ok {
+		switch kubernetes.WorkloadKind(kind) {
+		case kubernetes.WorkloadKindPod, kubernetes.WorkloadKindStatefulSet, kubernetes.WorkloadKindDeployment:
+		default:
+			return ValidationError(
+				fmt.Sprintf("unsupported workload_kind %s, expected 'pod', 'statefulset', or 'deployment'", kind),
+				"kubernetes", "workload_kind",
+			)
+		}
+
+		if kind != string(kubernetes.WorkloadKindPod) {
+			if _, ok := c.WorkloadName.Get(); !ok && !c.WorkloadName.Unknown {
+				return ValidationError(
+					fmt.Sprintf("you must provide a workload_name when workload_kind is %s", kind),
+					"kubernetes", "workload_name",
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *vaultStartKubernetesConfig) attrs() map[string]tftypes.Type {
+	return map[string]tftypes.Type{
+		"config_map_name": c.ConfigMapName.TFType(),
+		"secret_name":     c.SecretName.TFType(),
+		"workload_kind":   c.WorkloadKind.TFType(),
+		"workload_name":   c.WorkloadName.TFType(),
+		"label_selectors": c.LabelSelectors.TFType(),
+		"wait_for_ready":  c.WaitForReady.TFType(),
+	}
+}
+
+func (c *vaultStartKubernetesConfig) optionalAttrs() map[string]struct{} {
+	return map[string]struct{}{
+		"config_map_name": {},
+		"secret_name":     {},
+		"workload_kind":   {},
+		"workload_name":   {},
+		"label_selectors": {},
+		"wait_for_ready":  {},
+	}
+}
+
+// Terraform5Type is the tftypes.Type.
+func (c *vaultStartKubernetesConfig) Terraform5Type() tftypes.Type {
+	return tftypes.Object{
+		AttributeTypes:     c.attrs(),
+		OptionalAttributes: c.optionalAttrs(),
+	}
+}
+
+// Terraform5Value is the tftypes.Value.
+func (c *vaultStartKubernetesConfig) Terraform5Value() tftypes.Value {
+	typ := tftypes.Object{
+		AttributeTypes: c.attrs(),
+	}
+
+	if c.Null {
+		return tftypes.NewValue(typ, nil)
+	}
+
+	if c.Unknown {
+		return tftypes.NewValue(typ, tftypes.UnknownValue)
+	}
+
+	return tftypes.NewValue(typ, map[string]tftypes.Value{
+		"config_map_name": c.ConfigMapName.TFValue(),
+		"secret_name":     c.SecretName.TFValue(),
+		"workload_kind":   c.WorkloadKind.TFValue(),
+		"workload_name":   c.WorkloadName.TFValue(),
+		"label_selectors": c.LabelSelectors.TFValue(),
+		"wait_for_ready":  c.WaitForReady.TFValue(),
+	})
+}
+
+// FromTerraform5Value unmarshals the value to the struct.
+func (c *vaultStartKubernetesConfig) FromTerraform5Value(val tftypes.Value) error {
+	if val.IsNull() {
+		c.Null = true
+		c.Unknown = false
+
+		return nil
+	}
+
+	if !val.IsKnown() {
+		c.Null = false
+		c.Unknown = true
+
+		return nil
+	}
+
+	c.Null = false
+	c.Unknown = false
+
+	_, err := mapAttributesTo(val, map[string]any{
+		"config_map_name": c.ConfigMapName,
+		"secret_name":     c.SecretName,
+		"workload_kind":   c.WorkloadKind,
+		"workload_name":   c.WorkloadName,
+		"label_selectors": c.LabelSelectors,
+		"wait_for_ready":  c.WaitForReady,
+	})
+
+	return err
+}
+
+// startVaultKubernetes writes the vault configuration into a ConfigMap and Secret, restarts the
+// vault workload, waits for the pods to be replaced, and then waits for them to be running.
+func (s *vaultStartStateV1) startVaultKubernetes(ctx context.Context, transport it.Transport) error {
+	k, ok := transport.(*k8s.Transport)
+	if !ok {
+		return errors.New("failed to start vault: type mismatch between transport")
+	}
+
+	if s.Kubernetes.Null {
+		return errors.New("failed to start vault: the kubernetes attribute is required when using the kubernetes transport")
+	}
+
+	selectors, ok := s.Kubernetes.LabelSelectors.GetStrings()
+	if !ok || len(selectors) == 0 {
+		return errors.New("failed to start vault: the kubernetes label_selectors are required")
+	}
+
+	kind, ok := s.Kubernetes.WorkloadKind.Get()
+	if !ok {
+		return errors.New("failed to start vault: the kubernetes workload_kind is required")
+	}
+
+	hclConfig, envVars, err := s.renderConfig()
+	if err != nil {
+		return err
+	}
+
+	if license, ok := s.License.Get(); ok {
+		envVars["VAULT_LICENSE"] = license
+	}
+
+	config, err := hclConfig.BuildHCL()
+	if err != nil {
+		return fmt.Errorf("failed to create the vault HCL configuration, due to: %w", err)
+	}
+
+	configMapName := defaultVaultK8sConfigMapName
+	if name, ok := s.Kubernetes.ConfigMapName.Get(); ok {
+		configMapName = name
+	}
+
+	err = k.Client.ApplyConfigMap(ctx, kubernetes.ApplyConfigMapRequest{
+		Namespace: k.Namespace,
+		Name:      configMapName,
+		Data:      map[string]string{"vault.hcl": config},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write the vault configuration, due to: %w", err)
+	}
+
+	secretName := defaultVaultK8sSecretName
+	if name, ok := s.Kubernetes.SecretName.Get(); ok {
+		secretName = name
+	}
+
+	err = k.Client.ApplySecret(ctx, kubernetes.ApplySecretRequest{
+		Namespace: k.Namespace,
+		Name:      secretName,
+		Data:      envVars,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write the vault environment, due to: %w", err)
+	}
+
+	workload := k.Pod
+	if name, ok := s.Kubernetes.WorkloadName.Get(); ok {
+		workload = name
+	}
+	workloadKind := kubernetes.WorkloadKind(kind)
+
+	// Get the pods before we restart them so that we can tell when they have been replaced.
+	pods, err := listVaultPods(ctx, k, selectors)
+	if err != nil {
+		return fmt.Errorf("failed to list the vault pods, due to: %w", err)
+	}
+
+	replaced := pods
+	if workloadKind == kubernetes.WorkloadKindPod {
+		// Only the pod that we delete will be replaced.
+		replaced = slices.DeleteFunc(slices.Clone(pods), func(pod v1.Pod) bool { return pod.Name != workload })
+		if len(replaced) == 0 {
+			return fmt.Errorf("failed to start vault: the pod %s does not match the kubernetes label_selectors", workload)
+		}
+	} else {
+		err = verifyVaultWorkloadRestartable(ctx, k, workloadKind, workload)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = k.Client.RestartWorkload(ctx, kubernetes.RestartWorkloadRequest{
+		Namespace: k.Namespace,
+		Kind:      workloadKind,
+		Name:      workload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restart vault, due to: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	if workloadKind != kubernetes.WorkloadKindPod {
+		err = waitForVaultWorkloadRollout(timeoutCtx, k, workloadKind, workload, 2*time.Second)
+		if err != nil {
+			return fmt.Errorf("failed to start vault, due to: %w", err)
+		}
+	}
+
+	newPods, err := waitForVaultPodsReplaced(timeoutCtx, k, selectors, replaced, len(pods), 2*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to start vault, due to: %w", err)
+	}
+
+	// If the transport pod was replaced we need to run the remaining checks in a replacement.
+	if !slices.ContainsFunc(newPods, func(pod v1.Pod) bool { return pod.Name == k.Pod }) {
+		transport = &k8s.Transport{
+			Client:    k.Client,
+			Namespace: k.Namespace,
+			Pod:       newPods[0].Name,
+			Container: k.Container,
+		}
+	}
+
+	checks := []vault.CheckStater{
+		vault.CheckStateAllPodsHavePhase(v1.PodRunning),
+		vault.CheckStateSealStateIsKnown(),
+	}
+	if wait, ok := s.Kubernetes.WaitForReady.Get(); ok && wait {
+		checks = append(checks, vault.CheckStateAllContainersAreReady())
+	}
+
+	return s.waitForVault(timeoutCtx, transport, "kubernetes workload",
+		[]vault.StateRequestOpt{vault.WithStateRequestListPodsRequestOpts(
+			kubernetes.WithListPodsRequestNamespace(k.Namespace),
+			kubernetes.WithListPodsRequestLabelSelectors(selectors),
+		)},
+		checks...,
+	)
+}
+
+// listVaultPods lists the pods that match the label selectors.
+func listVaultPods(ctx context.Context, k *k8s.Transport, selectors []string) ([]v1.Pod, error) {
+	res, err := k.Client.ListPods(ctx, kubernetes.NewListPodsRequest(
+		kubernetes.WithListPodsRequestNamespace(k.Namespace),
+		kubernetes.WithListPodsRequestLabelSelectors(selectors),
+		kubernetes.WithListPodsRequestRetryOpts(retry.WithMaxRetries(1)),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Pods == nil {
+		return []v1.Pod{}, nil
+	}
+
+	return res.Pods.Items, nil
+}
+
+// verifyVaultWorkloadRestartable verifies that restarting the stateful set or deployment will
+// replace its pods.
+func verifyVaultWorkloadRestartable(
+	ctx context.Context,
+	k *k8s.Transport,
+	kind kubernetes.WorkloadKind,
+	name string,
+) error {
+	status, err := k.Client.GetWorkloadRolloutStatus(ctx, kubernetes.GetWorkloadRolloutStatusRequest{
+		Namespace: k.Namespace,
+		Kind:      kind,
+		Name:      name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get the vault workload, due to: %w", err)
+	}
+
+	if status.UpdateStrategy == string(appsv1.OnDeleteStatefulSetStrategyType) {
+		return fmt.Errorf(
+			"failed to start vault: the %s %s uses the OnDelete update strategy so restarting it will not replace its pods",
+			kind, name,
+		)
+	}
+
+	return nil
+}
+
+// waitForVaultWorkloadRollout waits until all of the pods of the stateful set or deployment have
+// been replaced by its controller. We rely on the rollout status rather than the readiness of the
+// pods as sealed vault pods are usually not ready.
+func waitForVaultWorkloadRollout(
+	ctx context.Context,
+	k *k8s.Transport,
+	kind kubernetes.WorkloadKind,
+	name string,
+	interval time.Duration,
+) error {
+	retrier, err := retry.NewRetrier(
+		retry.WithIntervalFunc(retry.IntervalDuration(interval)),
+		retry.WithRetrierFunc(func(ctx context.Context) (any, error) {
+			status, err := k.Client.GetWorkloadRolloutStatus(ctx, kubernetes.GetWorkloadRolloutStatusRequest{
+				Namespace: k.Namespace,
+				Kind:      kind,
+				Name:      name,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			if !status.Complete() {
+				return nil, fmt.Errorf(
+					"%d of %d pods of the %s %s have been replaced. Rolling updates only replace the next pod "+
+						"once the previous pod is ready, so the vault pods must become ready while sealed or be auto-unsealed",
+					status.UpdatedReplicas, status.DesiredReplicas, kind, name,
+				)
+			}
+
+			return nil, nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = retry.Retry(ctx, retrier)
+	if err != nil {
+		return fmt.Errorf("waiting for the vault %s rollout: %w", kind, err)
+	}
+
+	return nil
+}
+
+// waitForVaultPodsReplaced waits until the expected number of pods that match the label selectors
+// exist, none of them are the replaced pods, and all of them are running. The running pods are
+// returned sorted by name. Terminating pods continue to report that they are running so we compare
+// the pod UIDs rather than relying on the pod phase.
+func waitForVaultPodsReplaced(
+	ctx context.Context,
+	k *k8s.Transport,
+	selectors []string,
+	oldPods []v1.Pod,
+	expected int,
+	interval time.Duration,
+) ([]v1.Pod, error) {
+	oldUIDs := map[types.UID]struct{}{}
+	for i := range oldPods {
+		oldUIDs[oldPods[i].UID] = struct{}{}
+	}
+
+	retrier, err := retry.NewRetrier(
+		retry.WithIntervalFunc(retry.IntervalDuration(interval)),
+		retry.WithRetrierFunc(func(ctx context.Context) (any, error) {
+			pods, err := listVaultPods(ctx, k, selectors)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(pods) == 0 {
+				return nil, errors.New("no vault pods matched the label selectors")
+			}
+
+			if len(pods) < expected {
+				return nil, fmt.Errorf("expected %d vault pods, found %d", expected, len(pods))
+			}
+
+			for i := range pods {
+				if _, ok := oldUIDs[pods[i].UID]; ok {
+					return nil, fmt.Errorf("vault pod %s has not been replaced", pods[i].Name)
+				}
+
+				if pods[i].Status.Phase != v1.PodRunning {
+					return nil, fmt.Errorf("vault pod %s has phase %s", pods[i].Name, pods[i].Status.Phase)
+				}
+			}
+
+			slices.SortFunc(pods, func(a, b v1.Pod) int { return strings.Compare(a.Name, b.Name) })
+
+			return pods, nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := retry.Retry(ctx, retrier)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for the vault pods to be replaced: %w", err)
+	}
+
+	pods, ok := res.([]v1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("unexpected vault pods response type: %v", res)
+	}
+
+	return pods, nil
+}
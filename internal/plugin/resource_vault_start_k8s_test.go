@@ -0,0 +1,213 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/kubernetes"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/k8s"
+)
+
+func testVaultPod(name string, uid types.UID, phase v1.PodPhase) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: uid},
+		Status:     v1.PodStatus{Phase: phase},
+	}
+}
+
+// TestWaitForVaultPodsReplaced tests that we wait for the old pods to be replaced by running pods
+// rather than accepting terminating pods that still report that they are running.
+func TestWaitForVaultPodsReplaced(t *testing.T) {
+	t.Parallel()
+
+	oldPod := testVaultPod("vault-7d9f8-abcde", "old", v1.PodRunning)
+	responses := [][]v1.Pod{
+		{oldPod},
+		{},
+		{testVaultPod("vault-7d9f8-fghij", "new", v1.PodPending)},
+		{testVaultPod("vault-7d9f8-fghij", "new", v1.PodRunning)},
+	}
+
+	mu := sync.Mutex{}
+	calls := 0
+	transport := &k8s.Transport{
+		Namespace: "vault",
+		Pod:       oldPod.Name,
+		Client: &kubernetes.MockClient{
+			ListPodsFunc: func(ctx context.Context, req *kubernetes.ListPodsRequest) (*kubernetes.ListPodsResponse, error) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				require.Equal(t, "vault", req.Namespace)
+				require.Equal(t, []string{"app.kubernetes.io/name=vault"}, req.LabelSelectors)
+
+				pods := responses[min(calls, len(responses)-1)]
+				calls++
+
+				return &kubernetes.ListPodsResponse{Pods: &kubernetes.Pods{Items: pods}}, nil
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pods, err := waitForVaultPodsReplaced(
+		ctx, transport, []string{"app.kubernetes.io/name=vault"}, []v1.Pod{oldPod}, 1, time.Millisecond,
+	)
+	require.NoError(t, err)
+	require.Len(t, pods, 1)
+	require.Equal(t, types.UID("new"), pods[0].UID)
+	require.Equal(t, len(responses), calls)
+}
+
+// TestWaitForVaultPodsReplacedTimeout tests that we don't accept the old pods.
+func TestWaitForVaultPodsReplacedTimeout(t *testing.T) {
+	t.Parallel()
+
+	oldPod := testVaultPod("vault-0", "old", v1.PodRunning)
+	transport := &k8s.Transport{
+		Namespace: "vault",
+		Pod:       oldPod.Name,
+		Client: &kubernetes.MockClient{
+			ListPodsFunc: func(ctx context.Context, req *kubernetes.ListPodsRequest) (*kubernetes.ListPodsResponse, error) {
+				return &kubernetes.ListPodsResponse{Pods: &kubernetes.Pods{Items: []v1.Pod{oldPod}}}, nil
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := waitForVaultPodsReplaced(
+		ctx, transport, []string{"app.kubernetes.io/name=vault"}, []v1.Pod{oldPod}, 1, time.Millisecond,
+	)
+	require.ErrorContains(t, err, "has not been replaced")
+}
+
+// TestWaitForVaultPodsReplacedSinglePod tests that we only wait for the deleted pod to be replaced
+// when other pods match the label selectors.
+func TestWaitForVaultPodsReplacedSinglePod(t *testing.T) {
+	t.Parallel()
+
+	deleted := testVaultPod("vault-0", "old-0", v1.PodRunning)
+	other := testVaultPod("vault-1", "old-1", v1.PodRunning)
+	responses := [][]v1.Pod{
+		{deleted, other},
+		{other},
+		{testVaultPod("vault-0", "new-0", v1.PodRunning), other},
+	}
+
+	mu := sync.Mutex{}
+	calls := 0
+	transport := &k8s.Transport{
+		Namespace: "vault",
+		Pod:       deleted.Name,
+		Client: &kubernetes.MockClient{
+			ListPodsFunc: func(ctx context.Context, req *kubernetes.ListPodsRequest) (*kubernetes.ListPodsResponse, error) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				pods := responses[min(calls, len(responses)-1)]
+				calls++
+
+				return &kubernetes.ListPodsResponse{Pods: &kubernetes.Pods{Items: pods}}, nil
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pods, err := waitForVaultPodsReplaced(
+		ctx, transport, []string{"app.kubernetes.io/name=vault"}, []v1.Pod{deleted}, 2, time.Millisecond,
+	)
+	require.NoError(t, err)
+	require.Len(t, pods, 2)
+	require.Equal(t, types.UID("new-0"), pods[0].UID)
+	require.Equal(t, types.UID("old-1"), pods[1].UID)
+}
+
+// TestWaitForVaultWorkloadRollout tests that we wait for the workload rollout to replace all of
+// the pods.
+func TestWaitForVaultWorkloadRollout(t *testing.T) {
+	t.Parallel()
+
+	responses := []*kubernetes.WorkloadRolloutStatus{
+		{Generation: 2, ObservedGeneration: 1, DesiredReplicas: 3, Replicas: 3, UpdatedReplicas: 0},
+		{Generation: 2, ObservedGeneration: 2, DesiredReplicas: 3, Replicas: 3, UpdatedReplicas: 1},
+		{Generation: 2, ObservedGeneration: 2, DesiredReplicas: 3, Replicas: 3, UpdatedReplicas: 3},
+	}
+
+	mu := sync.Mutex{}
+	calls := 0
+	transport := &k8s.Transport{
+		Namespace: "vault",
+		Pod:       "vault-0",
+		Client: &kubernetes.MockClient{
+			GetWorkloadRolloutStatusFunc: func(ctx context.Context, req kubernetes.GetWorkloadRolloutStatusRequest) (*kubernetes.WorkloadRolloutStatus, error) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				require.Equal(t, kubernetes.WorkloadKindStatefulSet, req.Kind)
+				require.Equal(t, "vault", req.Name)
+
+				status := responses[min(calls, len(responses)-1)]
+				calls++
+
+				return status, nil
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, waitForVaultWorkloadRollout(
+		ctx, transport, kubernetes.WorkloadKindStatefulSet, "vault", time.Millisecond,
+	))
+	require.Equal(t, len(responses), calls)
+}
+
+// TestVerifyVaultWorkloadRestartable tests that we reject workloads that won't replace their pods
+// when they are restarted.
+func TestVerifyVaultWorkloadRestartable(t *testing.T) {
+	t.Parallel()
+
+	for strategy, shouldFail := range map[string]bool{
+		"RollingUpdate": false,
+		"OnDelete":      true,
+	} {
+		t.Run(strategy, func(t *testing.T) {
+			t.Parallel()
+
+			transport := &k8s.Transport{
+				Namespace: "vault",
+				Client: &kubernetes.MockClient{
+					GetWorkloadRolloutStatusFunc: func(ctx context.Context, req kubernetes.GetWorkloadRolloutStatusRequest) (*kubernetes.WorkloadRolloutStatus, error) {
+						return &kubernetes.WorkloadRolloutStatus{UpdateStrategy: strategy}, nil
+					},
+				},
+			}
+
+			err := verifyVaultWorkloadRestartable(
+				context.Background(), transport, kubernetes.WorkloadKindStatefulSet, "vault",
+			)
+			if shouldFail {
+				require.ErrorContains(t, err, "OnDelete")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/nomad"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/vault"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/retry"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	tnomad "github.com/hashicorp-forge/terraform-provider-enos/internal/transport/nomad"
+)
+
+// vaultStartNomadConfig is the configuration enos_vault_start uses when
+// starting vault with the nomad transport.
+type vaultStartNomadConfig struct {
+	VariablePath *tfString
+
+	Unknown bool
+	Null    bool
+}
+
+func newVaultStartNomadConfig() *vaultStartNomadConfig {
+	return &vaultStartNomadConfig{
+		VariablePath: newTfString(),
+		Unknown:      false,
+		Null:         true,
+	}
+}
+
+// SchemaAttribute returns the schema attribute for the nomad attribute.
+func (c *vaultStartNomadConfig) SchemaAttribute() *tfprotov6.SchemaAttribute {
+	return &tfprotov6.SchemaAttribute{
+		Name:            "nomad",
+		Type:            c.Terraform5Type(),
+		Optional:        true,
+		DescriptionKind: tfprotov6.StringKindMarkdown,
+		Description: docCaretToBacktick(`
+How to start Vault when using the nomad transport. The rendered ^config^ is written to the ^vault.hcl^ item of a Nomad variable and any environment variables, including the license as ^VAULT_LICENSE^, are written as additional items. The Vault task is expected to render the variable with a task ^template^ that uses ^change_mode = "noop"^. After the variable has been written the task is restarted in place, and we wait for Nomad to report the restart before checking the task.
+- ^nomad.variable_path^ (String) The path of the Nomad variable. Defaults to ^nomad/jobs/<job>/<group>/<task>^ of the transport allocation, which the task can read without any additional policy
+`),
+	}
+}
+
+func (c *vaultStartNomadConfig) attrs() map[string]tftypes.Type {
+	return map[string]tftypes.Type{
+		"variable_path": c.VariablePath.TFType(),
+	}
+}
+
+func (c *vaultStartNomadConfig) optionalAttrs() map[string]struct{} {
+	return map[string]struct{}{
+		"variable_path": {},
+	}
+}
+
+// Terraform5Type is the tftypes.Type.
+func (c *vaultStartNomadConfig) Terraform5Type() tftypes.Type {
+	return tftypes.Object{
+		AttributeTypes:     c.attrs(),
+		OptionalAttributes: c.optionalAttrs(),
+	}
+}
+
+// Terraform5Value is the tftypes.Value.
+func (c *vaultStartNomadConfig) Terraform5Value() tftypes.Value {
+	typ := tftypes.Object{
+		AttributeTypes: c.attrs(),
+	}
+
+	if c.Null {
+		return tftypes.NewValue(typ, nil)
+	}
+
+	if c.Unknown {
+		return tftypes.NewValue(typ, tftypes.UnknownValue)
+	}
+
+	return tftypes.NewValue(typ, map[string]tftypes.Value{
+		"variable_path": c.VariablePath.TFValue(),
+	})
+}
+
+// FromTerraform5Value unmarshals the value to the struct.
+func (c *vaultStartNomadConfig) FromTerraform5Value(val tftypes.Value) error {
+	if val.IsNull() {
+		c.Null = true
+		c.Unknown = false
+
+		return nil
+	}
+
+	if !val.IsKnown() {
+		c.Null = false
+		c.Unknown = true
+
+		return nil
+	}
+
+	c.Null = false
+	c.Unknown = false
+
+	_, err := mapAttributesTo(val, map[string]any{
+		"variable_path": c.VariablePath,
+	})
+
+	return err
+}
+
+// startVaultNomad writes the vault configuration into a Nomad variable, restarts the vault task,
+// and waits for the task to be running.
+func (s *vaultStartStateV1) startVaultNomad(ctx context.Context, transport it.Transport) error {
+	n, ok := transport.(*tnomad.Transport)
+	if !ok {
+		return errors.New("failed to start vault: type mismatch between transport")
+	}
+
+	hclConfig, envVars, err := s.renderConfig()
+	if err != nil {
+		return err
+	}
+
+	if license, ok := s.License.Get(); ok {
+		envVars["VAULT_LICENSE"] = license
+	}
+
+	config, err := hclConfig.BuildHCL()
+	if err != nil {
+		return fmt.Errorf("failed to create the vault HCL configuration, due to: %w", err)
+	}
+
+	alloc, err := n.Client.GetAllocation(n.AllocationID)
+	if err != nil {
+		return fmt.Errorf("failed to start vault, due to: %w", err)
+	}
+
+	varPath := path.Join("nomad/jobs", alloc.JobID, alloc.TaskGroup, n.TaskName)
+	if p, ok := s.Nomad.VariablePath.Get(); ok {
+		varPath = p
+	}
+
+	// Get the time that the task was last restarted before we update the variable so that we can
+	// tell when it has been restarted.
+	lastRestart := nomadTaskLastRestart(alloc, n.TaskName)
+
+	items := map[string]string{"vault.hcl": config}
+	maps.Copy(items, envVars)
+
+	err = n.Client.SetVariable(ctx, nomad.SetVariableRequest{
+		Namespace: alloc.Namespace,
+		Path:      varPath,
+		Items:     items,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write the vault configuration, due to: %w", err)
+	}
+
+	err = n.Client.RestartTask(ctx, nomad.RestartTaskRequest{
+		AllocationID: alloc.ID,
+		Task:         n.TaskName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restart vault, due to: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	// Make sure that we don't check the task before it has been restarted.
+	err = waitForNomadTaskRestart(timeoutCtx, n.Client, alloc.ID, n.TaskName, lastRestart, 2*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to start vault, due to: %w", err)
+	}
+
+	return s.waitForVault(timeoutCtx, transport, "nomad task", nil,
+		vault.CheckStateNomadTaskIsRunning(n.TaskName),
+		vault.CheckStateSealStateIsKnown(),
+	)
+}
+
+// nomadTaskLastRestart returns the time that the task in the allocation was last restarted.
+func nomadTaskLastRestart(alloc *api.Allocation, task string) time.Time {
+	if alloc == nil {
+		return time.Time{}
+	}
+
+	ts, ok := alloc.TaskStates[task]
+	if !ok || ts == nil {
+		return time.Time{}
+	}
+
+	return ts.LastRestart
+}
+
+// waitForNomadTaskRestart waits until the task in the allocation has been restarted after the
+// previous restart time. We compare the restart times that Nomad reports rather than our own
+// clock as they might not be in sync.
+func waitForNomadTaskRestart(
+	ctx context.Context,
+	client nomad.Client,
+	allocID string,
+	task string,
+	previous time.Time,
+	interval time.Duration,
+) error {
+	retrier, err := retry.NewRetrier(
+		retry.WithIntervalFunc(retry.IntervalDuration(interval)),
+		retry.WithRetrierFunc(func(ctx context.Context) (any, error) {
+			alloc, err := client.GetAllocationInfo(allocID)
+			if err != nil {
+				return nil, err
+			}
+
+			if !nomadTaskLastRestart(alloc, task).After(previous) {
+				return nil, fmt.Errorf("nomad task %s in allocation %s has not been restarted", task, allocID)
+			}
+
+			return nil, nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = retry.Retry(ctx, retrier)
+	if err != nil {
+		return fmt.Errorf("waiting for the vault task to restart: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/nomad"
+)
+
+// testNomadAllocClient is a nomad client that returns allocations in order.
+type testNomadAllocClient struct {
+	nomad.Client
+
+	mu     sync.Mutex
+	calls  int
+	allocs []*api.Allocation
+}
+
+func (c *testNomadAllocClient) GetAllocationInfo(allocID string) (*api.Allocation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	alloc := c.allocs[min(c.calls, len(c.allocs)-1)]
+	c.calls++
+
+	return alloc, nil
+}
+
+func testNomadVaultAlloc(lastRestart time.Time) *api.Allocation {
+	return &api.Allocation{
+		ID: "4a212111-7d45-6ad4-0ad8-abe2d8661248",
+		TaskStates: map[string]*api.TaskState{
+			"vault": {State: "running", LastRestart: lastRestart},
+		},
+	}
+}
+
+// TestWaitForNomadTaskRestart tests that we wait for the task to report a newer restart.
+func TestWaitForNomadTaskRestart(t *testing.T) {
+	t.Parallel()
+
+	previous := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := &testNomadAllocClient{allocs: []*api.Allocation{
+		testNomadVaultAlloc(previous),
+		testNomadVaultAlloc(previous),
+		testNomadVaultAlloc(previous.Add(time.Second)),
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, waitForNomadTaskRestart(ctx, client, "4a212111", "vault", previous, time.Millisecond))
+	require.Equal(t, 3, client.calls)
+}
+
+// TestWaitForNomadTaskRestartTimeout tests that we don't accept the task before it has restarted.
+func TestWaitForNomadTaskRestartTimeout(t *testing.T) {
+	t.Parallel()
+
+	previous := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := &testNomadAllocClient{allocs: []*api.Allocation{testNomadVaultAlloc(previous)}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := waitForNomadTaskRestart(ctx, client, "4a212111", "vault", previous, time.Millisecond)
+	require.ErrorContains(t, err, "has not been restarted")
+}
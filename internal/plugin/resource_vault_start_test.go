@@ -78,6 +78,70 @@ func TestVaultStartConfigOptionalAttrs(t *testing.T) {
 	_ = vaultCfg.Seals.Terraform5Value()
 }
 
+// TestVaultStartKubernetesConfigValidate tests validation of the kubernetes attribute.
+func TestVaultStartKubernetesConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		kind       string
+		name       string
+		selectors  []string
+		shouldFail bool
+	}{
+		"defaults": {
+			"", "", nil, true,
+		},
+		"pod": {
+			"pod", "", []string{"app.kubernetes.io/name=vault"}, false,
+		},
+		"pod-without-selectors": {
+			"pod", "", nil, true,
+		},
+		"pod-with-empty-selectors": {
+			"pod", "", []string{}, true,
+		},
+		"statefulset": {
+			"statefulset", "vault", []string{"app.kubernetes.io/name=vault"}, false,
+		},
+		"statefulset-without-name": {
+			"statefulset", "", []string{"app.kubernetes.io/name=vault"}, true,
+		},
+		"without-kind": {
+			"", "vault", []string{"app.kubernetes.io/name=vault"}, true,
+		},
+		"unsupported": {
+			"daemonset", "vault", []string{"app.kubernetes.io/name=vault"}, true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newVaultStartKubernetesConfig()
+			cfg.Null = false
+			if test.kind != "" {
+				cfg.WorkloadKind.Set(test.kind)
+			}
+			if test.name != "" {
+				cfg.WorkloadName.Set(test.name)
+			}
+			if test.selectors != nil {
+				cfg.LabelSelectors.SetStrings(test.selectors)
+			}
+
+			// Make sure we can create a dynamic value with optional attrs
+			val := cfg.Terraform5Value()
+			_, err := tfprotov6.NewDynamicValue(val.Type(), val)
+			require.NoError(t, err)
+
+			if test.shouldFail {
+				require.Error(t, cfg.Validate())
+			} else {
+				require.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
+
 // TestAccResourceVaultStart tests the vault_start resource.
 func TestAccResourceVaultStart(t *testing.T) {
 	t.Parallel()
@@ -178,6 +242,13 @@ func TestAccResourceVaultStart(t *testing.T) {
       {{end -}}
 
     }
+
+    {{if not .TLS.Null -}}
+    tls = {
+      ca_cert = "{{.TLS.CACert.Value}}"
+    }
+    {{end -}}
+
     {{ renderTransport .Transport }}
   }`))
 
@@ -234,6 +305,8 @@ func TestAccResourceVaultStart(t *testing.T) {
 	vaultStart.License.Set("some-license-key")
 	vaultStart.SystemdUnitName.Set("vaulter")
 	vaultStart.Username.Set("vault")
+	vaultStart.TLS.Null = false
+	vaultStart.TLS.CACert.Set("/etc/vault.d/tls/ca.pem")
 	ssh := newEmbeddedTransportSSH()
 	ssh.User.Set("ubuntu")
 	ssh.Host.Set("localhost")
@@ -274,6 +347,7 @@ func TestAccResourceVaultStart(t *testing.T) {
 			resource.TestCheckResourceAttr("enos_vault_start.foo", "config.license", "some-license-key"),
 			resource.TestCheckResourceAttr("enos_vault_start.foo", "config.unit_name", "vault"),
 			resource.TestCheckResourceAttr("enos_vault_start.foo", "config.username", "vaulter"),
+			resource.TestCheckResourceAttr("enos_vault_start.foo", "tls.ca_cert", "/etc/vault.d/tls/ca.pem"),
 			resource.TestCheckResourceAttr("enos_vault_start.foo", "transport.ssh.user", "ubuntu"),
 			resource.TestCheckResourceAttr("enos_vault_start.foo", "transport.ssh.host", "localhost"),
 		),
@@ -503,3 +577,70 @@ func Test_sealAttrsToEnvVars(t *testing.T) {
 		})
 	}
 }
+
+// TestVaultConfigRenderFile tests rendering the vault config as HCL.
+func TestVaultConfigRenderFile(t *testing.T) {
+	t.Parallel()
+
+	vaultCfg := newVaultConfig()
+	vaultCfg.APIAddr.Set("http://127.0.0.1:8200")
+	vaultCfg.ClusterAddr.Set("http://127.0.0.1:8201")
+	vaultCfg.ClusterName.Set("avaultcluster")
+	vaultCfg.UI.Set(true)
+	vaultCfg.LogLevel.Set("debug")
+	vaultCfg.Listener.Set(newVaultListenerConfigSet(
+		"tcp", map[string]map[string]any{
+			"attributes": {
+				"address":     "0.0.0.0:8200",
+				"tls_disable": "true",
+			},
+			"telemetry": {
+				"unauthenticated_metrics_access": true,
+			},
+		},
+	))
+	vaultCfg.Storage.Set(newVaultStorageConfigSet("raft", map[string]any{
+		"node_id": "node_1",
+		"path":    "/opt/raft/data",
+	}, map[string]any{"auto_join": &tfString{Val: "provider=aws tag_key=Type tag_value=vault"}}))
+	require.NoError(t, vaultCfg.Seals.SetSeals(map[string]*vaultConfigBlockSet{
+		"primary": newVaultConfigBlockSet("awskms", map[string]any{
+			"kms_key_id": "some-key-id",
+		}, "config", "seal"),
+	}))
+	vaultCfg.Telemetry.Object.Set(map[string]any{
+		"usage_gauge_period": "30m",
+	})
+
+	hcl, _, err := vaultCfg.Render("file")
+	require.NoError(t, err)
+	config, err := hcl.BuildHCL()
+	require.NoError(t, err)
+	require.Equal(t, `api_addr     = "http://127.0.0.1:8200"
+cluster_addr = "http://127.0.0.1:8201"
+ui           = true
+log_level    = "debug"
+listener "tcp" {
+  address     = "0.0.0.0:8200"
+  tls_disable = "true"
+  telemetry {
+    unauthenticated_metrics_access = true
+  }
+}
+seal "awskms" {
+  kms_key_id = "some-key-id"
+  name       = "primary"
+  priority   = "1"
+}
+storage "raft" {
+  node_id = "node_1"
+  path    = "/opt/raft/data"
+  retry_join {
+    auto_join = "provider=aws tag_key=Type tag_value=vault"
+  }
+}
+telemetry {
+  usage_gauge_period = "30m"
+}
+`, config)
+}
@@ -36,6 +36,7 @@ type vaultUnsealStateV1 struct {
 	SealType        *tfString
 	UnsealKeys      *tfStringSlice
 	Status          *tfNum
+	TLS             *tlsClientConfig
 	Transport       *embeddedTransportV1
 
 	failureHandlers
@@ -64,6 +65,7 @@ func newVaultUnsealStateV1() *vaultUnsealStateV1 {
 		SystemdUnitName: newTfString(),
 		SealType:        newTfString(),
 		UnsealKeys:      newTfStringSlice(),
+		TLS:             newTLSClientConfig(),
 		Transport:       transport,
 		failureHandlers: fh,
 	}
@@ -240,6 +242,7 @@ resource simply performs a seal status check loop to ensure the cluster reaches
 					Type:        tftypes.String,
 					Optional:    true,
 				},
+				s.TLS.SchemaAttribute("Vault"),
 				s.Transport.SchemaAttributeTransport(supportsSSH | supportsK8s | supportsNomad),
 				{
 					Name:            "unseal_keys",
@@ -278,7 +281,7 @@ func (s *vaultUnsealStateV1) Validate(ctx context.Context) error {
 		return ValidationError("you must provide the Vault address", "vault_addr")
 	}
 
-	return nil
+	return s.TLS.Validate()
 }
 
 // FromTerraform5Value is a callback to unmarshal from the tftypes.Vault with As().
@@ -295,6 +298,11 @@ func (s *vaultUnsealStateV1) FromTerraform5Value(val tftypes.Value) error {
 		return err
 	}
 
+	err = s.TLS.FromTerraform5Value(vals["tls"])
+	if err != nil {
+		return err
+	}
+
 	if !vals["transport"].IsKnown() {
 		return nil
 	}
@@ -308,6 +316,7 @@ func (s *vaultUnsealStateV1) Terraform5Type() tftypes.Type {
 		"id":          s.ID.TFType(),
 		"bin_path":    s.BinPath.TFType(),
 		"seal_type":   s.SealType.TFType(),
+		"tls":         s.TLS.Terraform5Type(),
 		"transport":   s.Transport.Terraform5Type(),
 		"unit_name":   s.SystemdUnitName.TFType(),
 		"unseal_keys": s.UnsealKeys.TFType(),
@@ -321,6 +330,7 @@ func (s *vaultUnsealStateV1) Terraform5Value() tftypes.Value {
 		"id":          s.ID.TFValue(),
 		"bin_path":    s.BinPath.TFValue(),
 		"seal_type":   s.SealType.TFValue(),
+		"tls":         s.TLS.Terraform5Value(),
 		"transport":   s.Transport.Terraform5Value(),
 		"unit_name":   s.SystemdUnitName.TFValue(),
 		"unseal_keys": s.UnsealKeys.TFValue(),
@@ -372,6 +382,8 @@ func (s *vaultUnsealStateV1) buildUnsealRequest() *vault.UnsealRequest {
 		stateOpts = append(stateOpts, vault.WithStateRequestVaultAddr(vaultAddr))
 	}
 
+	stateOpts = append(stateOpts, vault.WithStateRequestTLSConfig(s.TLS.TLSConfig()))
+
 	unitName := "vault"
 	if unit, ok := s.SystemdUnitName.Get(); ok {
 		unitName = unit
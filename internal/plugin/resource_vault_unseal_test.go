@@ -44,6 +44,13 @@ func TestAccResourceVaultUnseal(t *testing.T) {
 		]
 		{{end}}
 
+		{{if not .TLS.Null}}
+		tls = {
+			ca_cert     = "{{.TLS.CACert.Value}}"
+			server_name = "{{.TLS.ServerName.Value}}"
+		}
+		{{end}}
+
 		{{ renderTransport .Transport }}
 	}`))
 
@@ -55,6 +62,9 @@ func TestAccResourceVaultUnseal(t *testing.T) {
 	vaultUnseal.VaultAddr.Set("http://127.0.0.1:8200")
 	vaultUnseal.SystemdUnitName.Set("vaulter")
 	vaultUnseal.SealType.Set("shamir")
+	vaultUnseal.TLS.Null = false
+	vaultUnseal.TLS.CACert.Set("/etc/vault.d/tls/ca.pem")
+	vaultUnseal.TLS.ServerName.Set("vault.example.com")
 	vaultUnseal.UnsealKeys.SetStrings([]string{"bar"})
 	ssh := newEmbeddedTransportSSH()
 	ssh.User.Set("ubuntu")
@@ -73,6 +83,8 @@ func TestAccResourceVaultUnseal(t *testing.T) {
 			resource.TestMatchResourceAttr("enos_vault_unseal.foo", "unit_name", regexp.MustCompile(`^vaulter$`)),
 			resource.TestMatchResourceAttr("enos_vault_unseal.foo", "seal_type", regexp.MustCompile(`^shamisr$`)),
 			resource.TestMatchResourceAttr("enos_vault_unseal.foo", "unseal_keys[0]", regexp.MustCompile("^bar$")),
+			resource.TestMatchResourceAttr("enos_vault_unseal.foo", "tls.ca_cert", regexp.MustCompile(`^/etc/vault.d/tls/ca.pem$`)),
+			resource.TestMatchResourceAttr("enos_vault_unseal.foo", "tls.server_name", regexp.MustCompile(`^vault.example.com$`)),
 			resource.TestMatchResourceAttr("enos_vault_unseal.foo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
 			resource.TestMatchResourceAttr("enos_vault_unseal.foo", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
 		),
@@ -0,0 +1,383 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/vault"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+)
+
+type vaultVerifyVersion struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*vaultVerifyVersion)(nil)
+
+type vaultVerifyVersionStateV1 struct {
+	ID        *tfString
+	BinPath   *tfString
+	VaultAddr *tfString
+	Token     *tfString
+	Version   *tfString
+	Edition   *tfString
+	Revision  *tfString
+	BuildDate *tfString
+	Transport *embeddedTransportV1
+
+	failureHandlers
+}
+
+var _ state.State = (*vaultVerifyVersionStateV1)(nil)
+
+func newVaultVerifyVersion() *vaultVerifyVersion {
+	return &vaultVerifyVersion{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newVaultVerifyVersionStateV1() *vaultVerifyVersionStateV1 {
+	transport := newEmbeddedTransport()
+	fh := failureHandlers{
+		TransportDebugFailureHandler(transport),
+		GetApplicationLogsFailureHandler(transport, []string{"vault"}),
+	}
+
+	return &vaultVerifyVersionStateV1{
+		ID:              newTfString(),
+		BinPath:         newTfString(),
+		VaultAddr:       newTfString(),
+		Token:           newTfString(),
+		Version:         newTfString(),
+		Edition:         newTfString(),
+		Revision:        newTfString(),
+		BuildDate:       newTfString(),
+		Transport:       transport,
+		failureHandlers: fh,
+	}
+}
+
+func (r *vaultVerifyVersion) Name() string {
+	return "enos_vault_verify_version"
+}
+
+func (r *vaultVerifyVersion) Schema() *tfprotov6.Schema {
+	return newVaultVerifyVersionStateV1().Schema()
+}
+
+func (r *vaultVerifyVersion) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *vaultVerifyVersion) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *vaultVerifyVersion) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newVaultVerifyVersionStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *vaultVerifyVersion) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newVaultVerifyVersionStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *vaultVerifyVersion) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newVaultVerifyVersionStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *vaultVerifyVersion) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newVaultVerifyVersionStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *vaultVerifyVersion) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newVaultVerifyVersionStateV1()
+	proposedState := newVaultVerifyVersionStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *vaultVerifyVersion) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newVaultVerifyVersionStateV1()
+	plannedState := newVaultVerifyVersionStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do on delete
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	// Only verify if we're creating the resource or something has changed.
+	if _, ok := priorState.ID.Get(); ok && reflect.DeepEqual(plannedState, priorState) {
+		return
+	}
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	err = plannedState.Verify(ctx, client)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Vault Verify Version Error", err))
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *vaultVerifyVersionStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_vault_verify_version^ resource verifies that the installed Vault binary and the running
+Vault service report the expected version and build metadata. It is used to catch packaging
+regressions.
+
+The binary is verified with ^vault version^ and the running service with the ^/v1/sys/health^ API.
+If a ^token^ is provided the most recently installed version in the ^/v1/sys/version-history^ API
+is also verified. Only the attributes that are set are verified.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        s.ID.TFType(),
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "bin_path",
+					Type:        s.BinPath.TFType(),
+					Required:    true,
+					Description: "The fully qualified path to the vault binary",
+				},
+				{
+					Name:            "build_date",
+					Type:            s.BuildDate.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The expected RFC3339 build date, e.g. `2023-11-06T11:33:28Z`",
+				},
+				{
+					Name:            "edition",
+					Type:            s.Edition.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The expected edition, e.g. `ce`, `ent`, `ent.hsm`, `ent.fips1402`, `ent.hsm.fips1402`",
+				},
+				{
+					Name:        "revision",
+					Type:        s.Revision.TFType(),
+					Optional:    true,
+					Description: "The expected git revision SHA. Abbreviated SHAs of at least seven characters are allowed",
+				},
+				{
+					Name:        "token",
+					Type:        s.Token.TFType(),
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The Vault token to use when reading the version history",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH | supportsK8s | supportsNomad),
+				{
+					Name:            "vault_addr",
+					Type:            s.VaultAddr.TFType(),
+					Required:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The configured `api_addr` from `enos_vault_start`",
+				},
+				{
+					Name:            "version",
+					Type:            s.Version.TFType(),
+					Required:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The expected version, e.g. `1.15.2` or `1.15.2-rc1`. Use `edition` to verify the build metadata",
+				},
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *vaultVerifyVersionStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if _, ok := s.BinPath.Get(); !ok {
+		return ValidationError("you must provide the Vault bin path", "bin_path")
+	}
+
+	if _, ok := s.VaultAddr.Get(); !ok {
+		return ValidationError("you must provide the Vault address", "vault_addr")
+	}
+
+	if err := s.buildVerifyVersionRequest().ExpectedVersion.Validate(); err != nil {
+		return ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Vault with As().
+func (s *vaultVerifyVersionStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":         s.ID,
+		"bin_path":   s.BinPath,
+		"build_date": s.BuildDate,
+		"edition":    s.Edition,
+		"revision":   s.Revision,
+		"token":      s.Token,
+		"vault_addr": s.VaultAddr,
+		"version":    s.Version,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *vaultVerifyVersionStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":         s.ID.TFType(),
+		"bin_path":   s.BinPath.TFType(),
+		"build_date": s.BuildDate.TFType(),
+		"edition":    s.Edition.TFType(),
+		"revision":   s.Revision.TFType(),
+		"token":      s.Token.TFType(),
+		"transport":  s.Transport.Terraform5Type(),
+		"vault_addr": s.VaultAddr.TFType(),
+		"version":    s.Version.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *vaultVerifyVersionStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":         s.ID.TFValue(),
+		"bin_path":   s.BinPath.TFValue(),
+		"build_date": s.BuildDate.TFValue(),
+		"edition":    s.Edition.TFValue(),
+		"revision":   s.Revision.TFValue(),
+		"token":      s.Token.TFValue(),
+		"transport":  s.Transport.Terraform5Value(),
+		"vault_addr": s.VaultAddr.TFValue(),
+		"version":    s.Version.TFValue(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *vaultVerifyVersionStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+// Verify verifies the vault version.
+func (s *vaultVerifyVersionStateV1) Verify(ctx context.Context, client it.Transport) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	err := vault.VerifyVersion(ctx, client, s.buildVerifyVersionRequest())
+	if err != nil {
+		return fmt.Errorf("failed to verify the vault version: %w", err)
+	}
+
+	return nil
+}
+
+func (s *vaultVerifyVersionStateV1) buildVerifyVersionRequest() *vault.VerifyVersionRequest {
+	opts := []vault.VerifyVersionRequestOpt{
+		vault.WithVerifyVersionRequestFlightControlUseHomeDir(),
+		vault.WithVerifyVersionRequestBinPath(s.BinPath.Value()),
+		vault.WithVerifyVersionRequestVaultAddr(s.VaultAddr.Value()),
+	}
+
+	if token, ok := s.Token.Get(); ok {
+		opts = append(opts, vault.WithVerifyVersionRequestToken(token))
+	}
+
+	if version, ok := s.Version.Get(); ok {
+		opts = append(opts, vault.WithVerifyVersionRequestVersion(version))
+	}
+
+	if edition, ok := s.Edition.Get(); ok {
+		opts = append(opts, vault.WithVerifyVersionRequestEdition(edition))
+	}
+
+	if revision, ok := s.Revision.Get(); ok {
+		opts = append(opts, vault.WithVerifyVersionRequestRevision(revision))
+	}
+
+	if date, ok := s.BuildDate.Get(); ok {
+		opts = append(opts, vault.WithVerifyVersionRequestBuildDate(date))
+	}
+
+	return vault.NewVerifyVersionRequest(opts...)
+}
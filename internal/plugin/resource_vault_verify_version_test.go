@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccResourceVaultVerifyVersion tests the vault_verify_version resource.
+func TestAccResourceVaultVerifyVersion(t *testing.T) {
+	t.Parallel()
+	cfg := template.Must(template.New("enos_vault_verify_version").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_vault_verify_version" "{{.ID.Value}}" {
+		{{if .BinPath.Value}}
+		bin_path = "{{.BinPath.Value}}"
+		{{end}}
+
+		{{if .VaultAddr.Value}}
+		vault_addr = "{{.VaultAddr.Value}}"
+		{{end}}
+
+		{{if .Token.Value}}
+		token = "{{.Token.Value}}"
+		{{end}}
+
+		{{if .Version.Value}}
+		version = "{{.Version.Value}}"
+		{{end}}
+
+		{{if .Edition.Value}}
+		edition = "{{.Edition.Value}}"
+		{{end}}
+
+		{{if .Revision.Value}}
+		revision = "{{.Revision.Value}}"
+		{{end}}
+
+		{{if .BuildDate.Value}}
+		build_date = "{{.BuildDate.Value}}"
+		{{end}}
+
+		{{ renderTransport .Transport }}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	vaultVerifyVersion := newVaultVerifyVersionStateV1()
+	vaultVerifyVersion.ID.Set("foo")
+	vaultVerifyVersion.BinPath.Set("/opt/vault/bin/vault")
+	vaultVerifyVersion.VaultAddr.Set("http://127.0.0.1:8200")
+	vaultVerifyVersion.Token.Set("root")
+	vaultVerifyVersion.Version.Set("1.15.2")
+	vaultVerifyVersion.Edition.Set("ent.hsm")
+	vaultVerifyVersion.Revision.Set("cf1b5cafa047bc8e4a3f93444fcb4011593b92cb")
+	vaultVerifyVersion.BuildDate.Set("2023-11-06T11:33:28Z")
+	ssh := newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	ssh.Host.Set("localhost")
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, vaultVerifyVersion.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		vaultVerifyVersion,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_vault_verify_version.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_version.foo", "bin_path", regexp.MustCompile(`^/opt/vault/bin/vault$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_version.foo", "vault_addr", regexp.MustCompile(`^http://127.0.0.1:8200$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_version.foo", "version", regexp.MustCompile(`^1.15.2$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_version.foo", "edition", regexp.MustCompile(`^ent.hsm$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_version.foo", "revision", regexp.MustCompile(`^cf1b5cafa047bc8e4a3f93444fcb4011593b92cb$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_version.foo", "build_date", regexp.MustCompile(`^2023-11-06T11:33:28Z$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_version.foo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_version.foo", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
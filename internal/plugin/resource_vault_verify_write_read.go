@@ -0,0 +1,467 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/vault"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	istrings "github.com/hashicorp-forge/terraform-provider-enos/internal/strings"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+)
+
+type vaultVerifyWriteRead struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*vaultVerifyWriteRead)(nil)
+
+type vaultVerifyWriteReadStateV1 struct {
+	ID        *tfString
+	BinPath   *tfString
+	VaultAddr *tfString
+	Token     *tfString
+	Mount     *tfString
+	KVVersion *tfNum
+	Path      *tfString
+	Data      *tfStringMap
+	Mode      *tfString
+	Nodes     *tfStringSlice
+	Transport *embeddedTransportV1
+
+	failureHandlers
+}
+
+var _ state.State = (*vaultVerifyWriteReadStateV1)(nil)
+
+const (
+	vaultVerifyWriteReadModeWrite  = "write"
+	vaultVerifyWriteReadModeVerify = "verify"
+)
+
+func newVaultVerifyWriteRead() *vaultVerifyWriteRead {
+	return &vaultVerifyWriteRead{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newVaultVerifyWriteReadStateV1() *vaultVerifyWriteReadStateV1 {
+	transport := newEmbeddedTransport()
+	fh := failureHandlers{
+		TransportDebugFailureHandler(transport),
+		GetApplicationLogsFailureHandler(transport, []string{"vault"}),
+	}
+
+	return &vaultVerifyWriteReadStateV1{
+		ID:              newTfString(),
+		BinPath:         newTfString(),
+		VaultAddr:       newTfString(),
+		Token:           newTfString(),
+		Mount:           newTfString(),
+		KVVersion:       newTfNum(),
+		Path:            newTfString(),
+		Data:            newTfStringMap(),
+		Mode:            newTfString(),
+		Nodes:           newTfStringSlice(),
+		Transport:       transport,
+		failureHandlers: fh,
+	}
+}
+
+func (r *vaultVerifyWriteRead) Name() string {
+	return "enos_vault_verify_write_read"
+}
+
+func (r *vaultVerifyWriteRead) Schema() *tfprotov6.Schema {
+	return newVaultVerifyWriteReadStateV1().Schema()
+}
+
+func (r *vaultVerifyWriteRead) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *vaultVerifyWriteRead) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *vaultVerifyWriteRead) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newVaultVerifyWriteReadStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *vaultVerifyWriteRead) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newVaultVerifyWriteReadStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *vaultVerifyWriteRead) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newVaultVerifyWriteReadStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *vaultVerifyWriteRead) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newVaultVerifyWriteReadStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *vaultVerifyWriteRead) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newVaultVerifyWriteReadStateV1()
+	proposedState := newVaultVerifyWriteReadStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *vaultVerifyWriteRead) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newVaultVerifyWriteReadStateV1()
+	plannedState := newVaultVerifyWriteReadStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do on delete
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	// Only write or verify if we're creating the resource or something has changed.
+	if _, ok := priorState.ID.Get(); ok && reflect.DeepEqual(plannedState, priorState) {
+		return
+	}
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	if plannedState.Mode.Value() == vaultVerifyWriteReadModeVerify {
+		err = plannedState.Verify(ctx, client)
+		if err != nil {
+			res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Vault Verify Read Error", err))
+		}
+
+		return
+	}
+
+	err = plannedState.Write(ctx, client)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Vault Verify Write Error", err))
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *vaultVerifyWriteReadStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_vault_verify_write_read^ resource is used to verify that data written to Vault is durable.
+The common pattern is to write data before an operation, e.g. an upgrade, and then read it back
+afterwards.
+
+When ^mode^ is ^write^ the resource enables a KV secrets engine at ^mount^ if one does not already
+exist and writes the ^data^ to ^path^. When ^mode^ is ^verify^ the resource reads the secret from
+every address in ^nodes^ and ensures that each node has the expected ^data^. The read is retried for
+a time to allow data to replicate to performance standbys and replication secondaries. Any
+mismatches are reported for each node.
+
+The Vault CLI is executed on the target host of the ^transport^ so every node address must be
+reachable from it. Replication secondaries will likely require their own ^token^, in which case
+use a separate verify resource for each cluster.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        s.ID.TFType(),
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "bin_path",
+					Type:        s.BinPath.TFType(),
+					Required:    true,
+					Description: "The fully qualified path to the vault binary",
+				},
+				{
+					Name:            "data",
+					Type:            s.Data.TFType(),
+					Required:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "A map of keys and values to write to, or verify in, the secret at `path`",
+				},
+				{
+					Name:            "kv_version",
+					Type:            s.KVVersion.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The version of the KV secrets engine to enable. Must be `1` or `2`. Defaults to `2`",
+				},
+				{
+					Name:            "mode",
+					Type:            s.Mode.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "Either `write` or `verify`. Defaults to `write`",
+				},
+				{
+					Name:            "mount",
+					Type:            s.Mount.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The path of the KV secrets engine mount. Defaults to `secret`",
+				},
+				{
+					Name:            "nodes",
+					Type:            s.Nodes.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "A list of Vault node addresses to verify the data on when `mode` is `verify`. Defaults to `vault_addr`",
+				},
+				{
+					Name:        "path",
+					Type:        s.Path.TFType(),
+					Required:    true,
+					Description: "The path of the secret inside of the KV secrets engine mount",
+				},
+				{
+					Name:        "token",
+					Type:        s.Token.TFType(),
+					Required:    true,
+					Sensitive:   true,
+					Description: "The Vault token to use",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH | supportsK8s | supportsNomad),
+				{
+					Name:            "vault_addr",
+					Type:            s.VaultAddr.TFType(),
+					Required:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The Vault address to write data to, e.g. the configured `api_addr` from `enos_vault_start`",
+				},
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *vaultVerifyWriteReadStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if _, ok := s.BinPath.Get(); !ok {
+		return ValidationError("you must provide the Vault bin path", "bin_path")
+	}
+
+	if _, ok := s.VaultAddr.Get(); !ok {
+		return ValidationError("you must provide the Vault address", "vault_addr")
+	}
+
+	if path, ok := s.Path.Get(); ok && path == "" {
+		return ValidationError("you must provide a secret path", "path")
+	}
+
+	if mode, ok := s.Mode.Get(); ok {
+		switch mode {
+		case vaultVerifyWriteReadModeWrite, vaultVerifyWriteReadModeVerify:
+		default:
+			return ValidationError(
+				fmt.Sprintf("unsupported mode: %s, must be one of: %s, %s",
+					mode, vaultVerifyWriteReadModeWrite, vaultVerifyWriteReadModeVerify,
+				),
+				"mode",
+			)
+		}
+	}
+
+	if version, ok := s.KVVersion.Get(); ok && version != 1 && version != 2 {
+		return ValidationError(
+			fmt.Sprintf("unsupported kv_version: %d, must be 1 or 2", version), "kv_version",
+		)
+	}
+
+	return nil
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Vault with As().
+func (s *vaultVerifyWriteReadStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":         s.ID,
+		"bin_path":   s.BinPath,
+		"data":       s.Data,
+		"kv_version": s.KVVersion,
+		"mode":       s.Mode,
+		"mount":      s.Mount,
+		"nodes":      s.Nodes,
+		"path":       s.Path,
+		"token":      s.Token,
+		"vault_addr": s.VaultAddr,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *vaultVerifyWriteReadStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":         s.ID.TFType(),
+		"bin_path":   s.BinPath.TFType(),
+		"data":       s.Data.TFType(),
+		"kv_version": s.KVVersion.TFType(),
+		"mode":       s.Mode.TFType(),
+		"mount":      s.Mount.TFType(),
+		"nodes":      s.Nodes.TFType(),
+		"path":       s.Path.TFType(),
+		"token":      s.Token.TFType(),
+		"transport":  s.Transport.Terraform5Type(),
+		"vault_addr": s.VaultAddr.TFType(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *vaultVerifyWriteReadStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":         s.ID.TFValue(),
+		"bin_path":   s.BinPath.TFValue(),
+		"data":       s.Data.TFValue(),
+		"kv_version": s.KVVersion.TFValue(),
+		"mode":       s.Mode.TFValue(),
+		"mount":      s.Mount.TFValue(),
+		"nodes":      s.Nodes.TFValue(),
+		"path":       s.Path.TFValue(),
+		"token":      s.Token.TFValue(),
+		"transport":  s.Transport.Terraform5Value(),
+		"vault_addr": s.VaultAddr.TFValue(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *vaultVerifyWriteReadStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+// Write enables the KV secrets engine if necessary and writes the data.
+func (s *vaultVerifyWriteReadStateV1) Write(ctx context.Context, client it.Transport) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	req := s.buildKVRequest(s.VaultAddr.Value())
+
+	if err := vault.EnableKVMount(ctx, client, req); err != nil {
+		return err
+	}
+
+	return vault.PutKV(ctx, client, req)
+}
+
+// Verify reads the data back from every node and reports any mismatches for
+// each node.
+func (s *vaultVerifyWriteReadStateV1) Verify(ctx context.Context, client it.Transport) error {
+	nodes, ok := s.Nodes.GetStrings()
+	if !ok || len(nodes) < 1 {
+		nodes = []string{s.VaultAddr.Value()}
+	}
+
+	var err error
+	for _, node := range nodes {
+		nodeCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		nodeErr := vault.WaitForKV(nodeCtx, client, s.buildKVRequest(node))
+		cancel()
+		if nodeErr != nil {
+			err = errors.Join(err, fmt.Errorf("node %s:\n%s", node, istrings.Indent("  ", nodeErr.Error())))
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("verifying data on vault nodes: %w", err)
+	}
+
+	return nil
+}
+
+func (s *vaultVerifyWriteReadStateV1) buildKVRequest(vaultAddr string) *vault.KVRequest {
+	opts := []vault.KVRequestOpt{
+		vault.WithKVRequestBinPath(s.BinPath.Value()),
+		vault.WithKVRequestVaultAddr(vaultAddr),
+		vault.WithKVRequestToken(s.Token.Value()),
+		vault.WithKVRequestPath(s.Path.Value()),
+	}
+
+	if mount, ok := s.Mount.Get(); ok {
+		opts = append(opts, vault.WithKVRequestMount(mount))
+	}
+
+	if version, ok := s.KVVersion.Get(); ok {
+		opts = append(opts, vault.WithKVRequestVersion(version))
+	}
+
+	if data, ok := s.Data.GetStrings(); ok {
+		opts = append(opts, vault.WithKVRequestData(data))
+	}
+
+	return vault.NewKVRequest(opts...)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccResourceVaultVerifyWriteRead tests the vault_verify_write_read resource.
+func TestAccResourceVaultVerifyWriteRead(t *testing.T) {
+	t.Parallel()
+	cfg := template.Must(template.New("enos_vault_verify_write_read").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_vault_verify_write_read" "{{.ID.Value}}" {
+		{{if .BinPath.Value}}
+		bin_path = "{{.BinPath.Value}}"
+		{{end}}
+
+		{{if .VaultAddr.Value}}
+		vault_addr = "{{.VaultAddr.Value}}"
+		{{end}}
+
+		{{if .Token.Value}}
+		token = "{{.Token.Value}}"
+		{{end}}
+
+		{{if .Mount.Value}}
+		mount = "{{.Mount.Value}}"
+		{{end}}
+
+		{{if .KVVersion.Value}}
+		kv_version = {{.KVVersion.Value}}
+		{{end}}
+
+		{{if .Path.Value}}
+		path = "{{.Path.Value}}"
+		{{end}}
+
+		{{if .Mode.Value}}
+		mode = "{{.Mode.Value}}"
+		{{end}}
+
+		{{if .Data.StringValue}}
+		data = {
+		{{range $key, $val := .Data.StringValue}}
+			"{{$key}}" = "{{$val}}"
+		{{end}}
+		}
+		{{end}}
+
+		{{if .Nodes.StringValue}}
+		nodes = [
+		{{range .Nodes.StringValue}}
+			"{{.}}",
+		{{end}}
+		]
+		{{end}}
+
+		{{ renderTransport .Transport }}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	ssh := newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	ssh.Host.Set("localhost")
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh.PrivateKey.Set(privateKey)
+
+	write := newVaultVerifyWriteReadStateV1()
+	write.ID.Set("write")
+	write.BinPath.Set("/opt/vault/bin/vault")
+	write.VaultAddr.Set("http://127.0.0.1:8200")
+	write.Token.Set("root")
+	write.Mount.Set("enos")
+	write.KVVersion.Set(1)
+	write.Path.Set("upgrade")
+	write.Mode.Set("write")
+	write.Data.SetStrings(map[string]string{"foo": "bar"})
+	require.NoError(t, write.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"write fields are loaded correctly",
+		write,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.write", "id", regexp.MustCompile(`^write$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.write", "bin_path", regexp.MustCompile(`^/opt/vault/bin/vault$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.write", "vault_addr", regexp.MustCompile(`^http://127.0.0.1:8200$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.write", "mount", regexp.MustCompile(`^enos$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.write", "kv_version", regexp.MustCompile(`^1$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.write", "path", regexp.MustCompile(`^upgrade$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.write", "mode", regexp.MustCompile(`^write$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.write", "data.foo", regexp.MustCompile(`^bar$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.write", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.write", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
+		),
+		false,
+	})
+
+	verify := newVaultVerifyWriteReadStateV1()
+	verify.ID.Set("verify")
+	verify.BinPath.Set("/opt/vault/bin/vault")
+	verify.VaultAddr.Set("http://127.0.0.1:8200")
+	verify.Token.Set("root")
+	verify.Path.Set("upgrade")
+	verify.Mode.Set("verify")
+	verify.Data.SetStrings(map[string]string{"foo": "bar"})
+	verify.Nodes.SetStrings([]string{"http://10.0.0.1:8200", "http://10.0.0.2:8200"})
+	require.NoError(t, verify.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"verify fields are loaded correctly",
+		verify,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.verify", "mode", regexp.MustCompile(`^verify$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.verify", "nodes[0]", regexp.MustCompile(`^http://10.0.0.1:8200$`)),
+			resource.TestMatchResourceAttr("enos_vault_verify_write_read.verify", "nodes[1]", regexp.MustCompile(`^http://10.0.0.2:8200$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
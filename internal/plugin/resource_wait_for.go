@@ -0,0 +1,514 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/diags"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	resource "github.com/hashicorp-forge/terraform-provider-enos/internal/server/resourcerouter"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/server/state"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+)
+
+type waitFor struct {
+	providerConfig *config
+	mu             sync.Mutex
+}
+
+var _ resource.Resource = (*waitFor)(nil)
+
+type waitForStateV1 struct {
+	ID              *tfString
+	TCP             *tfStringSlice
+	HTTP            *tfStringSlice
+	HTTPStatusCodes *tfStringSlice
+	Files           *tfStringSlice
+	Processes       *tfStringSlice
+	Interval        *tfString
+	Timeout         *tfString
+	ProbeTimeout    *tfString
+	CACert          *tfString
+	TLSSkipVerify   *tfBool
+	Transport       *embeddedTransportV1
+
+	failureHandlers
+}
+
+var _ state.State = (*waitForStateV1)(nil)
+
+func newWaitFor() *waitFor {
+	return &waitFor{
+		providerConfig: newProviderConfig(),
+		mu:             sync.Mutex{},
+	}
+}
+
+func newWaitForStateV1() *waitForStateV1 {
+	transport := newEmbeddedTransport()
+	fh := failureHandlers{
+		TransportDebugFailureHandler(transport),
+	}
+
+	return &waitForStateV1{
+		ID:              newTfString(),
+		TCP:             newTfStringSlice(),
+		HTTP:            newTfStringSlice(),
+		HTTPStatusCodes: newTfStringSlice(),
+		Files:           newTfStringSlice(),
+		Processes:       newTfStringSlice(),
+		Interval:        newTfString(),
+		Timeout:         newTfString(),
+		ProbeTimeout:    newTfString(),
+		CACert:          newTfString(),
+		TLSSkipVerify:   newTfBool(),
+		Transport:       transport,
+		failureHandlers: fh,
+	}
+}
+
+func (r *waitFor) Name() string {
+	return "enos_wait_for"
+}
+
+func (r *waitFor) Schema() *tfprotov6.Schema {
+	return newWaitForStateV1().Schema()
+}
+
+func (r *waitFor) SetProviderConfig(meta tftypes.Value) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.FromTerraform5Value(meta)
+}
+
+func (r *waitFor) GetProviderConfig() (*config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerConfig.Copy()
+}
+
+// ValidateResourceConfig is the request Terraform sends when it wants to
+// validate the resource's configuration.
+func (r *waitFor) ValidateResourceConfig(ctx context.Context, req tfprotov6.ValidateResourceConfigRequest, res *tfprotov6.ValidateResourceConfigResponse) {
+	newState := newWaitForStateV1()
+
+	transportUtil.ValidateResourceConfig(ctx, newState, req, res)
+}
+
+// UpgradeResourceState is the request Terraform sends when it wants to
+// upgrade the resource's state to a new version.
+func (r *waitFor) UpgradeResourceState(ctx context.Context, req tfprotov6.UpgradeResourceStateRequest, res *tfprotov6.UpgradeResourceStateResponse) {
+	newState := newWaitForStateV1()
+
+	transportUtil.UpgradeResourceState(ctx, newState, req, res)
+}
+
+// ReadResource is the request Terraform sends when it wants to get the latest
+// state for the resource.
+func (r *waitFor) ReadResource(ctx context.Context, req tfprotov6.ReadResourceRequest, res *tfprotov6.ReadResourceResponse) {
+	newState := newWaitForStateV1()
+
+	transportUtil.ReadResource(ctx, newState, req, res)
+}
+
+// ImportResourceState is the request Terraform sends when it wants the provider
+// to import one or more resources specified by an ID.
+func (r *waitFor) ImportResourceState(ctx context.Context, req tfprotov6.ImportResourceStateRequest, res *tfprotov6.ImportResourceStateResponse) {
+	newState := newWaitForStateV1()
+
+	transportUtil.ImportResourceState(ctx, newState, req, res)
+}
+
+// PlanResourceChange is the request Terraform sends when it is generating a plan
+// for the resource and wants the provider's input on what the planned state should be.
+func (r *waitFor) PlanResourceChange(ctx context.Context, req resource.PlanResourceChangeRequest, res *resource.PlanResourceChangeResponse) {
+	priorState := newWaitForStateV1()
+	proposedState := newWaitForStateV1()
+	res.PlannedState = proposedState
+
+	transportUtil.PlanUnmarshalVerifyAndBuildTransport(ctx, priorState, proposedState, r, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+	if _, ok := priorState.ID.Get(); !ok {
+		proposedState.ID.Unknown = true
+	}
+}
+
+// ApplyResourceChange is the request Terraform sends when it needs to apply a
+// planned set of changes to the resource.
+func (r *waitFor) ApplyResourceChange(ctx context.Context, req resource.ApplyResourceChangeRequest, res *resource.ApplyResourceChangeResponse) {
+	priorState := newWaitForStateV1()
+	plannedState := newWaitForStateV1()
+	res.NewState = plannedState
+
+	transportUtil.ApplyUnmarshalState(ctx, priorState, plannedState, req, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	if req.IsDelete() {
+		// nothing to do on delete
+		return
+	}
+
+	transport := transportUtil.ApplyValidatePlannedAndBuildTransport(ctx, plannedState, r, res)
+	if diags.HasErrors(res.Diagnostics) {
+		return
+	}
+
+	plannedState.ID.Set("static")
+
+	// Only wait if we're creating the resource or something has changed.
+	if _, ok := priorState.ID.Get(); ok && reflect.DeepEqual(plannedState, priorState) {
+		return
+	}
+
+	client, err := transport.Client(ctx)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Transport Error", err))
+		return
+	}
+	defer client.Close()
+
+	err = plannedState.Wait(ctx, client)
+	if err != nil {
+		res.Diagnostics = append(res.Diagnostics, diags.ErrToDiagnostic("Wait For Error", err))
+	}
+}
+
+// Schema is the file states Terraform schema.
+func (s *waitForStateV1) Schema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Description: docCaretToBacktick(`
+The ^enos_wait_for^ resource waits on a target host until all of the configured probes succeed.
+It is useful for waiting until a service is listening on a port, responds to HTTP health checks,
+has written a file, or has started a process before moving on to the next step of a scenario.
+
+The probes are executed on the target with ^enos-flight-control wait-for^ so they do not rely on
+utilities like ^curl^ or ^nc^ being installed. Each probe is attempted every ^interval^ until it
+succeeds or the ^timeout^ is reached. Probes that have succeeded are not attempted again.
+`),
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        s.ID.TFType(),
+					Computed:    true,
+					Description: resourceStaticIDDescription,
+				},
+				{
+					Name:        "ca_cert",
+					Type:        s.CACert.TFType(),
+					Optional:    true,
+					Description: "A PEM encoded CA certificate bundle used to verify HTTP server certificates",
+				},
+				{
+					Name:        "files",
+					Type:        s.Files.TFType(),
+					Optional:    true,
+					Description: "Paths to files that must exist",
+				},
+				{
+					Name:        "http",
+					Type:        s.HTTP.TFType(),
+					Optional:    true,
+					Description: "URLs that must return an expected HTTP status code",
+				},
+				{
+					Name:            "http_status_codes",
+					Type:            s.HTTPStatusCodes.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The expected HTTP status codes, e.g. `[200, 429]`. Defaults to any 2xx status code",
+				},
+				{
+					Name:            "interval",
+					Type:            s.Interval.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The time to wait between probe attempts. Defaults to `1s`",
+				},
+				{
+					Name:            "probe_timeout",
+					Type:            s.ProbeTimeout.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The maximum allowable time of each probe attempt. Defaults to `5s`",
+				},
+				{
+					Name:        "processes",
+					Type:        s.Processes.TFType(),
+					Optional:    true,
+					Description: "Names of processes that must be running. A process matches if either its command name or the base name of its executable matches",
+				},
+				{
+					Name:            "tcp",
+					Type:            s.TCP.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "Addresses that must accept TCP connections, e.g. `127.0.0.1:8200`",
+				},
+				{
+					Name:            "timeout",
+					Type:            s.Timeout.TFType(),
+					Optional:        true,
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Description:     "The maximum allowable time to wait for all probes to succeed. Defaults to `5m`",
+				},
+				{
+					Name:        "tls_skip_verify",
+					Type:        s.TLSSkipVerify.TFType(),
+					Optional:    true,
+					Description: "Do not verify HTTP server certificates",
+				},
+				s.Transport.SchemaAttributeTransport(supportsSSH | supportsK8s | supportsNomad),
+			},
+		},
+	}
+}
+
+// Validate validates the configuration.
+func (s *waitForStateV1) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	probes := 0
+	for _, attr := range []*tfStringSlice{s.TCP, s.HTTP, s.Files, s.Processes} {
+		if attr.Unknown || (!attr.Null && !attr.FullyKnown()) {
+			// We can't validate unknown values so assume they'll contain a probe
+			probes++
+			continue
+		}
+
+		vals, ok := attr.GetStrings()
+		if ok {
+			probes += len(vals)
+		}
+	}
+	if probes == 0 {
+		return ValidationError("at least one tcp, http, files or processes probe is required")
+	}
+
+	if addrs, ok := s.TCP.GetStrings(); ok {
+		for _, addr := range addrs {
+			if _, _, err := net.SplitHostPort(addr); err != nil {
+				return ValidationError(fmt.Sprintf("invalid TCP address %q: %s", addr, err), "tcp")
+			}
+		}
+	}
+
+	if urls, ok := s.HTTP.GetStrings(); ok {
+		for _, u := range urls {
+			parsed, err := url.Parse(u)
+			if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+				return ValidationError(fmt.Sprintf("invalid HTTP URL %q", u), "http")
+			}
+		}
+	}
+
+	if _, err := s.httpStatusCodes(); err != nil {
+		return ValidationError(err.Error(), "http_status_codes")
+	}
+
+	for attr, dur := range map[string]*tfString{
+		"interval":      s.Interval,
+		"timeout":       s.Timeout,
+		"probe_timeout": s.ProbeTimeout,
+	} {
+		if val, ok := dur.Get(); ok {
+			parsed, err := time.ParseDuration(val)
+			if err != nil || parsed <= 0 {
+				return ValidationError(fmt.Sprintf("invalid %s %q, it must be a positive duration", attr, val), attr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// FromTerraform5Value is a callback to unmarshal from the tftypes.Vault with As().
+func (s *waitForStateV1) FromTerraform5Value(val tftypes.Value) error {
+	vals, err := mapAttributesTo(val, map[string]any{
+		"id":                s.ID,
+		"ca_cert":           s.CACert,
+		"files":             s.Files,
+		"http":              s.HTTP,
+		"http_status_codes": s.HTTPStatusCodes,
+		"interval":          s.Interval,
+		"probe_timeout":     s.ProbeTimeout,
+		"processes":         s.Processes,
+		"tcp":               s.TCP,
+		"timeout":           s.Timeout,
+		"tls_skip_verify":   s.TLSSkipVerify,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !vals["transport"].IsKnown() {
+		return nil
+	}
+
+	return s.Transport.FromTerraform5Value(vals["transport"])
+}
+
+// Terraform5Type is the file state tftypes.Type.
+func (s *waitForStateV1) Terraform5Type() tftypes.Type {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":                s.ID.TFType(),
+		"ca_cert":           s.CACert.TFType(),
+		"files":             s.Files.TFType(),
+		"http":              s.HTTP.TFType(),
+		"http_status_codes": s.HTTPStatusCodes.TFType(),
+		"interval":          s.Interval.TFType(),
+		"probe_timeout":     s.ProbeTimeout.TFType(),
+		"processes":         s.Processes.TFType(),
+		"tcp":               s.TCP.TFType(),
+		"timeout":           s.Timeout.TFType(),
+		"tls_skip_verify":   s.TLSSkipVerify.TFType(),
+		"transport":         s.Transport.Terraform5Type(),
+	}}
+}
+
+// Terraform5Value is the file state tftypes.Value.
+func (s *waitForStateV1) Terraform5Value() tftypes.Value {
+	return tftypes.NewValue(s.Terraform5Type(), map[string]tftypes.Value{
+		"id":                s.ID.TFValue(),
+		"ca_cert":           s.CACert.TFValue(),
+		"files":             s.Files.TFValue(),
+		"http":              s.HTTP.TFValue(),
+		"http_status_codes": s.HTTPStatusCodes.TFValue(),
+		"interval":          s.Interval.TFValue(),
+		"probe_timeout":     s.ProbeTimeout.TFValue(),
+		"processes":         s.Processes.TFValue(),
+		"tcp":               s.TCP.TFValue(),
+		"timeout":           s.Timeout.TFValue(),
+		"tls_skip_verify":   s.TLSSkipVerify.TFValue(),
+		"transport":         s.Transport.Terraform5Value(),
+	})
+}
+
+// EmbeddedTransport returns a pointer the resources embedded transport.
+func (s *waitForStateV1) EmbeddedTransport() *embeddedTransportV1 {
+	return s.Transport
+}
+
+// Wait installs enos-flight-control on the target and waits for the probes to succeed.
+func (s *waitForStateV1) Wait(ctx context.Context, client it.Transport) error {
+	req, err := s.buildWaitForRequest()
+	if err != nil {
+		return err
+	}
+
+	timeout, err := time.ParseDuration(req.Timeout)
+	if err != nil {
+		return err
+	}
+
+	// Allow some extra time to install enos-flight-control
+	ctx, cancel := context.WithTimeout(ctx, timeout+time.Minute)
+	defer cancel()
+
+	fcRes, err := remoteflight.InstallFlightControl(ctx, client, remoteflight.NewInstallFlightControlRequest(
+		remoteflight.WithInstallFlightControlRequestUseHomeDir(),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to install enos-flight-control binary: %w", err)
+	}
+	req.FlightControlPath = fcRes.Path
+
+	_, err = remoteflight.WaitFor(ctx, client, req)
+	if err != nil {
+		return fmt.Errorf("failed waiting for probes to succeed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *waitForStateV1) buildWaitForRequest() (*remoteflight.WaitForRequest, error) {
+	opts := []remoteflight.WaitForOpt{}
+
+	if addrs, ok := s.TCP.GetStrings(); ok {
+		opts = append(opts, remoteflight.WithWaitForRequestTCP(addrs...))
+	}
+
+	if urls, ok := s.HTTP.GetStrings(); ok {
+		opts = append(opts, remoteflight.WithWaitForRequestHTTP(urls...))
+	}
+
+	codes, err := s.httpStatusCodes()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, remoteflight.WithWaitForRequestHTTPStatuses(codes...))
+
+	if files, ok := s.Files.GetStrings(); ok {
+		opts = append(opts, remoteflight.WithWaitForRequestFiles(files...))
+	}
+
+	if procs, ok := s.Processes.GetStrings(); ok {
+		opts = append(opts, remoteflight.WithWaitForRequestProcesses(procs...))
+	}
+
+	if interval, ok := s.Interval.Get(); ok {
+		opts = append(opts, remoteflight.WithWaitForRequestInterval(interval))
+	}
+
+	if timeout, ok := s.Timeout.Get(); ok {
+		opts = append(opts, remoteflight.WithWaitForRequestTimeout(timeout))
+	}
+
+	if timeout, ok := s.ProbeTimeout.Get(); ok {
+		opts = append(opts, remoteflight.WithWaitForRequestProbeTimeout(timeout))
+	}
+
+	if cert, ok := s.CACert.Get(); ok {
+		opts = append(opts, remoteflight.WithWaitForRequestCACert(cert))
+	}
+
+	if skip, ok := s.TLSSkipVerify.Get(); ok {
+		opts = append(opts, remoteflight.WithWaitForRequestTLSSkipVerify(skip))
+	}
+
+	return remoteflight.NewWaitForRequest(opts...), nil
+}
+
+// httpStatusCodes returns the expected HTTP status codes.
+func (s *waitForStateV1) httpStatusCodes() ([]int, error) {
+	codes := []int{}
+
+	statuses, ok := s.HTTPStatusCodes.GetStrings()
+	if !ok {
+		return codes, nil
+	}
+
+	for _, status := range statuses {
+		code, err := strconv.Atoi(status)
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("invalid HTTP status code %q", status)
+		}
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
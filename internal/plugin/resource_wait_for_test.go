@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccResourceWaitFor tests the wait_for resource.
+func TestAccResourceWaitFor(t *testing.T) {
+	t.Parallel()
+	cfg := template.Must(template.New("enos_wait_for").
+		Funcs(transportRenderFunc).
+		Parse(`resource "enos_wait_for" "{{.ID.Value}}" {
+		{{if .TCP.StringValue}}
+		tcp = [{{range .TCP.StringValue}}"{{.}}",{{end}}]
+		{{end}}
+
+		{{if .HTTP.StringValue}}
+		http = [{{range .HTTP.StringValue}}"{{.}}",{{end}}]
+		{{end}}
+
+		{{if .HTTPStatusCodes.StringValue}}
+		http_status_codes = [{{range .HTTPStatusCodes.StringValue}}{{.}},{{end}}]
+		{{end}}
+
+		{{if .Files.StringValue}}
+		files = [{{range .Files.StringValue}}"{{.}}",{{end}}]
+		{{end}}
+
+		{{if .Processes.StringValue}}
+		processes = [{{range .Processes.StringValue}}"{{.}}",{{end}}]
+		{{end}}
+
+		{{if .Interval.Value}}
+		interval = "{{.Interval.Value}}"
+		{{end}}
+
+		{{if .Timeout.Value}}
+		timeout = "{{.Timeout.Value}}"
+		{{end}}
+
+		{{if .ProbeTimeout.Value}}
+		probe_timeout = "{{.ProbeTimeout.Value}}"
+		{{end}}
+
+		{{if .TLSSkipVerify.Value}}
+		tls_skip_verify = {{.TLSSkipVerify.Value}}
+		{{end}}
+
+		{{ renderTransport .Transport }}
+	}`))
+
+	cases := []testAccResourceTemplate{}
+
+	waitFor := newWaitForStateV1()
+	waitFor.ID.Set("foo")
+	waitFor.TCP.SetStrings([]string{"127.0.0.1:8200"})
+	waitFor.HTTP.SetStrings([]string{"https://127.0.0.1:8200/v1/sys/health"})
+	waitFor.HTTPStatusCodes.SetStrings([]string{"200", "429"})
+	waitFor.Files.SetStrings([]string{"/etc/vault.d/vault.hcl"})
+	waitFor.Processes.SetStrings([]string{"vault"})
+	waitFor.Interval.Set("2s")
+	waitFor.Timeout.Set("2m")
+	waitFor.ProbeTimeout.Set("10s")
+	waitFor.TLSSkipVerify.Set(true)
+	ssh := newEmbeddedTransportSSH()
+	ssh.User.Set("ubuntu")
+	ssh.Host.Set("localhost")
+	privateKey, err := readTestFile("../fixtures/ssh.pem")
+	require.NoError(t, err)
+	ssh.PrivateKey.Set(privateKey)
+	require.NoError(t, waitFor.Transport.SetTransportState(ssh))
+	cases = append(cases, testAccResourceTemplate{
+		"all fields are loaded correctly",
+		waitFor,
+		resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr("enos_wait_for.foo", "id", regexp.MustCompile(`^foo$`)),
+			resource.TestMatchResourceAttr("enos_wait_for.foo", "tcp.0", regexp.MustCompile(`^127.0.0.1:8200$`)),
+			resource.TestMatchResourceAttr("enos_wait_for.foo", "http.0", regexp.MustCompile(`^https://127.0.0.1:8200/v1/sys/health$`)),
+			resource.TestMatchResourceAttr("enos_wait_for.foo", "http_status_codes.1", regexp.MustCompile(`^429$`)),
+			resource.TestMatchResourceAttr("enos_wait_for.foo", "files.0", regexp.MustCompile(`^/etc/vault.d/vault.hcl$`)),
+			resource.TestMatchResourceAttr("enos_wait_for.foo", "processes.0", regexp.MustCompile(`^vault$`)),
+			resource.TestMatchResourceAttr("enos_wait_for.foo", "interval", regexp.MustCompile(`^2s$`)),
+			resource.TestMatchResourceAttr("enos_wait_for.foo", "timeout", regexp.MustCompile(`^2m$`)),
+			resource.TestMatchResourceAttr("enos_wait_for.foo", "probe_timeout", regexp.MustCompile(`^10s$`)),
+			resource.TestMatchResourceAttr("enos_wait_for.foo", "tls_skip_verify", regexp.MustCompile(`^true$`)),
+			resource.TestMatchResourceAttr("enos_wait_for.foo", "transport.ssh.user", regexp.MustCompile(`^ubuntu$`)),
+			resource.TestMatchResourceAttr("enos_wait_for.foo", "transport.ssh.host", regexp.MustCompile(`^localhost$`)),
+		),
+		false,
+	})
+
+	//nolint:paralleltest// because our resource handles it
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := cfg.Execute(&buf, test.state)
+			if err != nil {
+				t.Fatalf("error executing test template: %s", err.Error())
+			}
+
+			step := resource.TestStep{
+				Config: buf.String(),
+				Check:  test.check,
+			}
+
+			if !test.apply {
+				step.PlanOnly = true
+				step.ExpectNonEmptyPlan = true
+			}
+
+			resource.ParallelTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testProviders(t),
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
+
+// TestWaitForValidate tests validation of the wait_for resource configuration.
+func TestWaitForValidate(t *testing.T) {
+	t.Parallel()
+
+	for desc, test := range map[string]struct {
+		setup   func(*waitForStateV1)
+		wantErr bool
+	}{
+		"valid": {
+			setup: func(s *waitForStateV1) {
+				s.TCP.SetStrings([]string{"127.0.0.1:8200"})
+				s.HTTPStatusCodes.SetStrings([]string{"200"})
+				s.Timeout.Set("1m")
+			},
+		},
+		"no probes": {
+			setup:   func(s *waitForStateV1) {},
+			wantErr: true,
+		},
+		"unknown probes": {
+			setup: func(s *waitForStateV1) {
+				s.Files.Unknown = true
+			},
+		},
+		"invalid tcp address": {
+			setup: func(s *waitForStateV1) {
+				s.TCP.SetStrings([]string{"127.0.0.1"})
+			},
+			wantErr: true,
+		},
+		"invalid http url": {
+			setup: func(s *waitForStateV1) {
+				s.HTTP.SetStrings([]string{"/v1/sys/health"})
+			},
+			wantErr: true,
+		},
+		"invalid http status code": {
+			setup: func(s *waitForStateV1) {
+				s.HTTP.SetStrings([]string{"http://127.0.0.1:8200/v1/sys/health"})
+				s.HTTPStatusCodes.SetStrings([]string{"600"})
+			},
+			wantErr: true,
+		},
+		"invalid interval": {
+			setup: func(s *waitForStateV1) {
+				s.Processes.SetStrings([]string{"vault"})
+				s.Interval.Set("often")
+			},
+			wantErr: true,
+		},
+		"negative timeout": {
+			setup: func(s *waitForStateV1) {
+				s.Processes.SetStrings([]string{"vault"})
+				s.Timeout.Set("-1m")
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			s := newWaitForStateV1()
+			test.setup(s)
+			err := s.Validate(context.Background())
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
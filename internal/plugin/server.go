@@ -41,27 +41,45 @@ func defaultDataSources() []dr.DataSource {
 	return []dr.DataSource{
 		newArtifactoryItem(),
 		newEnvironment(),
+		newConsulState(),
 		newKubernetesPods(),
+		newNomadAllocations(),
 	}
 }
 
 // defaultResources returns a slice of all the resources that the provider supports.
 func defaultResources() []rr.Resource {
 	return []rr.Resource{
+		newArtifactoryUpload(),
 		newBoundaryInit(),
+		newBoundaryMigrate(),
 		newBoundaryStart(),
+		newBoundaryVerifySession(),
+		newBoundaryWorker(),
 		newBundleInstall(),
+		newConsulACL(),
+		newConsulKV(),
+		newConsulSnapshot(),
 		newConsulStart(),
+		newConsulVerifyCluster(),
+		newConsulVerifyVersion(),
 		newFile(),
 		newHostInfo(),
 		newLocalKindCluster(),
 		newLocalKindLoadImage(),
 		newLocalExec(),
+		newNomadJob(),
+		newNomadStart(),
 		newRemoteExec(),
+		newSystemdService(),
 		newUser(),
 		newVaultInit(),
+		newVaultLicense(),
 		newVaultStart(),
 		newVaultUnseal(),
+		newVaultVerifyVersion(),
+		newVaultVerifyWriteRead(),
+		newWaitFor(),
 	}
 }
 
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+)
+
+// tlsClientConfig is the client TLS configuration that resources use when
+// talking to a TLS enabled listener on the target.
+type tlsClientConfig struct {
+	CACert     *tfString
+	ClientCert *tfString
+	ClientKey  *tfString
+	ServerName *tfString
+	SkipVerify *tfBool
+
+	Unknown bool
+	Null    bool
+}
+
+func newTLSClientConfig() *tlsClientConfig {
+	return &tlsClientConfig{
+		CACert:     newTfString(),
+		ClientCert: newTfString(),
+		ClientKey:  newTfString(),
+		ServerName: newTfString(),
+		SkipVerify: newTfBool(),
+		Unknown:    false,
+		Null:       true,
+	}
+}
+
+// SchemaAttribute returns the schema attribute for the tls attribute. The
+// product is the name of the service whose listener we're talking to.
+func (c *tlsClientConfig) SchemaAttribute(product string) *tfprotov6.SchemaAttribute {
+	return &tfprotov6.SchemaAttribute{
+		Name:            "tls",
+		Type:            c.Terraform5Type(),
+		Optional:        true,
+		DescriptionKind: tfprotov6.StringKindMarkdown,
+		Description: docCaretToBacktick(fmt.Sprintf(`
+The client TLS configuration to use when talking to a TLS enabled %[1]s listener. All paths are paths on the target machine.
+- ^tls.ca_cert^ (String) The path to the CA certificate used to verify the %[1]s server certificate
+- ^tls.client_cert^ (String) The path to the client certificate. Requires ^tls.client_key^
+- ^tls.client_key^ (String) The path to the client key. Requires ^tls.client_cert^
+- ^tls.server_name^ (String) The server name to use when verifying the %[1]s server certificate
+- ^tls.skip_verify^ (Bool) Do not verify the %[1]s server certificate
+`, product)),
+	}
+}
+
+// Validate validates the TLS configuration.
+func (c *tlsClientConfig) Validate() error {
+	if c == nil || c.Null || c.Unknown {
+		return nil
+	}
+
+	if err := c.TLSConfig().Validate(); err != nil {
+		return ValidationError(err.Error(), "tls")
+	}
+
+	return nil
+}
+
+// TLSConfig returns the configuration as a remoteflight TLS configuration.
+func (c *tlsClientConfig) TLSConfig() remoteflight.TLSConfig {
+	if c == nil || c.Null || c.Unknown {
+		return remoteflight.TLSConfig{}
+	}
+
+	skipVerify, _ := c.SkipVerify.Get()
+
+	return remoteflight.TLSConfig{
+		CACert:     c.CACert.Value(),
+		ClientCert: c.ClientCert.Value(),
+		ClientKey:  c.ClientKey.Value(),
+		ServerName: c.ServerName.Value(),
+		SkipVerify: skipVerify,
+	}
+}
+
+func (c *tlsClientConfig) attrs() map[string]tftypes.Type {
+	return map[string]tftypes.Type{
+		"ca_cert":     c.CACert.TFType(),
+		"client_cert": c.ClientCert.TFType(),
+		"client_key":  c.ClientKey.TFType(),
+		"server_name": c.ServerName.TFType(),
+		"skip_verify": c.SkipVerify.TFType(),
+	}
+}
+
+func (c *tlsClientConfig) optionalAttrs() map[string]struct{} {
+	return map[string]struct{}{
+		"ca_cert":     {},
+		"client_cert": {},
+		"client_key":  {},
+		"server_name": {},
+		"skip_verify": {},
+	}
+}
+
+// Terraform5Type is the tftypes.Type.
+func (c *tlsClientConfig) Terraform5Type() tftypes.Type {
+	return tftypes.Object{
+		AttributeTypes:     c.attrs(),
+		OptionalAttributes: c.optionalAttrs(),
+	}
+}
+
+// Terraform5Value is the tftypes.Value.
+func (c *tlsClientConfig) Terraform5Value() tftypes.Value {
+	typ := tftypes.Object{
+		AttributeTypes: c.attrs(),
+	}
+
+	if c.Null {
+		return tftypes.NewValue(typ, nil)
+	}
+
+	if c.Unknown {
+		return tftypes.NewValue(typ, tftypes.UnknownValue)
+	}
+
+	return tftypes.NewValue(typ, map[string]tftypes.Value{
+		"ca_cert":     c.CACert.TFValue(),
+		"client_cert": c.ClientCert.TFValue(),
+		"client_key":  c.ClientKey.TFValue(),
+		"server_name": c.ServerName.TFValue(),
+		"skip_verify": c.SkipVerify.TFValue(),
+	})
+}
+
+// FromTerraform5Value unmarshals the value to the struct.
+func (c *tlsClientConfig) FromTerraform5Value(val tftypes.Value) error {
+	if val.IsNull() {
+		c.Null = true
+		c.Unknown = false
+
+		return nil
+	}
+
+	if !val.IsKnown() {
+		c.Null = false
+		c.Unknown = true
+
+		return nil
+	}
+
+	c.Null = false
+	c.Unknown = false
+
+	_, err := mapAttributesTo(val, map[string]any{
+		"ca_cert":     c.CACert,
+		"client_cert": c.ClientCert,
+		"client_key":  c.ClientKey,
+		"server_name": c.ServerName,
+		"skip_verify": c.SkipVerify,
+	})
+
+	return err
+}
@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package releases
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	// VersionLatest resolves to the latest non-prerelease version of the release edition.
+	VersionLatest = "latest"
+	// VersionLatestPrerelease resolves to the latest version of the release edition, including
+	// prereleases.
+	VersionLatestPrerelease = "latest-prerelease"
+)
+
+// ErrNoMatchingVersion means that we could not find a version in the releases index that
+// satisfies the requested version.
+var ErrNoMatchingVersion = errors.New("no release version matches the requested version")
+
+// Index is a product index from the releases endpoint.
+type Index struct {
+	Name     string                   `json:"name"`
+	Versions map[string]*IndexVersion `json:"versions"`
+}
+
+// IndexVersion is a version of a product in the releases index.
+type IndexVersion struct {
+	Name    string        `json:"name"`
+	Version string        `json:"version"`
+	Builds  []*IndexBuild `json:"builds"`
+}
+
+// IndexBuild is a build of a product version in the releases index.
+type IndexBuild struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+}
+
+// WithReleaseGetIndex sets the function used to get the releases index.
+func WithReleaseGetIndex(get func(*Release) (*Index, error)) ReleaseOpt {
+	return func(re *Release) *Release {
+		re.GetIndex = get
+
+		return re
+	}
+}
+
+// IndexURL returns the fully qualified URL to the product releases index.
+func (r *Release) IndexURL() string {
+	baseURL := r.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return fmt.Sprintf("%s/%s/index.json", baseURL, r.Product)
+}
+
+// IsVersionLiteral returns whether or not the version is a literal version that does not need to
+// be resolved with the releases index.
+func IsVersionLiteral(ver string) bool {
+	if ver == VersionLatest || ver == VersionLatestPrerelease {
+		return false
+	}
+
+	_, err := version.NewVersion(ver)
+
+	return err == nil
+}
+
+// ValidateVersion validates that the version is a literal version, "latest", "latest-prerelease",
+// or a valid version constraint.
+func ValidateVersion(ver string) error {
+	if ver == VersionLatest || ver == VersionLatestPrerelease || IsVersionLiteral(ver) {
+		return nil
+	}
+
+	_, err := version.NewConstraint(ver)
+	if err != nil {
+		return fmt.Errorf("invalid release version %q, it must be a version, %q, %q, or a version constraint: %w",
+			ver, VersionLatest, VersionLatestPrerelease, err,
+		)
+	}
+
+	return nil
+}
+
+// ResolveVersion resolves the release version. If the version is "latest", "latest-prerelease", or
+// a version constraint, we'll search the releases index for the greatest version of the release
+// edition that satisfies it and set it as the release version. Literal versions are returned
+// as-is without searching the index.
+func (r *Release) ResolveVersion() (string, error) {
+	if IsVersionLiteral(r.Version) {
+		return r.Version, nil
+	}
+
+	if err := ValidateVersion(r.Version); err != nil {
+		return "", err
+	}
+
+	if r.GetIndex == nil {
+		return "", errors.New("resolving release version: no index getter has been configured")
+	}
+
+	idx, err := r.GetIndex(r)
+	if err != nil {
+		return "", err
+	}
+
+	ver, err := r.resolveVersionFromIndex(idx)
+	if err != nil {
+		return "", err
+	}
+	r.Version = ver
+
+	return ver, nil
+}
+
+func (r *Release) resolveVersionFromIndex(idx *Index) (string, error) {
+	var constraints version.Constraints
+	if r.Version != VersionLatest && r.Version != VersionLatestPrerelease {
+		var err error
+		constraints, err = version.NewConstraint(r.Version)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var resolved *version.Version
+	for _, iv := range idx.Versions {
+		if iv == nil {
+			continue
+		}
+
+		v, err := version.NewVersion(iv.Version)
+		if err != nil {
+			// Ignore anything in the index that isn't a valid version
+			continue
+		}
+
+		if !r.editionMatches(v.Metadata()) || !r.hasBuild(iv) {
+			continue
+		}
+
+		switch {
+		case constraints != nil:
+			if !constraints.Check(v) {
+				continue
+			}
+		case r.Version == VersionLatest:
+			if v.Prerelease() != "" {
+				continue
+			}
+		}
+
+		if resolved == nil || v.GreaterThan(resolved) {
+			resolved = v
+		}
+	}
+
+	if resolved == nil {
+		return "", fmt.Errorf("%w: %s %s (%s)", ErrNoMatchingVersion, r.Product, r.Version, r.Edition)
+	}
+
+	// Return the version without the edition metadata as it is added when building the URLs.
+	ver, _, _ := strings.Cut(resolved.Original(), "+")
+
+	return strings.TrimPrefix(ver, "v"), nil
+}
+
+// editionMatches returns whether or not the version metadata matches the release edition.
+func (r *Release) editionMatches(metadata string) bool {
+	switch r.Edition {
+	case "", "ce", "oss":
+		return metadata == ""
+	default:
+		return metadata == r.Edition
+	}
+}
+
+// hasBuild returns whether or not the index version has a build for the release platform and
+// architecture. If either have not been set any build will match.
+func (r *Release) hasBuild(iv *IndexVersion) bool {
+	if r.Platform == "" || r.Arch == "" {
+		return true
+	}
+
+	for _, build := range iv.Builds {
+		if build != nil && build.OS == r.Platform && build.Arch == r.Arch {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DefaultGetIndex attempts to download the product index from the releases endpoint.
+func DefaultGetIndex(rel *Release) (*Index, error) {
+	body, err := rel.get(rel.IndexURL())
+	if err != nil {
+		return nil, fmt.Errorf("getting releases index: %w", err)
+	}
+
+	idx := &Index{}
+	err = json.Unmarshal(body, idx)
+	if err != nil {
+		return nil, fmt.Errorf("decoding releases index: %w", err)
+	}
+
+	return idx, nil
+}
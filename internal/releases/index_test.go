@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package releases
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testIndex = `{
+  "name": "vault",
+  "versions": {
+    "1.14.8": {"name": "vault", "version": "1.14.8", "builds": [{"os": "linux", "arch": "amd64"}, {"os": "linux", "arch": "arm64"}]},
+    "1.14.8+ent": {"name": "vault", "version": "1.14.8+ent", "builds": [{"os": "linux", "arch": "amd64"}, {"os": "linux", "arch": "arm64"}]},
+    "1.15.0": {"name": "vault", "version": "1.15.0", "builds": [{"os": "linux", "arch": "amd64"}, {"os": "linux", "arch": "arm64"}]},
+    "1.15.2": {"name": "vault", "version": "1.15.2", "builds": [{"os": "linux", "arch": "amd64"}, {"os": "linux", "arch": "arm64"}]},
+    "1.15.2+ent": {"name": "vault", "version": "1.15.2+ent", "builds": [{"os": "linux", "arch": "amd64"}, {"os": "linux", "arch": "arm64"}]},
+    "1.15.2+ent.hsm": {"name": "vault", "version": "1.15.2+ent.hsm", "builds": [{"os": "linux", "arch": "amd64"}]},
+    "1.15.3+ent.hsm.fips1403": {"name": "vault", "version": "1.15.3+ent.hsm.fips1403", "builds": [{"os": "linux", "arch": "amd64"}]},
+    "1.15.4+ent": {"name": "vault", "version": "1.15.4+ent", "builds": [{"os": "linux", "arch": "amd64"}]},
+    "1.16.0-rc1": {"name": "vault", "version": "1.16.0-rc1", "builds": [{"os": "linux", "arch": "amd64"}, {"os": "linux", "arch": "arm64"}]},
+    "1.16.0-rc1+ent": {"name": "vault", "version": "1.16.0-rc1+ent", "builds": [{"os": "linux", "arch": "amd64"}, {"os": "linux", "arch": "arm64"}]},
+    "not-a-version": {"name": "vault", "version": "not-a-version", "builds": []}
+  }
+}`
+
+func TestResolveVersion(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/vault/index.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testIndex))
+	}))
+	t.Cleanup(srv.Close)
+
+	for desc, test := range map[string]struct {
+		version  string
+		edition  string
+		arch     string
+		expected string
+		fails    bool
+	}{
+		"literal":                 {"1.7.0", "ce", "amd64", "1.7.0", false},
+		"latest ce":               {"latest", "ce", "amd64", "1.15.2", false},
+		"latest oss":              {"latest", "oss", "amd64", "1.15.2", false},
+		"latest ent":              {"latest", "ent", "amd64", "1.15.4", false},
+		"latest ent arm64":        {"latest", "ent", "arm64", "1.15.2", false},
+		"latest ent.hsm":          {"latest", "ent.hsm", "amd64", "1.15.2", false},
+		"latest ent.hsm.fips1403": {"latest", "ent.hsm.fips1403", "amd64", "1.15.3", false},
+		"latest-prerelease ce":    {"latest-prerelease", "ce", "amd64", "1.16.0-rc1", false},
+		"latest-prerelease ent":   {"latest-prerelease", "ent", "amd64", "1.16.0-rc1", false},
+		"pessimistic patch":       {"~> 1.15.0", "ce", "amd64", "1.15.2", false},
+		"pessimistic minor":       {"~> 1.14", "ent", "amd64", "1.15.4", false},
+		"range":                   {">= 1.14.0, < 1.15.0", "ent", "amd64", "1.14.8", false},
+		"no matching version":     {"~> 1.13.0", "ce", "amd64", "", true},
+		"no matching edition":     {"latest", "ent.fips1403", "amd64", "", true},
+		"invalid constraint":      {"not a version", "ce", "amd64", "", true},
+		"prerelease constraint":   {"~> 1.16.0-rc1", "ce", "amd64", "1.16.0-rc1", false},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			rel, err := NewRelease(
+				WithReleaseBaseURL(srv.URL),
+				WithReleaseProduct("vault"),
+				WithReleaseVersion(test.version),
+				WithReleaseEdition(test.edition),
+				WithReleasePlatform("linux"),
+				WithReleaseArch(test.arch),
+			)
+			require.NoError(t, err)
+
+			ver, err := rel.ResolveVersion()
+			if test.fails {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expected, ver)
+			require.Equal(t, test.expected, rel.Version)
+		})
+	}
+}
+
+func TestValidateVersion(t *testing.T) {
+	t.Parallel()
+
+	for _, ver := range []string{"1.15.2", "1.15.2+ent", "latest", "latest-prerelease", "~> 1.15.0", ">= 1.14, < 2.0"} {
+		require.NoError(t, ValidateVersion(ver), ver)
+	}
+
+	for _, ver := range []string{"", "newest", "~>"} {
+		require.Error(t, ValidateVersion(ver), ver)
+	}
+}
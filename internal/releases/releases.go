@@ -5,21 +5,43 @@ package releases
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/cacert"
 )
 
+// DefaultBaseURL is the default releases endpoint.
+const DefaultBaseURL = "https://releases.hashicorp.com"
+
+// ErrSHA256SumsSignatureInvalid means the SHA256SUMS signature could not be verified with any of
+// the trusted public keys.
+var ErrSHA256SumsSignatureInvalid = errors.New("SHA256SUMS signature verification failed")
+
 // Release represents a release from releases.hashicorp.com.
 type Release struct {
-	Product       string
-	Version       string
-	Edition       string
-	Platform      string
-	Arch          string
-	GetSHA256Sums func(*Release) (string, error)
+	Product          string
+	Version          string
+	Edition          string
+	Platform         string
+	Arch             string
+	BaseURL          string
+	Headers          map[string]string // Headers to add to requests made to the releases endpoint
+	CACert           string            // PEM encoded CA certificate bundle used to verify the releases endpoint
+	TrustedKeys      []string          // Armored public keys that we trust to sign the SHA256SUMS
+	GetSHA256Sums    func(*Release) (string, error)
+	GetSHA256SumsSig func(*Release) ([]byte, error)
+	GetIndex         func(*Release) (*Index, error)
+
+	signatureKeyID string
 }
 
 // ReleaseOpt is a function option for NewRelease.
@@ -28,7 +50,11 @@ type ReleaseOpt func(*Release) *Release
 // NewRelease takes optional functional options and returns a new Release.
 func NewRelease(opts ...ReleaseOpt) (*Release, error) {
 	r := &Release{
-		GetSHA256Sums: DefaultGetSHA256Sums,
+		BaseURL:          DefaultBaseURL,
+		TrustedKeys:      []string{HashiCorpPublicKey},
+		GetSHA256Sums:    DefaultGetSHA256Sums,
+		GetSHA256SumsSig: DefaultGetSHA256SumsSig,
+		GetIndex:         DefaultGetIndex,
 	}
 
 	for _, opt := range opts {
@@ -83,6 +109,43 @@ func WithReleaseArch(arch string) ReleaseOpt {
 	}
 }
 
+// WithReleaseBaseURL sets the base URL of the releases endpoint.
+func WithReleaseBaseURL(url string) ReleaseOpt {
+	return func(re *Release) *Release {
+		re.BaseURL = strings.TrimSuffix(url, "/")
+
+		return re
+	}
+}
+
+// WithReleaseHeaders sets headers that are added to requests made to the releases endpoint.
+func WithReleaseHeaders(headers map[string]string) ReleaseOpt {
+	return func(re *Release) *Release {
+		re.Headers = headers
+
+		return re
+	}
+}
+
+// WithReleaseCACert sets the PEM encoded CA certificate bundle that is used to verify the
+// releases endpoint.
+func WithReleaseCACert(pem string) ReleaseOpt {
+	return func(re *Release) *Release {
+		re.CACert = pem
+
+		return re
+	}
+}
+
+// WithReleaseTrustedKeys sets the armored public keys that we trust to sign the SHA256SUMS.
+func WithReleaseTrustedKeys(keys ...string) ReleaseOpt {
+	return func(re *Release) *Release {
+		re.TrustedKeys = keys
+
+		return re
+	}
+}
+
 // BundleURL returns the fully qualified URL to the release bundle archive.
 func (r *Release) BundleURL() string {
 	return fmt.Sprintf("%s%s", r.directoryURL(), r.bundleArtifactName())
@@ -93,6 +156,17 @@ func (r *Release) SHA256SUMSURL() string {
 	return fmt.Sprintf("%s%s_%s_SHA256SUMS", r.directoryURL(), r.Product, r.versionWithEdition())
 }
 
+// SHA256SUMSSigURL returns the fully qualified URL to the releases SHA256SUMS signature.
+func (r *Release) SHA256SUMSSigURL() string {
+	return r.SHA256SUMSURL() + ".sig"
+}
+
+// SignatureKeyID returns the ID of the key that signed the SHA256SUMS. It is only set after
+// the SHA256SUMS have been verified.
+func (r *Release) SignatureKeyID() string {
+	return r.signatureKeyID
+}
+
 func (r *Release) versionWithEdition() string {
 	switch r.Edition {
 	case "ce", "oss":
@@ -103,16 +177,21 @@ func (r *Release) versionWithEdition() string {
 }
 
 func (r *Release) directoryURL() string {
-	return fmt.Sprintf("https://releases.hashicorp.com/%s/%s/", r.Product, r.versionWithEdition())
+	baseURL := r.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return fmt.Sprintf("%s/%s/%s/", baseURL, r.Product, r.versionWithEdition())
 }
 
 func (r *Release) bundleArtifactName() string {
 	return fmt.Sprintf("%s_%s_%s_%s.zip", r.Product, r.versionWithEdition(), r.Platform, r.Arch)
 }
 
-// SHA256 parses the sums list for the release SHA256.
+// SHA256 parses the verified sums list for the release SHA256.
 func (r *Release) SHA256() (string, error) {
-	sums, err := r.GetSHA256Sums(r)
+	sums, err := r.VerifiedSHA256Sums()
 	if err != nil {
 		return "", err
 	}
@@ -133,27 +212,131 @@ func (r *Release) SHA256() (string, error) {
 	return "", fmt.Errorf("unable to locate SHA256Sum for %s", r.bundleArtifactName())
 }
 
+// VerifiedSHA256Sums gets the SHA256SUMS and verifies the detached signature with the trusted
+// public keys. If the signature cannot be verified an error is returned and the sums must not
+// be trusted.
+func (r *Release) VerifiedSHA256Sums() (string, error) {
+	sums, err := r.GetSHA256Sums(r)
+	if err != nil {
+		return "", err
+	}
+
+	if r.GetSHA256SumsSig == nil {
+		return "", fmt.Errorf("%w: no signature getter has been configured", ErrSHA256SumsSignatureInvalid)
+	}
+
+	sig, err := r.GetSHA256SumsSig(r)
+	if err != nil {
+		return "", err
+	}
+
+	keyID, err := VerifySHA256SumsSignature([]byte(sums), sig, r.TrustedKeys...)
+	if err != nil {
+		return "", err
+	}
+	r.signatureKeyID = keyID
+
+	return sums, nil
+}
+
+// VerifySHA256SumsSignature verifies the detached signature of the sums with the armored
+// public keys. It returns the ID of the key that made the signature.
+func VerifySHA256SumsSignature(sums []byte, sig []byte, keys ...string) (string, error) {
+	if len(keys) == 0 {
+		return "", fmt.Errorf("%w: no trusted public keys have been configured", ErrSHA256SumsSignatureInvalid)
+	}
+
+	keyring := openpgp.EntityList{}
+	for i, key := range keys {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+		if err != nil {
+			return "", fmt.Errorf("reading trusted public key %d: %w", i, err)
+		}
+		keyring = append(keyring, entities...)
+	}
+
+	_, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(sums), bytes.NewReader(sig), nil)
+	if err != nil {
+		return "", errors.Join(ErrSHA256SumsSignatureInvalid, err)
+	}
+
+	// Get the ID of the key that signed the sums, which might be a sub key.
+	p, err := packet.Read(bytes.NewReader(sig))
+	if err != nil {
+		return "", fmt.Errorf("reading signature packet: %w", err)
+	}
+
+	s, ok := p.(*packet.Signature)
+	if !ok || s.IssuerKeyId == nil {
+		return "", fmt.Errorf("%w: signature does not have an issuer key ID", ErrSHA256SumsSignatureInvalid)
+	}
+
+	return fmt.Sprintf("%016X", *s.IssuerKeyId), nil
+}
+
 // DefaultGetSHA256Sums attempts to download the SHA256Sums lists from the releases
 // endpoint.
 func DefaultGetSHA256Sums(rel *Release) (string, error) {
-	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, rel.SHA256SUMSURL(), nil)
+	body, err := rel.get(rel.SHA256SUMSURL())
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("getting release SHA256SUMS: %w", err)
 	}
-	resp, err := http.DefaultClient.Do(req)
+
+	return string(body), nil
+}
+
+// DefaultGetSHA256SumsSig attempts to download the SHA256SUMS signature from the releases
+// endpoint.
+func DefaultGetSHA256SumsSig(rel *Release) ([]byte, error) {
+	body, err := rel.get(rel.SHA256SUMSSigURL())
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("getting release SHA256SUMS signature: %w", err)
+	}
+
+	return body, nil
+}
+
+// HTTPClient returns the HTTP client to use for requests to the releases endpoint. If the
+// release has not been configured with a CA certificate the default client is used.
+func (r *Release) HTTPClient() (*http.Client, error) {
+	client, err := cacert.HTTPClient(r.CACert)
+	if err != nil {
+		return nil, fmt.Errorf("releases CA certificate: %w", err)
+	}
+
+	return client, nil
+}
+
+// get performs a GET request to the releases endpoint and returns the response body.
+func (r *Release) get(url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, val := range r.Headers {
+		req.Header.Set(key, val)
+	}
+
+	client, err := r.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("getting release SHA256SUMS: %s - %s", resp.Status, string(body))
+		return nil, fmt.Errorf("%s - %s", resp.Status, string(body))
 	}
 
-	return string(body), nil
+	return body, nil
 }
@@ -4,10 +4,17 @@
 package releases
 
 import (
+	"bytes"
+	"encoding/pem"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
 	"github.com/stretchr/testify/require"
 )
 
@@ -105,6 +112,8 @@ b192e31e2c0ddc001ca39b25fb4b3c99e916f41e7dc2713ab1b542ce7304bf37  vault_1.7.0_wi
 func TestSHA256(t *testing.T) {
 	t.Parallel()
 
+	signer := newTestSigner(t)
+
 	for _, test := range []struct {
 		Rel      *Release
 		Expected string
@@ -173,18 +182,21 @@ func TestSHA256(t *testing.T) {
 		rel := test.Rel
 		t.Run(fmt.Sprintf("%s_%s_%s", rel.Edition, rel.Platform, rel.Arch), func(t *testing.T) {
 			t.Parallel()
+			sums := testOSSSHASums
 			if rel.Edition == "ent" {
-				rel.GetSHA256Sums = func(*Release) (string, error) {
-					return testEntSHASums, nil
-				}
-			} else {
-				rel.GetSHA256Sums = func(*Release) (string, error) {
-					return testOSSSHASums, nil
-				}
+				sums = testEntSHASums
+			}
+			rel.GetSHA256Sums = func(*Release) (string, error) {
+				return sums, nil
 			}
+			rel.GetSHA256SumsSig = func(*Release) ([]byte, error) {
+				return signer.sign(t, sums), nil
+			}
+			rel.TrustedKeys = []string{signer.publicKey}
 			sha, err := rel.SHA256()
 			require.NoError(t, err)
 			require.Equal(t, test.Expected, sha)
+			require.Equal(t, signer.keyID, rel.SignatureKeyID())
 		})
 	}
 }
@@ -207,7 +219,215 @@ func TestAccDefaultGetSHA256Sums(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	sums, err := rel.GetSHA256Sums(rel)
+	sums, err := rel.VerifiedSHA256Sums()
 	require.NoError(t, err)
 	require.Equal(t, testEntSHASums, sums)
+	require.NotEmpty(t, rel.SignatureKeyID())
+}
+
+// testSigner is a throwaway PGP key that we use to sign test SHA256SUMS.
+type testSigner struct {
+	entity    *openpgp.Entity
+	publicKey string
+	keyID     string
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("enos test", "", "enos@example.com", nil)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	return &testSigner{
+		entity:    entity,
+		publicKey: buf.String(),
+		keyID:     fmt.Sprintf("%016X", entity.PrimaryKey.KeyId),
+	}
+}
+
+func (s *testSigner) sign(t *testing.T, content string) []byte {
+	t.Helper()
+
+	sig := &bytes.Buffer{}
+	require.NoError(t, openpgp.DetachSign(sig, s.entity, strings.NewReader(content), nil))
+
+	return sig.Bytes()
+}
+
+func TestVerifySHA256SumsSignature(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	other := newTestSigner(t)
+	sig := signer.sign(t, testOSSSHASums)
+
+	t.Run("trusted key", func(t *testing.T) {
+		t.Parallel()
+
+		keyID, err := VerifySHA256SumsSignature([]byte(testOSSSHASums), sig, other.publicKey, signer.publicKey)
+		require.NoError(t, err)
+		require.Equal(t, signer.keyID, keyID)
+	})
+
+	t.Run("untrusted key", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := VerifySHA256SumsSignature([]byte(testOSSSHASums), sig, other.publicKey)
+		require.ErrorIs(t, err, ErrSHA256SumsSignatureInvalid)
+	})
+
+	t.Run("tampered sums", func(t *testing.T) {
+		t.Parallel()
+
+		tampered := strings.Replace(testOSSSHASums, "aad2f506", "00000000", 1)
+		_, err := VerifySHA256SumsSignature([]byte(tampered), sig, signer.publicKey)
+		require.ErrorIs(t, err, ErrSHA256SumsSignatureInvalid)
+	})
+
+	t.Run("no trusted keys", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := VerifySHA256SumsSignature([]byte(testOSSSHASums), sig)
+		require.ErrorIs(t, err, ErrSHA256SumsSignatureInvalid)
+	})
+
+	t.Run("default key does not trust others", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := VerifySHA256SumsSignature([]byte(testOSSSHASums), sig, HashiCorpPublicKey)
+		require.ErrorIs(t, err, ErrSHA256SumsSignatureInvalid)
+	})
+}
+
+// TestSHA256VerifiesSignatureFromServer tests that we download and verify the SHA256SUMS and
+// signature from the releases endpoint and fail closed if the signature does not match.
+func TestSHA256VerifiesSignatureFromServer(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	other := newTestSigner(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vault/1.7.0/vault_1.7.0_SHA256SUMS", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testOSSSHASums))
+	})
+	mux.HandleFunc("/vault/1.7.0/vault_1.7.0_SHA256SUMS.sig", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(signer.sign(t, testOSSSHASums))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	newRel := func(keys ...string) *Release {
+		rel, err := NewRelease(
+			WithReleaseBaseURL(srv.URL+"/"),
+			WithReleaseProduct("vault"),
+			WithReleaseVersion("1.7.0"),
+			WithReleaseEdition("ce"),
+			WithReleasePlatform("linux"),
+			WithReleaseArch("amd64"),
+			WithReleaseTrustedKeys(keys...),
+		)
+		require.NoError(t, err)
+
+		return rel
+	}
+
+	rel := newRel(signer.publicKey)
+	require.Equal(t, srv.URL+"/vault/1.7.0/vault_1.7.0_SHA256SUMS.sig", rel.SHA256SUMSSigURL())
+	sha, err := rel.SHA256()
+	require.NoError(t, err)
+	require.Equal(t, "aad2f50635ef4e3f2495b0b6c855061c4047c795821fda886b326c1a71c71c35", sha)
+	require.Equal(t, signer.keyID, rel.SignatureKeyID())
+
+	rel = newRel(other.publicKey)
+	_, err = rel.SHA256()
+	require.ErrorIs(t, err, ErrSHA256SumsSignatureInvalid)
+	require.Empty(t, rel.SignatureKeyID())
+}
+
+// TestSHA256FromMirror tests that we can get and verify the SHA256SUMS from a releases mirror that
+// requires auth headers and is served with a certificate signed by a private CA.
+func TestSHA256FromMirror(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+
+	requireAuth := func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer mirror-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next(w, r)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mirror/vault/1.7.0/vault_1.7.0_SHA256SUMS", requireAuth(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testOSSSHASums))
+	}))
+	mux.HandleFunc("/mirror/vault/1.7.0/vault_1.7.0_SHA256SUMS.sig", requireAuth(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(signer.sign(t, testOSSSHASums))
+	}))
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	newRel := func(opts ...ReleaseOpt) *Release {
+		rel, err := NewRelease(append([]ReleaseOpt{
+			WithReleaseBaseURL(srv.URL + "/mirror"),
+			WithReleaseProduct("vault"),
+			WithReleaseVersion("1.7.0"),
+			WithReleaseEdition("ce"),
+			WithReleasePlatform("linux"),
+			WithReleaseArch("amd64"),
+			WithReleaseTrustedKeys(signer.publicKey),
+		}, opts...)...)
+		require.NoError(t, err)
+
+		return rel
+	}
+
+	t.Run("ca and headers", func(t *testing.T) {
+		t.Parallel()
+
+		rel := newRel(
+			WithReleaseCACert(caCert),
+			WithReleaseHeaders(map[string]string{"Authorization": "Bearer mirror-token"}),
+		)
+		require.Equal(t, srv.URL+"/mirror/vault/1.7.0/vault_1.7.0_linux_amd64.zip", rel.BundleURL())
+		sha, err := rel.SHA256()
+		require.NoError(t, err)
+		require.Equal(t, "aad2f50635ef4e3f2495b0b6c855061c4047c795821fda886b326c1a71c71c35", sha)
+	})
+
+	t.Run("missing headers", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newRel(WithReleaseCACert(caCert)).SHA256()
+		require.ErrorContains(t, err, "401")
+	})
+
+	t.Run("untrusted ca", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newRel(
+			WithReleaseHeaders(map[string]string{"Authorization": "Bearer mirror-token"}),
+		).SHA256()
+		require.Error(t, err)
+	})
+
+	t.Run("invalid ca", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newRel(WithReleaseCACert("not a certificate")).HTTPClient()
+		require.Error(t, err)
+	})
 }
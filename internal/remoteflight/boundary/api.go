@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package boundary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// APIRequest is a request to the Boundary controller API that is made with the Boundary CLI.
+// Requests are authenticated with a token, or the password auth method credentials returned by
+// Boundary init.
+type APIRequest struct {
+	*CLIRequest
+	// Addr the address of the Boundary controller API
+	Addr         string
+	AuthMethodID string
+	LoginName    string
+	Password     string
+	// ScopeID the scope in which resources are created. Defaults to global
+	ScopeID string
+	// Token an auth token. If not set the request will authenticate with the auth method
+	Token string
+}
+
+// APIRequestOpt is a functional option for a API request.
+type APIRequestOpt func(*APIRequest) *APIRequest
+
+// NewAPIRequest takes functional options and returns a new API request.
+func NewAPIRequest(opts ...APIRequestOpt) *APIRequest {
+	r := &APIRequest{
+		CLIRequest: &CLIRequest{
+			BinName: "boundary",
+		},
+		ScopeID: "global",
+	}
+
+	for _, opt := range opts {
+		r = opt(r)
+	}
+
+	return r
+}
+
+// WithAPIRequestBinName sets the Boundary binary name.
+func WithAPIRequestBinName(name string) APIRequestOpt {
+	return func(r *APIRequest) *APIRequest {
+		r.BinName = name
+		return r
+	}
+}
+
+// WithAPIRequestBinPath sets the Boundary binary path.
+func WithAPIRequestBinPath(path string) APIRequestOpt {
+	return func(r *APIRequest) *APIRequest {
+		r.BinPath = path
+		return r
+	}
+}
+
+// WithAPIRequestAddr sets the Boundary controller API address.
+func WithAPIRequestAddr(addr string) APIRequestOpt {
+	return func(r *APIRequest) *APIRequest {
+		r.Addr = addr
+		return r
+	}
+}
+
+// WithAPIRequestAuthMethodID sets the password auth method ID.
+func WithAPIRequestAuthMethodID(id string) APIRequestOpt {
+	return func(r *APIRequest) *APIRequest {
+		r.AuthMethodID = id
+		return r
+	}
+}
+
+// WithAPIRequestLoginName sets the login name.
+func WithAPIRequestLoginName(name string) APIRequestOpt {
+	return func(r *APIRequest) *APIRequest {
+		r.LoginName = name
+		return r
+	}
+}
+
+// WithAPIRequestPassword sets the password.
+func WithAPIRequestPassword(password string) APIRequestOpt {
+	return func(r *APIRequest) *APIRequest {
+		r.Password = password
+		return r
+	}
+}
+
+// WithAPIRequestScopeID sets the scope ID.
+func WithAPIRequestScopeID(id string) APIRequestOpt {
+	return func(r *APIRequest) *APIRequest {
+		r.ScopeID = id
+		return r
+	}
+}
+
+// WithAPIRequestToken sets the auth token.
+func WithAPIRequestToken(token string) APIRequestOpt {
+	return func(r *APIRequest) *APIRequest {
+		r.Token = token
+		return r
+	}
+}
+
+// Validate validates that the API request has the required fields.
+func (r *APIRequest) Validate() error {
+	if r.BinPath == "" {
+		return errors.New("no binary path has been supplied")
+	}
+
+	if r.Addr == "" {
+		return errors.New("no controller address has been supplied")
+	}
+
+	if r.Token != "" {
+		return nil
+	}
+
+	if r.AuthMethodID == "" {
+		return errors.New("no auth method ID has been supplied")
+	}
+
+	if r.LoginName == "" {
+		return errors.New("no login name has been supplied")
+	}
+
+	if r.Password == "" {
+		return errors.New("no password has been supplied")
+	}
+
+	return nil
+}
+
+// Command returns the Boundary CLI command for the sub-command and arguments.
+func (r *APIRequest) Command(args ...string) string {
+	return fmt.Sprintf("%s %s -format json", filepath.Join(r.BinPath, r.BinName), strings.Join(args, " "))
+}
+
+// envVars returns the environment variables that configure the Boundary CLI.
+func (r *APIRequest) envVars() map[string]string {
+	env := map[string]string{
+		"BOUNDARY_ADDR": r.Addr,
+	}
+
+	if r.Token != "" {
+		env["BOUNDARY_TOKEN"] = r.Token
+	}
+
+	return env
+}
+
+// Authenticate authenticates with the password auth method and returns an auth token.
+func Authenticate(ctx context.Context, tr it.Transport, req *APIRequest) (string, error) {
+	res := &struct {
+		Item struct {
+			Attributes struct {
+				Token string `json:"token"`
+			} `json:"attributes"`
+		} `json:"item"`
+	}{}
+
+	env := req.envVars()
+	env["BOUNDARY_AUTHENTICATE_PASSWORD_PASSWORD"] = req.Password
+
+	err := runJSON(ctx, tr, command.New(
+		req.Command(
+			"authenticate password",
+			"-auth-method-id", req.AuthMethodID,
+			"-login-name", req.LoginName,
+			"-password", "env://BOUNDARY_AUTHENTICATE_PASSWORD_PASSWORD",
+			"-keyring-type", "none",
+		),
+		command.WithEnvVars(env),
+	), res)
+	if err != nil {
+		return "", fmt.Errorf("failed to authenticate with auth method: %s, due to: %w", req.AuthMethodID, err)
+	}
+
+	if res.Item.Attributes.Token == "" {
+		return "", errors.New("authenticate did not return a token")
+	}
+
+	return res.Item.Attributes.Token, nil
+}
+
+// runJSON runs the command and deserializes the JSON output onto the response.
+func runJSON(ctx context.Context, tr it.Transport, cmd it.Command, res any) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	stdout, stderr, err := tr.Run(ctx, cmd)
+	if err != nil {
+		return fmt.Errorf("%w, stderr: %s", err, stderr)
+	}
+
+	if stdout == "" {
+		return errors.New("no JSON body was written to STDOUT")
+	}
+
+	err = json.Unmarshal([]byte(stdout), res)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal json: %w, stdout: %q", err, stdout)
+	}
+
+	return nil
+}
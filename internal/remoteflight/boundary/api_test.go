@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package boundary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestAPIRequestCommand tests that the API request becomes a valid command.
+func TestAPIRequestCommand(t *testing.T) {
+	t.Parallel()
+
+	req := NewAPIRequest(
+		WithAPIRequestBinPath("/opt/boundary/bin"),
+		WithAPIRequestAddr("http://10.0.0.1:9200"),
+		WithAPIRequestToken("at_1234"),
+	)
+	require.NoError(t, req.Validate())
+	require.Equal(t,
+		"/opt/boundary/bin/boundary workers read -id w_1234 -format json",
+		req.Command("workers read", "-id", "w_1234"),
+	)
+	require.Equal(t, map[string]string{
+		"BOUNDARY_ADDR":  "http://10.0.0.1:9200",
+		"BOUNDARY_TOKEN": "at_1234",
+	}, req.envVars())
+}
+
+// TestAPIRequestValidate tests the API request validation.
+func TestAPIRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		desc string
+		opts []APIRequestOpt
+		pass bool
+	}{
+		{
+			"has password credentials",
+			[]APIRequestOpt{
+				WithAPIRequestBinPath("/opt/boundary/bin"),
+				WithAPIRequestAddr("http://10.0.0.1:9200"),
+				WithAPIRequestAuthMethodID("ampw_1234567890"),
+				WithAPIRequestLoginName("admin"),
+				WithAPIRequestPassword("password"),
+			},
+			true,
+		},
+		{
+			"has token",
+			[]APIRequestOpt{
+				WithAPIRequestBinPath("/opt/boundary/bin"),
+				WithAPIRequestAddr("http://10.0.0.1:9200"),
+				WithAPIRequestToken("at_1234"),
+			},
+			true,
+		},
+		{
+			"missing addr",
+			[]APIRequestOpt{
+				WithAPIRequestBinPath("/opt/boundary/bin"),
+				WithAPIRequestToken("at_1234"),
+			},
+			false,
+		},
+		{
+			"missing password",
+			[]APIRequestOpt{
+				WithAPIRequestBinPath("/opt/boundary/bin"),
+				WithAPIRequestAddr("http://10.0.0.1:9200"),
+				WithAPIRequestAuthMethodID("ampw_1234567890"),
+				WithAPIRequestLoginName("admin"),
+			},
+			false,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			req := NewAPIRequest(test.opts...)
+			if test.pass {
+				require.NoError(t, req.Validate())
+			} else {
+				require.Error(t, req.Validate())
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package boundary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// MigrateRequest is a Boundary database migrate request.
+type MigrateRequest struct {
+	*CLIRequest
+}
+
+// MigrateRequestOpt is a functional option for a migrate request.
+type MigrateRequestOpt func(*MigrateRequest) *MigrateRequest
+
+// NewMigrateRequest takes functional options and returns a new migrate request.
+func NewMigrateRequest(opts ...MigrateRequestOpt) *MigrateRequest {
+	m := &MigrateRequest{
+		&CLIRequest{},
+	}
+
+	for _, opt := range opts {
+		m = opt(m)
+	}
+
+	return m
+}
+
+// WithMigrateRequestBinName sets the Boundary binary name.
+func WithMigrateRequestBinName(name string) MigrateRequestOpt {
+	return func(m *MigrateRequest) *MigrateRequest {
+		m.BinName = name
+		return m
+	}
+}
+
+// WithMigrateRequestBinPath sets the Boundary binary path.
+func WithMigrateRequestBinPath(path string) MigrateRequestOpt {
+	return func(m *MigrateRequest) *MigrateRequest {
+		m.BinPath = path
+		return m
+	}
+}
+
+// WithMigrateRequestConfigPath sets the Boundary config path.
+func WithMigrateRequestConfigPath(path string) MigrateRequestOpt {
+	return func(m *MigrateRequest) *MigrateRequest {
+		m.ConfigPath = path
+		return m
+	}
+}
+
+// WithMigrateRequestLicense sets the Boundary license.
+func WithMigrateRequestLicense(license string) MigrateRequestOpt {
+	return func(m *MigrateRequest) *MigrateRequest {
+		m.License = license
+		return m
+	}
+}
+
+// Validate validates that the migrate request has the required fields.
+func (r *MigrateRequest) Validate() error {
+	if r.BinPath == "" {
+		return errors.New("no binary path has been supplied")
+	}
+	if r.ConfigPath == "" {
+		return errors.New("no config path has been supplied")
+	}
+
+	return nil
+}
+
+// String returns the migrate request as a migrate command.
+func (r *MigrateRequest) String() string {
+	cmd := &strings.Builder{}
+	fmt.Fprintf(cmd, "%s/%s database migrate", r.BinPath, r.BinName)
+	fmt.Fprintf(cmd, " -config=%s/boundary.hcl", r.ConfigPath)
+
+	return cmd.String()
+}
+
+// Migrate calls boundary database migrate to upgrade the schema of an existing database to the
+// version that is supported by the Boundary binary. It should be run before the controllers are
+// restarted with the new binary.
+func Migrate(ctx context.Context, tr it.Transport, req *MigrateRequest) error {
+	envVars := map[string]string{}
+
+	if req.License != "" {
+		envVars["BOUNDARY_LICENSE"] = req.License
+	}
+
+	_, stderr, err := tr.Run(ctx, command.New(
+		req.String(),
+		command.WithEnvVars(envVars),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to migrate database: %w stderr: %s", err, stderr)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package boundary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMigrateRequestString tests that the migrate request becomes a valid string.
+func TestMigrateRequestString(t *testing.T) {
+	t.Parallel()
+
+	req := NewMigrateRequest(
+		WithMigrateRequestBinName("boundary"),
+		WithMigrateRequestBinPath("/opt/boundary/bin"),
+		WithMigrateRequestConfigPath("/etc/boundary"),
+	)
+	require.NoError(t, req.Validate())
+	require.Equal(t,
+		"/opt/boundary/bin/boundary database migrate -config=/etc/boundary/boundary.hcl",
+		req.String(),
+	)
+}
+
+// TestMigrateRequestValidate tests the migrate request validation.
+func TestMigrateRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		desc string
+		opts []MigrateRequestOpt
+		pass bool
+	}{
+		{
+			"has required args",
+			[]MigrateRequestOpt{
+				WithMigrateRequestBinPath("/opt/boundary/bin"),
+				WithMigrateRequestConfigPath("/etc/boundary"),
+			},
+			true,
+		},
+		{
+			"missing config path",
+			[]MigrateRequestOpt{
+				WithMigrateRequestBinPath("/opt/boundary/bin"),
+			},
+			false,
+		},
+		{
+			"missing bin path",
+			[]MigrateRequestOpt{
+				WithMigrateRequestConfigPath("/etc/boundary"),
+			},
+			false,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			req := NewMigrateRequest(test.opts...)
+			if test.pass {
+				require.NoError(t, req.Validate())
+			} else {
+				require.Error(t, req.Validate())
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package boundary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/retry"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// SessionStatusActive is the status of a session that has had a connection established.
+const SessionStatusActive = "active"
+
+// errSessionNotReady is returned when a session has not been established yet but might be.
+var errSessionNotReady = errors.New("session is not ready")
+
+// SessionAuthorization is a Boundary session authorization as returned by the Boundary CLI.
+type SessionAuthorization struct {
+	SessionID          string `json:"session_id"`
+	TargetID           string `json:"target_id"`
+	ScopeID            string `json:"scope_id"`
+	UserID             string `json:"user_id"`
+	Endpoint           string `json:"endpoint"`
+	Type               string `json:"type"`
+	AuthorizationToken string `json:"authorization_token"`
+}
+
+// Session is a Boundary session as returned by the Boundary CLI.
+type Session struct {
+	ID       string         `json:"id"`
+	TargetID string         `json:"target_id"`
+	ScopeID  string         `json:"scope_id"`
+	UserID   string         `json:"user_id"`
+	Type     string         `json:"type"`
+	Status   string         `json:"status"`
+	States   []SessionState `json:"states"`
+}
+
+// SessionState is a state that a session has been in.
+type SessionState struct {
+	Status    string `json:"status"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// AuthorizeSession authorizes a session to the target and returns the authorization.
+func AuthorizeSession(ctx context.Context, tr it.Transport, req *APIRequest, targetID string) (*SessionAuthorization, error) {
+	res := &struct {
+		Item *SessionAuthorization `json:"item"`
+	}{}
+
+	err := runJSON(ctx, tr, command.New(
+		req.Command("targets authorize-session", "-id", targetID),
+		command.WithEnvVars(req.envVars()),
+	), res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize session to target: %s, due to: %w", targetID, err)
+	}
+
+	if res.Item == nil || res.Item.AuthorizationToken == "" {
+		return nil, fmt.Errorf("failed to authorize session to target: %s, no authorization token was returned", targetID)
+	}
+
+	return res.Item, nil
+}
+
+// ConnectSession uses the session authorization token to open a local proxy to the target and
+// makes a TCP connection through it. A successful connection means that the controller, worker
+// and target are all reachable from the host.
+func ConnectSession(ctx context.Context, tr it.Transport, req *APIRequest, authzToken string) error {
+	cmd := fmt.Sprintf(
+		"%s connect -authz-token %s -exec bash -- -c 'exec 3<>/dev/tcp/{{boundary.ip}}/{{boundary.port}} && exec 3>&-'",
+		filepath.Join(req.BinPath, req.BinName), authzToken,
+	)
+
+	_, stderr, err := tr.Run(ctx, command.New(cmd, command.WithEnvVars(req.envVars())))
+	if err != nil {
+		return fmt.Errorf("failed to connect to session, due to: %w, stderr: %s", err, stderr)
+	}
+
+	return nil
+}
+
+// GetSession reads the session.
+func GetSession(ctx context.Context, tr it.Transport, req *APIRequest, id string) (*Session, error) {
+	res := &struct {
+		Item *Session `json:"item"`
+	}{}
+
+	err := runJSON(ctx, tr, command.New(
+		req.Command("sessions read", "-id", id),
+		command.WithEnvVars(req.envVars()),
+	), res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %s, due to: %w", id, err)
+	}
+
+	if res.Item == nil {
+		return nil, fmt.Errorf("failed to read session: %s, no session was returned", id)
+	}
+
+	return res.Item, nil
+}
+
+// CancelSession cancels the session.
+func CancelSession(ctx context.Context, tr it.Transport, req *APIRequest, id string) error {
+	_, stderr, err := tr.Run(ctx, command.New(
+		req.Command("sessions cancel", "-id", id),
+		command.WithEnvVars(req.envVars()),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to cancel session: %s, due to: %w, stderr: %s", id, err, stderr)
+	}
+
+	return nil
+}
+
+// VerifySession authorizes a session to the target, connects to it, and verifies that the
+// session became active. The session is canceled after it has been verified. Authorization and
+// connection are retried until the context is done as workers might still be connecting to
+// the controller.
+func VerifySession(ctx context.Context, tr it.Transport, req *APIRequest, targetID string) (*Session, error) {
+	verify := func(ctx context.Context) (any, error) {
+		authz, err := AuthorizeSession(ctx, tr, req, targetID)
+		if err != nil {
+			return nil, errors.Join(errSessionNotReady, err)
+		}
+
+		connectErr := ConnectSession(ctx, tr, req, authz.AuthorizationToken)
+
+		session, err := GetSession(ctx, tr, req, authz.SessionID)
+		if err == nil && !session.IsTerminated() {
+			err = CancelSession(ctx, tr, req, authz.SessionID)
+		}
+		if err != nil {
+			return nil, errors.Join(errSessionNotReady, connectErr, err)
+		}
+
+		if connectErr != nil {
+			return session, errors.Join(errSessionNotReady, connectErr)
+		}
+
+		if !session.HasBeenActive() {
+			return session, fmt.Errorf("%w: session: %s never became active", errSessionNotReady, session.ID)
+		}
+
+		return session, nil
+	}
+
+	r, err := retry.NewRetrier(
+		retry.WithIntervalFunc(retry.IntervalDuration(2*time.Second)),
+		retry.WithRetrierFunc(verify),
+		retry.WithOnlyRetryErrors(errSessionNotReady),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := retry.Retry(ctx, r)
+	if err != nil {
+		return nil, fmt.Errorf("verifying session to target: %s: %w", targetID, err)
+	}
+
+	session, ok := res.(*Session)
+	if !ok {
+		return nil, errors.New("verifying session: unexpected response")
+	}
+
+	return session, nil
+}
+
+// HasBeenActive returns whether or not the session is, or has been, active.
+func (s *Session) HasBeenActive() bool {
+	if s == nil {
+		return false
+	}
+
+	if s.Status == SessionStatusActive {
+		return true
+	}
+
+	return slices.ContainsFunc(s.States, func(state SessionState) bool {
+		return state.Status == SessionStatusActive
+	})
+}
+
+// IsTerminated returns whether or not the session has been terminated.
+func (s *Session) IsTerminated() bool {
+	return s != nil && s.Status == "terminated"
+}
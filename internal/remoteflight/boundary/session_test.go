@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package boundary
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSessionHasBeenActive tests that we can determine if a session has been active from the
+// session read response.
+func TestSessionHasBeenActive(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		desc       string
+		body       string
+		active     bool
+		terminated bool
+	}{
+		{
+			"active",
+			`{"status_code":200,"item":{"id":"s_1234567890","target_id":"ttcp_1234567890","status":"active","states":[{"status":"active","start_time":"2024-05-01T12:00:01Z"},{"status":"pending","start_time":"2024-05-01T12:00:00Z","end_time":"2024-05-01T12:00:01Z"}]}}`,
+			true,
+			false,
+		},
+		{
+			"terminated after being active",
+			`{"status_code":200,"item":{"id":"s_1234567890","target_id":"ttcp_1234567890","status":"terminated","states":[{"status":"terminated","start_time":"2024-05-01T12:00:02Z"},{"status":"active","start_time":"2024-05-01T12:00:01Z","end_time":"2024-05-01T12:00:02Z"},{"status":"pending","start_time":"2024-05-01T12:00:00Z","end_time":"2024-05-01T12:00:01Z"}]}}`,
+			true,
+			true,
+		},
+		{
+			"pending",
+			`{"status_code":200,"item":{"id":"s_1234567890","target_id":"ttcp_1234567890","status":"pending","states":[{"status":"pending","start_time":"2024-05-01T12:00:00Z"}]}}`,
+			false,
+			false,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			res := &struct {
+				Item *Session `json:"item"`
+			}{}
+			require.NoError(t, json.Unmarshal([]byte(test.body), res))
+			require.Equal(t, "s_1234567890", res.Item.ID)
+			require.Equal(t, test.active, res.Item.HasBeenActive())
+			require.Equal(t, test.terminated, res.Item.IsTerminated())
+		})
+	}
+}
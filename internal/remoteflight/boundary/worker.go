@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package boundary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/retry"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// WorkerAuthRequestTokenFile is the name of the file that a worker writes its worker-led
+// registration request to. It is written to the workers auth storage path.
+const WorkerAuthRequestTokenFile = "auth_request_token"
+
+// errWorkerNotReady is returned when a worker is not ready yet but might become ready.
+var errWorkerNotReady = errors.New("worker is not ready")
+
+// Worker is a Boundary worker as returned by the Boundary CLI.
+type Worker struct {
+	ID                                 string `json:"id"`
+	ScopeID                            string `json:"scope_id"`
+	Name                               string `json:"name"`
+	Description                        string `json:"description"`
+	Type                               string `json:"type"`
+	Address                            string `json:"address"`
+	ReleaseVersion                     string `json:"release_version"`
+	LastStatusTime                     string `json:"last_status_time"`
+	ControllerGeneratedActivationToken string `json:"controller_generated_activation_token"`
+}
+
+// CreateControllerLedWorker creates a worker on the controller and returns it. The returned
+// worker includes the activation token that the worker needs to be configured with.
+func CreateControllerLedWorker(ctx context.Context, tr it.Transport, req *APIRequest, name string) (*Worker, error) {
+	args := []string{"workers create controller-led", "-scope-id", req.ScopeID}
+	if name != "" {
+		args = append(args, "-name", fmt.Sprintf("'%s'", name))
+	}
+
+	worker, err := runWorkerCommand(ctx, tr, req, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create controller-led worker, due to: %w", err)
+	}
+
+	if worker.ControllerGeneratedActivationToken == "" {
+		return nil, errors.New("failed to create controller-led worker, no activation token was returned")
+	}
+
+	return worker, nil
+}
+
+// CreateWorkerLedWorker authorizes a worker with the auth registration request that the worker
+// generated.
+func CreateWorkerLedWorker(ctx context.Context, tr it.Transport, req *APIRequest, name string, authRequestToken string) (*Worker, error) {
+	args := []string{
+		"workers create worker-led",
+		"-scope-id", req.ScopeID,
+		"-worker-generated-auth-token", authRequestToken,
+	}
+	if name != "" {
+		args = append(args, "-name", fmt.Sprintf("'%s'", name))
+	}
+
+	worker, err := runWorkerCommand(ctx, tr, req, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worker-led worker, due to: %w", err)
+	}
+
+	return worker, nil
+}
+
+// GetWorker reads the worker.
+func GetWorker(ctx context.Context, tr it.Transport, req *APIRequest, id string) (*Worker, error) {
+	worker, err := runWorkerCommand(ctx, tr, req, "workers read", "-id", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read worker: %s, due to: %w", id, err)
+	}
+
+	return worker, nil
+}
+
+// WaitForWorkerConnected waits for the worker to report its status to the controller.
+func WaitForWorkerConnected(ctx context.Context, tr it.Transport, req *APIRequest, id string) (*Worker, error) {
+	check := func(ctx context.Context) (any, error) {
+		worker, err := GetWorker(ctx, tr, req, id)
+		if err != nil {
+			return nil, errors.Join(errWorkerNotReady, err)
+		}
+
+		if !worker.IsConnected() {
+			return worker, fmt.Errorf("%w: worker: %s has not connected to the controller", errWorkerNotReady, id)
+		}
+
+		return worker, nil
+	}
+
+	r, err := retry.NewRetrier(
+		retry.WithIntervalFunc(retry.IntervalDuration(2*time.Second)),
+		retry.WithRetrierFunc(check),
+		retry.WithOnlyRetryErrors(errWorkerNotReady),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := retry.Retry(ctx, r)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for worker to connect: %w", err)
+	}
+
+	worker, ok := res.(*Worker)
+	if !ok {
+		return nil, errors.New("waiting for worker to connect: unexpected response")
+	}
+
+	return worker, nil
+}
+
+// WaitForWorkerAuthRequestToken waits for the worker to write its worker-led auth registration
+// request to the auth storage path and returns it.
+func WaitForWorkerAuthRequestToken(ctx context.Context, tr it.Transport, authStoragePath string) (string, error) {
+	path := filepath.Join(authStoragePath, WorkerAuthRequestTokenFile)
+
+	read := func(ctx context.Context) (any, error) {
+		stdout, stderr, err := tr.Run(ctx, command.New(
+			fmt.Sprintf("cat '%[1]s' 2>/dev/null || sudo cat '%[1]s'", path),
+		))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s, due to: %w, stderr: %s", path, err, stderr)
+		}
+
+		token := strings.TrimSpace(stdout)
+		if token == "" {
+			return nil, fmt.Errorf("%s is empty", path)
+		}
+
+		return token, nil
+	}
+
+	r, err := retry.NewRetrier(
+		retry.WithIntervalFunc(retry.IntervalDuration(2*time.Second)),
+		retry.WithRetrierFunc(read),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := retry.Retry(ctx, r)
+	if err != nil {
+		return "", fmt.Errorf("waiting for worker auth registration request: %w", err)
+	}
+
+	token, ok := res.(string)
+	if !ok {
+		return "", errors.New("waiting for worker auth registration request: unexpected response")
+	}
+
+	return token, nil
+}
+
+// IsConnected returns whether or not the worker has connected and reported its status to the
+// controller.
+func (w *Worker) IsConnected() bool {
+	if w == nil {
+		return false
+	}
+
+	return w.Address != "" && w.LastStatusTime != ""
+}
+
+// runWorkerCommand runs a Boundary workers command and returns the worker item.
+func runWorkerCommand(ctx context.Context, tr it.Transport, req *APIRequest, args ...string) (*Worker, error) {
+	res := &struct {
+		Item *Worker `json:"item"`
+	}{}
+
+	err := runJSON(ctx, tr, command.New(req.Command(args...), command.WithEnvVars(req.envVars())), res)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Item == nil {
+		return nil, errors.New("no worker was returned")
+	}
+
+	return res.Item, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package boundary
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestWorkerIsConnected tests that we can determine if a worker is connected from the worker
+// read response.
+func TestWorkerIsConnected(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		desc      string
+		body      string
+		connected bool
+	}{
+		{
+			"connected",
+			`{"status_code":200,"item":{"id":"w_8WBQq4Lq2T","scope_id":"global","type":"pki","address":"10.0.1.5:9202","last_status_time":"2024-05-01T12:00:00.000000Z","release_version":"Boundary v0.16.0"}}`,
+			true,
+		},
+		{
+			"pending",
+			`{"status_code":200,"item":{"id":"w_8WBQq4Lq2T","scope_id":"global","type":"pki","controller_generated_activation_token":"neslat_2KrL"}}`,
+			false,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			res := &struct {
+				Item *Worker `json:"item"`
+			}{}
+			require.NoError(t, json.Unmarshal([]byte(test.body), res))
+			require.Equal(t, "w_8WBQq4Lq2T", res.Item.ID)
+			require.Equal(t, test.connected, res.Item.IsConnected())
+		})
+	}
+}
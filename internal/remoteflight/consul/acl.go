@@ -0,0 +1,389 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// ErrACLAlreadyBootstrapped is returned when the consul ACL system has already been bootstrapped.
+var ErrACLAlreadyBootstrapped = errors.New("consul ACLs have already been bootstrapped")
+
+// ACLToken is the JSON stdout result of the "consul acl token" commands.
+type ACLToken struct {
+	AccessorID  string     `json:"AccessorID"`
+	SecretID    string     `json:"SecretID"`
+	Description string     `json:"Description"`
+	Policies    []*ACLLink `json:"Policies,omitempty"`
+}
+
+// ACLPolicy is the JSON stdout result of the "consul acl policy" commands.
+type ACLPolicy struct {
+	ID          string `json:"ID"`
+	Name        string `json:"Name"`
+	Description string `json:"Description"`
+	Rules       string `json:"Rules,omitempty"`
+}
+
+// ACLLink is a reference to an ACL policy.
+type ACLLink struct {
+	ID   string `json:"ID"`
+	Name string `json:"Name"`
+}
+
+// ACLPolicyRequest is a request to create or update an ACL policy.
+type ACLPolicyRequest struct {
+	*CLIRequest
+	Name        string
+	Description string
+	Rules       string
+}
+
+// ACLPolicyRequestOpt is a functional option for an ACL policy request.
+type ACLPolicyRequestOpt func(*ACLPolicyRequest) *ACLPolicyRequest
+
+// ACLTokenRequest is a request to create or update an ACL token. Tokens are
+// identified by their description.
+type ACLTokenRequest struct {
+	*CLIRequest
+	Description string
+	Policies    []string
+}
+
+// ACLTokenRequestOpt is a functional option for an ACL token request.
+type ACLTokenRequestOpt func(*ACLTokenRequest) *ACLTokenRequest
+
+// NewACLPolicyRequest takes functional options and returns a new ACL policy request.
+func NewACLPolicyRequest(opts ...ACLPolicyRequestOpt) *ACLPolicyRequest {
+	r := &ACLPolicyRequest{
+		CLIRequest: &CLIRequest{},
+	}
+
+	for _, opt := range opts {
+		r = opt(r)
+	}
+
+	return r
+}
+
+// WithACLPolicyRequestCLIRequest sets the consul CLI request.
+func WithACLPolicyRequestCLIRequest(cli *CLIRequest) ACLPolicyRequestOpt {
+	return func(r *ACLPolicyRequest) *ACLPolicyRequest {
+		r.CLIRequest = cli
+		return r
+	}
+}
+
+// WithACLPolicyRequestName sets the policy name.
+func WithACLPolicyRequestName(name string) ACLPolicyRequestOpt {
+	return func(r *ACLPolicyRequest) *ACLPolicyRequest {
+		r.Name = name
+		return r
+	}
+}
+
+// WithACLPolicyRequestDescription sets the policy description.
+func WithACLPolicyRequestDescription(desc string) ACLPolicyRequestOpt {
+	return func(r *ACLPolicyRequest) *ACLPolicyRequest {
+		r.Description = desc
+		return r
+	}
+}
+
+// WithACLPolicyRequestRules sets the policy rules.
+func WithACLPolicyRequestRules(rules string) ACLPolicyRequestOpt {
+	return func(r *ACLPolicyRequest) *ACLPolicyRequest {
+		r.Rules = rules
+		return r
+	}
+}
+
+// NewACLTokenRequest takes functional options and returns a new ACL token request.
+func NewACLTokenRequest(opts ...ACLTokenRequestOpt) *ACLTokenRequest {
+	r := &ACLTokenRequest{
+		CLIRequest: &CLIRequest{},
+	}
+
+	for _, opt := range opts {
+		r = opt(r)
+	}
+
+	return r
+}
+
+// WithACLTokenRequestCLIRequest sets the consul CLI request.
+func WithACLTokenRequestCLIRequest(cli *CLIRequest) ACLTokenRequestOpt {
+	return func(r *ACLTokenRequest) *ACLTokenRequest {
+		r.CLIRequest = cli
+		return r
+	}
+}
+
+// WithACLTokenRequestDescription sets the token description.
+func WithACLTokenRequestDescription(desc string) ACLTokenRequestOpt {
+	return func(r *ACLTokenRequest) *ACLTokenRequest {
+		r.Description = desc
+		return r
+	}
+}
+
+// WithACLTokenRequestPolicies sets the names of the policies to attach to the token.
+func WithACLTokenRequestPolicies(policies []string) ACLTokenRequestOpt {
+	return func(r *ACLTokenRequest) *ACLTokenRequest {
+		r.Policies = policies
+		return r
+	}
+}
+
+// Validate validates that the CLI request has the required fields.
+func (r *CLIRequest) Validate() error {
+	var err error
+
+	if r.BinPath == "" {
+		err = errors.Join(err, errors.New("you must supply a consul bin path"))
+	}
+
+	if r.ConsulAddr == "" {
+		err = errors.Join(err, errors.New("you must supply a consul listen address"))
+	}
+
+	return err
+}
+
+// Validate validates that the ACL policy request has the required fields.
+func (r *ACLPolicyRequest) Validate() error {
+	err := r.CLIRequest.Validate()
+
+	if r.Token == "" {
+		err = errors.Join(err, errors.New("you must supply a consul ACL token"))
+	}
+
+	if r.Name == "" {
+		err = errors.Join(err, errors.New("you must supply a policy name"))
+	}
+
+	if r.Rules == "" {
+		err = errors.Join(err, errors.New("you must supply policy rules"))
+	}
+
+	return err
+}
+
+// Validate validates that the ACL token request has the required fields.
+func (r *ACLTokenRequest) Validate() error {
+	err := r.CLIRequest.Validate()
+
+	if r.Token == "" {
+		err = errors.Join(err, errors.New("you must supply a consul ACL token"))
+	}
+
+	if r.Description == "" {
+		err = errors.Join(err, errors.New("you must supply a token description"))
+	}
+
+	return err
+}
+
+// ACLBootstrap bootstraps the consul ACL system and returns the initial management token.
+// ErrACLAlreadyBootstrapped is returned if the ACL system has already been bootstrapped.
+func ACLBootstrap(ctx context.Context, tr it.Transport, req *CLIRequest) (*ACLToken, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("bootstrapping consul ACLs: %w", err)
+	}
+
+	stdout, stderr, err := tr.Run(ctx, command.New(
+		req.BinPath+" acl bootstrap -format=json",
+		command.WithEnvVars(req.EnvVars()),
+	))
+	if err != nil {
+		if isACLAlreadyBootstrapped(stderr) || isACLAlreadyBootstrapped(err.Error()) {
+			return nil, ErrACLAlreadyBootstrapped
+		}
+
+		return nil, remoteflight.WrapErrorWith(fmt.Errorf("bootstrapping consul ACLs: %w", err), stderr)
+	}
+
+	token := &ACLToken{}
+	if err = json.Unmarshal([]byte(stdout), token); err != nil {
+		return nil, fmt.Errorf("bootstrapping consul ACLs: decoding bootstrap token: %w", err)
+	}
+
+	return token, nil
+}
+
+// ListACLPolicies returns all of the ACL policies. The policy rules are not included.
+func ListACLPolicies(ctx context.Context, tr it.Transport, req *CLIRequest) ([]*ACLPolicy, error) {
+	policies := []*ACLPolicy{}
+	err := runACLCommandJSON(ctx, tr, req, "acl policy list -format=json", &policies)
+	if err != nil {
+		return nil, fmt.Errorf("listing consul ACL policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// ListACLTokens returns all of the ACL tokens. The token secrets are not guaranteed
+// to be included.
+func ListACLTokens(ctx context.Context, tr it.Transport, req *CLIRequest) ([]*ACLToken, error) {
+	tokens := []*ACLToken{}
+	err := runACLCommandJSON(ctx, tr, req, "acl token list -format=json", &tokens)
+	if err != nil {
+		return nil, fmt.Errorf("listing consul ACL tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// ReadACLToken reads the ACL token with the given accessor ID.
+func ReadACLToken(ctx context.Context, tr it.Transport, req *CLIRequest, accessorID string) (*ACLToken, error) {
+	token := &ACLToken{}
+	err := runACLCommandJSON(ctx, tr, req, "acl token read -format=json -id "+remoteflight.ShellQuote(accessorID), token)
+	if err != nil {
+		return nil, fmt.Errorf("reading consul ACL token: %w", err)
+	}
+
+	return token, nil
+}
+
+// WriteACLPolicy creates the ACL policy, or updates it if a policy with the same name exists.
+func WriteACLPolicy(ctx context.Context, tr it.Transport, req *ACLPolicyRequest) (*ACLPolicy, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("writing consul ACL policy: %w", err)
+	}
+
+	policies, err := ListACLPolicies(ctx, tr, req.CLIRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := req.createCommand()
+	for _, policy := range policies {
+		if policy.Name == req.Name {
+			cmd = req.updateCommand(policy.ID)
+			break
+		}
+	}
+
+	res := &ACLPolicy{}
+	err = runACLCommandJSON(ctx, tr, req.CLIRequest, cmd, res)
+	if err != nil {
+		return nil, fmt.Errorf("writing consul ACL policy %s: %w", req.Name, err)
+	}
+
+	return res, nil
+}
+
+// WriteACLToken creates the ACL token, or updates it if a token with the same description
+// exists. The returned token always includes the secret ID.
+func WriteACLToken(ctx context.Context, tr it.Transport, req *ACLTokenRequest) (*ACLToken, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("writing consul ACL token: %w", err)
+	}
+
+	tokens, err := ListACLTokens(ctx, tr, req.CLIRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		if token.Description != req.Description {
+			continue
+		}
+
+		err = runACLCommandJSON(ctx, tr, req.CLIRequest, req.updateCommand(token.AccessorID), &ACLToken{})
+		if err != nil {
+			return nil, fmt.Errorf("updating consul ACL token %s: %w", req.Description, err)
+		}
+
+		// The update response does not always include the secret so we read it back.
+		return ReadACLToken(ctx, tr, req.CLIRequest, token.AccessorID)
+	}
+
+	res := &ACLToken{}
+	err = runACLCommandJSON(ctx, tr, req.CLIRequest, req.createCommand(), res)
+	if err != nil {
+		return nil, fmt.Errorf("creating consul ACL token %s: %w", req.Description, err)
+	}
+
+	return res, nil
+}
+
+// createCommand returns the consul acl policy create sub-command.
+func (r *ACLPolicyRequest) createCommand() string {
+	return fmt.Sprintf("acl policy create -format=json -name %s -description %s -rules %s",
+		remoteflight.ShellQuote(r.Name), remoteflight.ShellQuote(r.Description), remoteflight.ShellQuote(r.Rules),
+	)
+}
+
+// updateCommand returns the consul acl policy update sub-command.
+func (r *ACLPolicyRequest) updateCommand(id string) string {
+	return fmt.Sprintf("acl policy update -format=json -id %s -description %s -rules %s",
+		remoteflight.ShellQuote(id), remoteflight.ShellQuote(r.Description), remoteflight.ShellQuote(r.Rules),
+	)
+}
+
+// createCommand returns the consul acl token create sub-command.
+func (r *ACLTokenRequest) createCommand() string {
+	return "acl token create -format=json -description " + remoteflight.ShellQuote(r.Description) + r.policyArgs()
+}
+
+// updateCommand returns the consul acl token update sub-command. The policies of the
+// token are replaced by the requested policies.
+func (r *ACLTokenRequest) updateCommand(accessorID string) string {
+	return fmt.Sprintf("acl token update -format=json -id %s -description %s%s",
+		remoteflight.ShellQuote(accessorID), remoteflight.ShellQuote(r.Description), r.policyArgs(),
+	)
+}
+
+func (r *ACLTokenRequest) policyArgs() string {
+	args := strings.Builder{}
+	for _, policy := range r.Policies {
+		args.WriteString(" -policy-name " + remoteflight.ShellQuote(policy))
+	}
+
+	return args.String()
+}
+
+// runACLCommandJSON runs the consul sub-command and decodes the JSON stdout into res.
+func runACLCommandJSON(ctx context.Context, tr it.Transport, req *CLIRequest, subCmd string, res any) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	stdout, stderr, err := tr.Run(ctx, command.New(
+		req.BinPath+" "+subCmd,
+		command.WithEnvVars(req.EnvVars()),
+	))
+	if err != nil {
+		return remoteflight.WrapErrorWith(err, stderr)
+	}
+
+	if stdout == "" {
+		return errors.New("no JSON body was written to STDOUT")
+	}
+
+	return json.Unmarshal([]byte(stdout), res)
+}
+
+// isACLAlreadyBootstrapped returns whether or not the output of the consul acl bootstrap
+// command indicates that the ACL system has already been bootstrapped.
+func isACLAlreadyBootstrapped(out string) bool {
+	return strings.Contains(out, "ACL bootstrap no longer allowed")
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLBootstrapDeserialize(t *testing.T) {
+	t.Parallel()
+
+	token := &ACLToken{}
+	require.NoError(t, json.Unmarshal(testReadSupport(t, "acl-bootstrap.json"), token))
+	require.Equal(t, "b5b1a918-50bc-fc46-dec2-d481359da4e3", token.AccessorID)
+	require.Equal(t, "527347d3-9653-07dc-adc0-598b8f2b0f4d", token.SecretID)
+	require.Len(t, token.Policies, 1)
+	require.Equal(t, "global-management", token.Policies[0].Name)
+}
+
+func TestIsACLAlreadyBootstrapped(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isACLAlreadyBootstrapped(
+		"Failed ACL bootstrapping: Unexpected response code: 403 (Permission denied: ACL bootstrap no longer allowed (reset index: 12))",
+	))
+	require.False(t, isACLAlreadyBootstrapped(
+		"Failed ACL bootstrapping: Unexpected response code: 500 (The ACL system is currently in legacy mode.)",
+	))
+}
+
+func TestACLPolicyRequestCommands(t *testing.T) {
+	t.Parallel()
+
+	req := NewACLPolicyRequest(
+		WithACLPolicyRequestName("vault"),
+		WithACLPolicyRequestDescription("Vault's storage policy"),
+		WithACLPolicyRequestRules(`key_prefix "vault/" { policy = "write" }`),
+	)
+
+	require.Equal(t,
+		`acl policy create -format=json -name 'vault' -description 'Vault'\''s storage policy' -rules 'key_prefix "vault/" { policy = "write" }'`,
+		req.createCommand(),
+	)
+	require.Equal(t,
+		`acl policy update -format=json -id 'abc' -description 'Vault'\''s storage policy' -rules 'key_prefix "vault/" { policy = "write" }'`,
+		req.updateCommand("abc"),
+	)
+}
+
+func TestACLTokenRequestCommands(t *testing.T) {
+	t.Parallel()
+
+	req := NewACLTokenRequest(
+		WithACLTokenRequestDescription("vault"),
+		WithACLTokenRequestPolicies([]string{"vault", "agent"}),
+	)
+
+	require.Equal(t,
+		`acl token create -format=json -description 'vault' -policy-name 'vault' -policy-name 'agent'`,
+		req.createCommand(),
+	)
+	require.Equal(t,
+		`acl token update -format=json -id 'abc' -description 'vault' -policy-name 'vault' -policy-name 'agent'`,
+		req.updateCommand("abc"),
+	)
+}
+
+func TestACLRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	cli := &CLIRequest{BinPath: "/opt/consul/bin/consul", ConsulAddr: "http://127.0.0.1:8500", Token: "root"}
+
+	require.Error(t, NewACLPolicyRequest(WithACLPolicyRequestCLIRequest(cli)).Validate())
+	require.NoError(t, NewACLPolicyRequest(
+		WithACLPolicyRequestCLIRequest(cli),
+		WithACLPolicyRequestName("vault"),
+		WithACLPolicyRequestRules(`key_prefix "vault/" { policy = "write" }`),
+	).Validate())
+
+	require.Error(t, NewACLTokenRequest(WithACLTokenRequestCLIRequest(&CLIRequest{})).Validate())
+	require.NoError(t, NewACLTokenRequest(
+		WithACLTokenRequestCLIRequest(cli),
+		WithACLTokenRequestDescription("vault"),
+	).Validate())
+}
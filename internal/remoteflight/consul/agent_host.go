@@ -18,6 +18,7 @@ import (
 type AgentHostRequest struct {
 	FlightControlPath string
 	ConsulAddr        string
+	remoteflight.TLSConfig
 }
 
 // AgentHostRequest is a consul /v1/agent/host response.
@@ -56,6 +57,14 @@ func WithAgentHostRequestFlightControlPath(path string) AgentHostRequestOpt {
 	}
 }
 
+// WithAgentHostRequestTLSConfig sets the client TLS configuration.
+func WithAgentHostRequestTLSConfig(tls remoteflight.TLSConfig) AgentHostRequestOpt {
+	return func(u *AgentHostRequest) *AgentHostRequest {
+		u.TLSConfig = tls
+		return u
+	}
+}
+
 // WithAgentHostRequestConsulAddr sets the consul bind address.
 func WithAgentHostRequestConsulAddr(addr string) AgentHostRequestOpt {
 	return func(u *AgentHostRequest) *AgentHostRequest {
@@ -117,9 +126,10 @@ func GetAgentHost(ctx context.Context, tr it.Transport, req *AgentHostRequest) (
 // String returns the request as a string.
 func (r *AgentHostRequest) String() string {
 	return fmt.Sprintf(
-		"%s download --url '%s/v1/agent/host' --stdout",
+		"%s download --url '%s/v1/agent/host' --stdout%s",
 		r.FlightControlPath,
 		r.ConsulAddr,
+		r.FlightControlArgs(),
 	)
 }
 
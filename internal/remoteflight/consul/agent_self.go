@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// AgentSelfRequest is a consul /v1/agent/self request.
+type AgentSelfRequest struct {
+	FlightControlPath string
+	ConsulAddr        string
+	remoteflight.TLSConfig
+}
+
+// AgentSelfResponse is a consul /v1/agent/self response.
+type AgentSelfResponse struct {
+	Config *AgentSelfResponseConfig `json:"Config"`
+}
+
+// AgentSelfResponseConfig is the Config section of the response.
+type AgentSelfResponseConfig struct {
+	Datacenter string `json:"Datacenter"`
+	NodeName   string `json:"NodeName"`
+	NodeID     string `json:"NodeID"`
+	Revision   string `json:"Revision"`
+	Server     bool   `json:"Server"`
+	Version    string `json:"Version"`
+	BuildDate  string `json:"BuildDate"`
+}
+
+// AgentSelfRequestOpt is a functional option agent self requests.
+type AgentSelfRequestOpt func(*AgentSelfRequest) *AgentSelfRequest
+
+// NewAgentSelfRequest takes functional options and returns a new request.
+func NewAgentSelfRequest(opts ...AgentSelfRequestOpt) *AgentSelfRequest {
+	c := &AgentSelfRequest{
+		FlightControlPath: remoteflight.DefaultFlightControlPath,
+		ConsulAddr:        "http://127.0.0.1:8500",
+	}
+
+	for _, opt := range opts {
+		c = opt(c)
+	}
+
+	return c
+}
+
+// WithAgentSelfRequestFlightControlPath sets the path to flightcontrol.
+func WithAgentSelfRequestFlightControlPath(path string) AgentSelfRequestOpt {
+	return func(u *AgentSelfRequest) *AgentSelfRequest {
+		u.FlightControlPath = path
+		return u
+	}
+}
+
+// WithAgentSelfRequestTLSConfig sets the client TLS configuration.
+func WithAgentSelfRequestTLSConfig(tls remoteflight.TLSConfig) AgentSelfRequestOpt {
+	return func(u *AgentSelfRequest) *AgentSelfRequest {
+		u.TLSConfig = tls
+		return u
+	}
+}
+
+// WithAgentSelfRequestConsulAddr sets the consul bind address.
+func WithAgentSelfRequestConsulAddr(addr string) AgentSelfRequestOpt {
+	return func(u *AgentSelfRequest) *AgentSelfRequest {
+		u.ConsulAddr = addr
+		return u
+	}
+}
+
+// GetAgentSelf gets the agent self response.
+func GetAgentSelf(ctx context.Context, tr it.Transport, req *AgentSelfRequest) (
+	*AgentSelfResponse,
+	error,
+) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	var err error
+	res := &AgentSelfResponse{Config: &AgentSelfResponseConfig{}}
+
+	if req.FlightControlPath == "" {
+		err = errors.Join(err, errors.New("you must supply an enos-flight-control path"))
+	}
+
+	if req.ConsulAddr == "" {
+		err = errors.Join(err, errors.New("you must supply a consul listen address"))
+	}
+
+	if err == nil {
+		stdout, stderr, err1 := tr.Run(ctx, command.New(
+			req.String(),
+		))
+
+		if err1 != nil {
+			err = err1
+		}
+
+		if stderr != "" {
+			err = errors.Join(err, fmt.Errorf("unexpected write to STDERR: %s", stderr))
+		}
+
+		// Deserialize the body onto our response.
+		if stdout == "" {
+			err = errors.Join(err, errors.New("no JSON body was written to STDOUT"))
+		} else {
+			err = errors.Join(err, json.Unmarshal([]byte(stdout), res))
+		}
+	}
+
+	if err != nil {
+		return nil, errors.Join(errors.New("read /v1/agent/self"), err)
+	}
+
+	return res, nil
+}
+
+// String returns the request as a string.
+func (r *AgentSelfRequest) String() string {
+	return fmt.Sprintf(
+		"%s download --url '%s/v1/agent/self' --stdout%s",
+		r.FlightControlPath,
+		r.ConsulAddr,
+		r.FlightControlArgs(),
+	)
+}
@@ -117,3 +117,49 @@ func CheckStateClusterHasMinNVoters(min uint) CheckStater {
 		)
 	}
 }
+
+// CheckStateNodeChecksArePassing checks whether or not all of the health checks
+// for the consul node are passing.
+func CheckStateNodeChecksArePassing() CheckStater {
+	return func(s *State) error {
+		if s.HealthNodeResponse == nil || len(s.HealthNodeResponse.Nodes) < 1 {
+			return errors.New("node health checks were not found in state")
+		}
+
+		for _, check := range s.HealthNodeResponse.Nodes {
+			if check.Status != NodeHealthStatusHealthy {
+				return fmt.Errorf(
+					"expected all node health checks to be %s, got: %s",
+					NodeHealthStatusHealthy, s.HealthNodeResponse.String(),
+				)
+			}
+		}
+
+		return nil
+	}
+}
+
+// CheckStateClusterHasNVoters checks whether or not the cluster has exactly N raft voters.
+func CheckStateClusterHasNVoters(n uint) CheckStater {
+	return func(s *State) error {
+		if s.RaftConfigurationResponse == nil || s.Servers == nil {
+			return errors.New("no raft servers were found in state")
+		}
+
+		voters := uint(0)
+		for i := range s.Servers {
+			if s.RaftConfigurationResponse.Servers[i].Voter {
+				voters++
+			}
+		}
+
+		if voters == n {
+			return nil
+		}
+
+		return fmt.Errorf(
+			"expected %d raft voters, got %d, response: %s",
+			n, voters, s.RaftConfigurationResponse.String(),
+		)
+	}
+}
@@ -294,6 +294,111 @@ func TestStateClusterHasMinNVoters(t *testing.T) {
 	}
 }
 
+func TestStateNodeChecksArePassing(t *testing.T) {
+	t.Parallel()
+	for name, test := range map[string]struct {
+		state      func(*testing.T) *State
+		shouldFail bool
+	}{
+		"no-health-in-state": {
+			func(*testing.T) *State { return NewState() },
+			true,
+		},
+		"one-check-failing": {
+			func(t *testing.T) *State {
+				t.Helper()
+				content := testReadSupport(t, "health-node.json")
+				state := NewState()
+				state.HealthNodeResponse = &HealthNodeResponse{Nodes: make([]*NodeHealth, 0)}
+				require.NoError(t, json.Unmarshal(content, &state.HealthNodeResponse.Nodes))
+				cpy := *state.HealthNodeResponse.Nodes[0]
+				cpy.CheckID = "service:consul"
+				cpy.Status = "critical"
+				state.HealthNodeResponse.Nodes = append(state.HealthNodeResponse.Nodes, &cpy)
+
+				return state
+			},
+			true,
+		},
+		"all-checks-passing": {
+			func(t *testing.T) *State {
+				t.Helper()
+				content := testReadSupport(t, "health-node.json")
+				state := NewState()
+				state.HealthNodeResponse = &HealthNodeResponse{Nodes: make([]*NodeHealth, 0)}
+				require.NoError(t, json.Unmarshal(content, &state.HealthNodeResponse.Nodes))
+				cpy := *state.HealthNodeResponse.Nodes[0]
+				cpy.CheckID = "service:consul"
+				state.HealthNodeResponse.Nodes = append(state.HealthNodeResponse.Nodes, &cpy)
+
+				return state
+			},
+			false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			state := test.state(t)
+			if test.shouldFail {
+				require.Error(t, CheckStateNodeChecksArePassing()(state))
+			} else {
+				require.NoError(t, CheckStateNodeChecksArePassing()(state))
+			}
+		})
+	}
+}
+
+func TestStateClusterHasNVoters(t *testing.T) {
+	t.Parallel()
+	for name, test := range map[string]struct {
+		state      func(*testing.T) *State
+		shouldFail bool
+	}{
+		"no-servers-in-state": {
+			func(*testing.T) *State { return NewState() },
+			true,
+		},
+		"more-than-n-voters": {
+			func(t *testing.T) *State {
+				t.Helper()
+				content := testReadSupport(t, "raft-configuration.json")
+				state := NewState()
+				state.RaftConfigurationResponse = &RaftConfigurationResponse{Servers: make([]*RaftServer, 0)}
+				require.NoError(t, json.Unmarshal(content, &state.RaftConfigurationResponse))
+				cpy := *state.Servers[0]
+				state.Servers = append(state.Servers, &cpy)
+
+				return state
+			},
+			true,
+		},
+		"exactly-n-voters": {
+			func(t *testing.T) *State {
+				t.Helper()
+				content := testReadSupport(t, "raft-configuration.json")
+				state := NewState()
+				state.RaftConfigurationResponse = &RaftConfigurationResponse{Servers: make([]*RaftServer, 0)}
+				require.NoError(t, json.Unmarshal(content, &state.RaftConfigurationResponse))
+
+				return state
+			},
+			false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			state := test.state(t)
+			if test.shouldFail {
+				require.Error(t, CheckStateClusterHasNVoters(3)(state))
+			} else {
+				require.NoError(t, CheckStateClusterHasNVoters(3)(state))
+			}
+		})
+	}
+}
+
 func testReadSupport(t *testing.T, name string) []byte {
 	t.Helper()
 
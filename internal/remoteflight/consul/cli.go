@@ -3,7 +3,48 @@
 
 package consul
 
+import (
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+)
+
 // CLIRequest are common things that we need when making a CLI request.
 type CLIRequest struct {
-	BinPath string
+	BinPath    string
+	ConsulAddr string
+	Token      string
+	remoteflight.TLSConfig
+}
+
+// EnvVars returns the environment variables that configure the consul CLI to
+// talk to the consul HTTP API.
+func (r *CLIRequest) EnvVars() map[string]string {
+	env := map[string]string{}
+
+	if r == nil {
+		return env
+	}
+
+	if r.ConsulAddr != "" {
+		env["CONSUL_HTTP_ADDR"] = r.ConsulAddr
+	}
+	if r.Token != "" {
+		env["CONSUL_HTTP_TOKEN"] = r.Token
+	}
+	if r.CACert != "" {
+		env["CONSUL_CACERT"] = r.CACert
+	}
+	if r.ClientCert != "" {
+		env["CONSUL_CLIENT_CERT"] = r.ClientCert
+	}
+	if r.ClientKey != "" {
+		env["CONSUL_CLIENT_KEY"] = r.ClientKey
+	}
+	if r.ServerName != "" {
+		env["CONSUL_TLS_SERVER_NAME"] = r.ServerName
+	}
+	if r.SkipVerify {
+		env["CONSUL_HTTP_SSL_VERIFY"] = "false"
+	}
+
+	return env
 }
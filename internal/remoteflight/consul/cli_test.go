@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+)
+
+func TestCLIRequestEnvVars(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		req      *CLIRequest
+		expected map[string]string
+	}{
+		"bin-only": {
+			&CLIRequest{BinPath: "/usr/local/bin/consul"},
+			map[string]string{},
+		},
+		"tls": {
+			&CLIRequest{
+				BinPath:    "/usr/local/bin/consul",
+				ConsulAddr: "https://127.0.0.1:8501",
+				Token:      "root",
+				TLSConfig: remoteflight.TLSConfig{
+					CACert:     "/etc/consul.d/tls/ca.pem",
+					ClientCert: "/etc/consul.d/tls/client.pem",
+					ClientKey:  "/etc/consul.d/tls/client-key.pem",
+					ServerName: "server.dc1.consul",
+					SkipVerify: true,
+				},
+			},
+			map[string]string{
+				"CONSUL_HTTP_ADDR":       "https://127.0.0.1:8501",
+				"CONSUL_HTTP_TOKEN":      "root",
+				"CONSUL_CACERT":          "/etc/consul.d/tls/ca.pem",
+				"CONSUL_CLIENT_CERT":     "/etc/consul.d/tls/client.pem",
+				"CONSUL_CLIENT_KEY":      "/etc/consul.d/tls/client-key.pem",
+				"CONSUL_TLS_SERVER_NAME": "server.dc1.consul",
+				"CONSUL_HTTP_SSL_VERIFY": "false",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.expected, test.req.EnvVars())
+		})
+	}
+}
+
+func TestAgentHostRequestStringTLS(t *testing.T) {
+	t.Parallel()
+
+	req := NewAgentHostRequest(
+		WithAgentHostRequestConsulAddr("https://127.0.0.1:8501"),
+		WithAgentHostRequestFlightControlPath("/opt/qti/bin/enos-flight-control"),
+		WithAgentHostRequestTLSConfig(remoteflight.TLSConfig{
+			CACert:     "/etc/consul.d/tls/ca.pem",
+			ServerName: "server.dc1.consul",
+		}),
+	)
+
+	require.Equal(t,
+		"/opt/qti/bin/enos-flight-control download --url 'https://127.0.0.1:8501/v1/agent/host' --stdout --ca-cert '/etc/consul.d/tls/ca.pem' --tls-server-name 'server.dc1.consul'",
+		req.String(),
+	)
+}
@@ -20,6 +20,7 @@ type HealthNodeRequest struct {
 	FlightControlPath string
 	NodeName          string
 	ConsulAddr        string
+	remoteflight.TLSConfig
 }
 
 // HealthNodeResponse is a consul /v1/health/node/:node response.
@@ -29,10 +30,12 @@ type HealthNodeResponse struct {
 
 // NodeHealth is the Node section of the response.
 type NodeHealth struct {
-	Node   string `json:"Node"`
-	Status string `json:"Status"`
-	Output string `json:"Output"`
-	Notes  string `json:"Notes"`
+	Node    string `json:"Node"`
+	CheckID string `json:"CheckID"`
+	Name    string `json:"Name"`
+	Status  string `json:"Status"`
+	Output  string `json:"Output"`
+	Notes   string `json:"Notes"`
 }
 
 // NodeHealthStatusHealthy is the "healthy" status of a node.
@@ -63,6 +66,14 @@ func WithHealthNodeRequestFlightControlPath(path string) HealthNodeRequestOpt {
 	}
 }
 
+// WithHealthNodeRequestTLSConfig sets the client TLS configuration.
+func WithHealthNodeRequestTLSConfig(tls remoteflight.TLSConfig) HealthNodeRequestOpt {
+	return func(u *HealthNodeRequest) *HealthNodeRequest {
+		u.TLSConfig = tls
+		return u
+	}
+}
+
 // WithHealthNodeRequestConsulAddr sets consul bind address.
 func WithHealthNodeRequestConsulAddr(addr string) HealthNodeRequestOpt {
 	return func(u *HealthNodeRequest) *HealthNodeRequest {
@@ -136,10 +147,11 @@ func GetHealthNode(ctx context.Context, tr it.Transport, req *HealthNodeRequest)
 // String returns the request as a string.
 func (r *HealthNodeRequest) String() string {
 	return fmt.Sprintf(
-		"%s download --url '%s/v1/health/node/%s' --stdout",
+		"%s download --url '%s/v1/health/node/%s' --stdout%s",
 		r.FlightControlPath,
 		r.ConsulAddr,
 		r.NodeName,
+		r.FlightControlArgs(),
 	)
 }
 
@@ -160,6 +172,10 @@ func (n *NodeHealth) String() string {
 		fmt.Fprintf(out, "Node: %s\n", n.Node)
 	}
 
+	if n.CheckID != "" {
+		fmt.Fprintf(out, "CheckID: %s\n", n.CheckID)
+	}
+
 	if n.Status != "" {
 		fmt.Fprintf(out, "Status: %s\n", n.Status)
 	}
@@ -20,6 +20,7 @@ import (
 type HealthStatePassingRequest struct {
 	FlightControlPath string
 	ConsulAddr        string
+	remoteflight.TLSConfig
 }
 
 // HealthStatePassingRequest is a consul /v1/health/state/passing response.
@@ -51,6 +52,14 @@ func WithHealthStatePassingRequestFlightControlPath(path string) HealthStatePass
 	}
 }
 
+// WithHealthStatePassingRequestTLSConfig sets the client TLS configuration.
+func WithHealthStatePassingRequestTLSConfig(tls remoteflight.TLSConfig) HealthStatePassingRequestOpt {
+	return func(u *HealthStatePassingRequest) *HealthStatePassingRequest {
+		u.TLSConfig = tls
+		return u
+	}
+}
+
 // WithHealthStatePassingRequestConsulAddr sets the consul bind address.
 func WithHealthStatePassingRequestConsulAddr(addr string) HealthStatePassingRequestOpt {
 	return func(u *HealthStatePassingRequest) *HealthStatePassingRequest {
@@ -112,9 +121,10 @@ func GetHealthStatePassing(ctx context.Context, tr it.Transport, req *HealthStat
 // String returns the request as a string.
 func (r *HealthStatePassingRequest) String() string {
 	return fmt.Sprintf(
-		"%s download --url '%s/v1/health/state/passing' --stdout",
+		"%s download --url '%s/v1/health/state/passing' --stdout%s",
 		r.FlightControlPath,
 		r.ConsulAddr,
+		r.FlightControlArgs(),
 	)
 }
 
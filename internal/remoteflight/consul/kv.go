@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/retry"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// KVRequest is a request to write or read keys in the consul KV store.
+type KVRequest struct {
+	*CLIRequest
+	Data map[string]string
+	// Stale allows reads to be served by any server rather than the leader.
+	Stale bool
+}
+
+// KVRequestOpt is a functional option for a KV request.
+type KVRequestOpt func(*KVRequest) *KVRequest
+
+// NewKVRequest takes functional options and returns a new KV request.
+func NewKVRequest(opts ...KVRequestOpt) *KVRequest {
+	r := &KVRequest{
+		CLIRequest: &CLIRequest{},
+		Data:       map[string]string{},
+	}
+
+	for _, opt := range opts {
+		r = opt(r)
+	}
+
+	return r
+}
+
+// WithKVRequestCLIRequest sets the consul CLI request.
+func WithKVRequestCLIRequest(cli *CLIRequest) KVRequestOpt {
+	return func(r *KVRequest) *KVRequest {
+		r.CLIRequest = cli
+		return r
+	}
+}
+
+// WithKVRequestData sets the keys and values.
+func WithKVRequestData(data map[string]string) KVRequestOpt {
+	return func(r *KVRequest) *KVRequest {
+		r.Data = data
+		return r
+	}
+}
+
+// WithKVRequestStale allows reads to be served by any server.
+func WithKVRequestStale() KVRequestOpt {
+	return func(r *KVRequest) *KVRequest {
+		r.Stale = true
+		return r
+	}
+}
+
+// Validate validates that the KV request has the required fields.
+func (r *KVRequest) Validate() error {
+	err := r.CLIRequest.Validate()
+
+	if len(r.Data) < 1 {
+		err = errors.Join(err, errors.New("you must supply KV data"))
+	}
+
+	for key := range r.Data {
+		if key == "" {
+			err = errors.Join(err, errors.New("KV keys cannot be empty"))
+		}
+	}
+
+	return err
+}
+
+// keys returns the data keys in a stable order.
+func (r *KVRequest) keys() []string {
+	keys := []string{}
+	for key := range r.Data {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// getCommand returns the consul kv get command for the key.
+func (r *KVRequest) getCommand(key string) string {
+	cmd := r.BinPath + " kv get"
+	if r.Stale {
+		cmd += " -stale"
+	}
+
+	return cmd + " " + remoteflight.ShellQuote(key)
+}
+
+// PutKV writes the request data to the KV store.
+func PutKV(ctx context.Context, tr it.Transport, req *KVRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("writing consul kv data: %w", err)
+	}
+
+	for _, key := range req.keys() {
+		_, stderr, err := tr.Run(ctx, command.New(
+			fmt.Sprintf("%s kv put %s %s", req.BinPath, remoteflight.ShellQuote(key), remoteflight.ShellQuote(req.Data[key])),
+			command.WithEnvVars(req.EnvVars()),
+		))
+		if err != nil {
+			return remoteflight.WrapErrorWith(fmt.Errorf("writing consul kv key %s: %w", key, err), stderr)
+		}
+	}
+
+	return nil
+}
+
+// GetKV reads the value of every key in the request data.
+func GetKV(ctx context.Context, tr it.Transport, req *KVRequest) (map[string]string, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("reading consul kv data: %w", err)
+	}
+
+	res := map[string]string{}
+	for _, key := range req.keys() {
+		stdout, stderr, err := tr.Run(ctx, command.New(
+			req.getCommand(key),
+			command.WithEnvVars(req.EnvVars()),
+		))
+		if err != nil {
+			if strings.Contains(stderr, "No key exists") {
+				continue
+			}
+
+			return nil, remoteflight.WrapErrorWith(fmt.Errorf("reading consul kv key %s: %w", key, err), stderr)
+		}
+
+		res[key] = strings.TrimSuffix(stdout, "\n")
+	}
+
+	return res, nil
+}
+
+// VerifyKV reads the KV data and verifies that it matches the request data.
+func VerifyKV(ctx context.Context, tr it.Transport, req *KVRequest) error {
+	got, err := GetKV(ctx, tr, req)
+	if err != nil {
+		return err
+	}
+
+	return VerifyKVData(req.Data, got)
+}
+
+// WaitForKV waits until the KV store contains the request data. This is useful
+// when reading stale data from servers that might lag behind the leader.
+func WaitForKV(ctx context.Context, tr it.Transport, req *KVRequest) error {
+	verifyKV := func(ctx context.Context) (any, error) {
+		return nil, VerifyKV(ctx, tr, req)
+	}
+
+	r, err := retry.NewRetrier(
+		retry.WithIntervalFunc(retry.IntervalDuration(2*time.Second)),
+		retry.WithRetrierFunc(verifyKV),
+	)
+	if err == nil {
+		_, err = retry.Retry(ctx, r)
+	}
+
+	if err != nil {
+		return fmt.Errorf("waiting for consul kv to have expected data: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyKVData verifies that every key and value that we expect is in the data
+// we got.
+func VerifyKVData(expected map[string]string, got map[string]string) error {
+	keys := []string{}
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var err error
+	for _, key := range keys {
+		val, ok := got[key]
+		if !ok {
+			err = errors.Join(err, fmt.Errorf("missing key: %s", key))
+			continue
+		}
+
+		if val != expected[key] {
+			err = errors.Join(err, fmt.Errorf(
+				"unexpected value for key: %s, expected: %s, got: %s", key, expected[key], val,
+			))
+		}
+	}
+
+	return err
+}
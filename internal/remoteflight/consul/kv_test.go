@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKVRequestGetCommand(t *testing.T) {
+	t.Parallel()
+
+	req := NewKVRequest(
+		WithKVRequestCLIRequest(&CLIRequest{BinPath: "/opt/consul/bin/consul"}),
+	)
+	require.Equal(t, `/opt/consul/bin/consul kv get 'foo/bar'`, req.getCommand("foo/bar"))
+
+	req = NewKVRequest(
+		WithKVRequestCLIRequest(&CLIRequest{BinPath: "/opt/consul/bin/consul"}),
+		WithKVRequestStale(),
+	)
+	require.Equal(t, `/opt/consul/bin/consul kv get -stale 'foo/bar'`, req.getCommand("foo/bar"))
+}
+
+func TestKVRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	cli := &CLIRequest{
+		BinPath:    "/opt/consul/bin/consul",
+		ConsulAddr: "http://127.0.0.1:8500",
+	}
+
+	require.NoError(t, NewKVRequest(
+		WithKVRequestCLIRequest(cli),
+		WithKVRequestData(map[string]string{"foo": "bar"}),
+	).Validate())
+	require.Error(t, NewKVRequest(WithKVRequestCLIRequest(cli)).Validate())
+	require.Error(t, NewKVRequest(
+		WithKVRequestCLIRequest(cli),
+		WithKVRequestData(map[string]string{"": "bar"}),
+	).Validate())
+}
+
+func TestVerifyKVData(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		expected  map[string]string
+		got       map[string]string
+		expectErr bool
+	}{
+		"match": {
+			expected: map[string]string{"foo": "bar"},
+			got:      map[string]string{"foo": "bar"},
+		},
+		"missing key": {
+			expected:  map[string]string{"foo": "bar"},
+			got:       map[string]string{},
+			expectErr: true,
+		},
+		"wrong value": {
+			expected:  map[string]string{"foo": "bar"},
+			got:       map[string]string{"foo": "baz"},
+			expectErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := VerifyKVData(test.expected, test.got)
+			if test.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 type RaftConfigurationRequest struct {
 	FlightControlPath string
 	ConsulAddr        string
+	remoteflight.TLSConfig
 }
 
 // RaftConfigurationResponse is a consul /v1/operator/raft/configuration response.
@@ -62,6 +63,14 @@ func WithRaftConfigurationRequestFlightControlPath(path string) RaftConfiguratio
 	}
 }
 
+// WithRaftConfigurationRequestTLSConfig sets the client TLS configuration.
+func WithRaftConfigurationRequestTLSConfig(tls remoteflight.TLSConfig) RaftConfigurationRequestOpt {
+	return func(u *RaftConfigurationRequest) *RaftConfigurationRequest {
+		u.TLSConfig = tls
+		return u
+	}
+}
+
 // WithRaftConfigurationRequestConsulAddr sets the consul bind address.
 func WithRaftConfigurationRequestConsulAddr(addr string) RaftConfigurationRequestOpt {
 	return func(u *RaftConfigurationRequest) *RaftConfigurationRequest {
@@ -123,9 +132,10 @@ func GetRaftConfiguration(ctx context.Context, tr it.Transport, req *RaftConfigu
 // String returns the request as a string.
 func (r *RaftConfigurationRequest) String() string {
 	return fmt.Sprintf(
-		"%s download --url '%s/v1/operator/raft/configuration' --stdout",
+		"%s download --url '%s/v1/operator/raft/configuration' --stdout%s",
 		r.FlightControlPath,
 		r.ConsulAddr,
+		r.FlightControlArgs(),
 	)
 }
 
@@ -144,6 +154,21 @@ func (r *RaftConfigurationResponse) String() string {
 	return out.String()
 }
 
+// Leader returns the raft server that is the leader.
+func (r *RaftConfigurationResponse) Leader() (*RaftServer, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	for i := range r.Servers {
+		if r.Servers[i] != nil && r.Servers[i].Leader {
+			return r.Servers[i], true
+		}
+	}
+
+	return nil, false
+}
+
 // String returns the RaftServer as a string.
 func (s *RaftServer) String() string {
 	if s == nil {
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// snapshotIndexRegexp matches the raft index in the "consul snapshot save" output.
+var snapshotIndexRegexp = regexp.MustCompile(`snapshot to index (\d+)`)
+
+// SnapshotRequest is a request to save or restore a consul snapshot.
+type SnapshotRequest struct {
+	*CLIRequest
+	Path string
+}
+
+// SnapshotRequestOpt is a functional option for a snapshot request.
+type SnapshotRequestOpt func(*SnapshotRequest) *SnapshotRequest
+
+// NewSnapshotRequest takes functional options and returns a new snapshot request.
+func NewSnapshotRequest(opts ...SnapshotRequestOpt) *SnapshotRequest {
+	r := &SnapshotRequest{
+		CLIRequest: &CLIRequest{},
+	}
+
+	for _, opt := range opts {
+		r = opt(r)
+	}
+
+	return r
+}
+
+// WithSnapshotRequestCLIRequest sets the consul CLI request.
+func WithSnapshotRequestCLIRequest(cli *CLIRequest) SnapshotRequestOpt {
+	return func(r *SnapshotRequest) *SnapshotRequest {
+		r.CLIRequest = cli
+		return r
+	}
+}
+
+// WithSnapshotRequestPath sets the path of the snapshot file on the target.
+func WithSnapshotRequestPath(path string) SnapshotRequestOpt {
+	return func(r *SnapshotRequest) *SnapshotRequest {
+		r.Path = path
+		return r
+	}
+}
+
+// Validate validates that the snapshot request has the required fields.
+func (r *SnapshotRequest) Validate() error {
+	err := r.CLIRequest.Validate()
+
+	if r.Path == "" {
+		err = errors.Join(err, errors.New("you must supply a snapshot path"))
+	}
+
+	return err
+}
+
+// SaveSnapshot saves a snapshot of the cluster state to the request path and
+// returns the raft index of the snapshot. Consul forwards the request to the
+// leader so it can be run against any agent in the cluster.
+func SaveSnapshot(ctx context.Context, tr it.Transport, req *SnapshotRequest) (uint64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	default:
+	}
+
+	if err := req.Validate(); err != nil {
+		return 0, fmt.Errorf("saving consul snapshot: %w", err)
+	}
+
+	stdout, stderr, err := tr.Run(ctx, command.New(
+		fmt.Sprintf("%s snapshot save %s", req.BinPath, remoteflight.ShellQuote(req.Path)),
+		command.WithEnvVars(req.EnvVars()),
+	))
+	if err != nil {
+		return 0, remoteflight.WrapErrorWith(fmt.Errorf("saving consul snapshot to %s: %w", req.Path, err), stderr)
+	}
+
+	return parseSnapshotIndex(stdout)
+}
+
+// RestoreSnapshot restores the snapshot at the request path.
+func RestoreSnapshot(ctx context.Context, tr it.Transport, req *SnapshotRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("restoring consul snapshot: %w", err)
+	}
+
+	_, stderr, err := tr.Run(ctx, command.New(
+		fmt.Sprintf("%s snapshot restore %s", req.BinPath, remoteflight.ShellQuote(req.Path)),
+		command.WithEnvVars(req.EnvVars()),
+	))
+	if err != nil {
+		return remoteflight.WrapErrorWith(fmt.Errorf("restoring consul snapshot from %s: %w", req.Path, err), stderr)
+	}
+
+	return nil
+}
+
+// DownloadSnapshot copies the snapshot at the request path on the target to the
+// local destination.
+func DownloadSnapshot(ctx context.Context, tr it.Transport, req *SnapshotRequest, dest string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if req.Path == "" {
+		return errors.New("downloading consul snapshot: you must supply a snapshot path")
+	}
+
+	// Encode the snapshot so that the binary content survives the transport.
+	stdout, stderr, err := tr.Run(ctx, command.New("base64 "+remoteflight.ShellQuote(req.Path)))
+	if err != nil {
+		return remoteflight.WrapErrorWith(fmt.Errorf("reading consul snapshot %s: %w", req.Path, err), stderr)
+	}
+
+	snapshot, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(stdout), ""))
+	if err != nil {
+		return fmt.Errorf("decoding consul snapshot %s: %w", req.Path, err)
+	}
+
+	err = os.WriteFile(dest, snapshot, 0o600)
+	if err != nil {
+		return fmt.Errorf("writing consul snapshot to %s: %w", dest, err)
+	}
+
+	return nil
+}
+
+// parseSnapshotIndex parses the raft index from the "consul snapshot save" output.
+func parseSnapshotIndex(out string) (uint64, error) {
+	matches := snapshotIndexRegexp.FindStringSubmatch(out)
+	if len(matches) != 2 {
+		return 0, fmt.Errorf("unable to determine snapshot index from output: %s", out)
+	}
+
+	return strconv.ParseUint(matches[1], 10, 64)
+}
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSnapshotIndex(t *testing.T) {
+	t.Parallel()
+
+	idx, err := parseSnapshotIndex("Saved and verified snapshot to index 1234\n")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1234), idx)
+
+	_, err = parseSnapshotIndex("Error saving snapshot: Unexpected response code: 500")
+	require.Error(t, err)
+}
+
+func TestSnapshotRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	req := NewSnapshotRequest(
+		WithSnapshotRequestCLIRequest(&CLIRequest{
+			BinPath:    "/opt/consul/bin/consul",
+			ConsulAddr: "http://127.0.0.1:8500",
+		}),
+		WithSnapshotRequestPath("/tmp/consul.snap"),
+	)
+	require.NoError(t, req.Validate())
+
+	req.Path = ""
+	require.Error(t, req.Validate())
+}
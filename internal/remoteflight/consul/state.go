@@ -34,6 +34,7 @@ type StateRequest struct {
 	FlightControlUseHomeDir bool   // install enos-flight-control into the $HOME directory
 	SystemdUnitName         string // what the systemd unit name for the consul service is
 	ConsulAddr              string // consul bind address
+	remoteflight.TLSConfig         // consul client TLS configuration
 }
 
 // StateRequestOpt is a functional option for a config create request.
@@ -97,6 +98,14 @@ func WithStateRequestConsulAddr(addr string) StateRequestOpt {
 	}
 }
 
+// WithStateRequestTLSConfig sets the consul client TLS configuration.
+func WithStateRequestTLSConfig(tls remoteflight.TLSConfig) StateRequestOpt {
+	return func(u *StateRequest) *StateRequest {
+		u.TLSConfig = tls
+		return u
+	}
+}
+
 // GetState returns the consul cluster node state.
 func GetState(ctx context.Context, tr it.Transport, req *StateRequest) (*State, error) {
 	select {
@@ -144,6 +153,7 @@ func GetState(ctx context.Context, tr it.Transport, req *StateRequest) (*State,
 	state.AgentHostResponse, err = GetAgentHost(
 		ctx, tr, NewAgentHostRequest(
 			WithAgentHostRequestConsulAddr(req.ConsulAddr),
+			WithAgentHostRequestTLSConfig(req.TLSConfig),
 			WithAgentHostRequestFlightControlPath(fcRes.Path),
 		),
 	)
@@ -155,6 +165,7 @@ func GetState(ctx context.Context, tr it.Transport, req *StateRequest) (*State,
 		ctx, tr, NewHealthNodeRequest(
 			WithHealthNodeRequestFlightControlPath(fcRes.Path),
 			WithHealthNodeRequestConsulAddr(req.ConsulAddr),
+			WithHealthNodeRequestTLSConfig(req.TLSConfig),
 			WithHealthNodeRequestNodeName(state.Hostname()),
 		),
 	)
@@ -166,6 +177,7 @@ func GetState(ctx context.Context, tr it.Transport, req *StateRequest) (*State,
 		ctx, tr, NewHealthStatePassingRequest(
 			WithHealthStatePassingRequestFlightControlPath(fcRes.Path),
 			WithHealthStatePassingRequestConsulAddr(req.ConsulAddr),
+			WithHealthStatePassingRequestTLSConfig(req.TLSConfig),
 		),
 	)
 	if err != nil {
@@ -176,6 +188,7 @@ func GetState(ctx context.Context, tr it.Transport, req *StateRequest) (*State,
 		ctx, tr, NewRaftConfigurationRequest(
 			WithRaftConfigurationRequestFlightControlPath(fcRes.Path),
 			WithRaftConfigurationRequestConsulAddr(req.ConsulAddr),
+			WithRaftConfigurationRequestTLSConfig(req.TLSConfig),
 		),
 	)
 
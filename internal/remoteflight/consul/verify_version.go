@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package consul
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+)
+
+// VerifyVersionRequest is a request to verify the version and build metadata
+// of the consul binary and the running consul agent.
+type VerifyVersionRequest struct {
+	*CLIRequest
+	*remoteflight.ExpectedVersion
+	// Where to install enos-flight-control
+	FlightControlPath string
+	// Install enos-flight-control into the $HOME directory
+	FlightControlUseHomeDir bool
+}
+
+// VerifyVersionRequestOpt is a functional option for a verify version request.
+type VerifyVersionRequestOpt func(*VerifyVersionRequest) *VerifyVersionRequest
+
+// NewVerifyVersionRequest takes functional options and returns a new verify
+// version request.
+func NewVerifyVersionRequest(opts ...VerifyVersionRequestOpt) *VerifyVersionRequest {
+	v := &VerifyVersionRequest{
+		CLIRequest:        &CLIRequest{ConsulAddr: "http://127.0.0.1:8500"},
+		ExpectedVersion:   &remoteflight.ExpectedVersion{},
+		FlightControlPath: remoteflight.DefaultFlightControlPath,
+	}
+
+	for _, opt := range opts {
+		v = opt(v)
+	}
+
+	return v
+}
+
+// WithVerifyVersionRequestBinPath sets the consul binary path.
+func WithVerifyVersionRequestBinPath(path string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.BinPath = path
+		return v
+	}
+}
+
+// WithVerifyVersionRequestConsulAddr sets the consul address.
+func WithVerifyVersionRequestConsulAddr(addr string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.ConsulAddr = addr
+		return v
+	}
+}
+
+// WithVerifyVersionRequestVersion sets the expected version.
+func WithVerifyVersionRequestVersion(version string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.Version = version
+		return v
+	}
+}
+
+// WithVerifyVersionRequestEdition sets the expected edition.
+func WithVerifyVersionRequestEdition(edition string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.Edition = edition
+		return v
+	}
+}
+
+// WithVerifyVersionRequestRevision sets the expected revision SHA.
+func WithVerifyVersionRequestRevision(revision string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.Revision = revision
+		return v
+	}
+}
+
+// WithVerifyVersionRequestBuildDate sets the expected build date.
+func WithVerifyVersionRequestBuildDate(date string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.BuildDate = date
+		return v
+	}
+}
+
+// WithVerifyVersionRequestFlightControlUseHomeDir installs enos-flight-control
+// into the $HOME directory.
+func WithVerifyVersionRequestFlightControlUseHomeDir() VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.FlightControlUseHomeDir = true
+		return v
+	}
+}
+
+// VerifyVersion verifies that the consul binary and the running consul agent
+// report the expected version, edition, revision and build date. The binary is
+// verified with "consul version" and the agent with /v1/agent/self.
+func VerifyVersion(ctx context.Context, tr it.Transport, req *VerifyVersionRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if req.BinPath == "" {
+		return errors.New("you must supply a consul bin path")
+	}
+
+	if err := req.ExpectedVersion.Validate(); err != nil {
+		return err
+	}
+
+	var err error
+
+	cliRes, err1 := GetVersion(ctx, tr, req.CLIRequest)
+	if err1 != nil {
+		err = errors.Join(err, fmt.Errorf("consul version: %w", err1))
+	} else {
+		ver, err1 := cliRes.SemVer()
+		if err1 != nil {
+			err = errors.Join(err, fmt.Errorf("consul version: parsing version: %w", err1))
+		} else {
+			err = errors.Join(err, req.ExpectedVersion.VerifyVersion("consul version", ver))
+		}
+		err = errors.Join(err,
+			req.ExpectedVersion.VerifyRevision("consul version", cliRes.Revision),
+			req.ExpectedVersion.VerifyBuildDate("consul version", cliRes.BuildDate),
+		)
+	}
+
+	opts := []remoteflight.InstallFlightControlOpt{}
+	if req.FlightControlUseHomeDir {
+		opts = append(opts, remoteflight.WithInstallFlightControlRequestUseHomeDir())
+	} else {
+		opts = append(opts, remoteflight.WithInstallFlightControlRequestPath(req.FlightControlPath))
+	}
+
+	fcRes, err1 := remoteflight.InstallFlightControl(ctx, tr, remoteflight.NewInstallFlightControlRequest(opts...))
+	if err1 != nil {
+		return errors.Join(err, fmt.Errorf("failed to install enos-flight-control binary: %w", err1))
+	}
+
+	self, err1 := GetAgentSelf(ctx, tr, NewAgentSelfRequest(
+		WithAgentSelfRequestConsulAddr(req.ConsulAddr),
+		WithAgentSelfRequestFlightControlPath(fcRes.Path),
+		WithAgentSelfRequestTLSConfig(req.TLSConfig),
+	))
+	if err1 != nil {
+		return errors.Join(err, err1)
+	}
+
+	ver, err1 := semver.Make(strings.TrimLeft(self.Config.Version, "v"))
+	if err1 != nil {
+		return errors.Join(err, fmt.Errorf("/v1/agent/self: parsing version %s: %w", self.Config.Version, err1))
+	}
+
+	err = errors.Join(err,
+		req.ExpectedVersion.VerifyVersion("/v1/agent/self", ver),
+		req.ExpectedVersion.VerifyRevision("/v1/agent/self", self.Config.Revision),
+	)
+
+	// Older versions of consul do not include the build date in the agent config.
+	if self.Config.BuildDate != "" {
+		err = errors.Join(err, req.ExpectedVersion.VerifyBuildDate("/v1/agent/self", self.Config.BuildDate))
+	}
+
+	return err
+}
@@ -5,6 +5,8 @@ package consul
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -44,3 +46,54 @@ func parseConsulVersion(version string) (semver.Version, error) {
 
 	return ver, nil
 }
+
+// VersionResponse is the JSON stdout result of "consul version -format=json".
+type VersionResponse struct {
+	Version     string `json:"Version"`
+	Revision    string `json:"Revision"`
+	Prerelease  string `json:"Prerelease"`
+	FullVersion string `json:"FullVersion"`
+	BuildDate   string `json:"BuildDate"`
+}
+
+// GetVersion returns the version, revision and build date of the consul binary.
+func GetVersion(ctx context.Context, tr it.Transport, req *CLIRequest) (*VersionResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if req.BinPath == "" {
+		return nil, errors.New("you must supply a consul bin path")
+	}
+
+	stdout, stderr, err := tr.Run(ctx, command.New(req.BinPath+" version -format=json"))
+	if err != nil {
+		return nil, remoteflight.WrapErrorWith(err, stdout, stderr)
+	}
+
+	res := &VersionResponse{}
+	if err := json.Unmarshal([]byte(stdout), res); err != nil {
+		return nil, fmt.Errorf("failed to parse consul version output: %w", err)
+	}
+
+	return res, nil
+}
+
+// SemVer returns the full version, including the build metadata, as a semver.Version.
+func (r *VersionResponse) SemVer() (semver.Version, error) {
+	if r == nil {
+		return semver.Version{}, errors.New("no consul version")
+	}
+
+	ver := r.FullVersion
+	if ver == "" {
+		ver = r.Version
+		if r.Prerelease != "" {
+			ver = fmt.Sprintf("%s-%s", ver, r.Prerelease)
+		}
+	}
+
+	return semver.Make(strings.TrimLeft(ver, "v"))
+}
@@ -4,6 +4,7 @@
 package consul
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -82,3 +83,42 @@ func TestParseConsulVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestVersionDeserialize(t *testing.T) {
+	t.Parallel()
+
+	expected := &VersionResponse{
+		Version:     "1.17.0",
+		Revision:    "4e3f428b",
+		FullVersion: "1.17.0+ent",
+		BuildDate:   "2023-11-03T14:56:56Z",
+	}
+
+	got := &VersionResponse{}
+	body := testReadSupport(t, "version.json")
+	require.NoError(t, json.Unmarshal(body, got))
+	require.Equal(t, expected, got)
+
+	ver, err := got.SemVer()
+	require.NoError(t, err)
+	require.Equal(t, "1.17.0+ent", ver.String())
+}
+
+func TestAgentSelfDeserialize(t *testing.T) {
+	t.Parallel()
+
+	expected := &AgentSelfResponse{Config: &AgentSelfResponseConfig{
+		Datacenter: "dc1",
+		NodeName:   "ip-10-13-10-84",
+		NodeID:     "ec273548-8995-ce6d-079f-38406d190c2a",
+		Revision:   "4e3f428b",
+		Server:     true,
+		Version:    "1.17.0+ent",
+		BuildDate:  "2023-11-03T14:56:56Z",
+	}}
+
+	got := &AgentSelfResponse{Config: &AgentSelfResponseConfig{}}
+	body := testReadSupport(t, "agent-self.json")
+	require.NoError(t, json.Unmarshal(body, got))
+	require.Equal(t, expected, got)
+}
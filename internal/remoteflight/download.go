@@ -6,11 +6,16 @@ package remoteflight
 import (
 	"context"
 	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
 	"time"
 
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/random"
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/retry"
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+	tfile "github.com/hashicorp-forge/terraform-provider-enos/internal/transport/file"
 )
 
 // DownloadRequest performs a remote flight control download.
@@ -25,6 +30,9 @@ type DownloadRequest struct {
 	AuthUser          string
 	AuthPassword      string
 	AuthToken         string
+	Headers           map[string]string
+	CACert            string // PEM encoded CA certificate bundle used to verify the server
+	TmpDir            string
 	Sudo              bool
 	Replace           bool
 	RetryOpts         []retry.RetrierOpt
@@ -43,6 +51,7 @@ func NewDownloadRequest(opts ...DownloadOpt) *DownloadRequest {
 		HTTPMethod:        "GET",
 		Timeout:           "5m",
 		Mode:              "0755",
+		TmpDir:            "/tmp",
 		Sudo:              false,
 		Replace:           false,
 		RetryOpts: []retry.RetrierOpt{
@@ -123,6 +132,31 @@ func WithDownloadRequestAuthToken(token string) DownloadOpt {
 	}
 }
 
+// WithDownloadRequestHeaders sets headers that are added to the download request.
+func WithDownloadRequestHeaders(headers map[string]string) DownloadOpt {
+	return func(dr *DownloadRequest) *DownloadRequest {
+		dr.Headers = headers
+		return dr
+	}
+}
+
+// WithDownloadRequestCACert sets the PEM encoded CA certificate bundle that is used to verify
+// the server. It will be copied to the remote host before downloading.
+func WithDownloadRequestCACert(pem string) DownloadOpt {
+	return func(dr *DownloadRequest) *DownloadRequest {
+		dr.CACert = pem
+		return dr
+	}
+}
+
+// WithDownloadRequestTmpDir sets the temporary directory on the remote host.
+func WithDownloadRequestTmpDir(dir string) DownloadOpt {
+	return func(dr *DownloadRequest) *DownloadRequest {
+		dr.TmpDir = dir
+		return dr
+	}
+}
+
 // WithDownloadRequestSHA256 sets required SHA256 sum.
 func WithDownloadRequestSHA256(sha string) DownloadOpt {
 	return func(dr *DownloadRequest) *DownloadRequest {
@@ -190,6 +224,27 @@ func Download(ctx context.Context, tr transport.Transport, dr *DownloadRequest)
 		cmd = fmt.Sprintf("%s --auth-token '%s'", cmd, dr.AuthToken)
 	}
 
+	for _, key := range slices.Sorted(maps.Keys(dr.Headers)) {
+		cmd = fmt.Sprintf("%s --header '%s: %s'", cmd, key, dr.Headers[key])
+	}
+
+	if dr.CACert != "" {
+		caPath := filepath.Join(dr.TmpDir, fmt.Sprintf("enos_download_ca.%s.pem", random.ID()))
+		err := CopyFile(ctx, tr, NewCopyFileRequest(
+			WithCopyFileContent(tfile.NewReader(dr.CACert)),
+			WithCopyFileDestination(caPath),
+			WithCopyFileChmod("0644"),
+		))
+		if err != nil {
+			return res, fmt.Errorf("copying CA certificate to remote host: %w", err)
+		}
+		defer func() {
+			_ = DeleteFile(ctx, tr, NewDeleteFileRequest(WithDeleteFilePath(caPath)))
+		}()
+
+		cmd = fmt.Sprintf("%s --ca-cert '%s'", cmd, caPath)
+	}
+
 	runCmd := func(ctx context.Context) (any, error) {
 		var resp any
 		stdout, stderr, err := tr.Run(ctx, command.New(cmd))
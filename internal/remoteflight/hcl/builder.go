@@ -5,6 +5,8 @@ package hcl
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/zclconf/go-cty/cty"
 
@@ -85,10 +87,26 @@ func (h *Builder) AppendBlock(name string, labels []string) *Builder {
 }
 
 // AppendAttributes appends the provided attributes to the current block and returns the Builder for
-// the current block.
+// the current block. Attributes are appended in sorted order so that the rendered HCL is stable.
 func (h *Builder) AppendAttributes(attributes map[string]any) *Builder {
-	for k, v := range attributes {
-		h.AppendAttribute(k, v)
+	for _, k := range slices.Sorted(maps.Keys(attributes)) {
+		h.AppendAttribute(k, attributes[k])
+	}
+
+	return h
+}
+
+// AppendAttributesAsBlocks appends the provided attributes to the current block and returns the
+// Builder for the current block. Unlike AppendAttributes, nested objects are appended as blocks
+// rather than object attributes.
+func (h *Builder) AppendAttributesAsBlocks(attributes map[string]any) *Builder {
+	for _, k := range slices.Sorted(maps.Keys(attributes)) {
+		if obj, ok := attributes[k].(map[string]any); ok {
+			h.AppendBlock(k, nil).AppendAttributesAsBlocks(obj)
+			continue
+		}
+
+		h.AppendAttribute(k, attributes[k])
 	}
 
 	return h
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilderAppendAttributes(t *testing.T) {
+	t.Parallel()
+
+	b := NewBuilder()
+	b.AppendAttribute("ui", true)
+	b.AppendBlock("listener", []string{"tcp"}).AppendAttributes(map[string]any{
+		"tls_disable":                      "true",
+		"address":                          "0.0.0.0:8200",
+		"x_forwarded_for_authorized_addrs": []string{"10.0.0.0/8", "172.16.0.0/12"},
+		"telemetry": map[string]string{
+			"unauthenticated_metrics_access": "true",
+		},
+	})
+
+	hcl, err := b.BuildHCL()
+	require.NoError(t, err)
+	require.Equal(t, `ui = true
+listener "tcp" {
+  address = "0.0.0.0:8200"
+  telemetry = {
+    unauthenticated_metrics_access = "true"
+  }
+  tls_disable                      = "true"
+  x_forwarded_for_authorized_addrs = ["10.0.0.0/8", "172.16.0.0/12"]
+}
+`, hcl)
+}
+
+func TestBuilderAppendAttributesAsBlocks(t *testing.T) {
+	t.Parallel()
+
+	b := NewBuilder()
+	b.AppendAttributesAsBlocks(map[string]any{
+		"server": true,
+		"tls": map[string]any{
+			"internal_rpc": map[string]any{
+				"verify_server_hostname": true,
+			},
+			"defaults": map[string]any{
+				"verify_incoming": true,
+				"ca_file":         "/etc/consul.d/tls/ca.pem",
+			},
+		},
+		"retry_join": []string{"10.0.0.1", "10.0.0.2"},
+	})
+
+	hcl, err := b.BuildHCL()
+	require.NoError(t, err)
+	require.Equal(t, `retry_join = ["10.0.0.1", "10.0.0.2"]
+server     = true
+tls {
+  defaults {
+    ca_file         = "/etc/consul.d/tls/ca.pem"
+    verify_incoming = true
+  }
+  internal_rpc {
+    verify_server_hostname = true
+  }
+}
+`, hcl)
+}
@@ -0,0 +1,407 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/flightcontrol/httpclient"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/random"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+	tfile "github.com/hashicorp-forge/terraform-provider-enos/internal/transport/file"
+)
+
+// HTTPRequestRequest performs a remote flight control HTTP request.
+type HTTPRequestRequest struct {
+	FlightControlPath string
+	URL               string
+	Method            string
+	Headers           map[string]string
+	Body              string
+	JSONBody          bool // Whether or not the body is JSON
+	AuthUser          string
+	AuthPassword      string
+	AuthToken         string
+	ExpectedStatuses  []int // If unset any 2xx status is expected
+	Timeout           string
+	Retries           int
+	RetryInterval     string
+	JSONPath          string
+	CACert            string // PEM encoded CA certificate bundle used to verify the server
+	ClientCert        string // PEM encoded client certificate
+	ClientKey         string // PEM encoded client key
+	TLSServerName     string
+	TLSSkipVerify     bool
+	TmpDir            string
+	Sudo              bool
+}
+
+// HTTPRequestResponse is a flight control HTTP request response.
+type HTTPRequestResponse struct {
+	StatusCode int
+	Headers    map[string][]string
+	Body       string
+	// Value is the value at the JSON path of the response body. String values are returned as-is,
+	// all other values are JSON encoded.
+	Value string
+}
+
+// HTTPRequestOpt is a functional option for an HTTP request.
+type HTTPRequestOpt func(*HTTPRequestRequest) *HTTPRequestRequest
+
+// NewHTTPRequestRequest takes functional options and returns a new HTTP request.
+func NewHTTPRequestRequest(opts ...HTTPRequestOpt) *HTTPRequestRequest {
+	hr := &HTTPRequestRequest{
+		FlightControlPath: DefaultFlightControlPath,
+		Method:            "GET",
+		Headers:           map[string]string{},
+		Timeout:           "30s",
+		RetryInterval:     "1s",
+		TmpDir:            "/tmp",
+	}
+
+	for _, opt := range opts {
+		hr = opt(hr)
+	}
+
+	return hr
+}
+
+// WithHTTPRequestFlightControlPath sets the location of the enos-flight-control binary.
+func WithHTTPRequestFlightControlPath(path string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.FlightControlPath = path
+		return hr
+	}
+}
+
+// WithHTTPRequestURL sets the request URL.
+func WithHTTPRequestURL(url string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.URL = url
+		return hr
+	}
+}
+
+// WithHTTPRequestMethod sets the HTTP method.
+func WithHTTPRequestMethod(meth string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.Method = meth
+		return hr
+	}
+}
+
+// WithHTTPRequestHeaders sets headers that are added to the request.
+func WithHTTPRequestHeaders(headers map[string]string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		maps.Copy(hr.Headers, headers)
+		return hr
+	}
+}
+
+// WithHTTPRequestBody sets the request body.
+func WithHTTPRequestBody(body string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.Body = body
+		hr.JSONBody = false
+		return hr
+	}
+}
+
+// WithHTTPRequestJSONBody sets a JSON request body.
+func WithHTTPRequestJSONBody(body string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.Body = body
+		hr.JSONBody = true
+		return hr
+	}
+}
+
+// WithHTTPRequestAuthUser sets basic auth user.
+func WithHTTPRequestAuthUser(user string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.AuthUser = user
+		return hr
+	}
+}
+
+// WithHTTPRequestAuthPassword sets basic auth password.
+func WithHTTPRequestAuthPassword(password string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.AuthPassword = password
+		return hr
+	}
+}
+
+// WithHTTPRequestAuthToken sets auth token.
+func WithHTTPRequestAuthToken(token string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.AuthToken = token
+		return hr
+	}
+}
+
+// WithHTTPRequestExpectedStatuses sets the expected response status codes.
+func WithHTTPRequestExpectedStatuses(codes ...int) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.ExpectedStatuses = codes
+		return hr
+	}
+}
+
+// WithHTTPRequestTimeout sets the maximum allowable time of each request attempt.
+func WithHTTPRequestTimeout(timeout string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.Timeout = timeout
+		return hr
+	}
+}
+
+// WithHTTPRequestRetries sets the number of times to retry a failed request.
+func WithHTTPRequestRetries(retries int) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.Retries = retries
+		return hr
+	}
+}
+
+// WithHTTPRequestRetryInterval sets the time to wait between retries.
+func WithHTTPRequestRetryInterval(interval string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.RetryInterval = interval
+		return hr
+	}
+}
+
+// WithHTTPRequestJSONPath sets the JSON path of the value to extract from the response body.
+func WithHTTPRequestJSONPath(path string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.JSONPath = path
+		return hr
+	}
+}
+
+// WithHTTPRequestCACert sets the PEM encoded CA certificate bundle that is used to verify the
+// server. It will be copied to the remote host before making the request.
+func WithHTTPRequestCACert(pem string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.CACert = pem
+		return hr
+	}
+}
+
+// WithHTTPRequestClientCert sets the PEM encoded client certificate and key. They will be copied
+// to the remote host before making the request.
+func WithHTTPRequestClientCert(cert, key string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.ClientCert = cert
+		hr.ClientKey = key
+		return hr
+	}
+}
+
+// WithHTTPRequestTLSServerName sets the server name used to verify the server certificate.
+func WithHTTPRequestTLSServerName(name string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.TLSServerName = name
+		return hr
+	}
+}
+
+// WithHTTPRequestTLSSkipVerify disables verification of the server certificate.
+func WithHTTPRequestTLSSkipVerify(skip bool) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.TLSSkipVerify = skip
+		return hr
+	}
+}
+
+// WithHTTPRequestTmpDir sets the temporary directory on the remote host.
+func WithHTTPRequestTmpDir(dir string) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.TmpDir = dir
+		return hr
+	}
+}
+
+// WithHTTPRequestUseSudo determines if the request command should be run with sudo.
+func WithHTTPRequestUseSudo(useSudo bool) HTTPRequestOpt {
+	return func(hr *HTTPRequestRequest) *HTTPRequestRequest {
+		hr.Sudo = useSudo
+		return hr
+	}
+}
+
+// Validate validates the request.
+func (hr *HTTPRequestRequest) Validate() error {
+	if hr.URL == "" {
+		return errors.New("no request URL was provided")
+	}
+
+	if hr.JSONBody && !json.Valid([]byte(hr.Body)) {
+		return errors.New("the request body is not valid JSON")
+	}
+
+	if (hr.ClientCert == "") != (hr.ClientKey == "") {
+		return errors.New("you must provide both a client certificate and client key")
+	}
+
+	if hr.Retries < 0 {
+		return errors.New("retries cannot be negative")
+	}
+
+	return nil
+}
+
+// HTTPRequest makes an HTTP request from a remote machine with enos-flight-control. Any request
+// body and TLS certificates are copied to the remote machine before making the request.
+func HTTPRequest(ctx context.Context, tr transport.Transport, hr *HTTPRequestRequest) (*HTTPRequestResponse, error) {
+	res := &HTTPRequestResponse{}
+
+	select {
+	case <-ctx.Done():
+		return res, ctx.Err()
+	default:
+	}
+
+	if err := hr.Validate(); err != nil {
+		return res, err
+	}
+
+	files := map[string]string{}
+	for name, content := range map[string]string{
+		"body":        hr.Body,
+		"ca-cert":     hr.CACert,
+		"client-cert": hr.ClientCert,
+		"client-key":  hr.ClientKey,
+	} {
+		if content == "" {
+			continue
+		}
+
+		path := filepath.Join(hr.TmpDir, fmt.Sprintf("enos_http_%s.%s", strings.ReplaceAll(name, "-", "_"), random.ID()))
+		err := CopyFile(ctx, tr, NewCopyFileRequest(
+			WithCopyFileContent(tfile.NewReader(content)),
+			WithCopyFileDestination(path),
+			WithCopyFileChmod("0600"),
+		))
+		if err != nil {
+			return res, fmt.Errorf("copying HTTP request %s to remote host: %w", name, err)
+		}
+		defer func() {
+			_ = DeleteFile(ctx, tr, NewDeleteFileRequest(WithDeleteFilePath(path)))
+		}()
+
+		files[name] = path
+	}
+
+	stdout, stderr, err := tr.Run(ctx, command.New(hr.command(files)))
+	if err != nil {
+		return res, WrapErrorWith(err, stdout, stderr)
+	}
+
+	return parseHTTPRequestResponse(stdout)
+}
+
+// command returns the enos-flight-control http command. The files are the remote paths of the
+// files that have been copied to the remote host.
+func (hr *HTTPRequestRequest) command(files map[string]string) string {
+	cmd := fmt.Sprintf(
+		"%s http --format json --url %s --method %s --timeout %s --retries %d --retry-interval %s",
+		hr.FlightControlPath,
+		ShellQuote(hr.URL),
+		ShellQuote(hr.Method),
+		ShellQuote(hr.Timeout),
+		hr.Retries,
+		ShellQuote(hr.RetryInterval),
+	)
+	if hr.Sudo {
+		cmd = "sudo " + cmd
+	}
+
+	headers := maps.Clone(hr.Headers)
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	if hr.JSONBody {
+		if _, ok := headers["Content-Type"]; !ok {
+			headers["Content-Type"] = "application/json"
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(headers)) {
+		cmd = fmt.Sprintf("%s --header %s", cmd, ShellQuote(key+": "+headers[key]))
+	}
+
+	if hr.AuthUser != "" && hr.AuthPassword != "" {
+		cmd = fmt.Sprintf("%s --auth-user %s --auth-password %s", cmd, ShellQuote(hr.AuthUser), ShellQuote(hr.AuthPassword))
+	}
+
+	if hr.AuthToken != "" {
+		cmd = fmt.Sprintf("%s --auth-token %s", cmd, ShellQuote(hr.AuthToken))
+	}
+
+	for _, code := range hr.ExpectedStatuses {
+		cmd = fmt.Sprintf("%s --expected-status %d", cmd, code)
+	}
+
+	if hr.JSONPath != "" {
+		cmd = fmt.Sprintf("%s --json-path %s", cmd, ShellQuote(hr.JSONPath))
+	}
+
+	for _, name := range []string{"body", "ca-cert", "client-cert", "client-key"} {
+		path, ok := files[name]
+		if !ok {
+			continue
+		}
+
+		flag := name
+		if name == "body" {
+			flag = "body-file"
+		}
+		cmd = fmt.Sprintf("%s --%s %s", cmd, flag, ShellQuote(path))
+	}
+
+	if hr.TLSServerName != "" {
+		cmd = fmt.Sprintf("%s --tls-server-name %s", cmd, ShellQuote(hr.TLSServerName))
+	}
+
+	if hr.TLSSkipVerify {
+		cmd += " --tls-skip-verify"
+	}
+
+	return cmd
+}
+
+// parseHTTPRequestResponse parses the JSON output of the enos-flight-control http command.
+func parseHTTPRequestResponse(stdout string) (*HTTPRequestResponse, error) {
+	res := &HTTPRequestResponse{}
+
+	out := &httpclient.Response{}
+	if err := json.Unmarshal([]byte(stdout), out); err != nil {
+		return res, fmt.Errorf("unmarshaling HTTP response: %w, output: %s", err, stdout)
+	}
+
+	res.StatusCode = out.StatusCode
+	res.Headers = out.Headers
+	res.Body = out.Body
+
+	if len(out.Value) > 0 {
+		var str string
+		if err := json.Unmarshal(out.Value, &str); err == nil {
+			res.Value = str
+		} else {
+			res.Value = string(out.Value)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPRequestCommand(t *testing.T) {
+	t.Parallel()
+
+	for desc, test := range map[string]struct {
+		req      *HTTPRequestRequest
+		files    map[string]string
+		expected string
+	}{
+		"defaults": {
+			req: NewHTTPRequestRequest(WithHTTPRequestURL("http://127.0.0.1:8200/v1/sys/health")),
+			expected: "/opt/qti/bin/enos-flight-control http --format json --url 'http://127.0.0.1:8200/v1/sys/health'" +
+				" --method 'GET' --timeout '30s' --retries 0 --retry-interval '1s'",
+		},
+		"everything": {
+			req: NewHTTPRequestRequest(
+				WithHTTPRequestFlightControlPath("/home/ubuntu/enos-flight-control"),
+				WithHTTPRequestURL("https://127.0.0.1:8200/v1/sys/init"),
+				WithHTTPRequestMethod("PUT"),
+				WithHTTPRequestHeaders(map[string]string{"X-Vault-Request": "true", "X-Quote": "it's"}),
+				WithHTTPRequestJSONBody(`{"secret_shares": 1}`),
+				WithHTTPRequestAuthToken("token"),
+				WithHTTPRequestExpectedStatuses(200, 204),
+				WithHTTPRequestTimeout("5s"),
+				WithHTTPRequestRetries(3),
+				WithHTTPRequestRetryInterval("2s"),
+				WithHTTPRequestJSONPath(".root_token"),
+				WithHTTPRequestTLSServerName("vault.example.com"),
+				WithHTTPRequestTLSSkipVerify(true),
+				WithHTTPRequestUseSudo(true),
+			),
+			files: map[string]string{
+				"body":        "/tmp/body",
+				"ca-cert":     "/tmp/ca",
+				"client-cert": "/tmp/cert",
+				"client-key":  "/tmp/key",
+			},
+			expected: "sudo /home/ubuntu/enos-flight-control http --format json --url 'https://127.0.0.1:8200/v1/sys/init'" +
+				" --method 'PUT' --timeout '5s' --retries 3 --retry-interval '2s'" +
+				" --header 'Content-Type: application/json' --header 'X-Quote: it'\\''s' --header 'X-Vault-Request: true'" +
+				" --auth-token 'token' --expected-status 200 --expected-status 204 --json-path '.root_token'" +
+				" --body-file '/tmp/body' --ca-cert '/tmp/ca' --client-cert '/tmp/cert' --client-key '/tmp/key'" +
+				" --tls-server-name 'vault.example.com' --tls-skip-verify",
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			require.NoError(t, test.req.Validate())
+			require.Equal(t, test.expected, test.req.command(test.files))
+		})
+	}
+}
+
+func TestHTTPRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	for desc, req := range map[string]*HTTPRequestRequest{
+		"no url":           NewHTTPRequestRequest(),
+		"invalid json":     NewHTTPRequestRequest(WithHTTPRequestURL("http://localhost"), WithHTTPRequestJSONBody(`{"a"`)),
+		"client cert only": NewHTTPRequestRequest(WithHTTPRequestURL("http://localhost"), WithHTTPRequestClientCert("cert", "")),
+		"negative retries": NewHTTPRequestRequest(WithHTTPRequestURL("http://localhost"), WithHTTPRequestRetries(-1)),
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			require.Error(t, req.Validate())
+		})
+	}
+}
+
+func TestParseHTTPRequestResponse(t *testing.T) {
+	t.Parallel()
+
+	res, err := parseHTTPRequestResponse(`{"status_code":200,"headers":{"Content-Type":["application/json"]},"body":"{\"version\":\"1.15.2\"}","value":"1.15.2"}`)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.StatusCode)
+	require.Equal(t, []string{"application/json"}, res.Headers["Content-Type"])
+	require.Equal(t, `{"version":"1.15.2"}`, res.Body)
+	require.Equal(t, "1.15.2", res.Value)
+
+	res, err = parseHTTPRequestResponse(`{"status_code":200,"body":"{}","value":{"sealed":false}}`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"sealed":false}`, res.Value)
+
+	_, err = parseHTTPRequestResponse("not json")
+	require.Error(t, err)
+}
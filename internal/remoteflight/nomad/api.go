@@ -0,0 +1,303 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	istrings "github.com/hashicorp-forge/terraform-provider-enos/internal/strings"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// NodeStatusReady is the status of a nomad client node that is ready to run workloads.
+const NodeStatusReady = "ready"
+
+// APIRequest is a request to the nomad HTTP API that is made with enos-flight-control.
+type APIRequest struct {
+	FlightControlPath string
+	NomadAddr         string
+	Token             string
+	remoteflight.TLSConfig
+}
+
+// APIRequestOpt is a functional option for nomad API requests.
+type APIRequestOpt func(*APIRequest) *APIRequest
+
+// AgentSelfResponse is a nomad /v1/agent/self response.
+type AgentSelfResponse struct {
+	Config *AgentSelfResponseConfig  `json:"config"`
+	Member *AgentSelfResponseMember  `json:"member"`
+	Stats  map[string]map[string]any `json:"stats"`
+}
+
+// AgentSelfResponseConfig is the config section of the response.
+type AgentSelfResponseConfig struct {
+	Datacenter string `json:"Datacenter"`
+	NodeName   string `json:"NodeName"`
+	Region     string `json:"Region"`
+	Version    *struct {
+		Version string `json:"Version"`
+	} `json:"Version"`
+}
+
+// AgentSelfResponseMember is the member section of the response.
+type AgentSelfResponseMember struct {
+	Name   string `json:"Name"`
+	Status string `json:"Status"`
+}
+
+// NodesResponse is a nomad /v1/nodes response.
+type NodesResponse struct {
+	Nodes []*Node
+}
+
+// Node is a nomad client node.
+type Node struct {
+	ID                    string `json:"ID"`
+	Name                  string `json:"Name"`
+	Datacenter            string `json:"Datacenter"`
+	Status                string `json:"Status"`
+	SchedulingEligibility string `json:"SchedulingEligibility"`
+	Drain                 bool   `json:"Drain"`
+}
+
+// NewAPIRequest takes functional options and returns a new request.
+func NewAPIRequest(opts ...APIRequestOpt) *APIRequest {
+	r := &APIRequest{
+		FlightControlPath: remoteflight.DefaultFlightControlPath,
+		NomadAddr:         "http://127.0.0.1:4646",
+	}
+
+	for _, opt := range opts {
+		r = opt(r)
+	}
+
+	return r
+}
+
+// WithAPIRequestFlightControlPath sets the path to flightcontrol.
+func WithAPIRequestFlightControlPath(path string) APIRequestOpt {
+	return func(r *APIRequest) *APIRequest {
+		r.FlightControlPath = path
+		return r
+	}
+}
+
+// WithAPIRequestNomadAddr sets the nomad HTTP API address.
+func WithAPIRequestNomadAddr(addr string) APIRequestOpt {
+	return func(r *APIRequest) *APIRequest {
+		r.NomadAddr = addr
+		return r
+	}
+}
+
+// WithAPIRequestToken sets the nomad ACL token.
+func WithAPIRequestToken(token string) APIRequestOpt {
+	return func(r *APIRequest) *APIRequest {
+		r.Token = token
+		return r
+	}
+}
+
+// WithAPIRequestTLSConfig sets the client TLS configuration.
+func WithAPIRequestTLSConfig(tls remoteflight.TLSConfig) APIRequestOpt {
+	return func(r *APIRequest) *APIRequest {
+		r.TLSConfig = tls
+		return r
+	}
+}
+
+// Command returns the enos-flight-control command to read the API path.
+func (r *APIRequest) Command(path string) string {
+	cmd := fmt.Sprintf(
+		"%s download --url %s --stdout%s",
+		r.FlightControlPath,
+		remoteflight.ShellQuote(r.NomadAddr+path),
+		r.FlightControlArgs(),
+	)
+
+	if r.Token != "" {
+		cmd += " --auth-token " + remoteflight.ShellQuote(r.Token)
+	}
+
+	return cmd
+}
+
+// GetStatusLeader returns the address of the raft leader. An empty string means
+// that the cluster does not have a leader.
+func GetStatusLeader(ctx context.Context, tr it.Transport, req *APIRequest) (string, error) {
+	var leader string
+	err := getJSON(ctx, tr, req, "/v1/status/leader", &leader)
+
+	return leader, err
+}
+
+// GetStatusPeers returns the addresses of the raft peers.
+func GetStatusPeers(ctx context.Context, tr it.Transport, req *APIRequest) ([]string, error) {
+	peers := []string{}
+	err := getJSON(ctx, tr, req, "/v1/status/peers", &peers)
+
+	return peers, err
+}
+
+// GetAgentSelf returns the agent self response.
+func GetAgentSelf(ctx context.Context, tr it.Transport, req *APIRequest) (*AgentSelfResponse, error) {
+	res := &AgentSelfResponse{}
+	err := getJSON(ctx, tr, req, "/v1/agent/self", res)
+
+	return res, err
+}
+
+// GetNodes returns the nomad client nodes.
+func GetNodes(ctx context.Context, tr it.Transport, req *APIRequest) (*NodesResponse, error) {
+	res := &NodesResponse{Nodes: []*Node{}}
+	err := getJSON(ctx, tr, req, "/v1/nodes", &res.Nodes)
+
+	return res, err
+}
+
+// getJSON reads the API path and deserializes the JSON body onto the response.
+func getJSON(ctx context.Context, tr it.Transport, req *APIRequest, path string, res any) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	var err error
+
+	if req.FlightControlPath == "" {
+		err = errors.Join(err, errors.New("you must supply an enos-flight-control path"))
+	}
+
+	if req.NomadAddr == "" {
+		err = errors.Join(err, errors.New("you must supply a nomad listen address"))
+	}
+
+	if err == nil {
+		stdout, stderr, err1 := tr.Run(ctx, command.New(req.Command(path)))
+		if err1 != nil {
+			err = err1
+		}
+
+		if stderr != "" {
+			err = errors.Join(err, fmt.Errorf("unexpected write to STDERR: %s", stderr))
+		}
+
+		// Deserialize the body onto our response.
+		if stdout == "" {
+			err = errors.Join(err, errors.New("no JSON body was written to STDOUT"))
+		} else {
+			err = errors.Join(err, json.Unmarshal([]byte(stdout), res))
+		}
+	}
+
+	if err != nil {
+		return errors.Join(errors.New("read "+path), err)
+	}
+
+	return nil
+}
+
+// ClientNodeID returns the node ID of the agent if it is running as a client.
+func (r *AgentSelfResponse) ClientNodeID() (string, bool) {
+	if r == nil || r.Stats == nil {
+		return "", false
+	}
+
+	client, ok := r.Stats["client"]
+	if !ok {
+		return "", false
+	}
+
+	id, ok := client["node_id"].(string)
+
+	return id, ok && id != ""
+}
+
+// IsServer returns whether or not the agent is running as a server.
+func (r *AgentSelfResponse) IsServer() bool {
+	if r == nil || r.Stats == nil {
+		return false
+	}
+
+	_, ok := r.Stats["nomad"]
+
+	return ok
+}
+
+// String returns the agent self response as a string.
+func (r *AgentSelfResponse) String() string {
+	if r == nil {
+		return ""
+	}
+
+	out := new(strings.Builder)
+	if r.Config != nil {
+		fmt.Fprintf(out, "Name: %s\n", r.Config.NodeName)
+		fmt.Fprintf(out, "Region: %s\n", r.Config.Region)
+		fmt.Fprintf(out, "Datacenter: %s\n", r.Config.Datacenter)
+	}
+	if r.Member != nil {
+		fmt.Fprintf(out, "Member Status: %s\n", r.Member.Status)
+	}
+	fmt.Fprintf(out, "Server: %t\n", r.IsServer())
+	if id, ok := r.ClientNodeID(); ok {
+		fmt.Fprintf(out, "Client Node ID: %s\n", id)
+	}
+
+	return out.String()
+}
+
+// Node returns the node with the given ID.
+func (r *NodesResponse) Node(id string) (*Node, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	for _, node := range r.Nodes {
+		if node != nil && node.ID == id {
+			return node, true
+		}
+	}
+
+	return nil, false
+}
+
+// String returns the nodes response as a string.
+func (r *NodesResponse) String() string {
+	if r == nil || len(r.Nodes) < 1 {
+		return ""
+	}
+
+	out := new(strings.Builder)
+	for _, node := range r.Nodes {
+		_, _ = out.WriteString(istrings.Indent("  ", node.String()))
+	}
+
+	return out.String()
+}
+
+// String returns the node as a string.
+func (n *Node) String() string {
+	if n == nil {
+		return ""
+	}
+
+	out := new(strings.Builder)
+	fmt.Fprintf(out, "ID: %s\n", n.ID)
+	fmt.Fprintf(out, "Name: %s\n", n.Name)
+	fmt.Fprintf(out, "Datacenter: %s\n", n.Datacenter)
+	fmt.Fprintf(out, "Status: %s\n", n.Status)
+	fmt.Fprintf(out, "Eligibility: %s\n", n.SchedulingEligibility)
+	fmt.Fprintf(out, "Drain: %t\n", n.Drain)
+
+	return out.String()
+}
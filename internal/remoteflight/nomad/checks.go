@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/systemd"
+)
+
+// CheckStateHasSystemdEnabledAndRunningProperties checks that the nomad systemd service
+// has all of the properties and values we expect for a service to be running.
+func CheckStateHasSystemdEnabledAndRunningProperties() CheckStater {
+	return func(s *State) error {
+		props, err := s.FindProperties(systemd.EnabledAndRunningProperties)
+		if err != nil {
+			return fmt.Errorf("expected nomad systemd unit to be enabled and running, got: %s", err)
+		}
+
+		if !props.HasProperties(systemd.EnabledAndRunningProperties) {
+			return fmt.Errorf("expected nomad system unit to be enabled and running, got: %s", props.String())
+		}
+
+		return nil
+	}
+}
+
+// CheckStateClusterHasLeader checks whether or not the nomad cluster has a leader.
+func CheckStateClusterHasLeader() CheckStater {
+	return func(s *State) error {
+		if s.Leader == "" {
+			return errors.New("the nomad cluster does not have a leader")
+		}
+
+		return nil
+	}
+}
+
+// CheckStateClusterHasMinNPeers checks whether or not the cluster has a minimum of
+// N raft peers.
+func CheckStateClusterHasMinNPeers(min uint) CheckStater {
+	return func(s *State) error {
+		if peers := uint(len(s.Peers)); peers < min {
+			return fmt.Errorf("expected minimum of %d raft peers, got %d: %v", min, peers, s.Peers)
+		}
+
+		return nil
+	}
+}
+
+// CheckStateNodeIsReady checks whether or not the agent's client node is ready. Agents
+// that are not running as a client always pass.
+func CheckStateNodeIsReady() CheckStater {
+	return func(s *State) error {
+		if s.AgentSelfResponse == nil {
+			return errors.New("agent was not found in state")
+		}
+
+		id, ok := s.ClientNodeID()
+		if !ok {
+			return nil
+		}
+
+		node, ok := s.Node(id)
+		if !ok {
+			return fmt.Errorf("client node %s was not found in nodes: %s", id, s.NodesResponse.String())
+		}
+
+		if node.Status != NodeStatusReady {
+			return fmt.Errorf("expected client node status to be: %s, got: %s", NodeStatusReady, node.String())
+		}
+
+		return nil
+	}
+}
+
+// CheckStateClusterHasMinNReadyNodes checks whether or not the cluster has a minimum
+// of N ready client nodes.
+func CheckStateClusterHasMinNReadyNodes(min uint) CheckStater {
+	return func(s *State) error {
+		if s.NodesResponse == nil {
+			return errors.New("nodes were not found in state")
+		}
+
+		ready := uint(0)
+		for _, node := range s.Nodes {
+			if node != nil && node.Status == NodeStatusReady {
+				ready++
+			}
+		}
+
+		if ready >= min {
+			return nil
+		}
+
+		return fmt.Errorf(
+			"expected minimum of %d ready nodes, got %d, response: %s",
+			min, ready, s.NodesResponse.String(),
+		)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/systemd"
+)
+
+func TestStateHasSystemdEnabledAndRunningProperties(t *testing.T) {
+	t.Parallel()
+	for name, test := range map[string]struct {
+		props      systemd.UnitProperties
+		shouldFail bool
+	}{
+		"enabled": {
+			systemd.EnabledAndRunningProperties,
+			false,
+		},
+		"not-loaded": {
+			systemd.UnitProperties{
+				"LoadState":   "not-found",
+				"ActiveState": "inactive",
+				"SubState":    "dead",
+			},
+			true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			state := NewState()
+			state.UnitProperties = test.props
+			if test.shouldFail {
+				require.Error(t, CheckStateHasSystemdEnabledAndRunningProperties()(state))
+			} else {
+				require.NoError(t, CheckStateHasSystemdEnabledAndRunningProperties()(state))
+			}
+		})
+	}
+}
+
+func TestStateClusterHasLeader(t *testing.T) {
+	t.Parallel()
+
+	state := NewState()
+	require.Error(t, CheckStateClusterHasLeader()(state))
+	state.Leader = "10.13.10.161:4647"
+	require.NoError(t, CheckStateClusterHasLeader()(state))
+}
+
+func TestStateClusterHasMinNPeers(t *testing.T) {
+	t.Parallel()
+
+	state := NewState()
+	state.Peers = []string{"10.13.10.161:4647", "10.13.10.162:4647"}
+	require.NoError(t, CheckStateClusterHasMinNPeers(2)(state))
+	require.Error(t, CheckStateClusterHasMinNPeers(3)(state))
+}
+
+func TestStateNodeIsReady(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		nodeID     string
+		status     string
+		shouldFail bool
+	}{
+		"ready": {
+			"f7476465-4376-4bfd-a7e2-7c0a2d9c6bd5",
+			NodeStatusReady,
+			false,
+		},
+		"initializing": {
+			"f7476465-4376-4bfd-a7e2-7c0a2d9c6bd5",
+			"initializing",
+			true,
+		},
+		"missing": {
+			"0ffb8d8c-d1ea-4a4b-b8d5-6d54d18bd6b2",
+			NodeStatusReady,
+			true,
+		},
+		"server-only": {
+			"",
+			"",
+			false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			state := NewState()
+			state.AgentSelfResponse = testAgentSelf(t)
+			state.NodesResponse = testNodes(t)
+			if test.nodeID == "" {
+				delete(state.Stats, "client")
+			} else {
+				state.Stats["client"]["node_id"] = test.nodeID
+				state.Nodes[0].Status = test.status
+			}
+
+			if test.shouldFail {
+				require.Error(t, CheckStateNodeIsReady()(state))
+			} else {
+				require.NoError(t, CheckStateNodeIsReady()(state))
+			}
+		})
+	}
+}
+
+func TestStateClusterHasMinNReadyNodes(t *testing.T) {
+	t.Parallel()
+
+	state := NewState()
+	require.Error(t, CheckStateClusterHasMinNReadyNodes(1)(state))
+	state.NodesResponse = testNodes(t)
+	require.NoError(t, CheckStateClusterHasMinNReadyNodes(1)(state))
+	require.Error(t, CheckStateClusterHasMinNReadyNodes(2)(state))
+}
+
+func TestAgentSelfResponse(t *testing.T) {
+	t.Parallel()
+
+	res := testAgentSelf(t)
+	require.True(t, res.IsServer())
+	id, ok := res.ClientNodeID()
+	require.True(t, ok)
+	require.Equal(t, "f7476465-4376-4bfd-a7e2-7c0a2d9c6bd5", id)
+	require.Equal(t, "1.7.2", res.Config.Version.Version)
+}
+
+func TestAPIRequestCommand(t *testing.T) {
+	t.Parallel()
+
+	req := NewAPIRequest(
+		WithAPIRequestFlightControlPath("/opt/qti/bin/enos-flight-control"),
+		WithAPIRequestNomadAddr("https://127.0.0.1:4646"),
+		WithAPIRequestToken("secret"),
+	)
+	require.Equal(t,
+		"/opt/qti/bin/enos-flight-control download --url 'https://127.0.0.1:4646/v1/status/leader' --stdout --auth-token 'secret'",
+		req.Command("/v1/status/leader"),
+	)
+
+	req = NewAPIRequest(
+		WithAPIRequestFlightControlPath("/opt/qti/bin/enos-flight-control"),
+		WithAPIRequestNomadAddr("https://127.0.0.1:4646"),
+		WithAPIRequestToken("it's"),
+	)
+	require.Equal(t,
+		"/opt/qti/bin/enos-flight-control download --url 'https://127.0.0.1:4646/v1/status/leader' --stdout --auth-token 'it'\\''s'",
+		req.Command("/v1/status/leader"),
+	)
+}
+
+func testAgentSelf(t *testing.T) *AgentSelfResponse {
+	t.Helper()
+
+	res := &AgentSelfResponse{}
+	require.NoError(t, json.Unmarshal(testReadSupport(t, "agent-self.json"), res))
+
+	return res
+}
+
+func testNodes(t *testing.T) *NodesResponse {
+	t.Helper()
+
+	res := &NodesResponse{}
+	require.NoError(t, json.Unmarshal(testReadSupport(t, "nodes.json"), &res.Nodes))
+
+	return res
+}
+
+func testReadSupport(t *testing.T, name string) []byte {
+	t.Helper()
+
+	p, err := filepath.Abs(filepath.Join("./support", name))
+	require.NoError(t, err)
+	f, err := os.Open(p)
+	require.NoError(t, err)
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+
+	return content
+}
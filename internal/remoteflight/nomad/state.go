@@ -0,0 +1,276 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/log"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight/systemd"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/retry"
+	istrings "github.com/hashicorp-forge/terraform-provider-enos/internal/strings"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+)
+
+// State represents the state of a node in a nomad cluster.
+type State struct {
+	*AgentSelfResponse              // /v1/agent/self
+	*NodesResponse                  // /v1/nodes
+	Leader                 string   // /v1/status/leader
+	Peers                  []string // /v1/status/peers
+	systemd.UnitProperties          // systemd unit properties for nomad.service
+}
+
+// StateRequest is a nomad state request.
+type StateRequest struct {
+	FlightControlPath       string // where enos-flight-control is installed
+	FlightControlUseHomeDir bool   // install enos-flight-control into the $HOME directory
+	SystemdUnitName         string // what the systemd unit name for the nomad service is
+	NomadAddr               string // nomad bind address
+	Token                   string // nomad ACL token
+	remoteflight.TLSConfig         // nomad client TLS configuration
+}
+
+// StateRequestOpt is a functional option for a state request.
+type StateRequestOpt func(*StateRequest) *StateRequest
+
+// CheckStater is a validate function that takes a state and checks that it
+// has expected values.
+type CheckStater func(s *State) error
+
+// NewState returns a new nomad cluster node state.
+func NewState() *State {
+	return &State{}
+}
+
+// NewStateRequest takes functional options and returns a new state request.
+func NewStateRequest(opts ...StateRequestOpt) *StateRequest {
+	c := &StateRequest{
+		FlightControlPath: remoteflight.DefaultFlightControlPath,
+		SystemdUnitName:   "nomad",
+		NomadAddr:         "http://127.0.0.1:4646",
+	}
+
+	for _, opt := range opts {
+		c = opt(c)
+	}
+
+	return c
+}
+
+// WithStateRequestFlightControlPath sets the enos-flight-control binary path.
+func WithStateRequestFlightControlPath(path string) StateRequestOpt {
+	return func(u *StateRequest) *StateRequest {
+		u.FlightControlPath = path
+		return u
+	}
+}
+
+// WithStateRequestFlightControlUseHomeDir will use the $HOME directory for the enos-flight-control
+// binary path.
+func WithStateRequestFlightControlUseHomeDir() StateRequestOpt {
+	return func(u *StateRequest) *StateRequest {
+		u.FlightControlUseHomeDir = true
+		return u
+	}
+}
+
+// WithStateRequestSystemdUnitName sets the systemd unit name.
+func WithStateRequestSystemdUnitName(name string) StateRequestOpt {
+	return func(u *StateRequest) *StateRequest {
+		u.SystemdUnitName = name
+		return u
+	}
+}
+
+// WithStateRequestNomadAddr sets the nomad bind address.
+func WithStateRequestNomadAddr(addr string) StateRequestOpt {
+	return func(u *StateRequest) *StateRequest {
+		u.NomadAddr = addr
+		return u
+	}
+}
+
+// WithStateRequestToken sets the nomad ACL token.
+func WithStateRequestToken(token string) StateRequestOpt {
+	return func(u *StateRequest) *StateRequest {
+		u.Token = token
+		return u
+	}
+}
+
+// WithStateRequestTLSConfig sets the nomad client TLS configuration.
+func WithStateRequestTLSConfig(tls remoteflight.TLSConfig) StateRequestOpt {
+	return func(u *StateRequest) *StateRequest {
+		u.TLSConfig = tls
+		return u
+	}
+}
+
+// GetState returns the nomad cluster node state.
+func GetState(ctx context.Context, tr it.Transport, req *StateRequest) (*State, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	var err error
+
+	opts := []remoteflight.InstallFlightControlOpt{
+		remoteflight.WithInstallFlightControlRequestTargetRequest(
+			// Don't auto-retry installing enos-flight-control. WaitForState
+			// can handle retrying for us.
+			remoteflight.NewTargetRequest(
+				remoteflight.WithTargetRequestRetryOpts(
+					retry.WithMaxRetries(0),
+				),
+			),
+		),
+	}
+	if req.FlightControlUseHomeDir {
+		opts = append(opts, remoteflight.WithInstallFlightControlRequestUseHomeDir())
+	} else {
+		opts = append(opts, remoteflight.WithInstallFlightControlRequestPath(req.FlightControlPath))
+	}
+
+	// We use flightcontrol to read data from the nomad API
+	fcRes, err := remoteflight.InstallFlightControl(ctx, tr, remoteflight.NewInstallFlightControlRequest(opts...))
+	if err != nil {
+		return nil, fmt.Errorf("installing enos-flight-control binary to get nomad state: %w", err)
+	}
+
+	state := NewState()
+
+	// Get the systemd unit properties
+	sysd := systemd.NewClient(tr, log.NewLogger(ctx))
+
+	state.UnitProperties, err = sysd.ShowProperties(ctx, req.SystemdUnitName)
+	if err != nil {
+		return state, fmt.Errorf("getting the systemd unit properties: %w", err)
+	}
+
+	apiReq := NewAPIRequest(
+		WithAPIRequestFlightControlPath(fcRes.Path),
+		WithAPIRequestNomadAddr(req.NomadAddr),
+		WithAPIRequestToken(req.Token),
+		WithAPIRequestTLSConfig(req.TLSConfig),
+	)
+
+	state.AgentSelfResponse, err = GetAgentSelf(ctx, tr, apiReq)
+	if err != nil {
+		return state, err
+	}
+
+	state.Leader, err = GetStatusLeader(ctx, tr, apiReq)
+	if err != nil {
+		return state, err
+	}
+
+	state.Peers, err = GetStatusPeers(ctx, tr, apiReq)
+	if err != nil {
+		return state, err
+	}
+
+	state.NodesResponse, err = GetNodes(ctx, tr, apiReq)
+
+	return state, err
+}
+
+// WaitForState waits until the nomad cluster node state satisfies all of the
+// provided checks.
+func WaitForState(ctx context.Context, tr it.Transport, req *StateRequest, checks ...CheckStater) (*State, error) {
+	checkState := func(ctx context.Context) (any, error) {
+		var err error
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		state, err := GetState(ctx, tr, req)
+		if err != nil {
+			return state, err
+		}
+
+		if len(checks) == 0 {
+			return state, nil
+		}
+
+		if state == nil {
+			return nil, errors.Join(err, errors.New("no state data found"))
+		}
+
+		for _, check := range checks {
+			err = check(state)
+			if err != nil {
+				return state, err
+			}
+		}
+
+		return state, nil
+	}
+
+	r, err := retry.NewRetrier(
+		retry.WithIntervalFunc(retry.IntervalDuration(5*time.Second)),
+		retry.WithRetrierFunc(checkState),
+	)
+
+	var state any
+	if err == nil {
+		state, err = retry.Retry(ctx, r)
+	}
+
+	if err != nil {
+		err = fmt.Errorf("waiting for nomad to enter desired state: %w", err)
+	}
+
+	if state == nil {
+		return nil, err
+	}
+
+	return state.(*State), err
+}
+
+// String returns our state as a string.
+func (s *State) String() string {
+	out := new(strings.Builder)
+
+	s.printStateField(out, s.AgentSelfResponse, "Agent")
+	_, _ = fmt.Fprintf(out, "Leader: %s\n", s.Leader)
+	_, _ = fmt.Fprintf(out, "Peers: %s\n", strings.Join(s.Peers, ", "))
+	s.printStateField(out, s.NodesResponse, "Nodes")
+
+	// Most of the time we don't care about all of the systemd unit properties.
+	// Try and find our meaningful status properties. If we can't then something
+	// strange is afoot and we'll display all of our props.
+	props, err := s.FindProperties(systemd.EnabledAndRunningProperties)
+	if err != nil {
+		props = s.UnitProperties
+	}
+	s.printStateField(out, props, "Systemd Unit Properties")
+
+	return out.String()
+}
+
+// printStateField takes a writer, a string, and field name and writes the
+// body of the stringer result indented.
+func (s *State) printStateField(w io.Writer, f fmt.Stringer, fn string) {
+	if f == nil || w == nil {
+		return
+	}
+
+	fs := f.String()
+	if fs == "" {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s: \n%s\n", fn, istrings.Indent("  ", fs))
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// ValidateConfigRequest is a request to validate a nomad agent configuration.
+type ValidateConfigRequest struct {
+	BinPath  string
+	FilePath string
+}
+
+// ValidateConfigRequestOpt is a functional option for a validate config request.
+type ValidateConfigRequestOpt func(*ValidateConfigRequest) *ValidateConfigRequest
+
+// NewValidateConfigRequest takes functional options and returns a new validate config request.
+func NewValidateConfigRequest(opts ...ValidateConfigRequestOpt) *ValidateConfigRequest {
+	r := &ValidateConfigRequest{}
+
+	for _, opt := range opts {
+		r = opt(r)
+	}
+
+	return r
+}
+
+// WithValidateConfigBinPath sets the nomad binary path.
+func WithValidateConfigBinPath(path string) ValidateConfigRequestOpt {
+	return func(r *ValidateConfigRequest) *ValidateConfigRequest {
+		r.BinPath = path
+		return r
+	}
+}
+
+// WithValidateConfigFilePath sets the configuration file path.
+func WithValidateConfigFilePath(path string) ValidateConfigRequestOpt {
+	return func(r *ValidateConfigRequest) *ValidateConfigRequest {
+		r.FilePath = path
+		return r
+	}
+}
+
+// ValidateConfig validates the nomad agent configuration with the nomad config validate command.
+func ValidateConfig(ctx context.Context, tr it.Transport, req *ValidateConfigRequest) error {
+	if req.BinPath == "" {
+		return errors.New("you must provide a nomad binary path")
+	}
+
+	if req.FilePath == "" {
+		return errors.New("you must provide a configuration file path")
+	}
+
+	_, stderr, err := tr.Run(ctx, command.New(
+		fmt.Sprintf("%s config validate %s", req.BinPath, req.FilePath),
+	))
+	if err != nil {
+		return remoteflight.WrapErrorWith(err, stderr)
+	}
+
+	return nil
+}
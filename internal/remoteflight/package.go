@@ -10,6 +10,8 @@ import (
 	"net/url"
 	"path"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/random"
@@ -19,6 +21,10 @@ import (
 	tfile "github.com/hashicorp-forge/terraform-provider-enos/internal/transport/file"
 )
 
+// packageFileExtRegexp matches file extensions. It's used to tell the difference between an
+// extension and a version in a file name.
+var packageFileExtRegexp = regexp.MustCompile(`^\.[a-zA-Z][a-zA-Z0-9]*$`)
+
 // Package install gets. These are the built-in package getters.
 var (
 	PackageInstallGetterCopy        = &PackageInstallGetter{PackageGetterTypeCopy, packageInstallGetCopy}
@@ -38,6 +44,24 @@ var (
 			PackageInstallGetterArtifactory,
 		},
 	}
+	PackageInstallInstallerTar = &PackageInstallInstaller{
+		Type:    PackageInstallerTypeTar,
+		Install: packageInstallTarInstall,
+		CompatibleGetters: []*PackageInstallGetter{
+			PackageInstallGetterCopy,
+			PackageInstallGetterReleases,
+			PackageInstallGetterArtifactory,
+		},
+	}
+	PackageInstallInstallerBinary = &PackageInstallInstaller{
+		Type:    PackageInstallerTypeBinary,
+		Install: packageInstallBinaryInstall,
+		CompatibleGetters: []*PackageInstallGetter{
+			PackageInstallGetterCopy,
+			PackageInstallGetterReleases,
+			PackageInstallGetterArtifactory,
+		},
+	}
 	PackageInstallInstallerDEB = &PackageInstallInstaller{
 		Type:    PackageInstallerTypeDeb,
 		Install: packageInstallDEBInstall,
@@ -94,6 +118,8 @@ const (
 	PackageGetterTypeArtifactory PackageGetterType    = "artifactory"
 	PackageGetterTypeRepository  PackageGetterType    = "repository"
 	PackageInstallerTypeZip      PackageInstallerType = "zip"
+	PackageInstallerTypeTar      PackageInstallerType = "tar"
+	PackageInstallerTypeBinary   PackageInstallerType = "binary"
 	PackageInstallerTypeDeb      PackageInstallerType = "deb"
 	PackageInstallerTypeRPM      PackageInstallerType = "rpm"
 	PackageInstallerTypeYum      PackageInstallerType = "yum"
@@ -119,6 +145,21 @@ func (m *PackageInstallInstaller) Compatible(get *PackageInstallGetter) bool {
 	return false
 }
 
+// RequiresDestination determines if the installer requires a destination directory. Archives and
+// binaries need to persist in a destination, whereas packages are installed by the package manager.
+func (m *PackageInstallInstaller) RequiresDestination() bool {
+	if m == nil {
+		return false
+	}
+
+	switch m.Type {
+	case PackageInstallerTypeZip, PackageInstallerTypeTar, PackageInstallerTypeBinary:
+		return true
+	default:
+		return false
+	}
+}
+
 // PackageInstallGetter is where the package is coming from.
 type PackageInstallGetter struct {
 	Type PackageGetterType
@@ -130,12 +171,22 @@ type PackageInstallRequest struct {
 	Installer         *PackageInstallInstaller
 	Getter            *PackageInstallGetter
 	FlightControlPath string
-	UnzipOpts         []UnzipOpt    // Unzip options if we're getting a zip bundle
-	DownloadOpts      []DownloadOpt // Download options if we're downloading the artifact
-	CopyPath          string        // Where to copy from
-	TempArtifactPath  string        // Intermediate location of artifact
-	TempDir           string        // Base directory of temporary directory
-	DestionationPath  string        // Final destination of artifact
+	UnzipOpts         []UnzipOpt         // Unzip options if we're getting a zip bundle
+	UntarOpts         []UntarOpt         // Untar options if we're getting a tar archive
+	DownloadOpts      []DownloadOpt      // Download options if we're downloading the artifact
+	CopyPath          string             // Where to copy from
+	CachedPath        string             // Local cached copy of the artifact to copy instead of downloading it
+	TempArtifactPath  string             // Intermediate location of artifact
+	TempDir           string             // Base directory of temporary directory
+	DestionationPath  string             // Final destination of artifact
+	Repository        *PackageRepository // Repository to configure if we're installing from a repository
+	PackageName       string             // Name of the package if we're installing from a repository
+	PackageVersion    string             // Requested version of the package if we're installing from a repository
+	BinaryName        string             // Name of the installed binary if we're installing a binary, defaults to the artifact name
+	BinaryMode        string             // Mode of the installed binary if we're installing a binary
+
+	resolvedPackageVersion string // The package version that the repository getter resolved
+	artifactName           string // The name of the artifact that the getter got
 }
 
 // PackageInstallResponse is the response of the script run.
@@ -143,30 +194,61 @@ type PackageInstallResponse struct {
 	Name          string
 	GetterType    PackageGetterType
 	InstallerType PackageInstallerType
+	Version       string // The resolved package version if it was installed from a repository
 }
 
 // PackageInstallRequestOpt is a functional option for running a script.
 type PackageInstallRequestOpt func(*PackageInstallRequest) *PackageInstallRequest
 
 // PackageInstallInstallerForFile attempts to determine a suitable package
-// installation method given the file name.
+// installation method given the file name. Files without an extension are
+// assumed to be binaries.
 func PackageInstallInstallerForFile(name string) *PackageInstallInstaller {
-	switch filepath.Ext(filepath.Base(name)) {
+	base := filepath.Base(name)
+
+	if strings.HasSuffix(base, ".tar.gz") {
+		return PackageInstallInstallerTar
+	}
+
+	ext := filepath.Ext(base)
+	if !packageFileExtRegexp.MatchString(ext) {
+		// Versioned binaries, e.g. vault_1.15.2_linux_amd64, don't have an extension
+		ext = ""
+	}
+
+	switch ext {
 	case ".zip":
 		return PackageInstallInstallerZip
+	case ".tar", ".tgz":
+		return PackageInstallInstallerTar
 	case ".deb":
 		return PackageInstallInstallerDEB
 	case ".rpm":
 		return PackageInstallInstallerRPM
+	case "":
+		return PackageInstallInstallerBinary
 	default:
 		return nil
 	}
 }
 
+// PackageInstallInstallerForURL attempts to determine a suitable package
+// installation method given the artifact URL.
+func PackageInstallInstallerForURL(artifactURL string) *PackageInstallInstaller {
+	u, err := url.Parse(artifactURL)
+	if err != nil {
+		return nil
+	}
+
+	return PackageInstallInstallerForFile(path.Base(u.Path))
+}
+
 // NewPackageInstallRequest takes functional options and returns a new script run req.
 func NewPackageInstallRequest(opts ...PackageInstallRequestOpt) *PackageInstallRequest {
 	ir := &PackageInstallRequest{
 		UnzipOpts:         []UnzipOpt{},
+		UntarOpts:         []UntarOpt{},
+		BinaryMode:        "0755",
 		DownloadOpts:      []DownloadOpt{},
 		TempDir:           "/tmp",
 		FlightControlPath: DefaultFlightControlPath,
@@ -203,6 +285,30 @@ func WithPackageInstallUnzipOpts(opts ...UnzipOpt) PackageInstallRequestOpt {
 	}
 }
 
+// WithPackageInstallUntarOpts sets the package untar options.
+func WithPackageInstallUntarOpts(opts ...UntarOpt) PackageInstallRequestOpt {
+	return func(ir *PackageInstallRequest) *PackageInstallRequest {
+		ir.UntarOpts = opts
+		return ir
+	}
+}
+
+// WithPackageInstallBinaryName sets the name of the installed binary.
+func WithPackageInstallBinaryName(name string) PackageInstallRequestOpt {
+	return func(ir *PackageInstallRequest) *PackageInstallRequest {
+		ir.BinaryName = name
+		return ir
+	}
+}
+
+// WithPackageInstallBinaryMode sets the mode of the installed binary.
+func WithPackageInstallBinaryMode(mode string) PackageInstallRequestOpt {
+	return func(ir *PackageInstallRequest) *PackageInstallRequest {
+		ir.BinaryMode = mode
+		return ir
+	}
+}
+
 // WithPackageInstallDownloadOpts sets the package download options.
 func WithPackageInstallDownloadOpts(opts ...DownloadOpt) PackageInstallRequestOpt {
 	return func(ir *PackageInstallRequest) *PackageInstallRequest {
@@ -220,6 +326,16 @@ func WithPackageInstallCopyPath(path string) PackageInstallRequestOpt {
 	}
 }
 
+// WithPackageInstallCachedPath sets the location of a local cached copy of an artifact
+// that would otherwise be downloaded. When set the download getter will copy the cached
+// artifact to the remote machine instead of downloading it there.
+func WithPackageInstallCachedPath(path string) PackageInstallRequestOpt {
+	return func(ir *PackageInstallRequest) *PackageInstallRequest {
+		ir.CachedPath = path
+		return ir
+	}
+}
+
 // WithPackageInstallDestination sets final destination for binaries.
 func WithPackageInstallDestination(path string) PackageInstallRequestOpt {
 	return func(ir *PackageInstallRequest) *PackageInstallRequest {
@@ -258,6 +374,7 @@ func PackageInstall(ctx context.Context, tr it.Transport, req *PackageInstallReq
 	if err != nil {
 		return nil, err
 	}
+	req.artifactName = name
 
 	err = req.Installer.Install(ctx, tr, req)
 	if err != nil {
@@ -268,6 +385,7 @@ func PackageInstall(ctx context.Context, tr it.Transport, req *PackageInstallReq
 		Name:          name,
 		GetterType:    req.Getter.Type,
 		InstallerType: req.Installer.Type,
+		Version:       req.resolvedPackageVersion,
 	}, nil
 }
 
@@ -276,9 +394,20 @@ func packageInstallGetCopy(ctx context.Context, tr it.Transport, req *PackageIns
 		return "", errors.New("you must supply a path to the get artifact you wish you copy")
 	}
 
-	src, err := tfile.Open(req.CopyPath)
+	err := packageInstallCopyArtifact(ctx, tr, req, req.CopyPath)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(req.CopyPath), nil
+}
+
+// packageInstallCopyArtifact copies the local artifact to the temporary artifact path on the
+// remote machine.
+func packageInstallCopyArtifact(ctx context.Context, tr it.Transport, req *PackageInstallRequest, localPath string) error {
+	src, err := tfile.Open(localPath)
 	if err != nil {
-		return "", fmt.Errorf("opening artifact to copy to remote host: %w", err)
+		return fmt.Errorf("opening artifact to copy to remote host: %w", err)
 	}
 	defer src.Close()
 
@@ -286,20 +415,31 @@ func packageInstallGetCopy(ctx context.Context, tr it.Transport, req *PackageIns
 	// exists.
 	req.TempArtifactPath = filepath.Join(
 		req.TempDir,
-		fmt.Sprintf("enos_install_get.%s.%s", random.ID(), filepath.Base(req.CopyPath)),
+		fmt.Sprintf("enos_install_get.%s.%s", random.ID(), filepath.Base(localPath)),
 	)
 	err = CopyFile(ctx, tr, NewCopyFileRequest(
 		WithCopyFileContent(src),
 		WithCopyFileDestination(req.TempArtifactPath),
 	))
 	if err != nil {
-		return "", fmt.Errorf("copying artifact to remote host: %w", err)
+		return fmt.Errorf("copying artifact to remote host: %w", err)
 	}
 
-	return filepath.Base(req.CopyPath), nil
+	return nil
 }
 
 func packageInstallGetDownload(ctx context.Context, tr it.Transport, req *PackageInstallRequest) (string, error) {
+	if req.CachedPath != "" {
+		// We've already got a verified copy of the artifact locally so we'll distribute it
+		// to the remote machine rather than downloading it there.
+		err := packageInstallCopyArtifact(ctx, tr, req, req.CachedPath)
+		if err != nil {
+			return "", err
+		}
+
+		return filepath.Base(req.CachedPath), nil
+	}
+
 	res, err := InstallFlightControl(ctx, tr, NewInstallFlightControlRequest(
 		WithInstallFlightControlRequestUseHomeDir(),
 		WithInstallFlightControlRequestTargetRequest(
@@ -343,12 +483,6 @@ func packageInstallGetDownload(ctx context.Context, tr it.Transport, req *Packag
 	return path.Base(u.Path), nil
 }
 
-func packageInstallGetRepository(ctx context.Context, tr it.Transport, req *PackageInstallRequest) (string, error) {
-	// TODO: right now this is just a shim because we assume the package repository
-	// has the package. We could eventually check the package repo for the package.
-	return "", nil
-}
-
 func packageInstallZipInstall(ctx context.Context, tr it.Transport, req *PackageInstallRequest) error {
 	res, err := InstallFlightControl(ctx, tr, NewInstallFlightControlRequest(
 		WithInstallFlightControlRequestUseHomeDir(),
@@ -380,6 +514,68 @@ func packageInstallZipInstall(ctx context.Context, tr it.Transport, req *Package
 	return DeleteFile(ctx, tr, NewDeleteFileRequest(WithDeleteFilePath(req.TempArtifactPath)))
 }
 
+func packageInstallTarInstall(ctx context.Context, tr it.Transport, req *PackageInstallRequest) error {
+	res, err := InstallFlightControl(ctx, tr, NewInstallFlightControlRequest(
+		WithInstallFlightControlRequestUseHomeDir(),
+		WithInstallFlightControlRequestTargetRequest(
+			NewTargetRequest(
+				WithTargetRequestRetryOpts(
+					retry.WithIntervalFunc(retry.IntervalExponential(2*time.Second)),
+				),
+			),
+		),
+	))
+	if err != nil {
+		return fmt.Errorf("installing flight-control binary to untar archive: %w", err)
+	}
+
+	opts := []UntarOpt{
+		WithUntarRequestFlightControlPath(res.Path),
+		WithUntarRequestSourcePath(req.TempArtifactPath),
+		WithUntarRequestDestinationDir(req.DestionationPath),
+		WithUntarRequestUseSudo(true),
+		WithUntarRequestReplace(true),
+	}
+	opts = append(opts, req.UntarOpts...)
+	_, err = Untar(ctx, tr, NewUntarRequest(opts...))
+	if err != nil {
+		return err
+	}
+
+	return DeleteFile(ctx, tr, NewDeleteFileRequest(WithDeleteFilePath(req.TempArtifactPath)))
+}
+
+func packageInstallBinaryInstall(ctx context.Context, tr it.Transport, req *PackageInstallRequest) error {
+	cmd, err := req.binaryInstallCommand()
+	if err != nil {
+		return err
+	}
+
+	stdout, stderr, err := tr.Run(ctx, command.New(cmd))
+	if err != nil {
+		return WrapErrorWith(err, stdout, stderr, "installing binary")
+	}
+
+	return nil
+}
+
+// binaryInstallCommand returns the command to move the downloaded binary into the destination.
+func (r *PackageInstallRequest) binaryInstallCommand() (string, error) {
+	name := r.BinaryName
+	if name == "" {
+		name = r.artifactName
+	}
+	if name == "" {
+		return "", errors.New("you must supply a binary name to install a binary")
+	}
+
+	dest := ShellQuote(filepath.Join(r.DestionationPath, name))
+
+	return fmt.Sprintf("sudo mkdir -p %s && sudo mv -f %s %s && sudo chmod %s %s",
+		ShellQuote(r.DestionationPath), ShellQuote(r.TempArtifactPath), dest, ShellQuote(r.BinaryMode), dest,
+	), nil
+}
+
 func packageInstallDEBInstall(ctx context.Context, tr it.Transport, req *PackageInstallRequest) error {
 	// If we have existing config files, we're assuming we want to keep them.
 	// --force-confold defaults to using the existing files, instead of
@@ -405,11 +601,3 @@ func packageInstallRPMInstall(ctx context.Context, tr it.Transport, req *Package
 
 	return DeleteFile(ctx, tr, NewDeleteFileRequest(WithDeleteFilePath(req.TempArtifactPath)))
 }
-
-func packageInstallYumInstall(ctx context.Context, tr it.Transport, req *PackageInstallRequest) error {
-	return ErrPackageInstallInstallerUnknown
-}
-
-func packageInstallAptInstall(ctx context.Context, tr it.Transport, req *PackageInstallRequest) error {
-	return ErrPackageInstallInstallerUnknown
-}
@@ -0,0 +1,605 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/retry"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+	tfile "github.com/hashicorp-forge/terraform-provider-enos/internal/transport/file"
+)
+
+// Default HashiCorp package repository locations.
+const (
+	PackageRepositoryHashiCorpName      = "hashicorp"
+	PackageRepositoryHashiCorpAptURL    = "https://apt.releases.hashicorp.com"
+	PackageRepositoryHashiCorpAptGPGKey = "https://apt.releases.hashicorp.com/gpg"
+	PackageRepositoryHashiCorpYumURL    = "https://rpm.releases.hashicorp.com"
+	PackageRepositoryHashiCorpYumGPGKey = "https://rpm.releases.hashicorp.com/gpg"
+)
+
+// ErrPackageVersionNotAvailable means the requested version of the package is not available in the
+// configured repositories.
+var ErrPackageVersionNotAvailable = errors.New("package version is not available")
+
+// PackageRepository is an apt or yum package repository that we'll configure on the target.
+// Any fields that are not set will default to the HashiCorp package repository.
+type PackageRepository struct {
+	Name         string // The repository identifier. It is used to name the repository files
+	URL          string // The repository base URL
+	GPGKeyURL    string // The URL of the ASCII armored repository signing key
+	Distribution string // The apt distribution, defaults to the target's codename
+	Components   string // The apt components, defaults to "main"
+}
+
+// WithPackageInstallRepository sets the package repository that we'll configure before we install
+// the package. If no repository is set we'll assume the package is available in the repositories
+// that are already configured on the target.
+func WithPackageInstallRepository(repo *PackageRepository) PackageInstallRequestOpt {
+	return func(ir *PackageInstallRequest) *PackageInstallRequest {
+		ir.Repository = repo
+		return ir
+	}
+}
+
+// WithPackageInstallPackageName sets the name of the package to install from a repository.
+func WithPackageInstallPackageName(name string) PackageInstallRequestOpt {
+	return func(ir *PackageInstallRequest) *PackageInstallRequest {
+		ir.PackageName = name
+		return ir
+	}
+}
+
+// WithPackageInstallPackageVersion sets the version of the package to install from a repository.
+// The version can be the upstream version, e.g. 1.15.2+ent, or the full package version, e.g.
+// 1.15.2+ent-1. If no version or "latest" is set we'll install the latest available version.
+func WithPackageInstallPackageVersion(version string) PackageInstallRequestOpt {
+	return func(ir *PackageInstallRequest) *PackageInstallRequest {
+		ir.PackageVersion = version
+		return ir
+	}
+}
+
+// PackageInstallInstallerForTarget attempts to determine a suitable repository package installer
+// for the target by checking which package manager is available.
+func PackageInstallInstallerForTarget(ctx context.Context, tr it.Transport) (*PackageInstallInstaller, error) {
+	stdout, stderr, err := tr.Run(ctx, command.New(
+		"if command -v apt-get > /dev/null 2>&1; then echo -n apt; elif command -v dnf > /dev/null 2>&1 || command -v yum > /dev/null 2>&1; then echo -n yum; fi",
+	))
+	if err != nil {
+		return nil, WrapErrorWith(err, stdout, stderr, "determining target package manager")
+	}
+
+	switch strings.TrimSpace(stdout) {
+	case string(PackageInstallerTypeApt):
+		return PackageInstallInstallerApt, nil
+	case string(PackageInstallerTypeYum):
+		return PackageInstallInstallerYum, nil
+	default:
+		return nil, fmt.Errorf("%w: target does not have apt-get, dnf or yum", ErrPackageInstallInstallerUnsupported)
+	}
+}
+
+func packageInstallGetRepository(ctx context.Context, tr it.Transport, req *PackageInstallRequest) (string, error) {
+	if req.PackageName == "" {
+		return "", errors.New("you must supply a package name to install from a repository")
+	}
+
+	if req.Repository != nil {
+		var err error
+		switch req.Installer.Type {
+		case PackageInstallerTypeApt:
+			err = packageRepositoryConfigureApt(ctx, tr, req.Repository)
+		case PackageInstallerTypeYum:
+			err = packageRepositoryConfigureYum(ctx, tr, req.Repository)
+		default:
+			err = fmt.Errorf("%w: %s", ErrPackageInstallInstallerUnsupported, req.Installer.Type)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	versions, err := packageRepositoryAvailableVersions(ctx, tr, req)
+	if err != nil {
+		return "", err
+	}
+
+	version, err := packageRepositoryResolveVersion(versions, req.PackageVersion)
+	if err != nil {
+		return "", fmt.Errorf("%w: package %s version %s, available versions: %s",
+			err, req.PackageName, req.PackageVersion, strings.Join(versions, ", "),
+		)
+	}
+	req.resolvedPackageVersion = version
+
+	return packageRepositoryPackageSpec(req.Installer.Type, req.PackageName, version), nil
+}
+
+func packageInstallYumInstall(ctx context.Context, tr it.Transport, req *PackageInstallRequest) error {
+	spec := packageRepositoryPackageSpec(PackageInstallerTypeYum, req.PackageName, req.resolvedPackageVersion)
+
+	stdout, stderr, err := tr.Run(ctx, command.New("sudo yum install -y "+ShellQuote(spec)))
+	if err != nil {
+		return WrapErrorWith(err, stdout, stderr, "installing yum package")
+	}
+
+	installed, err := packageRepositoryInstalledVersion(ctx, tr, req)
+	if err != nil {
+		return err
+	}
+
+	if installed != req.resolvedPackageVersion {
+		// yum install will not downgrade an installed package so we have to do it explicitly
+		stdout, stderr, err = tr.Run(ctx, command.New("sudo yum downgrade -y "+ShellQuote(spec)))
+		if err != nil {
+			return WrapErrorWith(err, stdout, stderr, "downgrading yum package")
+		}
+	}
+
+	return packageRepositoryVerifyInstalledVersion(ctx, tr, req)
+}
+
+func packageInstallAptInstall(ctx context.Context, tr it.Transport, req *PackageInstallRequest) error {
+	// If we have existing config files, we're assuming we want to keep them.
+	// --force-confold defaults to using the existing files, instead of
+	// interactively choosing which to use.
+	spec := packageRepositoryPackageSpec(PackageInstallerTypeApt, req.PackageName, req.resolvedPackageVersion)
+	cmd := "sudo DEBIAN_FRONTEND=noninteractive apt-get install -y --allow-downgrades -o Dpkg::Options::=--force-confold " + ShellQuote(spec)
+	stdout, stderr, err := tr.Run(ctx, command.New(cmd))
+	if err != nil {
+		return WrapErrorWith(err, stdout, stderr, "installing apt package")
+	}
+
+	return packageRepositoryVerifyInstalledVersion(ctx, tr, req)
+}
+
+// packageRepositoryConfigureApt installs the repository signing key and apt source list and
+// updates the package index. The signing key is downloaded with enos-flight-control and installed
+// as an ASCII armored keyring so that the target does not need curl or gpg.
+func packageRepositoryConfigureApt(ctx context.Context, tr it.Transport, repo *PackageRepository) error {
+	name, url, key := packageRepositoryDefaults(repo, PackageRepositoryHashiCorpAptURL, PackageRepositoryHashiCorpAptGPGKey)
+	keyring := packageRepositoryAptKeyring(name)
+
+	res, err := InstallFlightControl(ctx, tr, NewInstallFlightControlRequest(
+		WithInstallFlightControlRequestUseHomeDir(),
+		WithInstallFlightControlRequestTargetRequest(
+			NewTargetRequest(
+				WithTargetRequestRetryOpts(
+					retry.WithIntervalFunc(retry.IntervalExponential(2*time.Second)),
+				),
+			),
+		),
+	))
+	if err != nil {
+		return fmt.Errorf("installing flight-control binary to download apt repository signing key: %w", err)
+	}
+
+	stdout, stderr, err := tr.Run(ctx, command.New("sudo mkdir -p /usr/share/keyrings"))
+	if err != nil {
+		return WrapErrorWith(err, stdout, stderr, "creating apt keyrings directory")
+	}
+
+	_, err = Download(ctx, tr, NewDownloadRequest(
+		WithDownloadRequestFlightControlPath(res.Path),
+		WithDownloadRequestURL(key),
+		WithDownloadRequestDestination(keyring),
+		WithDownloadRequestMode("0644"),
+		WithDownloadRequestUseSudo(true),
+		WithDownloadRequestReplace(true),
+	))
+	if err != nil {
+		return fmt.Errorf("installing apt repository signing key: %w", err)
+	}
+
+	dist := repo.Distribution
+	if dist == "" {
+		dist, err = packageRepositoryAptCodename(ctx, tr)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = CopyFile(ctx, tr, NewCopyFileRequest(
+		WithCopyFileContent(tfile.NewReader(packageRepositoryAptSource(repo, url, keyring, dist))),
+		WithCopyFileDestination(fmt.Sprintf("/etc/apt/sources.list.d/%s.list", name)),
+		WithCopyFileChmod("644"),
+		WithCopyFileChown("root:root"),
+	))
+	if err != nil {
+		return fmt.Errorf("writing apt repository source list: %w", err)
+	}
+
+	return packageRepositoryRefresh(ctx, tr, "sudo apt-get update")
+}
+
+// packageRepositoryConfigureYum writes the yum repository configuration and refreshes the
+// repository metadata.
+func packageRepositoryConfigureYum(ctx context.Context, tr it.Transport, repo *PackageRepository) error {
+	name, url, key := packageRepositoryDefaults(repo, "", PackageRepositoryHashiCorpYumGPGKey)
+	if url == "" {
+		distro, err := TargetDistro(ctx, tr, NewTargetRequest(
+			WithTargetRequestRetryOpts(retry.WithIntervalFunc(retry.IntervalExponential(2*time.Second))),
+		))
+		if err != nil {
+			return fmt.Errorf("determining target distro for yum repository: %w", err)
+		}
+		url = packageRepositoryHashiCorpYumURL(distro)
+	}
+
+	err := CopyFile(ctx, tr, NewCopyFileRequest(
+		WithCopyFileContent(tfile.NewReader(packageRepositoryYumRepo(name, url, key))),
+		WithCopyFileDestination(fmt.Sprintf("/etc/yum.repos.d/%s.repo", name)),
+		WithCopyFileChmod("644"),
+		WithCopyFileChown("root:root"),
+	))
+	if err != nil {
+		return fmt.Errorf("writing yum repository configuration: %w", err)
+	}
+
+	return packageRepositoryRefresh(ctx, tr, "sudo yum makecache -y --disablerepo='*' --enablerepo="+ShellQuote(name))
+}
+
+// packageRepositoryRefresh runs the repository metadata refresh command. Repository mirrors can be
+// flaky so we'll retry a few times.
+func packageRepositoryRefresh(ctx context.Context, tr it.Transport, cmd string) error {
+	refresh := func(ctx context.Context) (any, error) {
+		stdout, stderr, err := tr.Run(ctx, command.New(cmd))
+		if err != nil {
+			return nil, WrapErrorWith(err, stdout, stderr, "refreshing package repository metadata")
+		}
+
+		return nil, nil
+	}
+
+	r, err := retry.NewRetrier(
+		retry.WithMaxRetries(3),
+		retry.WithIntervalFunc(retry.IntervalExponential(2*time.Second)),
+		retry.WithRetrierFunc(refresh),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = retry.Retry(ctx, r)
+
+	return err
+}
+
+// packageRepositoryAptCodename determines the target's distribution codename.
+func packageRepositoryAptCodename(ctx context.Context, tr it.Transport) (string, error) {
+	stdout, stderr, err := tr.Run(ctx, command.New("cat /etc/os-release"))
+	if err != nil {
+		return "", WrapErrorWith(err, stdout, stderr, "determining target distribution codename")
+	}
+
+	codename, err := findInOsRelease(stdout, "VERSION_CODENAME")
+	if err != nil {
+		return "", fmt.Errorf("determining target distribution codename: %w", err)
+	}
+
+	return codename, nil
+}
+
+// packageRepositoryAvailableVersions returns all versions of the package that are available,
+// ordered newest first.
+func packageRepositoryAvailableVersions(ctx context.Context, tr it.Transport, req *PackageInstallRequest) ([]string, error) {
+	var parse func(string, string) []string
+
+	switch req.Installer.Type {
+	case PackageInstallerTypeApt:
+		parse = packageRepositoryParseAptMadison
+	case PackageInstallerTypeYum:
+		parse = packageRepositoryParseYumList
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrPackageInstallInstallerUnsupported, req.Installer.Type)
+	}
+
+	cmd, err := packageRepositoryAvailableVersionsCommand(req.Installer.Type, req.PackageName)
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, stderr, err := tr.Run(ctx, command.New(cmd))
+	if err != nil {
+		return nil, WrapErrorWith(err, stdout, stderr, "listing available package versions")
+	}
+
+	versions := parse(req.PackageName, stdout)
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: no versions of package %s were found", ErrPackageVersionNotAvailable, req.PackageName)
+	}
+	packageRepositorySortVersions(versions)
+
+	return versions, nil
+}
+
+// packageRepositoryInstalledVersion returns the installed version of the package.
+func packageRepositoryInstalledVersion(ctx context.Context, tr it.Transport, req *PackageInstallRequest) (string, error) {
+	cmd, err := packageRepositoryInstalledVersionCommand(req.Installer.Type, req.PackageName)
+	if err != nil {
+		return "", err
+	}
+
+	stdout, stderr, err := tr.Run(ctx, command.New(cmd))
+	if err != nil {
+		return "", WrapErrorWith(err, stdout, stderr, "determining installed package version")
+	}
+
+	return strings.TrimSpace(stdout), nil
+}
+
+// packageRepositoryAvailableVersionsCommand returns the command that lists the available versions
+// of the package.
+func packageRepositoryAvailableVersionsCommand(installer PackageInstallerType, name string) (string, error) {
+	switch installer {
+	case PackageInstallerTypeApt:
+		return "apt-cache madison " + ShellQuote(name), nil
+	case PackageInstallerTypeYum:
+		return "yum -q --showduplicates list " + ShellQuote(name), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrPackageInstallInstallerUnsupported, installer)
+	}
+}
+
+// packageRepositoryInstalledVersionCommand returns the command that prints the installed version
+// of the package.
+func packageRepositoryInstalledVersionCommand(installer PackageInstallerType, name string) (string, error) {
+	switch installer {
+	case PackageInstallerTypeApt:
+		return "dpkg-query -W -f='${Version}' " + ShellQuote(name), nil
+	case PackageInstallerTypeYum:
+		return "rpm -q --qf '%{VERSION}-%{RELEASE}' " + ShellQuote(name), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrPackageInstallInstallerUnsupported, installer)
+	}
+}
+
+// packageRepositoryVerifyInstalledVersion verifies that the resolved version of the package has
+// been installed.
+func packageRepositoryVerifyInstalledVersion(ctx context.Context, tr it.Transport, req *PackageInstallRequest) error {
+	installed, err := packageRepositoryInstalledVersion(ctx, tr, req)
+	if err != nil {
+		return err
+	}
+
+	if installed != req.resolvedPackageVersion {
+		return fmt.Errorf("expected package %s version %s to be installed, found version %s",
+			req.PackageName, req.resolvedPackageVersion, installed,
+		)
+	}
+
+	return nil
+}
+
+// packageRepositoryDefaults returns the repository name, URL and signing key URL with HashiCorp
+// repository defaults for any that are not set.
+func packageRepositoryDefaults(repo *PackageRepository, defaultURL, defaultKey string) (string, string, string) {
+	name := repo.Name
+	if name == "" {
+		name = PackageRepositoryHashiCorpName
+	}
+
+	url := repo.URL
+	if url == "" {
+		url = defaultURL
+	}
+
+	key := repo.GPGKeyURL
+	if key == "" {
+		key = defaultKey
+	}
+
+	return name, url, key
+}
+
+// packageRepositoryAptKeyring returns the path of the repository signing keyring. The .asc
+// extension is required for apt to accept an ASCII armored key.
+func packageRepositoryAptKeyring(name string) string {
+	return fmt.Sprintf("/usr/share/keyrings/%s-archive-keyring.asc", name)
+}
+
+// packageRepositoryHashiCorpYumURL returns the HashiCorp yum repository base URL for the distro.
+func packageRepositoryHashiCorpYumURL(distro string) string {
+	switch distro {
+	case "amzn":
+		return PackageRepositoryHashiCorpYumURL + "/AmazonLinux/$releasever/$basearch/stable"
+	case "fedora":
+		return PackageRepositoryHashiCorpYumURL + "/fedora/$releasever/$basearch/stable"
+	default:
+		return PackageRepositoryHashiCorpYumURL + "/RHEL/$releasever/$basearch/stable"
+	}
+}
+
+// packageRepositoryAptSource renders the apt source list entry.
+func packageRepositoryAptSource(repo *PackageRepository, url, keyring, dist string) string {
+	components := repo.Components
+	if components == "" {
+		components = "main"
+	}
+
+	return fmt.Sprintf("deb [signed-by=%s] %s %s %s\n", keyring, url, dist, components)
+}
+
+// packageRepositoryYumRepo renders the yum repository configuration.
+func packageRepositoryYumRepo(name, url, key string) string {
+	return fmt.Sprintf(`[%[1]s]
+name=%[1]s
+baseurl=%[2]s
+enabled=1
+gpgcheck=1
+gpgkey=%[3]s
+`, name, url, key)
+}
+
+// packageRepositoryPackageSpec returns the package manager specific name for a version of package.
+func packageRepositoryPackageSpec(installer PackageInstallerType, name, version string) string {
+	if version == "" {
+		return name
+	}
+
+	if installer == PackageInstallerTypeApt {
+		return fmt.Sprintf("%s=%s", name, version)
+	}
+
+	return fmt.Sprintf("%s-%s", name, version)
+}
+
+// packageRepositoryParseAptMadison parses the versions out of apt-cache madison output, e.g.
+//
+//	vault | 1.15.2-1 | https://apt.releases.hashicorp.com jammy/main amd64 Packages
+func packageRepositoryParseAptMadison(name string, out string) []string {
+	versions := []string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		parts := strings.Split(scanner.Text(), "|")
+		if len(parts) < 3 || strings.TrimSpace(parts[0]) != name {
+			continue
+		}
+
+		version := strings.TrimSpace(parts[1])
+		if !slices.Contains(versions, version) {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions
+}
+
+// packageRepositoryParseYumList parses the versions out of yum list output, e.g.
+//
+//	Available Packages
+//	vault.x86_64    1.15.2-1    hashicorp
+func packageRepositoryParseYumList(name string, out string) []string {
+	versions := []string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || !strings.HasPrefix(fields[0], name+".") {
+			continue
+		}
+
+		// yum includes the epoch if it's set but rpm -q, which we use to verify, does not
+		version := fields[1]
+		if _, after, found := strings.Cut(version, ":"); found {
+			version = after
+		}
+
+		if !slices.Contains(versions, version) {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions
+}
+
+// packageRepositoryResolveVersion returns the first version that matches the requested version.
+// The versions must be ordered newest first. If the requested version is blank or "latest" the
+// newest version is returned.
+func packageRepositoryResolveVersion(versions []string, requested string) (string, error) {
+	if len(versions) == 0 {
+		return "", ErrPackageVersionNotAvailable
+	}
+
+	if requested == "" || requested == "latest" {
+		return versions[0], nil
+	}
+
+	for _, version := range versions {
+		if version == requested {
+			return version, nil
+		}
+	}
+
+	for _, version := range versions {
+		upstream, _ := packageRepositorySplitVersion(version)
+		if upstream == packageRepositoryNormalizeVersion(requested) {
+			return version, nil
+		}
+	}
+
+	return "", ErrPackageVersionNotAvailable
+}
+
+// packageRepositorySortVersions sorts the package versions newest first.
+func packageRepositorySortVersions(versions []string) {
+	slices.SortStableFunc(versions, func(a, b string) int {
+		return packageRepositoryCompareVersions(b, a)
+	})
+}
+
+// packageRepositoryCompareVersions compares two package versions. The upstream versions are
+// compared as semantic versions and the package releases are compared numerically if possible.
+func packageRepositoryCompareVersions(a, b string) int {
+	aUp, aRel := packageRepositorySplitVersion(a)
+	bUp, bRel := packageRepositorySplitVersion(b)
+
+	aVer, aErr := semver.ParseTolerant(aUp)
+	bVer, bErr := semver.ParseTolerant(bUp)
+	switch {
+	case aErr == nil && bErr == nil:
+		if c := aVer.Compare(bVer); c != 0 {
+			return c
+		}
+	case aErr == nil:
+		return 1
+	case bErr == nil:
+		return -1
+	default:
+		if c := strings.Compare(aUp, bUp); c != 0 {
+			return c
+		}
+	}
+
+	aRelN, aErr := strconv.Atoi(aRel)
+	bRelN, bErr := strconv.Atoi(bRel)
+	if aErr == nil && bErr == nil {
+		switch {
+		case aRelN < bRelN:
+			return -1
+		case aRelN > bRelN:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(aRel, bRel)
+}
+
+// packageRepositorySplitVersion splits a package version into its normalized upstream version and
+// package release, e.g. 1:1.16.0~rc1-1 becomes 1.16.0-rc1 and 1.
+func packageRepositorySplitVersion(version string) (string, string) {
+	if _, after, found := strings.Cut(version, ":"); found {
+		version = after
+	}
+
+	upstream := version
+	release := ""
+	if i := strings.LastIndex(version, "-"); i > 0 {
+		upstream = version[:i]
+		release = version[i+1:]
+	}
+
+	return packageRepositoryNormalizeVersion(upstream), release
+}
+
+// packageRepositoryNormalizeVersion normalizes debian pre-release versions, e.g. 1.16.0~rc1, into
+// semantic versions, e.g. 1.16.0-rc1.
+func packageRepositoryNormalizeVersion(version string) string {
+	return strings.ReplaceAll(version, "~", "-")
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageRepositoryParseAptMadison(t *testing.T) {
+	t.Parallel()
+
+	out := `     vault | 1.15.1-1 | https://apt.releases.hashicorp.com jammy/main amd64 Packages
+     vault | 1.15.2-1 | https://apt.releases.hashicorp.com jammy/main amd64 Packages
+     vault | 1.16.0~rc1-1 | https://apt.releases.hashicorp.com jammy/main amd64 Packages
+ vault-enterprise | 1.15.2+ent-1 | https://apt.releases.hashicorp.com jammy/main amd64 Packages
+     vault | 1.15.2-1 | https://apt.releases.hashicorp.com jammy/main arm64 Packages`
+
+	require.Equal(t,
+		[]string{"1.15.1-1", "1.15.2-1", "1.16.0~rc1-1"},
+		packageRepositoryParseAptMadison("vault", out),
+	)
+}
+
+func TestPackageRepositoryParseYumList(t *testing.T) {
+	t.Parallel()
+
+	out := `Installed Packages
+vault.x86_64                 1.15.1-1                 @hashicorp
+Available Packages
+vault.x86_64                 1.15.1-1                 hashicorp
+vault.x86_64                 1:1.15.2-1               hashicorp
+vault-enterprise.x86_64      1.15.2+ent-1             hashicorp`
+
+	require.Equal(t,
+		[]string{"1.15.1-1", "1.15.2-1"},
+		packageRepositoryParseYumList("vault", out),
+	)
+}
+
+func TestPackageRepositoryResolveVersion(t *testing.T) {
+	t.Parallel()
+
+	versions := []string{"1.15.1-1", "1.16.0~rc1-1", "1.15.2+ent-2", "1.15.2+ent-10", "1.16.0-1", "1:1.14.0-1"}
+	packageRepositorySortVersions(versions)
+	require.Equal(t,
+		[]string{"1.16.0-1", "1.16.0~rc1-1", "1.15.2+ent-10", "1.15.2+ent-2", "1.15.1-1", "1:1.14.0-1"},
+		versions,
+	)
+
+	for _, test := range []struct {
+		requested string
+		expected  string
+		err       bool
+	}{
+		{"", "1.16.0-1", false},
+		{"latest", "1.16.0-1", false},
+		{"1.16.0", "1.16.0-1", false},
+		{"1.16.0-rc1", "1.16.0~rc1-1", false},
+		{"1.16.0~rc1-1", "1.16.0~rc1-1", false},
+		{"1.15.2+ent", "1.15.2+ent-10", false},
+		{"1.15.2+ent-2", "1.15.2+ent-2", false},
+		{"1.14.0", "1:1.14.0-1", false},
+		{"1.13.0", "", true},
+	} {
+		t.Run(test.requested, func(t *testing.T) {
+			t.Parallel()
+
+			version, err := packageRepositoryResolveVersion(versions, test.requested)
+			if test.err {
+				require.ErrorIs(t, err, ErrPackageVersionNotAvailable)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, version)
+		})
+	}
+}
+
+func TestPackageRepositoryRender(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t,
+		"deb [signed-by=/usr/share/keyrings/hashicorp-archive-keyring.asc] https://apt.releases.hashicorp.com jammy main\n",
+		packageRepositoryAptSource(&PackageRepository{}, PackageRepositoryHashiCorpAptURL, packageRepositoryAptKeyring("hashicorp"), "jammy"),
+	)
+
+	require.Equal(t, `[hashicorp]
+name=hashicorp
+baseurl=https://rpm.releases.hashicorp.com/AmazonLinux/$releasever/$basearch/stable
+enabled=1
+gpgcheck=1
+gpgkey=https://rpm.releases.hashicorp.com/gpg
+`, packageRepositoryYumRepo("hashicorp", packageRepositoryHashiCorpYumURL("amzn"), PackageRepositoryHashiCorpYumGPGKey))
+
+	require.Equal(t, "vault=1.15.2-1", packageRepositoryPackageSpec(PackageInstallerTypeApt, "vault", "1.15.2-1"))
+	require.Equal(t, "vault-1.15.2-1", packageRepositoryPackageSpec(PackageInstallerTypeYum, "vault", "1.15.2-1"))
+}
+
+func TestPackageRepositoryCommands(t *testing.T) {
+	t.Parallel()
+
+	for desc, test := range map[string]struct {
+		installer PackageInstallerType
+		available string
+		installed string
+	}{
+		"apt": {
+			PackageInstallerTypeApt,
+			`apt-cache madison 'vault; rm -rf /'`,
+			`dpkg-query -W -f='${Version}' 'vault; rm -rf /'`,
+		},
+		"yum": {
+			PackageInstallerTypeYum,
+			`yum -q --showduplicates list 'vault; rm -rf /'`,
+			`rpm -q --qf '%{VERSION}-%{RELEASE}' 'vault; rm -rf /'`,
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			cmd, err := packageRepositoryAvailableVersionsCommand(test.installer, "vault; rm -rf /")
+			require.NoError(t, err)
+			require.Equal(t, test.available, cmd)
+
+			cmd, err = packageRepositoryInstalledVersionCommand(test.installer, "vault; rm -rf /")
+			require.NoError(t, err)
+			require.Equal(t, test.installed, cmd)
+		})
+	}
+
+	_, err := packageRepositoryAvailableVersionsCommand(PackageInstallerType("zypper"), "vault")
+	require.ErrorIs(t, err, ErrPackageInstallInstallerUnsupported)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageInstallInstallerForFile(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]*PackageInstallInstaller{
+		"vault_1.15.2_linux_amd64.zip":        PackageInstallInstallerZip,
+		"/path/to/vault_1.15.2.tar":           PackageInstallInstallerTar,
+		"/path/to/vault_1.15.2.tar.gz":        PackageInstallInstallerTar,
+		"vault_1.15.2.tgz":                    PackageInstallInstallerTar,
+		"vault-1.15.2-1.x86_64.rpm":           PackageInstallInstallerRPM,
+		"vault_1.15.2-1_amd64.deb":            PackageInstallInstallerDEB,
+		"/usr/local/bin/vault":                PackageInstallInstallerBinary,
+		"vault_1.15.2_linux_amd64":            PackageInstallInstallerBinary,
+		"vault_1.15.2_linux_amd64.unknownext": nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, expected, PackageInstallInstallerForFile(name))
+		})
+	}
+}
+
+func TestPackageInstallInstallerForURL(t *testing.T) {
+	t.Parallel()
+
+	for u, expected := range map[string]*PackageInstallInstaller{
+		"https://artifactory.example.com/vault/1.15.2%2Bent/vault_1.15.2%2Bent_linux_amd64.zip": PackageInstallInstallerZip,
+		"https://example.com/downloads/vault.tar.gz?token=abcd":                                 PackageInstallInstallerTar,
+		"https://example.com/downloads/vault-1.15.2-1.x86_64.rpm#fragment":                      PackageInstallInstallerRPM,
+		"https://example.com/downloads/vault":                                                   PackageInstallInstallerBinary,
+	} {
+		t.Run(u, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, expected, PackageInstallInstallerForURL(u))
+		})
+	}
+}
+
+func TestPackageInstallInstallerRequiresDestination(t *testing.T) {
+	t.Parallel()
+
+	for installer, expected := range map[*PackageInstallInstaller]bool{
+		PackageInstallInstallerZip:    true,
+		PackageInstallInstallerTar:    true,
+		PackageInstallInstallerBinary: true,
+		PackageInstallInstallerDEB:    false,
+		PackageInstallInstallerRPM:    false,
+		PackageInstallInstallerApt:    false,
+		PackageInstallInstallerYum:    false,
+	} {
+		require.Equal(t, expected, installer.RequiresDestination(), installer.Type)
+	}
+	require.False(t, (*PackageInstallInstaller)(nil).RequiresDestination())
+}
+
+func TestPackageInstallBinaryInstallCommand(t *testing.T) {
+	t.Parallel()
+
+	req := NewPackageInstallRequest(
+		WithPackageInstallDestination("/opt/it's/bin"),
+		WithPackageInstallBinaryName("vault"),
+		WithPackageInstallBinaryMode("0755"),
+	)
+	req.TempArtifactPath = "/tmp/vault.download"
+	cmd, err := req.binaryInstallCommand()
+	require.NoError(t, err)
+	require.Equal(t,
+		`sudo mkdir -p '/opt/it'\''s/bin' && sudo mv -f '/tmp/vault.download' '/opt/it'\''s/bin/vault' && sudo chmod '0755' '/opt/it'\''s/bin/vault'`,
+		cmd,
+	)
+
+	_, err = NewPackageInstallRequest(WithPackageInstallDestination("/opt/bin")).binaryInstallCommand()
+	require.Error(t, err)
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShellQuote(t *testing.T) {
+	t.Parallel()
+
+	for in, expected := range map[string]string{
+		"":                `''`,
+		"foo=bar":         `'foo=bar'`,
+		"it's":            `'it'\''s'`,
+		"$(rm -rf /)":     `'$(rm -rf /)'`,
+		"with space":      `'with space'`,
+		"'quoted'":        `''\''quoted'\'''`,
+		"line\nbreak":     "'line\nbreak'",
+		"back`tick`":      "'back`tick`'",
+		`double "quotes"`: `'double "quotes"'`,
+	} {
+		require.Equal(t, expected, ShellQuote(in))
+	}
+}
@@ -20,16 +20,23 @@ import (
 // KnownServices are list of known HashiCorp services.
 var KnownServices = []string{"boundary", "consul", "vault"}
 
+// unitFileNotFound is written by ReadUnitFile when the unit file does not exist.
+const unitFileNotFound = "__ENOS_UNIT_FILE_NOT_FOUND__"
+
 // Client an interface for a sysetmd client.
 type Client interface {
 	// CreateUnitFile creates a systemd unit file for the provided request
 	CreateUnitFile(ctx context.Context, req *CreateUnitFileRequest) error
+	// DisableService disables a systemd service with the provided unit name
+	DisableService(ctx context.Context, unit string) error
 	// EnableService enables a systemd service with the provided unit name
 	EnableService(ctx context.Context, unit string) error
 	// GetUnitJournal gets the journal for a systemd unit
 	GetUnitJournal(ctx context.Context, req *GetUnitJournalRequest) (remoteflight.GetLogsResponse, error)
 	// ListServices gets the list of systemd services installed
 	ListServices(ctx context.Context) ([]ServiceInfo, error)
+	// ReadUnitFile reads the contents of a systemd unit file at the provided path
+	ReadUnitFile(ctx context.Context, path string) (string, bool, error)
 	// RestartService restarts a systemd service with the provided unit name
 	RestartService(ctx context.Context, unit string) error
 	// RunSystemctlCommand runs a systemctl command for the provided request
@@ -111,6 +118,23 @@ func (c *client) CreateUnitFile(ctx context.Context, req *CreateUnitFileRequest)
 	return remoteflight.CopyFile(ctx, c.transport, remoteflight.NewCopyFileRequest(copyOpts...))
 }
 
+// ReadUnitFile reads the unit file at the path and returns the contents. If the file does not exist
+// it will return false.
+func (c *client) ReadUnitFile(ctx context.Context, path string) (string, bool, error) {
+	stdout, stderr, err := c.transport.Run(ctx, command.New(fmt.Sprintf(
+		"if [ -f '%[1]s' ]; then cat '%[1]s'; else echo -n '%[2]s'; fi", path, unitFileNotFound,
+	)))
+	if err != nil {
+		return "", false, remoteflight.WrapErrorWith(err, stderr, "reading unit file "+path)
+	}
+
+	if stdout == unitFileNotFound {
+		return "", false, nil
+	}
+
+	return strings.TrimSpace(stdout), true, nil
+}
+
 // ListServices gets the list of systemd services installed.
 func (c *client) ListServices(ctx context.Context) ([]ServiceInfo, error) {
 	res, err := c.RunSystemctlCommand(ctx, NewRunSystemctlCommand(
@@ -161,6 +185,18 @@ func (c *client) EnableService(ctx context.Context, unit string) error {
 	return nil
 }
 
+func (c *client) DisableService(ctx context.Context, unit string) error {
+	res, err := c.RunSystemctlCommand(ctx, NewRunSystemctlCommand(
+		WithSystemctlCommandUnitName(unit),
+		WithSystemctlCommandSubCommand(SystemctlSubCommandDisable),
+	))
+	if err != nil {
+		return errors.Join(fmt.Errorf("disabling %s, stderr: %s", unit, res.Stderr), err)
+	}
+
+	return nil
+}
+
 func (c *client) StartService(ctx context.Context, unit string) error {
 	res, err := c.RunSystemctlCommand(ctx, NewRunSystemctlCommand(
 		WithSystemctlCommandUnitName(unit),
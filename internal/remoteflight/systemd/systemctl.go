@@ -6,7 +6,6 @@ package systemd
 import (
 	"errors"
 	"fmt"
-	"path/filepath"
 	"strings"
 )
 
@@ -35,6 +34,7 @@ type SystemctlSubCommand int
 const (
 	SystemctlSubCommandNotSet SystemctlSubCommand = iota
 	SystemctlSubCommandDaemonReload
+	SystemctlSubCommandDisable
 	SystemctlSubCommandEnable
 	SystemctlSubCommandIsActive
 	SystemctlSubCommandKill
@@ -162,11 +162,10 @@ func (c *SystemctlCommandReq) String() (string, error) {
 		cmd.WriteString(c.Options + " ")
 	}
 
+	// Append the unit type suffix unless the name already has a unit type suffix, e.g. foo.timer
 	unitName := c.Name
-	unitNameSuffix := filepath.Ext(unitName)
-	unitTypeSuffix := fmt.Sprintf(".%s", c.Type)
-	if unitNameSuffix != unitTypeSuffix {
-		unitName = fmt.Sprintf("%s%s", unitName, unitTypeSuffix)
+	if _, ok := UnitTypeFromName(unitName); !ok {
+		unitName = fmt.Sprintf("%s.%s", unitName, c.Type)
 	}
 
 	switch c.SubCommand {
@@ -174,6 +173,8 @@ func (c *SystemctlCommandReq) String() (string, error) {
 		return "", errors.New("sub command is not set")
 	case SystemctlSubCommandDaemonReload:
 		cmd.WriteString("daemon-reload")
+	case SystemctlSubCommandDisable:
+		cmd.WriteString("disable " + unitName)
 	case SystemctlSubCommandIsActive:
 		cmd.WriteString("is-active " + unitName)
 	case SystemctlSubCommandEnable:
@@ -166,6 +166,33 @@ func TestSystemctlCommandString(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"sudo systemctl disable vault.service",
+			&SystemctlCommandReq{
+				Name:       "vault",
+				Type:       UnitTypeService,
+				SubCommand: SystemctlSubCommandDisable,
+			},
+			false,
+		},
+		{
+			"sudo systemctl start backup.timer",
+			&SystemctlCommandReq{
+				Name:       "backup.timer",
+				Type:       UnitTypeService,
+				SubCommand: SystemctlSubCommandStart,
+			},
+			false,
+		},
+		{
+			"sudo systemctl start vault-1.16.service",
+			&SystemctlCommandReq{
+				Name:       "vault-1.16",
+				Type:       UnitTypeService,
+				SubCommand: SystemctlSubCommandStart,
+			},
+			false,
+		},
 		{
 			"sudo systemctl show vault.service",
 			&SystemctlCommandReq{
@@ -5,6 +5,8 @@ package systemd
 
 import (
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -124,21 +126,61 @@ func (u UnitType) String() string {
 	}
 }
 
-// ToIni converts a Unit to the textual representation.  Due to go maps
-// not being ordered, the Unit may render differently each time that the function
-// is called. In testing this hasn't shown any negative effects but might be
-// confusing.
+// UnitTypeFromName returns the unit type of unit name that has a unit type suffix, e.g.
+// foo.timer. If the name does not have a known unit type suffix it will return false.
+func UnitTypeFromName(name string) (UnitType, bool) {
+	suffix := strings.TrimPrefix(filepath.Ext(name), ".")
+	if suffix == "" {
+		return UnitTypeNotSet, false
+	}
+
+	for typ := UnitTypeService; typ <= UnitTypeScope; typ++ {
+		if typ.String() == suffix {
+			return typ, true
+		}
+	}
+
+	return UnitTypeNotSet, false
+}
+
+// ToIni converts a Unit to the textual representation. The [Unit] stanza is always
+// rendered first and the remaining stanzas and their fields are sorted so that the
+// same Unit always renders the same content.
 func (s Unit) ToIni() (string, error) {
 	unit := &strings.Builder{}
 
-	for stanza, fields := range s {
+	stanzas := make([]string, 0, len(s))
+	for stanza := range s {
+		stanzas = append(stanzas, stanza)
+	}
+	slices.SortFunc(stanzas, func(a, b string) int {
+		switch {
+		case a == b:
+			return 0
+		case a == "Unit":
+			return -1
+		case b == "Unit":
+			return 1
+		default:
+			return strings.Compare(a, b)
+		}
+	})
+
+	for _, stanza := range stanzas {
+		fields := s[stanza]
 		if len(fields) == 0 {
 			continue
 		}
 
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
 		fmt.Fprintf(unit, "[%s]\n", stanza)
-		for k, v := range fields {
-			fmt.Fprintf(unit, "%s=%s\n", k, v)
+		for _, k := range keys {
+			fmt.Fprintf(unit, "%s=%s\n", k, fields[k])
 		}
 
 		unit.WriteString("\n")
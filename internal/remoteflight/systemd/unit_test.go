@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package systemd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitToIni(t *testing.T) {
+	t.Parallel()
+
+	unit := Unit{
+		"Service": {
+			"User":      "vault",
+			"ExecStart": "/opt/vault/bin/vault server",
+			"Restart":   "on-failure",
+		},
+		"Install": {
+			"WantedBy": "multi-user.target",
+		},
+		"Unit": {
+			"Description": "Vault",
+			"After":       "network-online.target",
+		},
+		"X-Empty": {},
+	}
+
+	expected := `[Unit]
+After=network-online.target
+Description=Vault
+
+[Install]
+WantedBy=multi-user.target
+
+[Service]
+ExecStart=/opt/vault/bin/vault server
+Restart=on-failure
+User=vault`
+
+	// Render it a few times to make sure that our output is stable
+	for range 10 {
+		ini, err := unit.ToIni()
+		require.NoError(t, err)
+		require.Equal(t, expected, ini)
+	}
+}
+
+func TestUnitTypeFromName(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		typ UnitType
+		ok  bool
+	}{
+		"vault":         {UnitTypeNotSet, false},
+		"vault.service": {UnitTypeService, true},
+		"backup.timer":  {UnitTypeTimer, true},
+		"data.mount":    {UnitTypeMount, true},
+		"vault-1.16":    {UnitTypeNotSet, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			typ, ok := UnitTypeFromName(name)
+			require.Equal(t, test.ok, ok)
+			require.Equal(t, test.typ, typ)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TLSConfig is the client TLS configuration to use when making requests to a
+// TLS enabled listener. All paths are paths on the target machine.
+type TLSConfig struct {
+	CACert     string
+	ClientCert string
+	ClientKey  string
+	ServerName string
+	SkipVerify bool
+}
+
+// Validate validates that the TLS configuration is valid.
+func (t TLSConfig) Validate() error {
+	if (t.ClientCert == "") != (t.ClientKey == "") {
+		return errors.New("you must supply both a client certificate and client key")
+	}
+
+	return nil
+}
+
+// FlightControlArgs returns the TLS configuration as enos-flight-control
+// download arguments. Each argument is prefixed with a space so that the result
+// can be appended to an existing command.
+func (t TLSConfig) FlightControlArgs() string {
+	args := &strings.Builder{}
+
+	if t.CACert != "" {
+		fmt.Fprintf(args, " --ca-cert %s", ShellQuote(t.CACert))
+	}
+	if t.ClientCert != "" {
+		fmt.Fprintf(args, " --client-cert %s", ShellQuote(t.ClientCert))
+	}
+	if t.ClientKey != "" {
+		fmt.Fprintf(args, " --client-key %s", ShellQuote(t.ClientKey))
+	}
+	if t.ServerName != "" {
+		fmt.Fprintf(args, " --tls-server-name %s", ShellQuote(t.ServerName))
+	}
+	if t.SkipVerify {
+		args.WriteString(" --tls-skip-verify")
+	}
+
+	return args.String()
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSConfigFlightControlArgs(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		config   TLSConfig
+		expected string
+	}{
+		"empty": {
+			TLSConfig{},
+			"",
+		},
+		"ca-cert": {
+			TLSConfig{CACert: "/etc/vault.d/tls/ca.pem"},
+			" --ca-cert '/etc/vault.d/tls/ca.pem'",
+		},
+		"quoted": {
+			TLSConfig{CACert: "/home/it's/ca.pem"},
+			" --ca-cert '/home/it'\\''s/ca.pem'",
+		},
+		"all": {
+			TLSConfig{
+				CACert:     "/etc/vault.d/tls/ca.pem",
+				ClientCert: "/etc/vault.d/tls/client.pem",
+				ClientKey:  "/etc/vault.d/tls/client-key.pem",
+				ServerName: "vault.example.com",
+				SkipVerify: true,
+			},
+			" --ca-cert '/etc/vault.d/tls/ca.pem' --client-cert '/etc/vault.d/tls/client.pem' --client-key '/etc/vault.d/tls/client-key.pem' --tls-server-name 'vault.example.com' --tls-skip-verify",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.expected, test.config.FlightControlArgs())
+		})
+	}
+}
+
+func TestTLSConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, TLSConfig{}.Validate())
+	require.NoError(t, TLSConfig{ClientCert: "cert.pem", ClientKey: "key.pem"}.Validate())
+	require.Error(t, TLSConfig{ClientCert: "cert.pem"}.Validate())
+	require.Error(t, TLSConfig{ClientKey: "key.pem"}.Validate())
+}
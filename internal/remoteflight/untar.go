@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// UntarRequest performs a remote flight control untar.
+type UntarRequest struct {
+	FlightControlPath          string
+	SourcePath                 string
+	DestinationDirectory       string
+	FileMode                   string // If unset the archive file modes are kept
+	DestinationDirectoryMode   string
+	StripComponents            uint
+	Sudo                       bool
+	CreateDestinationDirectory bool
+	Replace                    bool
+}
+
+// UntarResponse is a flight control untar response.
+type UntarResponse struct{}
+
+// UntarOpt is a functional option for an untar request.
+type UntarOpt func(*UntarRequest) *UntarRequest
+
+// NewUntarRequest takes functional options and returns a new untar request.
+func NewUntarRequest(opts ...UntarOpt) *UntarRequest {
+	ur := &UntarRequest{
+		FlightControlPath:          DefaultFlightControlPath,
+		DestinationDirectoryMode:   "0755",
+		Sudo:                       false,
+		CreateDestinationDirectory: true,
+		Replace:                    false,
+	}
+
+	for _, opt := range opts {
+		ur = opt(ur)
+	}
+
+	return ur
+}
+
+// WithUntarRequestFlightControlPath sets the location of the enos-flight-control
+// binary.
+func WithUntarRequestFlightControlPath(path string) UntarOpt {
+	return func(ur *UntarRequest) *UntarRequest {
+		ur.FlightControlPath = path
+		return ur
+	}
+}
+
+// WithUntarRequestSourcePath sets the tar archive source path.
+func WithUntarRequestSourcePath(path string) UntarOpt {
+	return func(ur *UntarRequest) *UntarRequest {
+		ur.SourcePath = path
+		return ur
+	}
+}
+
+// WithUntarRequestDestinationDir sets the untar directory.
+func WithUntarRequestDestinationDir(dir string) UntarOpt {
+	return func(ur *UntarRequest) *UntarRequest {
+		ur.DestinationDirectory = dir
+		return ur
+	}
+}
+
+// WithUntarRequestFileMode sets the mode for files that are expanded.
+func WithUntarRequestFileMode(mode string) UntarOpt {
+	return func(ur *UntarRequest) *UntarRequest {
+		ur.FileMode = mode
+		return ur
+	}
+}
+
+// WithUntarRequestDestinationDirMode sets the mode for destination directory
+// if it is created.
+func WithUntarRequestDestinationDirMode(mode string) UntarOpt {
+	return func(ur *UntarRequest) *UntarRequest {
+		ur.DestinationDirectoryMode = mode
+		return ur
+	}
+}
+
+// WithUntarRequestStripComponents sets the number of leading path components
+// to strip from the expanded files.
+func WithUntarRequestStripComponents(n uint) UntarOpt {
+	return func(ur *UntarRequest) *UntarRequest {
+		ur.StripComponents = n
+		return ur
+	}
+}
+
+// WithUntarRequestCreateDestinationDir determines if the destination directory
+// should be created.
+func WithUntarRequestCreateDestinationDir(create bool) UntarOpt {
+	return func(ur *UntarRequest) *UntarRequest {
+		ur.CreateDestinationDirectory = create
+		return ur
+	}
+}
+
+// WithUntarRequestUseSudo determines if the untar command should be run with
+// sudo.
+func WithUntarRequestUseSudo(useSudo bool) UntarOpt {
+	return func(ur *UntarRequest) *UntarRequest {
+		ur.Sudo = useSudo
+		return ur
+	}
+}
+
+// WithUntarRequestReplace determines if the untar command should overwrite
+// the destination files if they exist.
+func WithUntarRequestReplace(replace bool) UntarOpt {
+	return func(ur *UntarRequest) *UntarRequest {
+		ur.Replace = replace
+		return ur
+	}
+}
+
+// Untar expands a tar archive on a remote machine with enos-flight-control.
+func Untar(ctx context.Context, tr transport.Transport, ur *UntarRequest) (*UntarResponse, error) {
+	res := &UntarResponse{}
+
+	select {
+	case <-ctx.Done():
+		return res, ctx.Err()
+	default:
+	}
+
+	cmd := fmt.Sprintf(
+		"%s untar --source '%s' --destination '%s' --destination-mode '%s' --strip-components %d --create-destination=%t --replace=%t",
+		ur.FlightControlPath,
+		ur.SourcePath,
+		ur.DestinationDirectory,
+		ur.DestinationDirectoryMode,
+		ur.StripComponents,
+		ur.CreateDestinationDirectory,
+		ur.Replace,
+	)
+	if ur.FileMode != "" {
+		cmd = fmt.Sprintf("%s --mode '%s'", cmd, ur.FileMode)
+	}
+	if ur.Sudo {
+		cmd = "sudo " + cmd
+	}
+
+	stdout, stderr, err := tr.Run(ctx, command.New(cmd))
+	if err != nil {
+		return res, WrapErrorWith(err, stdout, stderr)
+	}
+
+	return res, err
+}
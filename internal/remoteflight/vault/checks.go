@@ -231,6 +231,31 @@ func CheckStatePodContainerIsReady(podName string, containerName string) CheckSt
 	}
 }
 
+// CheckStateNomadTaskIsRunning takes a task name and asserts that the task in the nomad allocation
+// is running.
+func CheckStateNomadTaskIsRunning(task string) CheckStater {
+	return func(s *State) error {
+		if s == nil || s.Allocation == nil {
+			return fmt.Errorf("checking nomad task %s is running: no allocation found in state", task)
+		}
+
+		ts, ok := s.Allocation.TaskStates[task]
+		if !ok || ts == nil {
+			return fmt.Errorf(
+				"checking nomad task %s is running: task not found in allocation %s", task, s.Allocation.ID,
+			)
+		}
+
+		if ts.State != "running" {
+			return fmt.Errorf(
+				"checking nomad task %s is running: expected state running, got %s", task, ts.State,
+			)
+		}
+
+		return nil
+	}
+}
+
 // CheckStateIsInitialized checks whether or not vault is initialized.
 func CheckStateIsInitialized() CheckStater {
 	return func(s *State) error {
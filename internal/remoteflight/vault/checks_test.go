@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -529,6 +530,58 @@ func TestCheckStatePodContainersIsReady(t *testing.T) {
 	}
 }
 
+func TestCheckStateNomadTaskIsRunning(t *testing.T) {
+	t.Parallel()
+	for name, test := range map[string]struct {
+		alloc      *api.Allocation
+		shouldFail bool
+	}{
+		"no-allocation": {
+			nil,
+			true,
+		},
+		"missing-task": {
+			&api.Allocation{
+				ID: "4a212111",
+				TaskStates: map[string]*api.TaskState{
+					"vault-agent": {State: "running"},
+				},
+			},
+			true,
+		},
+		"pending": {
+			&api.Allocation{
+				ID: "4a212111",
+				TaskStates: map[string]*api.TaskState{
+					"vault": {State: "pending"},
+				},
+			},
+			true,
+		},
+		"running": {
+			&api.Allocation{
+				ID: "4a212111",
+				TaskStates: map[string]*api.TaskState{
+					"vault": {State: "running"},
+				},
+			},
+			false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			state := NewState()
+			state.Allocation = test.alloc
+			if test.shouldFail {
+				require.Error(t, CheckStateNomadTaskIsRunning("vault")(state))
+			} else {
+				require.NoError(t, CheckStateNomadTaskIsRunning("vault")(state))
+			}
+		})
+	}
+}
+
 func TestCheckStateHasStatusCode(t *testing.T) {
 	t.Parallel()
 	for name, test := range map[string]struct {
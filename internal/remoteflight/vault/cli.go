@@ -3,9 +3,48 @@
 
 package vault
 
+import (
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+)
+
 // CLIRequest are common things that we need when making a CLI request.
 type CLIRequest struct {
 	VaultAddr string
 	Token     string
 	BinPath   string
+	remoteflight.TLSConfig
+}
+
+// EnvVars returns the environment variables that configure the vault CLI to
+// talk to the vault listener.
+func (r *CLIRequest) EnvVars() map[string]string {
+	env := map[string]string{}
+
+	if r == nil {
+		return env
+	}
+
+	if r.VaultAddr != "" {
+		env["VAULT_ADDR"] = r.VaultAddr
+	}
+	if r.Token != "" {
+		env["VAULT_TOKEN"] = r.Token
+	}
+	if r.CACert != "" {
+		env["VAULT_CACERT"] = r.CACert
+	}
+	if r.ClientCert != "" {
+		env["VAULT_CLIENT_CERT"] = r.ClientCert
+	}
+	if r.ClientKey != "" {
+		env["VAULT_CLIENT_KEY"] = r.ClientKey
+	}
+	if r.ServerName != "" {
+		env["VAULT_TLS_SERVER_NAME"] = r.ServerName
+	}
+	if r.SkipVerify {
+		env["VAULT_SKIP_VERIFY"] = "true"
+	}
+
+	return env
 }
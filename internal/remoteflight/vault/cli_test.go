@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+)
+
+func TestCLIRequestEnvVars(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		req      *CLIRequest
+		expected map[string]string
+	}{
+		"addr-only": {
+			&CLIRequest{VaultAddr: "http://127.0.0.1:8200"},
+			map[string]string{"VAULT_ADDR": "http://127.0.0.1:8200"},
+		},
+		"token": {
+			&CLIRequest{VaultAddr: "http://127.0.0.1:8200", Token: "root"},
+			map[string]string{
+				"VAULT_ADDR":  "http://127.0.0.1:8200",
+				"VAULT_TOKEN": "root",
+			},
+		},
+		"tls": {
+			&CLIRequest{
+				VaultAddr: "https://127.0.0.1:8200",
+				Token:     "root",
+				TLSConfig: remoteflight.TLSConfig{
+					CACert:     "/etc/vault.d/tls/ca.pem",
+					ClientCert: "/etc/vault.d/tls/client.pem",
+					ClientKey:  "/etc/vault.d/tls/client-key.pem",
+					ServerName: "vault.example.com",
+					SkipVerify: true,
+				},
+			},
+			map[string]string{
+				"VAULT_ADDR":            "https://127.0.0.1:8200",
+				"VAULT_TOKEN":           "root",
+				"VAULT_CACERT":          "/etc/vault.d/tls/ca.pem",
+				"VAULT_CLIENT_CERT":     "/etc/vault.d/tls/client.pem",
+				"VAULT_CLIENT_KEY":      "/etc/vault.d/tls/client-key.pem",
+				"VAULT_TLS_SERVER_NAME": "vault.example.com",
+				"VAULT_SKIP_VERIFY":     "true",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.expected, test.req.EnvVars())
+		})
+	}
+}
+
+func TestHealthRequestStringTLS(t *testing.T) {
+	t.Parallel()
+
+	req := NewHealthRequest(
+		WithHealthRequestVaultAddr("https://127.0.0.1:8200"),
+		WithHealthFlightControlPath("/opt/qti/bin/enos-flight-control"),
+		WithHealthRequestTLSConfig(remoteflight.TLSConfig{CACert: "/etc/vault.d/tls/ca.pem"}),
+	)
+
+	require.Equal(t,
+		"/opt/qti/bin/enos-flight-control download --exit-with-status-code --stdout --url 'https://127.0.0.1:8200/v1/sys/health?standbyok=false&perfstandbyok=false&activecode=230&standbycode=231&drsecondarycode=232&performancestandbycode=233&sealedcode=235&uninitcode=234' --ca-cert '/etc/vault.d/tls/ca.pem'",
+		req.String(),
+	)
+}
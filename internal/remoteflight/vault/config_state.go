@@ -110,8 +110,7 @@ func GetConfigStateSanitized(ctx context.Context, tr it.Transport, req *CLIReque
 	if err == nil {
 		stdout, stderr, err1 := tr.Run(ctx, command.New(
 			req.BinPath+" read -format=json sys/config/state/sanitized",
-			command.WithEnvVar("VAULT_ADDR", req.VaultAddr),
-			command.WithEnvVar("VAULT_TOKEN", req.Token),
+			command.WithEnvVars(req.EnvVars()),
 		))
 		if err1 != nil {
 			err = err1
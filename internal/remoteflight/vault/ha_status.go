@@ -63,8 +63,7 @@ func GetHAStatus(ctx context.Context, tr it.Transport, req *CLIRequest) (*HAStat
 	if err == nil {
 		stdout, stderr, err1 := tr.Run(ctx, command.New(
 			req.BinPath+" read -format=json sys/ha-status",
-			command.WithEnvVar("VAULT_ADDR", req.VaultAddr),
-			command.WithEnvVar("VAULT_TOKEN", req.Token),
+			command.WithEnvVars(req.EnvVars()),
 		))
 		if err1 != nil {
 			err = err1
@@ -95,6 +95,7 @@ type HealthRequest struct {
 	PerformanceStandbyCode HealthStatus
 	SealedCode             HealthStatus
 	UnInitCode             HealthStatus
+	remoteflight.TLSConfig
 }
 
 // HealthRequestOpt is a functional option for health requests.
@@ -161,6 +162,14 @@ func WithHealthRequestVaultAddr(addr string) HealthRequestOpt {
 	}
 }
 
+// WithHealthRequestTLSConfig sets the client TLS configuration.
+func WithHealthRequestTLSConfig(tls remoteflight.TLSConfig) HealthRequestOpt {
+	return func(u *HealthRequest) *HealthRequest {
+		u.TLSConfig = tls
+		return u
+	}
+}
+
 // NewHealthResponse returns a new instance of NewHealthResponse.
 func NewHealthResponse() *HealthResponse {
 	return &HealthResponse{License: &HealthResponseDataLicense{}}
@@ -221,7 +230,7 @@ func GetHealth(ctx context.Context, tr it.Transport, req *HealthRequest) (*Healt
 // String returns the health status request as an enos-flight-control command string.
 func (r *HealthRequest) String() string {
 	return fmt.Sprintf(
-		"%s download --exit-with-status-code --stdout --url '%s/v1/sys/health?standbyok=%t&perfstandbyok=%t&activecode=%d&standbycode=%d&drsecondarycode=%d&performancestandbycode=%d&sealedcode=%d&uninitcode=%d'",
+		"%s download --exit-with-status-code --stdout --url '%s/v1/sys/health?standbyok=%t&perfstandbyok=%t&activecode=%d&standbycode=%d&drsecondarycode=%d&performancestandbycode=%d&sealedcode=%d&uninitcode=%d'%s",
 		r.FlightControlPath,
 		r.VaultAddr,
 		r.StandbyOk,
@@ -232,6 +241,7 @@ func (r *HealthRequest) String() string {
 		r.PerformanceStandbyCode,
 		r.SealedCode,
 		r.UnInitCode,
+		r.FlightControlArgs(),
 	)
 }
 
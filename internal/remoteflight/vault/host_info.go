@@ -67,8 +67,7 @@ func GetHostInfo(ctx context.Context, tr it.Transport, req *CLIRequest) (*HostIn
 	if err == nil {
 		stdout, stderr, err1 := tr.Run(ctx, command.New(
 			req.BinPath+" read -format=json sys/host-info",
-			command.WithEnvVar("VAULT_ADDR", req.VaultAddr),
-			command.WithEnvVar("VAULT_TOKEN", req.Token),
+			command.WithEnvVars(req.EnvVars()),
 		))
 		if err1 != nil {
 			err = err1
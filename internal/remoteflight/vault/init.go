@@ -292,7 +292,7 @@ func Init(ctx context.Context, tr it.Transport, req *InitRequest) (*InitResponse
 	tflog.Debug(ctx, "Running Vault Init command: "+req.String())
 	stdout, stderr, err := tr.Run(ctx, command.New(
 		req.String(),
-		command.WithEnvVar("VAULT_ADDR", req.VaultAddr),
+		command.WithEnvVars(req.EnvVars()),
 	))
 
 	if stdout == "" {
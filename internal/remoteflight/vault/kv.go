@@ -0,0 +1,450 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/retry"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// KVRequest is a request to write or read a secret in a KV secrets engine.
+type KVRequest struct {
+	*CLIRequest
+	Mount   string
+	Path    string
+	Version int
+	Data    map[string]string
+}
+
+// KVRequestOpt is a functional option for a KV request.
+type KVRequestOpt func(*KVRequest) *KVRequest
+
+// SecretsListResponse is the JSON stdout result of "vault secrets list". It is
+// keyed by the mount path, which always includes a trailing slash.
+type SecretsListResponse map[string]*SecretsEngine
+
+// SecretsEngine is a mounted secrets engine in the secrets list result.
+type SecretsEngine struct {
+	Type        string            `json:"type,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Accessor    string            `json:"accessor,omitempty"`
+	Local       bool              `json:"local,omitempty"`
+	SealWrap    bool              `json:"seal_wrap,omitempty"`
+	Options     map[string]string `json:"options,omitempty"`
+}
+
+// KVVersion returns the version of a kv secrets engine. Engines that have been mounted without a
+// version option, and generic engines, are version 1.
+func (e *SecretsEngine) KVVersion() int {
+	if e.Type == "kv" && e.Options["version"] == "2" {
+		return 2
+	}
+
+	return 1
+}
+
+// KVGetResponse is the JSON stdout result of "vault kv get". The shape of the
+// data differs between version 1 and version 2 of the KV secrets engine, use
+// KeyValues() to get the secret data.
+type KVGetResponse struct {
+	Data map[string]any `json:"data,omitempty"`
+}
+
+// NewKVRequest takes functional options and returns a new KV request.
+func NewKVRequest(opts ...KVRequestOpt) *KVRequest {
+	k := &KVRequest{
+		CLIRequest: &CLIRequest{},
+		Mount:      "secret",
+		Version:    2,
+		Data:       map[string]string{},
+	}
+
+	for _, opt := range opts {
+		k = opt(k)
+	}
+
+	return k
+}
+
+// WithKVRequestBinPath sets the vault binary path.
+func WithKVRequestBinPath(path string) KVRequestOpt {
+	return func(k *KVRequest) *KVRequest {
+		k.BinPath = path
+		return k
+	}
+}
+
+// WithKVRequestVaultAddr sets the vault address.
+func WithKVRequestVaultAddr(addr string) KVRequestOpt {
+	return func(k *KVRequest) *KVRequest {
+		k.VaultAddr = addr
+		return k
+	}
+}
+
+// WithKVRequestToken sets the vault token.
+func WithKVRequestToken(token string) KVRequestOpt {
+	return func(k *KVRequest) *KVRequest {
+		k.Token = token
+		return k
+	}
+}
+
+// WithKVRequestMount sets the KV secrets engine mount path.
+func WithKVRequestMount(mount string) KVRequestOpt {
+	return func(k *KVRequest) *KVRequest {
+		k.Mount = mount
+		return k
+	}
+}
+
+// WithKVRequestPath sets the secret path inside of the mount.
+func WithKVRequestPath(path string) KVRequestOpt {
+	return func(k *KVRequest) *KVRequest {
+		k.Path = path
+		return k
+	}
+}
+
+// WithKVRequestVersion sets the KV secrets engine version.
+func WithKVRequestVersion(version int) KVRequestOpt {
+	return func(k *KVRequest) *KVRequest {
+		k.Version = version
+		return k
+	}
+}
+
+// WithKVRequestData sets the secret data.
+func WithKVRequestData(data map[string]string) KVRequestOpt {
+	return func(k *KVRequest) *KVRequest {
+		k.Data = data
+		return k
+	}
+}
+
+// Validate validates that the KV request has the required fields.
+func (r *KVRequest) Validate() error {
+	var err error
+
+	if r.BinPath == "" {
+		err = errors.Join(err, errors.New("you must supply a vault bin path"))
+	}
+
+	if r.VaultAddr == "" {
+		err = errors.Join(err, errors.New("you must supply a vault listen address"))
+	}
+
+	if r.Token == "" {
+		err = errors.Join(err, errors.New("you must supply a vault token"))
+	}
+
+	if r.Mount == "" {
+		err = errors.Join(err, errors.New("you must supply a KV mount path"))
+	}
+
+	if r.Path == "" {
+		err = errors.Join(err, errors.New("you must supply a KV secret path"))
+	}
+
+	if r.Version != 1 && r.Version != 2 {
+		err = errors.Join(err, fmt.Errorf("unsupported KV version: %d, must be 1 or 2", r.Version))
+	}
+
+	return err
+}
+
+// mountKey returns the mount as it is keyed in the secrets list response.
+func (r *KVRequest) mountKey() string {
+	return strings.Trim(r.Mount, "/") + "/"
+}
+
+// ListSecretsEngines returns the mounted secrets engines.
+func ListSecretsEngines(ctx context.Context, tr it.Transport, req *CLIRequest) (SecretsListResponse, error) {
+	var err error
+	res := SecretsListResponse{}
+
+	select {
+	case <-ctx.Done():
+		err = ctx.Err()
+	default:
+	}
+
+	if req.BinPath == "" {
+		err = errors.Join(err, errors.New("you must supply a vault bin path"))
+	}
+
+	if req.VaultAddr == "" {
+		err = errors.Join(err, errors.New("you must supply a vault listen address"))
+	}
+
+	if req.Token == "" {
+		err = errors.Join(err, errors.New("you must supply a vault token"))
+	}
+
+	if err == nil {
+		stdout, stderr, err1 := tr.Run(ctx, command.New(
+			req.BinPath+" secrets list -format=json",
+			command.WithEnvVars(req.EnvVars()),
+		))
+		if err1 != nil {
+			err = err1
+		}
+		if stderr != "" {
+			err = errors.Join(err, fmt.Errorf("unexpected write to stderr: %s", stderr))
+		}
+
+		if stdout == "" {
+			err = errors.Join(err, errors.New("no body was written to stdout"))
+		} else {
+			err = errors.Join(err, json.Unmarshal([]byte(stdout), &res))
+		}
+	}
+
+	if err != nil {
+		return nil, errors.Join(errors.New("list vault secrets engines: vault secrets list"), err)
+	}
+
+	return res, nil
+}
+
+// EnableKVMount enables the KV secrets engine at the requested mount if it has not
+// already been enabled.
+func EnableKVMount(ctx context.Context, tr it.Transport, req *KVRequest) error {
+	engines, err := ListSecretsEngines(ctx, tr, req.CLIRequest)
+	if err != nil {
+		return err
+	}
+
+	if engine, ok := engines[req.mountKey()]; ok {
+		if engine.Type != "kv" && engine.Type != "generic" {
+			return fmt.Errorf("a %s secrets engine is already mounted at %s", engine.Type, req.Mount)
+		}
+
+		if version := engine.KVVersion(); version != req.Version {
+			return fmt.Errorf("a version %d kv secrets engine is already mounted at %s, expected version %d",
+				version, req.Mount, req.Version,
+			)
+		}
+
+		return nil
+	}
+
+	_, stderr, err := tr.Run(ctx, command.New(
+		fmt.Sprintf("%s secrets enable -path=%s -version=%d kv",
+			req.BinPath, remoteflight.ShellQuote(req.Mount), req.Version,
+		),
+		command.WithEnvVars(req.EnvVars()),
+	))
+	if err != nil {
+		return remoteflight.WrapErrorWith(
+			fmt.Errorf("enabling kv secrets engine at %s: %w", req.Mount, err), stderr,
+		)
+	}
+
+	return nil
+}
+
+// PutKV writes the request data to the KV secret.
+func PutKV(ctx context.Context, tr it.Transport, req *KVRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	if len(req.Data) < 1 {
+		return errors.New("you must supply data to write")
+	}
+
+	cmd, err := req.putCommand()
+	if err != nil {
+		return err
+	}
+
+	_, stderr, err := tr.Run(ctx, command.New(cmd, command.WithEnvVars(req.EnvVars())))
+	if err != nil {
+		return remoteflight.WrapErrorWith(
+			fmt.Errorf("writing kv secret %s/%s: %w", req.Mount, req.Path, err), stderr,
+		)
+	}
+
+	return nil
+}
+
+// putCommand returns the "vault kv put" command that writes the request data. The data is passed
+// as JSON on stdin rather than as key=value arguments, as the vault CLI would otherwise read values
+// that begin with "@" from a file and a value of "-" from stdin.
+func (r *KVRequest) putCommand() (string, error) {
+	body, err := json.Marshal(r.Data)
+	if err != nil {
+		return "", fmt.Errorf("encoding kv secret data: %w", err)
+	}
+
+	// The JSON encoding is always a single line so it cannot contain the heredoc delimiter.
+	return fmt.Sprintf("%s kv put -mount=%s %s - <<'EOF'\n%s\nEOF",
+		r.BinPath, remoteflight.ShellQuote(r.Mount), remoteflight.ShellQuote(r.Path), body,
+	), nil
+}
+
+// GetKV reads the KV secret.
+func GetKV(ctx context.Context, tr it.Transport, req *KVRequest) (*KVGetResponse, error) {
+	var err error
+	res := NewKVGetResponse()
+
+	select {
+	case <-ctx.Done():
+		err = ctx.Err()
+	default:
+	}
+
+	err = errors.Join(err, req.Validate())
+
+	if err == nil {
+		stdout, stderr, err1 := tr.Run(ctx, command.New(
+			fmt.Sprintf("%s kv get -format=json -mount=%s %s",
+				req.BinPath, remoteflight.ShellQuote(req.Mount), remoteflight.ShellQuote(req.Path),
+			),
+			command.WithEnvVars(req.EnvVars()),
+		))
+		if err1 != nil {
+			err = remoteflight.WrapErrorWith(err1, stderr)
+		}
+
+		if stdout == "" {
+			err = errors.Join(err, errors.New("no body was written to stdout"))
+		} else {
+			err = errors.Join(err, json.Unmarshal([]byte(stdout), res))
+		}
+	}
+
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("get vault kv secret: vault kv get %s/%s", req.Mount, req.Path), err)
+	}
+
+	return res, nil
+}
+
+// VerifyKV reads the KV secret and verifies that it contains the request data.
+func VerifyKV(ctx context.Context, tr it.Transport, req *KVRequest) error {
+	res, err := GetKV(ctx, tr, req)
+	if err != nil {
+		return err
+	}
+
+	got, err := res.KeyValues(req.Version)
+	if err != nil {
+		return err
+	}
+
+	return VerifyKVData(req.Data, got)
+}
+
+// WaitForKV waits until the KV secret contains the request data. This is useful
+// when reading from nodes that might lag behind the node we wrote to, e.g.
+// performance standbys or replication secondaries.
+func WaitForKV(ctx context.Context, tr it.Transport, req *KVRequest) error {
+	verifyKV := func(ctx context.Context) (any, error) {
+		return nil, VerifyKV(ctx, tr, req)
+	}
+
+	r, err := retry.NewRetrier(
+		retry.WithIntervalFunc(retry.IntervalDuration(2*time.Second)),
+		retry.WithRetrierFunc(verifyKV),
+	)
+	if err == nil {
+		_, err = retry.Retry(ctx, r)
+	}
+
+	if err != nil {
+		return fmt.Errorf("waiting for kv secret %s/%s to have expected data: %w", req.Mount, req.Path, err)
+	}
+
+	return nil
+}
+
+// NewKVGetResponse returns a new instance of KVGetResponse.
+func NewKVGetResponse() *KVGetResponse {
+	return &KVGetResponse{}
+}
+
+// KeyValues returns the secret data for the given version of the KV secrets
+// engine. Non-string values are encoded as JSON.
+func (r *KVGetResponse) KeyValues(version int) (map[string]string, error) {
+	if r == nil || r.Data == nil {
+		return nil, errors.New("kv secret response has no data")
+	}
+
+	data := r.Data
+	if version == 2 {
+		d, ok := r.Data["data"]
+		if !ok {
+			return nil, errors.New("kv version 2 secret response has no data")
+		}
+
+		data, ok = d.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected kv version 2 secret data type: %T", d)
+		}
+	}
+
+	res := map[string]string{}
+	for key, val := range data {
+		switch v := val.(type) {
+		case string:
+			res[key] = v
+		case bool:
+			res[key] = strconv.FormatBool(v)
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			res[key] = string(b)
+		}
+	}
+
+	return res, nil
+}
+
+// VerifyKVData verifies that every key and value that we expect is in the data
+// we got. Extra keys are allowed.
+func VerifyKVData(expected map[string]string, got map[string]string) error {
+	keys := []string{}
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var err error
+	for _, key := range keys {
+		val, ok := got[key]
+		if !ok {
+			err = errors.Join(err, fmt.Errorf("missing key: %s", key))
+			continue
+		}
+
+		if val != expected[key] {
+			err = errors.Join(err, fmt.Errorf(
+				"unexpected value for key: %s, expected: %s, got: %s", key, expected[key], val,
+			))
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretsListDeserialize(t *testing.T) {
+	t.Parallel()
+
+	got := SecretsListResponse{}
+	body := testReadSupport(t, "secrets-list.json")
+	require.NoError(t, json.Unmarshal(body, &got))
+	require.Len(t, got, 2)
+	require.Equal(t, "kv", got["secret/"].Type)
+	require.Equal(t, map[string]string{"version": "2"}, got["secret/"].Options)
+	require.Equal(t, "cubbyhole", got["cubbyhole/"].Type)
+	require.True(t, got["cubbyhole/"].Local)
+}
+
+func TestKVGetDeserializeKeyValues(t *testing.T) {
+	t.Parallel()
+
+	expected := map[string]string{
+		"foo":     "bar",
+		"count":   "3",
+		"enabled": "true",
+	}
+
+	for name, test := range map[string]struct {
+		file    string
+		version int
+	}{
+		"v1": {"kv-get-v1.json", 1},
+		"v2": {"kv-get-v2.json", 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res := NewKVGetResponse()
+			body := testReadSupport(t, test.file)
+			require.NoError(t, json.Unmarshal(body, res))
+			got, err := res.KeyValues(test.version)
+			require.NoError(t, err)
+			require.Equal(t, expected, got)
+		})
+	}
+}
+
+func TestVerifyKVData(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		expected   map[string]string
+		got        map[string]string
+		shouldFail bool
+	}{
+		"match": {
+			map[string]string{"foo": "bar"},
+			map[string]string{"foo": "bar"},
+			false,
+		},
+		"extra-keys": {
+			map[string]string{"foo": "bar"},
+			map[string]string{"foo": "bar", "baz": "qux"},
+			false,
+		},
+		"missing-key": {
+			map[string]string{"foo": "bar", "baz": "qux"},
+			map[string]string{"foo": "bar"},
+			true,
+		},
+		"mismatched-value": {
+			map[string]string{"foo": "bar"},
+			map[string]string{"foo": "baz"},
+			true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if test.shouldFail {
+				require.Error(t, VerifyKVData(test.expected, test.got))
+			} else {
+				require.NoError(t, VerifyKVData(test.expected, test.got))
+			}
+		})
+	}
+}
+
+func TestSecretsEngineKVVersion(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		engine   *SecretsEngine
+		expected int
+	}{
+		"kv v2":      {&SecretsEngine{Type: "kv", Options: map[string]string{"version": "2"}}, 2},
+		"kv v1":      {&SecretsEngine{Type: "kv", Options: map[string]string{"version": "1"}}, 1},
+		"kv no opts": {&SecretsEngine{Type: "kv"}, 1},
+		"generic":    {&SecretsEngine{Type: "generic"}, 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, test.expected, test.engine.KVVersion())
+		})
+	}
+}
+
+// TestKVPutCommand tests that the secret data is written as JSON on stdin so that values that the
+// vault CLI would otherwise treat as files or stdin are written as-is.
+func TestKVPutCommand(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]string{
+		"file":  "@/etc/passwd",
+		"stdin": "-",
+		"quote": `it's "quoted"`,
+		"lines": "one\ntwo",
+	}
+
+	req := NewKVRequest(
+		WithKVRequestBinPath("/usr/bin/vault"),
+		WithKVRequestMount("secret"),
+		WithKVRequestPath("enos/verify"),
+		WithKVRequestData(data),
+	)
+
+	cmd, err := req.putCommand()
+	require.NoError(t, err)
+
+	lines := strings.Split(cmd, "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, "/usr/bin/vault kv put -mount='secret' 'enos/verify' - <<'EOF'", lines[0])
+	require.Equal(t, "EOF", lines[2])
+
+	got := map[string]string{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &got))
+	require.Equal(t, data, got)
+}
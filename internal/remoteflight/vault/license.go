@@ -5,10 +5,13 @@ package vault
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
 	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
@@ -100,10 +103,7 @@ func SetLegacyLicense(ctx context.Context, tr it.Transport, req *SetLegacyLicens
 
 	_, stderr, err := tr.Run(ctx, command.New(
 		fmt.Sprintf("%s write /sys/license text='%s'", req.BinPath, req.LicenseContent),
-		command.WithEnvVars(map[string]string{
-			"VAULT_ADDR":  req.VaultAddr,
-			"VAULT_TOKEN": req.Token,
-		}),
+		command.WithEnvVars(req.EnvVars()),
 	))
 	if err != nil {
 		return remoteflight.WrapErrorWith(err, stderr)
@@ -111,3 +111,160 @@ func SetLegacyLicense(ctx context.Context, tr it.Transport, req *SetLegacyLicens
 
 	return nil
 }
+
+// LicenseStatusResponse is the JSON stdout result of /v1/sys/license/status.
+type LicenseStatusResponse struct {
+	Data *LicenseStatusData `json:"data,omitempty"`
+}
+
+// LicenseStatusData is the data section of the license status result.
+type LicenseStatusData struct {
+	AutoloadingUsed   bool           `json:"autoloading_used,omitempty"`
+	Autoloaded        *LicenseStatus `json:"autoloaded,omitempty"`
+	PersistedAutoload *LicenseStatus `json:"persisted_autoload,omitempty"`
+}
+
+// LicenseStatus is the status of a license in the license status result.
+type LicenseStatus struct {
+	LicenseID               string   `json:"license_id,omitempty"`
+	CustomerID              string   `json:"customer_id,omitempty"`
+	StartTime               string   `json:"start_time,omitempty"`
+	ExpirationTime          string   `json:"expiration_time,omitempty"`
+	TerminationTime         string   `json:"termination_time,omitempty"`
+	Features                []string `json:"features,omitempty"`
+	PerformanceStandbyCount int      `json:"performance_standby_count,omitempty"`
+}
+
+// NewLicenseStatusResponse returns a new instance of LicenseStatusResponse.
+func NewLicenseStatusResponse() *LicenseStatusResponse {
+	return &LicenseStatusResponse{Data: &LicenseStatusData{}}
+}
+
+// GetLicenseStatus returns the vault license status.
+func GetLicenseStatus(ctx context.Context, tr it.Transport, req *CLIRequest) (*LicenseStatusResponse, error) {
+	var err error
+	res := NewLicenseStatusResponse()
+
+	select {
+	case <-ctx.Done():
+		err = ctx.Err()
+	default:
+	}
+
+	if req.BinPath == "" {
+		err = errors.Join(err, errors.New("you must supply a vault bin path"))
+	}
+
+	if req.VaultAddr == "" {
+		err = errors.Join(err, errors.New("you must supply a vault listen address"))
+	}
+
+	if req.Token == "" {
+		err = errors.Join(err, errors.New("you must supply a vault token for the /v1/sys/license/status endpoint"))
+	}
+
+	if err == nil {
+		stdout, stderr, err1 := tr.Run(ctx, command.New(
+			req.BinPath+" read -format=json sys/license/status",
+			command.WithEnvVars(req.EnvVars()),
+		))
+		if err1 != nil {
+			err = err1
+		}
+		if stderr != "" {
+			err = errors.Join(err, fmt.Errorf("unexpected write to stderr: %s", stderr))
+		}
+
+		// Deserialize the body onto our response.
+		if stdout == "" {
+			err = errors.Join(err, errors.New("no body was written to stdout"))
+		} else {
+			err = errors.Join(err, json.Unmarshal([]byte(stdout), res))
+		}
+	}
+
+	if err != nil {
+		return nil, errors.Join(errors.New("get vault license status: vault read sys/license/status"), err)
+	}
+
+	return res, nil
+}
+
+// ReloadLicense reloads the autoloaded license from the license file or environment
+// that vault has been configured with.
+func ReloadLicense(ctx context.Context, tr it.Transport, req *CLIRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	_, stderr, err := tr.Run(ctx, command.New(
+		req.BinPath+" write -f sys/config/reload/license",
+		command.WithEnvVars(req.EnvVars()),
+	))
+	if err != nil {
+		return remoteflight.WrapErrorWith(fmt.Errorf("reloading vault license: %w", err), stderr)
+	}
+
+	return nil
+}
+
+// License returns the status of the autoloaded license.
+func (r *LicenseStatusResponse) License() (*LicenseStatus, error) {
+	if r == nil || r.Data == nil || r.Data.Autoloaded == nil {
+		return nil, errors.New("license status has no autoloaded license")
+	}
+
+	return r.Data.Autoloaded, nil
+}
+
+// ExpiresWithin returns whether or not the license expires within the window
+// of time from now.
+func (l *LicenseStatus) ExpiresWithin(window time.Duration, now time.Time) (bool, error) {
+	if l == nil {
+		return true, errors.New("license status is unknown")
+	}
+
+	expiration, err := time.Parse(time.RFC3339, l.ExpirationTime)
+	if err != nil {
+		return true, fmt.Errorf("parsing license expiration time %s: %w", l.ExpirationTime, err)
+	}
+
+	return expiration.Before(now.Add(window)), nil
+}
+
+// String returns the license status as a string.
+func (r *LicenseStatusResponse) String() string {
+	if r == nil || r.Data == nil {
+		return ""
+	}
+
+	out := new(strings.Builder)
+	_, _ = fmt.Fprintf(out, "Autoloading Used: %t\n", r.Data.AutoloadingUsed)
+	if r.Data.Autoloaded != nil {
+		_, _ = fmt.Fprintln(out, "Autoloaded")
+		_, _ = out.WriteString(r.Data.Autoloaded.String())
+	}
+
+	return out.String()
+}
+
+// String returns the license as a string.
+func (l *LicenseStatus) String() string {
+	if l == nil {
+		return ""
+	}
+
+	out := new(strings.Builder)
+	_, _ = fmt.Fprintf(out, "  License ID: %s\n", l.LicenseID)
+	if l.CustomerID != "" {
+		_, _ = fmt.Fprintf(out, "  Customer ID: %s\n", l.CustomerID)
+	}
+	_, _ = fmt.Fprintf(out, "  Start Time: %s\n", l.StartTime)
+	_, _ = fmt.Fprintf(out, "  Expiration Time: %s\n", l.ExpirationTime)
+	_, _ = fmt.Fprintf(out, "  Termination Time: %s\n", l.TerminationTime)
+	_, _ = fmt.Fprintf(out, "  Features: %s\n", strings.Join(l.Features, ", "))
+
+	return out.String()
+}
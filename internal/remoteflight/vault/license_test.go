@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenseStatusDeserialize(t *testing.T) {
+	t.Parallel()
+
+	got := NewLicenseStatusResponse()
+	body := testReadSupport(t, "license-status.json")
+	require.NoError(t, json.Unmarshal(body, got))
+	require.True(t, got.Data.AutoloadingUsed)
+
+	license, err := got.License()
+	require.NoError(t, err)
+	require.Equal(t, "4e0c5a3f-7b2d-1c9e-8f6a-2d3b4c5e6f7a", license.LicenseID)
+	require.Equal(t, "2023-07-01T00:00:00Z", license.StartTime)
+	require.Equal(t, "2026-07-01T00:00:00Z", license.ExpirationTime)
+	require.Equal(t, "2026-07-02T00:00:00Z", license.TerminationTime)
+	require.Equal(t, 9999, license.PerformanceStandbyCount)
+	require.Len(t, license.Features, 16)
+	require.Equal(t, "HSM", license.Features[0])
+}
+
+func TestLicenseStatusExpiresWithin(t *testing.T) {
+	t.Parallel()
+
+	license := &LicenseStatus{ExpirationTime: "2026-07-01T00:00:00Z"}
+	now, err := time.Parse(time.RFC3339, "2026-06-01T00:00:00Z")
+	require.NoError(t, err)
+
+	expires, err := license.ExpiresWithin(24*time.Hour, now)
+	require.NoError(t, err)
+	require.False(t, expires)
+
+	expires, err = license.ExpiresWithin(31*24*time.Hour, now)
+	require.NoError(t, err)
+	require.True(t, expires)
+
+	_, err = (&LicenseStatus{ExpirationTime: "never"}).ExpiresWithin(time.Hour, now)
+	require.Error(t, err)
+}
@@ -175,8 +175,7 @@ func GetRaftConfiguration(ctx context.Context, tr it.Transport, req *CLIRequest)
 	if err == nil {
 		stdout, stderr, err1 := tr.Run(ctx, command.New(
 			req.BinPath+" read -format=json sys/storage/raft/configuration",
-			command.WithEnvVar("VAULT_ADDR", req.VaultAddr),
-			command.WithEnvVar("VAULT_TOKEN", req.Token),
+			command.WithEnvVars(req.EnvVars()),
 		))
 		if err1 != nil {
 			err = err1
@@ -226,8 +225,7 @@ func GetRaftAutopilotConfiguration(ctx context.Context, tr it.Transport, req *CL
 	if err == nil {
 		stdout, stderr, err1 := tr.Run(ctx, command.New(
 			req.BinPath+" read -format=json sys/storage/raft/autopilot/configuration",
-			command.WithEnvVar("VAULT_ADDR", req.VaultAddr),
-			command.WithEnvVar("VAULT_TOKEN", req.Token),
+			command.WithEnvVars(req.EnvVars()),
 		))
 		if err1 != nil {
 			err = err1
@@ -277,8 +275,7 @@ func GetRaftAutopilotState(ctx context.Context, tr it.Transport, req *CLIRequest
 	if err == nil {
 		stdout, stderr, err1 := tr.Run(ctx, command.New(
 			req.BinPath+" read -format=json sys/storage/raft/autopilot/state",
-			command.WithEnvVar("VAULT_ADDR", req.VaultAddr),
-			command.WithEnvVar("VAULT_TOKEN", req.Token),
+			command.WithEnvVars(req.EnvVars()),
 		))
 		if err1 != nil {
 			err = err1
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
 	istrings "github.com/hashicorp-forge/terraform-provider-enos/internal/strings"
 	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
@@ -75,6 +76,14 @@ func WithReplicationRequestBinPath(path string) ReplicationRequestOpt {
 	}
 }
 
+// WithReplicationRequestTLSConfig sets the client TLS configuration.
+func WithReplicationRequestTLSConfig(tls remoteflight.TLSConfig) ReplicationRequestOpt {
+	return func(u *ReplicationRequest) *ReplicationRequest {
+		u.TLSConfig = tls
+		return u
+	}
+}
+
 // WithReplicationRequestVaultAddr sets the vault address.
 func WithReplicationRequestVaultAddr(addr string) ReplicationRequestOpt {
 	return func(u *ReplicationRequest) *ReplicationRequest {
@@ -105,7 +114,7 @@ func GetReplicationStatus(ctx context.Context, tr it.Transport, req *Replication
 	if err == nil {
 		stdout, stderr, err1 := tr.Run(ctx, command.New(
 			req.BinPath+" read -format=json sys/replication/status",
-			command.WithEnvVar("VAULT_ADDR", req.VaultAddr),
+			command.WithEnvVars(req.EnvVars()),
 		))
 		if err1 != nil {
 			err = err1
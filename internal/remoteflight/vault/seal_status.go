@@ -19,6 +19,7 @@ import (
 type SealStatusRequest struct {
 	VaultAddr         string
 	FlightControlPath string
+	remoteflight.TLSConfig
 }
 
 // SealStatusRequestOpt is a functional option for seal-status requests.
@@ -75,6 +76,14 @@ func WithSealStatusFlightControlPath(path string) SealStatusRequestOpt {
 	}
 }
 
+// WithSealStatusRequestTLSConfig sets the client TLS configuration.
+func WithSealStatusRequestTLSConfig(tls remoteflight.TLSConfig) SealStatusRequestOpt {
+	return func(u *SealStatusRequest) *SealStatusRequest {
+		u.TLSConfig = tls
+		return u
+	}
+}
+
 // WithSealStatusRequestVaultAddr sets vault address.
 func WithSealStatusRequestVaultAddr(addr string) SealStatusRequestOpt {
 	return func(u *SealStatusRequest) *SealStatusRequest {
@@ -141,9 +150,10 @@ func GetSealStatus(ctx context.Context, tr it.Transport, req *SealStatusRequest)
 // get the raw body to support prior and post response body types.
 func (r *SealStatusRequest) String() string {
 	return fmt.Sprintf(
-		"%s download --stdout --url '%s/v1/sys/seal-status'",
+		"%s download --stdout --url '%s/v1/sys/seal-status'%s",
 		r.FlightControlPath,
 		r.VaultAddr,
+		r.FlightControlArgs(),
 	)
 }
 
@@ -11,6 +11,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/nomad/api"
+
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/kubernetes"
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/log"
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
@@ -19,10 +21,12 @@ import (
 	istrings "github.com/hashicorp-forge/terraform-provider-enos/internal/strings"
 	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
 	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/k8s"
+	tnomad "github.com/hashicorp-forge/terraform-provider-enos/internal/transport/nomad"
 )
 
 // State represents the state of a node in a vault cluster.
 type State struct {
+	Allocation        *api.Allocation                     // nomad allocation info for vault task
 	AutopilotConfig   *RaftAutopilotConfigurationResponse // /v1/sys/storage/raft/autopilot/configuration
 	AutopilotState    *RaftAutopilotStateResponse         // /v1/sys/storage/raft/autopilot/state
 	ConfigSanitized   *ConfigStateSanitizedResponse       // /v1/sys/config/state/sanitized
@@ -114,6 +118,14 @@ func WithStateRequestVaultToken(token string) StateRequestOpt {
 	}
 }
 
+// WithStateRequestTLSConfig sets the client TLS configuration.
+func WithStateRequestTLSConfig(tls remoteflight.TLSConfig) StateRequestOpt {
+	return func(u *StateRequest) *StateRequest {
+		u.TLSConfig = tls
+		return u
+	}
+}
+
 // WithStateRequestSystemdUnitName sets the vault systemd unit name.
 func WithStateRequestSystemdUnitName(unit string) StateRequestOpt {
 	return func(u *StateRequest) *StateRequest {
@@ -196,6 +208,16 @@ func GetState(ctx context.Context, tr it.Transport, req *StateRequest) (*State,
 		if err != nil {
 			return state, fmt.Errorf("getting the kubernetes pod information: %w", err)
 		}
+	case "nomad":
+		n, ok := tr.(*tnomad.Transport)
+		if !ok {
+			return state, errors.New("getting the nomad allocation state: type mismatch between transport")
+		}
+
+		state.Allocation, err = n.Client.GetAllocation(n.AllocationID)
+		if err != nil {
+			return state, fmt.Errorf("getting the nomad allocation information: %w", err)
+		}
 	default:
 	}
 
@@ -224,6 +246,7 @@ func GetState(ctx context.Context, tr it.Transport, req *StateRequest) (*State,
 	state.SealStatus, err = GetSealStatus(ctx, tr, NewSealStatusRequest(
 		WithSealStatusRequestVaultAddr(req.VaultAddr),
 		WithSealStatusFlightControlPath(fcRes.Path),
+		WithSealStatusRequestTLSConfig(req.TLSConfig),
 	))
 	if err != nil {
 		return state, err
@@ -232,6 +255,7 @@ func GetState(ctx context.Context, tr it.Transport, req *StateRequest) (*State,
 	state.Health, err = GetHealth(ctx, tr, NewHealthRequest(
 		WithHealthRequestVaultAddr(req.VaultAddr),
 		WithHealthFlightControlPath(fcRes.Path),
+		WithHealthRequestTLSConfig(req.TLSConfig),
 	))
 	if err != nil {
 		return state, err
@@ -271,6 +295,7 @@ func GetState(ctx context.Context, tr it.Transport, req *StateRequest) (*State,
 		state.ReplicationStatus, err = GetReplicationStatus(ctx, tr, NewReplicationRequest(
 			WithReplicationRequestBinPath(req.BinPath),
 			WithReplicationRequestVaultAddr(req.VaultAddr),
+			WithReplicationRequestTLSConfig(req.TLSConfig),
 		))
 		if err != nil {
 			return state, err
@@ -398,6 +423,15 @@ func (s *State) String() string {
 	s.printStateField(out, s.Status, "Status")
 	s.printStateField(out, s.PodList, "Kubernetes Pods")
 
+	if s.Allocation != nil {
+		alloc := new(strings.Builder)
+		_, _ = fmt.Fprintf(alloc, "ID: %s\nClient Status: %s\n", s.Allocation.ID, s.Allocation.ClientStatus)
+		for name, task := range s.Allocation.TaskStates {
+			_, _ = fmt.Fprintf(alloc, "Task %s: %s (restarts: %d)\n", name, task.State, task.Restarts)
+		}
+		_, _ = fmt.Fprintf(out, "Nomad Allocation: \n%s\n", istrings.Indent("  ", alloc.String()))
+	}
+
 	if s.UnitProperties != nil {
 		// Most of the time we don't care about all of the systemd unit properties.
 		// Try and find our meaningful status properties. If we can't then something
@@ -78,7 +78,7 @@ func GetStatus(ctx context.Context, tr it.Transport, req *CLIRequest) (*StatusRe
 		var err1 error
 		stdout, stderr, err1 := tr.Run(ctx, command.New(
 			req.BinPath+" status -format=json",
-			command.WithEnvVar("VAULT_ADDR", req.VaultAddr),
+			command.WithEnvVars(req.EnvVars()),
 		))
 
 		// Set our status from the status command exit code
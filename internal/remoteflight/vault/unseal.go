@@ -189,7 +189,7 @@ func Unseal(ctx context.Context, tr it.Transport, req *UnsealRequest) (*UnsealRe
 				_, stderr, err := tr.Run(
 					ctx, command.New(
 						fmt.Sprintf("%s operator unseal %s;", binPath, key),
-						command.WithEnvVar("VAULT_ADDR", vaultAddr),
+						command.WithEnvVars(req.EnvVars()),
 					),
 				)
 				if err != nil {
@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/remoteflight"
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+)
+
+// VerifyVersionRequest is a request to verify the version and build metadata
+// of the vault binary and the running vault service.
+type VerifyVersionRequest struct {
+	*CLIRequest
+	*remoteflight.ExpectedVersion
+	// Where to install enos-flight-control
+	FlightControlPath string
+	// Install enos-flight-control into the $HOME directory
+	FlightControlUseHomeDir bool
+}
+
+// VerifyVersionRequestOpt is a functional option for a verify version request.
+type VerifyVersionRequestOpt func(*VerifyVersionRequest) *VerifyVersionRequest
+
+// NewVerifyVersionRequest takes functional options and returns a new verify
+// version request.
+func NewVerifyVersionRequest(opts ...VerifyVersionRequestOpt) *VerifyVersionRequest {
+	v := &VerifyVersionRequest{
+		CLIRequest:        &CLIRequest{},
+		ExpectedVersion:   &remoteflight.ExpectedVersion{},
+		FlightControlPath: remoteflight.DefaultFlightControlPath,
+	}
+
+	for _, opt := range opts {
+		v = opt(v)
+	}
+
+	return v
+}
+
+// WithVerifyVersionRequestBinPath sets the vault binary path.
+func WithVerifyVersionRequestBinPath(path string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.BinPath = path
+		return v
+	}
+}
+
+// WithVerifyVersionRequestVaultAddr sets the vault address.
+func WithVerifyVersionRequestVaultAddr(addr string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.VaultAddr = addr
+		return v
+	}
+}
+
+// WithVerifyVersionRequestToken sets the vault token.
+func WithVerifyVersionRequestToken(token string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.Token = token
+		return v
+	}
+}
+
+// WithVerifyVersionRequestVersion sets the expected version.
+func WithVerifyVersionRequestVersion(version string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.Version = version
+		return v
+	}
+}
+
+// WithVerifyVersionRequestEdition sets the expected edition.
+func WithVerifyVersionRequestEdition(edition string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.Edition = edition
+		return v
+	}
+}
+
+// WithVerifyVersionRequestRevision sets the expected revision SHA.
+func WithVerifyVersionRequestRevision(revision string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.Revision = revision
+		return v
+	}
+}
+
+// WithVerifyVersionRequestBuildDate sets the expected build date.
+func WithVerifyVersionRequestBuildDate(date string) VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.BuildDate = date
+		return v
+	}
+}
+
+// WithVerifyVersionRequestFlightControlUseHomeDir installs enos-flight-control
+// into the $HOME directory.
+func WithVerifyVersionRequestFlightControlUseHomeDir() VerifyVersionRequestOpt {
+	return func(v *VerifyVersionRequest) *VerifyVersionRequest {
+		v.FlightControlUseHomeDir = true
+		return v
+	}
+}
+
+// VerifyVersion verifies that the vault binary and the running vault service
+// report the expected version, edition, revision and build date. The binary is
+// verified with "vault version", the service with /v1/sys/health and, if a token
+// has been provided, /v1/sys/version-history.
+func VerifyVersion(ctx context.Context, tr it.Transport, req *VerifyVersionRequest) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if req.BinPath == "" {
+		return errors.New("you must supply a vault bin path")
+	}
+
+	if req.VaultAddr == "" {
+		return errors.New("you must supply a vault listen address")
+	}
+
+	if err := req.ExpectedVersion.Validate(); err != nil {
+		return err
+	}
+
+	var err error
+
+	cliRes, err1 := GetVersion(ctx, tr, req.CLIRequest)
+	if err1 != nil {
+		err = errors.Join(err, fmt.Errorf("vault version: %w", err1))
+	} else {
+		err = errors.Join(err,
+			req.ExpectedVersion.VerifyVersion("vault version", cliRes.Version),
+			req.ExpectedVersion.VerifyRevision("vault version", cliRes.Revision),
+			req.ExpectedVersion.VerifyBuildDate("vault version", cliRes.BuildDate),
+		)
+	}
+
+	opts := []remoteflight.InstallFlightControlOpt{}
+	if req.FlightControlUseHomeDir {
+		opts = append(opts, remoteflight.WithInstallFlightControlRequestUseHomeDir())
+	} else {
+		opts = append(opts, remoteflight.WithInstallFlightControlRequestPath(req.FlightControlPath))
+	}
+
+	fcRes, err1 := remoteflight.InstallFlightControl(ctx, tr, remoteflight.NewInstallFlightControlRequest(opts...))
+	if err1 != nil {
+		return errors.Join(err, fmt.Errorf("failed to install enos-flight-control binary: %w", err1))
+	}
+
+	health, err1 := GetHealth(ctx, tr, NewHealthRequest(
+		WithHealthRequestVaultAddr(req.VaultAddr),
+		WithHealthFlightControlPath(fcRes.Path),
+		WithHealthRequestTLSConfig(req.TLSConfig),
+	))
+	if err1 != nil {
+		err = errors.Join(err, err1)
+	} else {
+		ver, err1 := semver.Parse(strings.TrimPrefix(health.Version, "v"))
+		if err1 != nil {
+			err = errors.Join(err, fmt.Errorf("/v1/sys/health: parsing version %s: %w", health.Version, err1))
+		} else {
+			err = errors.Join(err, req.ExpectedVersion.VerifyVersion("/v1/sys/health", ver))
+		}
+	}
+
+	if req.Token == "" {
+		return err
+	}
+
+	history, err1 := GetVersionHistory(ctx, tr, req.CLIRequest)
+	if err1 != nil {
+		return errors.Join(err, err1)
+	}
+
+	current, info, err1 := history.Current()
+	if err1 != nil {
+		return errors.Join(err, fmt.Errorf("/v1/sys/version-history: %w", err1))
+	}
+
+	// The version history does not include the build metadata so we can only
+	// verify the version and build date.
+	ver, err1 := semver.Parse(strings.TrimPrefix(current, "v"))
+	if err1 != nil {
+		return errors.Join(err, fmt.Errorf("/v1/sys/version-history: parsing version %s: %w", current, err1))
+	}
+	expected := *req.ExpectedVersion
+	expected.Edition = ""
+
+	return errors.Join(err,
+		expected.VerifyVersion("/v1/sys/version-history", ver),
+		expected.VerifyBuildDate("/v1/sys/version-history", info.BuildDate),
+	)
+}
@@ -5,7 +5,9 @@ package vault
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/blang/semver"
@@ -44,3 +46,52 @@ func parseVaultVersion(version string) (semver.Version, error) {
 
 	return ver, nil
 }
+
+// VersionResponse is the parsed result of "vault version".
+type VersionResponse struct {
+	Version   semver.Version
+	Revision  string
+	BuildDate string
+}
+
+var vaultVersionRe = regexp.MustCompile(`^Vault v(\S+)(?: \(([0-9a-fA-F]+)\))?(?:, built (\S+))?`)
+
+// GetVersion returns the version, revision and build date of the vault binary.
+func GetVersion(ctx context.Context, tr it.Transport, req *CLIRequest) (*VersionResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if req.BinPath == "" {
+		return nil, errors.New("you must supply a vault bin path")
+	}
+
+	stdout, stderr, err := tr.Run(ctx, command.New(req.BinPath+" version"))
+	if err != nil {
+		return nil, remoteflight.WrapErrorWith(err, stdout, stderr)
+	}
+
+	return parseVaultVersionResponse(stdout)
+}
+
+// parseVaultVersionResponse parses the output of "vault version".
+func parseVaultVersionResponse(out string) (*VersionResponse, error) {
+	out = strings.TrimSpace(out)
+	matches := vaultVersionRe.FindStringSubmatch(out)
+	if len(matches) != 4 {
+		return nil, fmt.Errorf("failed to parse vault version output: %s", out)
+	}
+
+	ver, err := semver.Make(matches[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse version from vault version %s: %w", matches[1], err)
+	}
+
+	return &VersionResponse{
+		Version:   ver,
+		Revision:  matches[2],
+		BuildDate: matches[3],
+	}, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+)
+
+// VersionHistoryResponse is the JSON stdout result of /v1/sys/version-history.
+type VersionHistoryResponse struct {
+	Data *VersionHistoryData `json:"data,omitempty"`
+}
+
+// VersionHistoryData is the data section of the version-history result.
+type VersionHistoryData struct {
+	// Keys are sorted by the time the version was first installed.
+	Keys    []string                       `json:"keys,omitempty"`
+	KeyInfo map[string]*VersionHistoryInfo `json:"key_info,omitempty"`
+}
+
+// VersionHistoryInfo is the information about a version in the version-history result.
+type VersionHistoryInfo struct {
+	BuildDate          string `json:"build_date,omitempty"`
+	PreviousVersion    string `json:"previous_version,omitempty"`
+	TimestampInstalled string `json:"timestamp_installed,omitempty"`
+}
+
+// NewVersionHistoryResponse returns a new instance of VersionHistoryResponse.
+func NewVersionHistoryResponse() *VersionHistoryResponse {
+	return &VersionHistoryResponse{Data: &VersionHistoryData{}}
+}
+
+// GetVersionHistory returns the vault version history.
+func GetVersionHistory(ctx context.Context, tr it.Transport, req *CLIRequest) (*VersionHistoryResponse, error) {
+	var err error
+	res := NewVersionHistoryResponse()
+
+	select {
+	case <-ctx.Done():
+		err = ctx.Err()
+	default:
+	}
+
+	if req.BinPath == "" {
+		err = errors.Join(err, errors.New("you must supply a vault bin path"))
+	}
+
+	if req.VaultAddr == "" {
+		err = errors.Join(err, errors.New("you must supply a vault listen address"))
+	}
+
+	if req.Token == "" {
+		err = errors.Join(err, errors.New("you must supply a vault token for the /v1/sys/version-history endpoint"))
+	}
+
+	if err == nil {
+		// The version-history endpoint is a LIST endpoint. "vault list" only
+		// returns the keys so we read it with the list parameter to get the
+		// key_info as well.
+		stdout, stderr, err1 := tr.Run(ctx, command.New(
+			req.BinPath+" read -format=json sys/version-history list=true",
+			command.WithEnvVars(req.EnvVars()),
+		))
+		if err1 != nil {
+			err = err1
+		}
+		if stderr != "" {
+			err = errors.Join(err, fmt.Errorf("unexpected write to stderr: %s", stderr))
+		}
+
+		// Deserialize the body onto our response.
+		if stdout == "" {
+			err = errors.Join(err, errors.New("no body was written to stdout"))
+		} else {
+			err = errors.Join(err, json.Unmarshal([]byte(stdout), res))
+		}
+	}
+
+	if err != nil {
+		return nil, errors.Join(errors.New("get vault version-history: vault read sys/version-history"), err)
+	}
+
+	return res, nil
+}
+
+// Current returns the most recently installed version and its info.
+func (r *VersionHistoryResponse) Current() (string, *VersionHistoryInfo, error) {
+	if r == nil || r.Data == nil || len(r.Data.Keys) < 1 {
+		return "", nil, errors.New("version history has no versions")
+	}
+
+	version := r.Data.Keys[len(r.Data.Keys)-1]
+	info, ok := r.Data.KeyInfo[version]
+	if !ok {
+		return version, nil, fmt.Errorf("version history has no info for version %s", version)
+	}
+
+	return version, info, nil
+}
+
+// String returns the version history as a string.
+func (r *VersionHistoryResponse) String() string {
+	if r == nil || r.Data == nil {
+		return ""
+	}
+
+	out := new(strings.Builder)
+	for _, key := range r.Data.Keys {
+		_, _ = fmt.Fprintf(out, "Version: %s\n", key)
+		info, ok := r.Data.KeyInfo[key]
+		if !ok || info == nil {
+			continue
+		}
+		_, _ = fmt.Fprintf(out, "  Build Date: %s\n", info.BuildDate)
+		if info.PreviousVersion != "" {
+			_, _ = fmt.Fprintf(out, "  Previous Version: %s\n", info.PreviousVersion)
+		}
+		_, _ = fmt.Fprintf(out, "  Timestamp Installed: %s\n", info.TimestampInstalled)
+	}
+
+	return out.String()
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionHistoryDeserialize(t *testing.T) {
+	t.Parallel()
+
+	expected := NewVersionHistoryResponse()
+	expected.Data.Keys = []string{"1.14.4", "1.15.2"}
+	expected.Data.KeyInfo = map[string]*VersionHistoryInfo{
+		"1.14.4": {
+			BuildDate:          "2023-09-22T21:29:05Z",
+			TimestampInstalled: "2023-10-18T15:51:02.401937318Z",
+		},
+		"1.15.2": {
+			BuildDate:          "2023-11-06T11:33:28Z",
+			PreviousVersion:    "1.14.4",
+			TimestampInstalled: "2023-10-18T16:22:43.712203498Z",
+		},
+	}
+
+	got := NewVersionHistoryResponse()
+	body := testReadSupport(t, "version-history.json")
+	require.NoError(t, json.Unmarshal(body, got))
+	require.Equal(t, expected, got)
+
+	current, info, err := got.Current()
+	require.NoError(t, err)
+	require.Equal(t, "1.15.2", current)
+	require.Equal(t, "2023-11-06T11:33:28Z", info.BuildDate)
+}
@@ -89,3 +89,31 @@ func TestParseVaultVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestParseVaultVersionResponse(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		out       string
+		version   string
+		revision  string
+		buildDate string
+	}{
+		{"Vault v1.8.0-rc1 (eba403741e344f9d9b686eaca122a5a3f446d442)\n", "1.8.0-rc1", "eba403741e344f9d9b686eaca122a5a3f446d442", ""},
+		{
+			"Vault v1.15.2+ent.hsm.fips1402 (cf1b5cafa047bc8e4a3f93444fcb4011593b92cb), built 2023-11-06T11:33:28Z\n",
+			"1.15.2+ent.hsm.fips1402", "cf1b5cafa047bc8e4a3f93444fcb4011593b92cb", "2023-11-06T11:33:28Z",
+		},
+		{"Vault v1.5.0", "1.5.0", "", ""},
+	} {
+		t.Run(test.out, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := parseVaultVersionResponse(test.out)
+			require.NoError(t, err)
+			require.Equal(t, test.version, res.Version.String())
+			require.Equal(t, test.revision, res.Revision)
+			require.Equal(t, test.buildDate, res.BuildDate)
+		})
+	}
+}
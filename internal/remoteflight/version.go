@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+)
+
+// ExpectedVersion is the version and build metadata that we expect a binary
+// or running service to report. Any fields that are not set are not verified.
+type ExpectedVersion struct {
+	Version   string
+	Edition   string
+	Revision  string
+	BuildDate string
+}
+
+// VersionEdition returns the edition of a version from its build metadata,
+// e.g. "ce", "ent", "ent.hsm", "ent.hsm.fips1403".
+func VersionEdition(ver semver.Version) string {
+	if len(ver.Build) == 0 {
+		return "ce"
+	}
+
+	return strings.Join(ver.Build, ".")
+}
+
+// normalizeEdition normalizes edition aliases.
+func normalizeEdition(edition string) string {
+	switch edition {
+	case "", "oss":
+		return "ce"
+	default:
+		return edition
+	}
+}
+
+// Validate validates that the expected version is valid.
+func (e *ExpectedVersion) Validate() error {
+	if e == nil {
+		return errors.New("no expected version")
+	}
+
+	if e.Version != "" {
+		if _, err := semver.Parse(strings.TrimPrefix(e.Version, "v")); err != nil {
+			return fmt.Errorf("parsing expected version %s: %w", e.Version, err)
+		}
+	}
+
+	if e.BuildDate != "" {
+		if _, err := time.Parse(time.RFC3339, e.BuildDate); err != nil {
+			return fmt.Errorf("parsing expected build date %s: %w", e.BuildDate, err)
+		}
+	}
+
+	return nil
+}
+
+// VerifyVersion verifies that the version reported by source matches the expected
+// version and edition. Build metadata on the expected version is ignored, the
+// edition is compared separately.
+func (e *ExpectedVersion) VerifyVersion(source string, got semver.Version) error {
+	var err error
+
+	if e.Version != "" {
+		expected, err1 := semver.Parse(strings.TrimPrefix(e.Version, "v"))
+		if err1 != nil {
+			return fmt.Errorf("parsing expected version %s: %w", e.Version, err1)
+		}
+		expected.Build = nil
+
+		core := got
+		core.Build = nil
+		if !expected.Equals(core) {
+			err = errors.Join(err, fmt.Errorf(
+				"%s: expected version %s, got %s", source, expected.String(), core.String(),
+			))
+		}
+	}
+
+	if e.Edition != "" {
+		expected := normalizeEdition(e.Edition)
+		edition := VersionEdition(got)
+		if expected != edition {
+			err = errors.Join(err, fmt.Errorf(
+				"%s: expected edition %s, got %s", source, expected, edition,
+			))
+		}
+	}
+
+	return err
+}
+
+// VerifyRevision verifies that the revision reported by source matches the
+// expected revision. Abbreviated revisions of at least seven characters are
+// allowed on either side.
+func (e *ExpectedVersion) VerifyRevision(source string, got string) error {
+	if e.Revision == "" {
+		return nil
+	}
+
+	expected := strings.ToLower(e.Revision)
+	got = strings.ToLower(got)
+
+	if expected == got {
+		return nil
+	}
+
+	if len(expected) >= 7 && len(got) >= 7 &&
+		(strings.HasPrefix(expected, got) || strings.HasPrefix(got, expected)) {
+		return nil
+	}
+
+	return fmt.Errorf("%s: expected revision %s, got %s", source, e.Revision, got)
+}
+
+// VerifyBuildDate verifies that the build date reported by source matches the
+// expected build date.
+func (e *ExpectedVersion) VerifyBuildDate(source string, got string) error {
+	if e.BuildDate == "" {
+		return nil
+	}
+
+	expected, err := time.Parse(time.RFC3339, e.BuildDate)
+	if err != nil {
+		return fmt.Errorf("parsing expected build date %s: %w", e.BuildDate, err)
+	}
+
+	gotDate, err := time.Parse(time.RFC3339, got)
+	if err != nil {
+		return fmt.Errorf("%s: parsing build date %s: %w", source, got, err)
+	}
+
+	if !expected.Equal(gotDate) {
+		return fmt.Errorf("%s: expected build date %s, got %s", source, e.BuildDate, got)
+	}
+
+	return nil
+}
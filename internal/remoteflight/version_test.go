@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionEdition(t *testing.T) {
+	t.Parallel()
+
+	for version, edition := range map[string]string{
+		"1.15.2":                      "ce",
+		"1.15.2-rc1":                  "ce",
+		"1.15.2+ent":                  "ent",
+		"1.15.2+ent.hsm":              "ent.hsm",
+		"1.15.2+ent.fips1402":         "ent.fips1402",
+		"1.15.2-rc1+ent.hsm.fips1403": "ent.hsm.fips1403",
+	} {
+		t.Run(version, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, edition, VersionEdition(semver.MustParse(version)))
+		})
+	}
+}
+
+func TestExpectedVersionVerifyVersion(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		expected   *ExpectedVersion
+		got        string
+		shouldFail bool
+	}{
+		"nothing-expected": {
+			&ExpectedVersion{},
+			"1.15.2+ent",
+			false,
+		},
+		"version-matches": {
+			&ExpectedVersion{Version: "1.15.2"},
+			"1.15.2+ent",
+			false,
+		},
+		"version-with-metadata-matches": {
+			&ExpectedVersion{Version: "1.15.2+ent"},
+			"1.15.2+ent.hsm",
+			false,
+		},
+		"version-mismatch": {
+			&ExpectedVersion{Version: "1.15.1"},
+			"1.15.2",
+			true,
+		},
+		"prerelease-mismatch": {
+			&ExpectedVersion{Version: "1.15.2"},
+			"1.15.2-rc1",
+			true,
+		},
+		"edition-matches": {
+			&ExpectedVersion{Version: "1.15.2", Edition: "ent.hsm"},
+			"1.15.2+ent.hsm",
+			false,
+		},
+		"edition-ce-alias": {
+			&ExpectedVersion{Edition: "oss"},
+			"1.15.2",
+			false,
+		},
+		"edition-mismatch": {
+			&ExpectedVersion{Edition: "ent.fips1402"},
+			"1.15.2+ent",
+			true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.expected.VerifyVersion("test", semver.MustParse(test.got))
+			if test.shouldFail {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestExpectedVersionVerifyRevision(t *testing.T) {
+	t.Parallel()
+
+	expected := &ExpectedVersion{Revision: "4e3f428b7e6a1b5d0e7f6c8d9a0b1c2d3e4f5a6b"}
+	require.NoError(t, expected.VerifyRevision("test", "4e3f428b7e6a1b5d0e7f6c8d9a0b1c2d3e4f5a6b"))
+	require.NoError(t, expected.VerifyRevision("test", "4e3f428b"))
+	require.Error(t, expected.VerifyRevision("test", "4e3f"))
+	require.Error(t, expected.VerifyRevision("test", "9a8b7c6d"))
+	require.NoError(t, (&ExpectedVersion{}).VerifyRevision("test", "9a8b7c6d"))
+}
+
+func TestExpectedVersionVerifyBuildDate(t *testing.T) {
+	t.Parallel()
+
+	expected := &ExpectedVersion{BuildDate: "2023-11-06T11:33:28Z"}
+	require.NoError(t, expected.VerifyBuildDate("test", "2023-11-06T11:33:28Z"))
+	require.NoError(t, expected.VerifyBuildDate("test", "2023-11-06T12:33:28+01:00"))
+	require.Error(t, expected.VerifyBuildDate("test", "2023-11-07T11:33:28Z"))
+	require.Error(t, expected.VerifyBuildDate("test", "yesterday"))
+}
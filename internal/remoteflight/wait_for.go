@@ -0,0 +1,250 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/random"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
+	"github.com/hashicorp-forge/terraform-provider-enos/internal/transport/command"
+	tfile "github.com/hashicorp-forge/terraform-provider-enos/internal/transport/file"
+)
+
+// WaitForRequest waits for probes to succeed on a remote machine with enos-flight-control.
+type WaitForRequest struct {
+	FlightControlPath string
+	TCP               []string // Addresses that must accept TCP connections
+	HTTP              []string // URLs that must return an expected status
+	HTTPStatuses      []int    // If unset any 2xx status is expected
+	Files             []string // Paths to files that must exist
+	Processes         []string // Names of processes that must be running
+	Interval          string
+	Timeout           string
+	ProbeTimeout      string
+	CACert            string // PEM encoded CA certificate bundle used to verify HTTP servers
+	TLSSkipVerify     bool
+	TmpDir            string
+	Sudo              bool
+}
+
+// WaitForResponse is a flight control wait-for response.
+type WaitForResponse struct{}
+
+// WaitForOpt is a functional option for a wait-for request.
+type WaitForOpt func(*WaitForRequest) *WaitForRequest
+
+// NewWaitForRequest takes functional options and returns a new wait-for request.
+func NewWaitForRequest(opts ...WaitForOpt) *WaitForRequest {
+	wr := &WaitForRequest{
+		FlightControlPath: DefaultFlightControlPath,
+		Interval:          "1s",
+		Timeout:           "5m",
+		ProbeTimeout:      "5s",
+		TmpDir:            "/tmp",
+	}
+
+	for _, opt := range opts {
+		wr = opt(wr)
+	}
+
+	return wr
+}
+
+// WithWaitForRequestFlightControlPath sets the location of the enos-flight-control binary.
+func WithWaitForRequestFlightControlPath(path string) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.FlightControlPath = path
+		return wr
+	}
+}
+
+// WithWaitForRequestTCP sets the addresses that must accept TCP connections.
+func WithWaitForRequestTCP(addrs ...string) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.TCP = addrs
+		return wr
+	}
+}
+
+// WithWaitForRequestHTTP sets the URLs that must return an expected status.
+func WithWaitForRequestHTTP(urls ...string) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.HTTP = urls
+		return wr
+	}
+}
+
+// WithWaitForRequestHTTPStatuses sets the expected HTTP status codes.
+func WithWaitForRequestHTTPStatuses(codes ...int) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.HTTPStatuses = codes
+		return wr
+	}
+}
+
+// WithWaitForRequestFiles sets the paths to files that must exist.
+func WithWaitForRequestFiles(paths ...string) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.Files = paths
+		return wr
+	}
+}
+
+// WithWaitForRequestProcesses sets the names of processes that must be running.
+func WithWaitForRequestProcesses(names ...string) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.Processes = names
+		return wr
+	}
+}
+
+// WithWaitForRequestInterval sets the time to wait between probe attempts.
+func WithWaitForRequestInterval(interval string) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.Interval = interval
+		return wr
+	}
+}
+
+// WithWaitForRequestTimeout sets the maximum allowable time to wait for the probes.
+func WithWaitForRequestTimeout(timeout string) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.Timeout = timeout
+		return wr
+	}
+}
+
+// WithWaitForRequestProbeTimeout sets the maximum allowable time of each probe attempt.
+func WithWaitForRequestProbeTimeout(timeout string) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.ProbeTimeout = timeout
+		return wr
+	}
+}
+
+// WithWaitForRequestCACert sets the PEM encoded CA certificate bundle that is used to verify
+// HTTP servers. It will be copied to the remote host before waiting.
+func WithWaitForRequestCACert(pem string) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.CACert = pem
+		return wr
+	}
+}
+
+// WithWaitForRequestTLSSkipVerify disables verification of HTTP server certificates.
+func WithWaitForRequestTLSSkipVerify(skip bool) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.TLSSkipVerify = skip
+		return wr
+	}
+}
+
+// WithWaitForRequestTmpDir sets the temporary directory on the remote host.
+func WithWaitForRequestTmpDir(dir string) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.TmpDir = dir
+		return wr
+	}
+}
+
+// WithWaitForRequestUseSudo determines if the wait-for command should be run with sudo.
+func WithWaitForRequestUseSudo(useSudo bool) WaitForOpt {
+	return func(wr *WaitForRequest) *WaitForRequest {
+		wr.Sudo = useSudo
+		return wr
+	}
+}
+
+// Validate validates the request.
+func (wr *WaitForRequest) Validate() error {
+	if len(wr.TCP)+len(wr.HTTP)+len(wr.Files)+len(wr.Processes) == 0 {
+		return errors.New("at least one tcp, http, file or process probe is required")
+	}
+
+	return nil
+}
+
+// WaitFor waits for all of the probes to succeed on a remote machine with enos-flight-control.
+func WaitFor(ctx context.Context, tr transport.Transport, wr *WaitForRequest) (*WaitForResponse, error) {
+	res := &WaitForResponse{}
+
+	select {
+	case <-ctx.Done():
+		return res, ctx.Err()
+	default:
+	}
+
+	if err := wr.Validate(); err != nil {
+		return res, err
+	}
+
+	caPath := ""
+	if wr.CACert != "" {
+		caPath = filepath.Join(wr.TmpDir, "enos_wait_for_ca."+random.ID()+".pem")
+		err := CopyFile(ctx, tr, NewCopyFileRequest(
+			WithCopyFileContent(tfile.NewReader(wr.CACert)),
+			WithCopyFileDestination(caPath),
+			WithCopyFileChmod("0644"),
+		))
+		if err != nil {
+			return res, fmt.Errorf("copying CA certificate to remote host: %w", err)
+		}
+		defer func() {
+			_ = DeleteFile(ctx, tr, NewDeleteFileRequest(WithDeleteFilePath(caPath)))
+		}()
+	}
+
+	stdout, stderr, err := tr.Run(ctx, command.New(wr.command(caPath)))
+	if err != nil {
+		return res, WrapErrorWith(err, stdout, stderr)
+	}
+
+	return res, nil
+}
+
+// command returns the enos-flight-control wait-for command.
+func (wr *WaitForRequest) command(caPath string) string {
+	cmd := fmt.Sprintf(
+		"%s wait-for --interval %s --timeout %s --probe-timeout %s",
+		wr.FlightControlPath,
+		ShellQuote(wr.Interval),
+		ShellQuote(wr.Timeout),
+		ShellQuote(wr.ProbeTimeout),
+	)
+	if wr.Sudo {
+		cmd = "sudo " + cmd
+	}
+
+	for _, probes := range []struct {
+		flag   string
+		values []string
+	}{
+		{"tcp", wr.TCP},
+		{"http", wr.HTTP},
+		{"file", wr.Files},
+		{"process", wr.Processes},
+	} {
+		for _, val := range probes.values {
+			cmd = fmt.Sprintf("%s --%s %s", cmd, probes.flag, ShellQuote(val))
+		}
+	}
+
+	for _, code := range wr.HTTPStatuses {
+		cmd = fmt.Sprintf("%s --http-status %d", cmd, code)
+	}
+
+	if caPath != "" {
+		cmd = fmt.Sprintf("%s --ca-cert %s", cmd, ShellQuote(caPath))
+	}
+
+	if wr.TLSSkipVerify {
+		cmd += " --tls-skip-verify"
+	}
+
+	return cmd
+}
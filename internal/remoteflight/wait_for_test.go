@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package remoteflight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForCommand(t *testing.T) {
+	t.Parallel()
+
+	req := NewWaitForRequest(
+		WithWaitForRequestFlightControlPath("/home/ubuntu/enos-flight-control"),
+		WithWaitForRequestTCP("127.0.0.1:8200", "127.0.0.1:8201"),
+		WithWaitForRequestHTTP("https://127.0.0.1:8200/v1/sys/health"),
+		WithWaitForRequestHTTPStatuses(200, 429),
+		WithWaitForRequestFiles("/etc/vault.d/vault's.hcl"),
+		WithWaitForRequestProcesses("vault"),
+		WithWaitForRequestInterval("2s"),
+		WithWaitForRequestTimeout("10m"),
+		WithWaitForRequestProbeTimeout("3s"),
+		WithWaitForRequestTLSSkipVerify(true),
+		WithWaitForRequestUseSudo(true),
+	)
+	require.NoError(t, req.Validate())
+	require.Equal(t,
+		"sudo /home/ubuntu/enos-flight-control wait-for --interval '2s' --timeout '10m' --probe-timeout '3s'"+
+			" --tcp '127.0.0.1:8200' --tcp '127.0.0.1:8201' --http 'https://127.0.0.1:8200/v1/sys/health'"+
+			` --file '/etc/vault.d/vault'\''s.hcl' --process 'vault'`+
+			" --http-status 200 --http-status 429 --ca-cert '/tmp/ca.pem' --tls-skip-verify",
+		req.command("/tmp/ca.pem"),
+	)
+
+	require.Error(t, NewWaitForRequest().Validate())
+}
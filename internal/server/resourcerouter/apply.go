@@ -71,6 +71,10 @@ type ApplyResourceChangeResponse struct {
 	Private                     []byte
 	Diagnostics                 []*tfprotov6.Diagnostic
 	UnsafeToUseLegacyTypeSystem bool
+	// SaveStateOnError returns the new state even if the apply failed. It should only be set when
+	// the apply created something that cannot be recreated, e.g. a one time bootstrap token, and
+	// the new state does not have any unknown values.
+	SaveStateOnError bool
 }
 
 // ToTFProto6Response Converts the response to a tfproto6 response type.
@@ -81,7 +85,7 @@ func (a ApplyResourceChangeResponse) ToTFProto6Response(isDelete bool) *tfprotov
 		UnsafeToUseLegacyTypeSystem: a.UnsafeToUseLegacyTypeSystem,
 	}
 
-	if !diags.HasErrors(a.Diagnostics) {
+	if !diags.HasErrors(a.Diagnostics) || a.SaveStateOnError {
 		val, err := marshalApply(a.NewState, isDelete)
 		if err != nil {
 			resp.Diagnostics = append(resp.Diagnostics, diags.ErrToDiagnostic("Serialization Error", err))
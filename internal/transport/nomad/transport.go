@@ -12,78 +12,91 @@ import (
 	it "github.com/hashicorp-forge/terraform-provider-enos/internal/transport"
 )
 
-// transport a Nomad based transport implementation.
-type transport struct {
-	client       nomad.Client
-	allocationID string
-	taskName     string
+// Transport is the Nomad transport. It's public because sometimes callers need to access the raw
+// client and data.
+type Transport struct {
+	Client       nomad.Client
+	AllocationID string
+	TaskName     string
 }
 
 // TransportOpts are the options required in order to create the nomad transport.
 type TransportOpts struct {
-	Host         string
-	SecretID     string
-	AllocationID string
-	TaskName     string
+	Host          string
+	SecretID      string
+	Namespace     string
+	Region        string
+	CACert        string
+	ClientCert    string
+	ClientKey     string
+	TLSServerName string
+	AllocationID  string
+	TaskName      string
 }
 
-var _ it.Transport = (*transport)(nil)
+var _ it.Transport = (*Transport)(nil)
 
 func NewTransport(opts TransportOpts) (it.Transport, error) {
 	client, err := nomad.NewClient(nomad.ClientCfg{
-		Host:     opts.Host,
-		SecretID: opts.SecretID,
+		Host:          opts.Host,
+		SecretID:      opts.SecretID,
+		Namespace:     opts.Namespace,
+		Region:        opts.Region,
+		CACert:        opts.CACert,
+		ClientCert:    opts.ClientCert,
+		ClientKey:     opts.ClientKey,
+		TLSServerName: opts.TLSServerName,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &transport{
-		client:       client,
-		allocationID: opts.AllocationID,
-		taskName:     opts.TaskName,
+	return &Transport{
+		Client:       client,
+		AllocationID: opts.AllocationID,
+		TaskName:     opts.TaskName,
 	}, nil
 }
 
-func (t *transport) Type() it.TransportType {
+func (t *Transport) Type() it.TransportType {
 	return it.TransportType("nomad")
 }
 
-func (t *transport) Copy(ctx context.Context, src it.Copyable, dst string) error {
+func (t *Transport) Copy(ctx context.Context, src it.Copyable, dst string) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
 	}
 
-	request := t.client.NewExecRequest(nomad.ExecRequestOpts{
+	request := t.Client.NewExecRequest(nomad.ExecRequestOpts{
 		Command:      []string{"tar", "-xmf", "-", "-C", filepath.Dir(dst)},
 		StdIn:        true,
-		AllocationID: t.allocationID,
-		TaskName:     t.taskName,
+		AllocationID: t.AllocationID,
+		TaskName:     t.TaskName,
 	})
 
 	return it.Copy(ctx, it.TarCopyWriter(src, dst, request.Streams().StdinWriter()), dst, request)
 }
 
-func (t *transport) Run(ctx context.Context, command it.Command) (stdout string, stderr string, err error) {
-	return it.Run(ctx, t.client.NewExecRequest(nomad.ExecRequestOpts{
+func (t *Transport) Run(ctx context.Context, command it.Command) (stdout string, stderr string, err error) {
+	return it.Run(ctx, t.Client.NewExecRequest(nomad.ExecRequestOpts{
 		Command:      []string{"sh", "-c", command.Cmd()},
-		AllocationID: t.allocationID,
-		TaskName:     t.taskName,
+		AllocationID: t.AllocationID,
+		TaskName:     t.TaskName,
 	}))
 }
 
-func (t *transport) Stream(ctx context.Context, command it.Command) (stdout io.Reader, stderr io.Reader, errC chan error) {
-	return it.Stream(ctx, t.client.NewExecRequest(nomad.ExecRequestOpts{
-		AllocationID: t.allocationID,
+func (t *Transport) Stream(ctx context.Context, command it.Command) (stdout io.Reader, stderr io.Reader, errC chan error) {
+	return it.Stream(ctx, t.Client.NewExecRequest(nomad.ExecRequestOpts{
+		AllocationID: t.AllocationID,
 		Command:      []string{"sh", "-c", command.Cmd()},
-		TaskName:     t.taskName,
+		TaskName:     t.TaskName,
 	}))
 }
 
-func (t *transport) Close() error {
-	t.client.Close()
+func (t *Transport) Close() error {
+	t.Client.Close()
 
 	return nil
 }